@@ -0,0 +1,151 @@
+package bsc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/bnb-chain/greenfield-go-sdk/bsctypes"
+	bsccommon "github.com/bnb-chain/greenfield-go-sdk/common"
+)
+
+// nonexistentTokenReasons are the revert reasons of the OpenZeppelin ERC721 contracts querying an unminted token.
+var nonexistentTokenReasons = []string{
+	"ERC721: invalid token ID",
+	"ERC721: owner query for nonexistent token",
+}
+
+// nonexistentTokenSelector is the selector of the ERC721NonexistentToken(uint256) custom error.
+var nonexistentTokenSelector = crypto.Keccak256([]byte("ERC721NonexistentToken(uint256)"))[:4]
+
+// IMirrorClient - Client APIs for connecting the Greenfield resources to their ERC721 tokens on BSC.
+type IMirrorClient interface {
+	GetResourceTokenContract(resourceType bsctypes.ResourceType) (common.Address, error)
+	GetResourceMirrorInfo(ctx context.Context, resourceType bsctypes.ResourceType, resourceID *big.Int) (*bsctypes.MirrorInfo, error)
+}
+
+// ResourceTokenID - Compute the ERC721 token id of the Greenfield resource, which equals to the resource id.
+func ResourceTokenID(resourceID uint64) *big.Int {
+	return new(big.Int).SetUint64(resourceID)
+}
+
+// GetResourceTokenContract - Get the ERC721 token contract address of the resource type in the current deployment.
+//
+// - resourceType: The type of the Greenfield resource.
+//
+// - ret1: The address of the token contract.
+//
+// - ret2: Return error when the resource type is unknown or the contract is not deployed, otherwise return nil.
+func (c *Client) GetResourceTokenContract(resourceType bsctypes.ResourceType) (common.Address, error) {
+	var contract string
+	switch resourceType {
+	case bsctypes.BucketResource:
+		contract = c.GetDeployment().BucketERC721Token
+	case bsctypes.ObjectResource:
+		contract = c.GetDeployment().ObjectERC721Token
+	case bsctypes.GroupResource:
+		contract = c.GetDeployment().GroupERC721Token
+	default:
+		return common.Address{}, fmt.Errorf("invalid resource type: %v", resourceType)
+	}
+	if !common.IsHexAddress(contract) {
+		return common.Address{}, fmt.Errorf("the %s token contract is not deployed", resourceType)
+	}
+	return common.HexToAddress(contract), nil
+}
+
+// GetResourceMirrorInfo - Query whether the Greenfield resource is currently mirrored to BSC.
+//
+// - ctx: Context variables for the current API call.
+//
+// - resourceType: The type of the Greenfield resource.
+//
+// - resourceID: The id of the Greenfield resource.
+//
+// - ret1: The BSC representation of the resource, its Mirrored field is false if the token is not minted.
+//
+// - ret2: Return error when the query failed, otherwise return nil.
+func (c *Client) GetResourceMirrorInfo(ctx context.Context, resourceType bsctypes.ResourceType, resourceID *big.Int) (*bsctypes.MirrorInfo, error) {
+	if resourceID == nil || resourceID.Sign() < 0 {
+		return nil, errors.New("invalid resource id")
+	}
+	contract, err := c.GetResourceTokenContract(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	info := &bsctypes.MirrorInfo{
+		ResourceType: resourceType,
+		TokenID:      new(big.Int).Set(resourceID),
+		Contract:     contract,
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(bsccommon.ERC721ABI))
+	if err != nil {
+		return nil, err
+	}
+	packedData, err := parsedABI.Pack("ownerOf", info.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.chainClient.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: packedData}, nil)
+	if err != nil {
+		// ownerOf reverts if the token is not minted
+		if isNonexistentTokenError(err) {
+			return info, nil
+		}
+		return nil, err
+	}
+	result, err := parsedABI.Unpack("ownerOf", resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 1 {
+		return nil, errors.New("expected one return value from ownerOf")
+	}
+	owner, ok := result[0].(common.Address)
+	if !ok {
+		return nil, errors.New("type assertion failed for the return value of ownerOf")
+	}
+	info.Mirrored = owner != (common.Address{})
+	info.Owner = owner
+	return info, nil
+}
+
+// isNonexistentTokenError reports whether the contract call reverted because the ERC721 token is not minted.
+func isNonexistentTokenError(err error) bool {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if revertData, decodeErr := hexutil.Decode(data); decodeErr == nil && len(revertData) >= 4 {
+				if bytes.Equal(revertData[:4], nonexistentTokenSelector) {
+					return true
+				}
+				if reason, unpackErr := abi.UnpackRevert(revertData); unpackErr == nil {
+					return isNonexistentTokenReason(reason)
+				}
+				return false
+			}
+		}
+	}
+	// some nodes only return the revert reason in the error message
+	return isNonexistentTokenReason(err.Error())
+}
+
+func isNonexistentTokenReason(reason string) bool {
+	for _, nonexistentReason := range nonexistentTokenReasons {
+		if strings.Contains(reason, nonexistentReason) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package bsc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/bsctypes"
+)
+
+// dataError is the JSON-RPC error carrying the revert data of the contract call.
+type dataError struct {
+	message string
+	data    interface{}
+}
+
+func (e dataError) Error() string { return e.message }
+
+func (e dataError) ErrorData() interface{} { return e.data }
+
+func revertData(t *testing.T, reason string) string {
+	typ, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: typ}}.Pack(reason)
+	require.NoError(t, err)
+	return hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], packed...))
+}
+
+func TestResourceTokenID(t *testing.T) {
+	require.Equal(t, big.NewInt(0), ResourceTokenID(0))
+	require.Equal(t, big.NewInt(12345), ResourceTokenID(12345))
+	require.Equal(t, "18446744073709551615", ResourceTokenID(^uint64(0)).String())
+}
+
+func TestIsNonexistentTokenError(t *testing.T) {
+	customError := hexutil.Encode(append(crypto.Keccak256([]byte("ERC721NonexistentToken(uint256)"))[:4], common.LeftPadBytes([]byte{1}, 32)...))
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"invalid token id reason", dataError{"execution reverted: ERC721: invalid token ID", revertData(t, "ERC721: invalid token ID")}, true},
+		{"nonexistent token reason", dataError{"execution reverted", revertData(t, "ERC721: owner query for nonexistent token")}, true},
+		{"nonexistent token custom error", dataError{"execution reverted", customError}, true},
+		{"other revert reason", dataError{"execution reverted: Pausable: paused", revertData(t, "Pausable: paused")}, false},
+		{"other custom error", dataError{"execution reverted", "0xdeadbeef"}, false},
+		{"reason in message only", errors.New("execution reverted: ERC721: invalid token ID"), true},
+		{"revert without reason", errors.New("execution reverted"), false},
+		{"connection error", errors.New("dial tcp: connection refused"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isNonexistentTokenError(tc.err))
+		})
+	}
+}
+
+func TestGetResourceMirrorInfo(t *testing.T) {
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	var rpcError map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcError != nil {
+			resp["error"] = rpcError
+		} else {
+			resp["result"] = hexutil.Encode(common.LeftPadBytes(owner.Bytes(), 32))
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	chainClient, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	c := &Client{
+		chainClient: chainClient,
+		deployment:  &bsctypes.Deployment{BucketERC721Token: "0x2222222222222222222222222222222222222222"},
+	}
+
+	info, err := c.GetResourceMirrorInfo(context.Background(), bsctypes.BucketResource, ResourceTokenID(1))
+	require.NoError(t, err)
+	require.True(t, info.Mirrored)
+	require.Equal(t, owner, info.Owner)
+
+	// the unminted token is not mirrored
+	rpcError = map[string]interface{}{"code": 3, "message": "execution reverted: ERC721: invalid token ID", "data": revertData(t, "ERC721: invalid token ID")}
+	info, err = c.GetResourceMirrorInfo(context.Background(), bsctypes.BucketResource, ResourceTokenID(1))
+	require.NoError(t, err)
+	require.False(t, info.Mirrored)
+	require.Equal(t, common.Address{}, info.Owner)
+
+	// the other reverts are returned
+	rpcError = map[string]interface{}{"code": 3, "message": "execution reverted: Pausable: paused", "data": revertData(t, "Pausable: paused")}
+	_, err = c.GetResourceMirrorInfo(context.Background(), bsctypes.BucketResource, ResourceTokenID(1))
+	require.ErrorContains(t, err, "Pausable: paused")
+}
@@ -21,6 +21,7 @@ type IClient interface {
 	IGreenfieldExecutorClient
 	IBasicClient
 	IAccountClient
+	IMirrorClient
 }
 
 // Client - The implementation for IClient, implement all Client APIs for Greenfield SDK.
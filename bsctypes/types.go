@@ -115,3 +115,35 @@ type UpdateGroupMemberSynPackage struct {
 	ExtraData        []byte            `json:"extraData"`
 	MemberExpiration []uint64          `json:"memberExpiration"`
 }
+
+// ResourceType indicates the type of the Greenfield resource mirrored to BSC.
+type ResourceType uint8
+
+const (
+	BucketResource ResourceType = iota
+	ObjectResource
+	GroupResource
+)
+
+// String returns the name of the resource type.
+func (t ResourceType) String() string {
+	switch t {
+	case BucketResource:
+		return "bucket"
+	case ObjectResource:
+		return "object"
+	case GroupResource:
+		return "group"
+	default:
+		return "unknown"
+	}
+}
+
+// MirrorInfo contains the BSC representation of a Greenfield resource.
+type MirrorInfo struct {
+	ResourceType ResourceType
+	TokenID      *big.Int       // TokenID is the ERC721 token id, which equals to the resource id on Greenfield.
+	Contract     common.Address // Contract is the ERC721 token contract of the resource type.
+	Mirrored     bool           // Mirrored indicates whether the token is currently minted on BSC.
+	Owner        common.Address // Owner is the owner of the token if it is mirrored.
+}
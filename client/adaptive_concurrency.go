@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// aimdLimiter bounds the in-flight requests of a transfer by a limit adjusted in the AIMD way: the limit grows by one
+// after a round of the successful requests, and is cut by the decrease factor once a request fails or is slow. A nil
+// limiter does not limit the requests.
+type aimdLimiter struct {
+	mu             sync.Mutex
+	limit          float64
+	min            float64
+	max            float64
+	targetLatency  time.Duration
+	decreaseFactor float64
+	inFlight       int
+	// epoch is increased by each decrease, the requests acquired before a decrease do not decrease the limit again,
+	// so a burst of the failures cuts the limit once.
+	epoch   uint64
+	changed chan struct{} // changed is closed and replaced when a request is released.
+	now     func() time.Time
+}
+
+// newAIMDLimiter returns the limiter starting from the initial concurrency, it returns nil if opts is nil.
+func newAIMDLimiter(opts *types.AdaptiveConcurrency, initial int) *aimdLimiter {
+	if opts == nil {
+		return nil
+	}
+	minConcurrency := opts.MinConcurrency
+	if minConcurrency <= 0 {
+		minConcurrency = 1
+	}
+	decreaseFactor := opts.DecreaseFactor
+	if decreaseFactor <= 0 {
+		decreaseFactor = types.DefaultConcurrencyDecreaseFactor
+	}
+	limit := math.Min(math.Max(float64(initial), float64(minConcurrency)), float64(opts.MaxConcurrency))
+	return &aimdLimiter{
+		limit:          limit,
+		min:            float64(minConcurrency),
+		max:            float64(opts.MaxConcurrency),
+		targetLatency:  opts.TargetLatency,
+		decreaseFactor: decreaseFactor,
+		changed:        make(chan struct{}),
+		now:            time.Now,
+	}
+}
+
+// workers returns the number of the workers to spawn, the limiter needs its upper bound to raise the concurrency.
+func (l *aimdLimiter) workers(concurrency int) int {
+	if l == nil {
+		return concurrency
+	}
+	return int(l.max)
+}
+
+// acquire waits until the in-flight requests are under the limit, the returned release should be called with the
+// result of the request.
+func (l *aimdLimiter) acquire(ctx context.Context) (func(err error), error) {
+	if l == nil {
+		return func(error) {}, nil
+	}
+	for {
+		l.mu.Lock()
+		if l.inFlight < int(l.limit) {
+			l.inFlight++
+			epoch, start := l.epoch, l.now()
+			l.mu.Unlock()
+			return func(err error) { l.release(epoch, start, err) }, nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (l *aimdLimiter) release(epoch uint64, start time.Time, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		// the canceled requests tell nothing about SP
+	case err != nil || (l.targetLatency > 0 && l.now().Sub(start) > l.targetLatency):
+		if epoch == l.epoch {
+			l.limit = math.Max(l.min, l.limit*l.decreaseFactor)
+			l.epoch++
+		}
+	default:
+		l.limit = math.Min(l.max, l.limit+1/l.limit)
+	}
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// concurrency returns the current limit of the in-flight requests.
+func (l *aimdLimiter) concurrency() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
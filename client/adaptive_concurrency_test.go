@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestAIMDLimiterAdjust(t *testing.T) {
+	limiter := newAIMDLimiter(&types.AdaptiveConcurrency{MinConcurrency: 2, MaxConcurrency: 8, TargetLatency: time.Second}, 4)
+	now := time.Unix(0, 0)
+	limiter.now = func() time.Time { return now }
+	require.Equal(t, 8, limiter.workers(4))
+	require.Equal(t, 4, limiter.concurrency())
+
+	// each success raises the limit by 1/limit, so about a round of the successful requests raises it by one
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		require.NoError(t, err)
+		release(nil)
+	}
+	require.Equal(t, 5, limiter.concurrency())
+
+	// the failures of the requests acquired before a decrease cut the limit once
+	releases := make([]func(error), 0)
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+	for _, release := range releases {
+		release(errors.New("broken"))
+	}
+	require.Equal(t, 2, limiter.concurrency())
+
+	// the slow requests lower the limit but not under the lower bound
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	now = now.Add(2 * time.Second)
+	release(nil)
+	require.Equal(t, 2, limiter.concurrency())
+
+	// the canceled requests keep the limit
+	release, err = limiter.acquire(context.Background())
+	require.NoError(t, err)
+	release(context.Canceled)
+	require.Equal(t, 2, limiter.concurrency())
+}
+
+func TestAIMDLimiterAcquire(t *testing.T) {
+	limiter := newAIMDLimiter(&types.AdaptiveConcurrency{MaxConcurrency: 1}, 4)
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		next, err := limiter.acquire(context.Background())
+		if err == nil {
+			next(nil)
+		}
+		close(acquired)
+	}()
+	release(nil)
+	<-acquired
+
+	var nilLimiter *aimdLimiter
+	require.Equal(t, 3, nilLimiter.workers(3))
+	release, err = nilLimiter.acquire(context.Background())
+	require.NoError(t, err)
+	release(nil)
+}
@@ -1,14 +1,22 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/bnb-chain/greenfield-go-sdk/types"
 	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
 	paymentTypes "github.com/bnb-chain/greenfield/x/payment/types"
+	bfttypes "github.com/cometbft/cometbft/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
@@ -29,6 +37,14 @@ type IAccountClient interface {
 	CreatePaymentAccount(ctx context.Context, address string, txOption gnfdSdkTypes.TxOption) (string, error)
 	Transfer(ctx context.Context, toAddress string, amount math.Int, txOption gnfdSdkTypes.TxOption) (string, error)
 	MultiTransfer(ctx context.Context, details []types.TransferDetail, txOption gnfdSdkTypes.TxOption) (string, error)
+	RequestFaucetFunds(ctx context.Context, address string, opts types.RequestFaucetOptions) (*sdk.Coin, error)
+
+	GetAccountPendingTxs(ctx context.Context, address string) ([]*types.PendingTx, error)
+	GetAccountSequence(ctx context.Context, address string) (*types.AccountSequence, error)
+	ResetSequence(ctx context.Context, address string) (uint64, error)
+
+	CreateOwnershipProof(nonce string) (*types.OwnershipProof, error)
+	VerifyOwnershipProof(proof *types.OwnershipProof, nonce string, maxAge time.Duration) error
 }
 
 // SetDefaultAccount - Set the default account of the Client.
@@ -79,7 +95,7 @@ func (c *Client) GetAccount(ctx context.Context, address string) (authTypes.Acco
 		return nil, err
 	}
 	// Call the DefaultAccount method of the chain Client with a QueryAccountRequest containing the address.
-	response, err := c.chainClient.Account(ctx, &authTypes.QueryAccountRequest{Address: accAddress.String()})
+	response, err := c.chainClient.Account(c.queryContext(ctx), &authTypes.QueryAccountRequest{Address: accAddress.String()})
 	if err != nil {
 		// Return an error if there was an issue retrieving the account.
 		return nil, err
@@ -136,7 +152,7 @@ func (c *Client) CreatePaymentAccount(ctx context.Context, address string, txOpt
 //
 // - ret2: Return error when getting failed, otherwise return nil.
 func (c *Client) GetModuleAccountByName(ctx context.Context, name string) (authTypes.ModuleAccountI, error) {
-	response, err := c.chainClient.ModuleAccountByName(ctx, &authTypes.QueryModuleAccountByNameRequest{Name: name})
+	response, err := c.chainClient.ModuleAccountByName(c.queryContext(ctx), &authTypes.QueryModuleAccountByNameRequest{Name: name})
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +176,7 @@ func (c *Client) GetModuleAccountByName(ctx context.Context, name string) (authT
 //
 // - ret2: Return error when getting failed, otherwise return nil.
 func (c *Client) GetModuleAccounts(ctx context.Context) ([]authTypes.ModuleAccountI, error) {
-	response, err := c.chainClient.ModuleAccounts(ctx, &authTypes.QueryModuleAccountsRequest{})
+	response, err := c.chainClient.ModuleAccounts(c.queryContext(ctx), &authTypes.QueryModuleAccountsRequest{})
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +207,7 @@ func (c *Client) GetAccountBalance(ctx context.Context, address string) (*sdk.Co
 	if err != nil {
 		return nil, err
 	}
-	response, err := c.chainClient.BankQueryClient.Balance(ctx, &bankTypes.QueryBalanceRequest{Address: accAddress.String(), Denom: gnfdSdkTypes.Denom})
+	response, err := c.chainClient.BankQueryClient.Balance(c.queryContext(ctx), &bankTypes.QueryBalanceRequest{Address: accAddress.String(), Denom: gnfdSdkTypes.Denom})
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +229,7 @@ func (c *Client) GetPaymentAccount(ctx context.Context, address string) (*paymen
 	if err != nil {
 		return nil, err
 	}
-	pa, err := c.chainClient.PaymentAccount(ctx, &paymentTypes.QueryPaymentAccountRequest{Addr: accAddress.String()})
+	pa, err := c.chainClient.PaymentAccount(c.queryContext(ctx), &paymentTypes.QueryPaymentAccountRequest{Addr: accAddress.String()})
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +251,7 @@ func (c *Client) GetPaymentAccountsByOwner(ctx context.Context, owner string) ([
 		return nil, err
 	}
 	// Call the GetPaymentAccountsByOwner method of the chain Client with a QueryGetPaymentAccountsByOwnerRequest containing the owner address.
-	accountsByOwnerResponse, err := c.chainClient.PaymentAccountsByOwner(ctx, &paymentTypes.QueryPaymentAccountsByOwnerRequest{Owner: ownerAcc.String()})
+	accountsByOwnerResponse, err := c.chainClient.PaymentAccountsByOwner(c.queryContext(ctx), &paymentTypes.QueryPaymentAccountsByOwnerRequest{Owner: ownerAcc.String()})
 	if err != nil {
 		return nil, err
 	}
@@ -327,3 +343,258 @@ func (c *Client) MultiTransfer(ctx context.Context, details []types.TransferDeta
 	}
 	return tx.TxResponse.TxHash, nil
 }
+
+// RequestFaucetFunds - Request the testnet tokens from the faucet and wait until the balance of the address increases.
+//
+// It only works on the testnet, the request is refused if the chain id of the client is the mainnet one.
+//
+// - ctx: Context variables for the current API call.
+//
+// - address: The HEX-encoded string of the address to be funded, the default account is used if it is empty.
+//
+// - opts: The options to override the public testnet faucet and to set the way to wait for the balance.
+//
+// - ret1: The balance of the address after funded.
+//
+// - ret2: Return error when the faucet request failed or the balance does not increase in time, otherwise return nil.
+// types.ErrFaucetRateLimited is wrapped if the faucet responds with 429 Too Many Requests.
+func (c *Client) RequestFaucetFunds(ctx context.Context, address string, opts types.RequestFaucetOptions) (*sdk.Coin, error) {
+	faucetURL := opts.FaucetURL
+	if faucetURL == "" {
+		faucetURL = types.TestnetFaucetURL
+	}
+	if address == "" {
+		acc, err := c.GetDefaultAccount()
+		if err != nil {
+			return nil, err
+		}
+		address = acc.GetAddress().String()
+	}
+	if c.chainID == types.MainnetChainID {
+		return nil, errors.New("the faucet is only available on the testnet")
+	}
+
+	before, err := c.GetAccountBalance(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]string{"address": address})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, faucetURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(types.HTTPHeaderContentType, "application/json")
+	req.Header.Set(types.HTTPHeaderUserAgent, c.userAgent)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, readErr := types.ReadLimitedBody(resp.Body, types.MaxErrResponseBodySize)
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("%w, retry after: %q, %s", types.ErrFaucetRateLimited, resp.Header.Get("Retry-After"), string(respBody))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("faucet request failed with status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	// the faucet may accept the request with an empty body
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		var faucetResp struct {
+			Error string `json:"error"`
+		}
+		if err = json.Unmarshal(respBody, &faucetResp); err != nil {
+			return nil, fmt.Errorf("fail to decode the faucet response %q: %w", string(respBody), err)
+		}
+		if faucetResp.Error != "" {
+			return nil, fmt.Errorf("the faucet refused the request: %s", faucetResp.Error)
+		}
+	}
+
+	waitTimeout := opts.WaitTimeout
+	if waitTimeout == 0 {
+		waitTimeout = types.ContextTimeout
+	}
+	pollInterval := opts.PollInterval
+	if pollInterval == 0 {
+		pollInterval = time.Second
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, waitTimeout)
+	defer cancel()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-waitCtx.Done():
+			return nil, fmt.Errorf("the balance of %s does not increase: %w", address, waitCtx.Err())
+		case <-ticker.C:
+			balance, err := c.GetAccountBalance(waitCtx, address)
+			if err != nil {
+				continue
+			}
+			if balance.Amount.GT(before.Amount) {
+				return balance, nil
+			}
+		}
+	}
+}
+
+// GetAccountPendingTxs - Get the unconfirmed txs signed by the account from the mempool of the connected node.
+//
+// Only the first types.MaxUnconfirmedTxs txs of the mempool are inspected, and the node may refuse to expose its
+// mempool, in which case the error is returned.
+//
+// - ctx: Context variables for the current API call.
+//
+// - address: The HEX-encoded string of the account address.
+//
+// - ret1: The pending txs of the account sorted by sequence.
+//
+// - ret2: Return error when the mempool can not be queried, otherwise return nil.
+func (c *Client) GetAccountPendingTxs(ctx context.Context, address string) ([]*types.PendingTx, error) {
+	accAddress, err := sdk.AccAddressFromHexUnsafe(address)
+	if err != nil {
+		return nil, err
+	}
+	limit := types.MaxUnconfirmedTxs
+	resp, err := c.chainClient.GetUnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingTxs := make([]*types.PendingTx, 0)
+	for _, txBytes := range resp.Txs {
+		decodedTx := &tx.Tx{}
+		if err = c.chainClient.GetCodec().Unmarshal(txBytes, decodedTx); err != nil {
+			// the txs which can not be decoded do not belong to any account of interest
+			continue
+		}
+		if decodedTx.AuthInfo == nil {
+			continue
+		}
+		for i, signer := range decodedTx.GetSigners() {
+			if !signer.Equals(accAddress) || i >= len(decodedTx.AuthInfo.SignerInfos) {
+				continue
+			}
+			pendingTxs = append(pendingTxs, &types.PendingTx{
+				Hash:     fmt.Sprintf("%X", bfttypes.Tx(txBytes).Hash()),
+				Sequence: decodedTx.AuthInfo.SignerInfos[i].Sequence,
+				Tx:       decodedTx,
+			})
+			break
+		}
+	}
+	sort.SliceStable(pendingTxs, func(i, j int) bool {
+		return pendingTxs[i].Sequence < pendingTxs[j].Sequence
+	})
+	return pendingTxs, nil
+}
+
+// GetAccountSequence - Compare the sequence of the account committed on chain with the sequence implied by its pending
+// txs in the mempool, which helps to find out why the txs of the account are not included in blocks.
+//
+// - ctx: Context variables for the current API call.
+//
+// - address: The HEX-encoded string of the account address.
+//
+// - ret1: The sequences and the pending txs of the account.
+//
+// - ret2: Return error when the account or the mempool can not be queried, otherwise return nil.
+func (c *Client) GetAccountSequence(ctx context.Context, address string) (*types.AccountSequence, error) {
+	account, err := c.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	pendingTxs, err := c.GetAccountPendingTxs(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	mempoolSequence, stuck := nextAccountSequence(account.GetSequence(), pendingTxs)
+	return &types.AccountSequence{
+		ChainSequence:   account.GetSequence(),
+		MempoolSequence: mempoolSequence,
+		PendingTxs:      pendingTxs,
+		Stuck:           stuck,
+	}, nil
+}
+
+// ResetSequence - Recompute the sequence which the next tx of the account should be signed with from the chain and the
+// mempool, the automated senders tracking the sequence locally can reset their counters to it and pass it by
+// TxOption.Nonce to recover from the rejected or stuck txs.
+//
+// If the pending txs have a gap in their sequences, the returned sequence fills the gap, so that the stuck txs after
+// it can be executed.
+//
+// - ctx: Context variables for the current API call.
+//
+// - address: The HEX-encoded string of the account address, the default account is used if it is empty.
+//
+// - ret1: The sequence for the next tx of the account.
+//
+// - ret2: Return error when the account or the mempool can not be queried, otherwise return nil.
+func (c *Client) ResetSequence(ctx context.Context, address string) (uint64, error) {
+	if address == "" {
+		acc, err := c.GetDefaultAccount()
+		if err != nil {
+			return 0, err
+		}
+		address = acc.GetAddress().String()
+	}
+	sequence, err := c.GetAccountSequence(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	return sequence.MempoolSequence, nil
+}
+
+// CreateOwnershipProof - Sign the proof that the default account is held by the client, for the services which
+// authenticate the Greenfield account holders off-chain by challenge-response.
+//
+// - nonce: The nonce issued by the verifying service.
+//
+// - ret1: The signed proof to send back to the service, which checks it by VerifyOwnershipProof.
+//
+// - ret2: Return error when the default account is not set or the signing failed, otherwise return nil.
+func (c *Client) CreateOwnershipProof(nonce string) (*types.OwnershipProof, error) {
+	acc, err := c.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+	return types.NewOwnershipProof(acc, nonce, c.now())
+}
+
+// VerifyOwnershipProof - Verify the proof is signed by its address for the nonce and is issued within maxAge.
+//
+// - proof: The proof received from the account holder.
+//
+// - nonce: The nonce issued to the account holder.
+//
+// - maxAge: The max age of the proof.
+//
+// - ret: Return error wrapping types.ErrInvalidOwnershipProof if the proof is invalid, otherwise return nil.
+func (c *Client) VerifyOwnershipProof(proof *types.OwnershipProof, nonce string, maxAge time.Duration) error {
+	return types.VerifyOwnershipProof(proof, nonce, maxAge, c.now())
+}
+
+// nextAccountSequence returns the sequence following the pending txs which are contiguous from chainSequence, and
+// whether some pending txs are stuck after a gap. The pending txs must be sorted by sequence.
+func nextAccountSequence(chainSequence uint64, pendingTxs []*types.PendingTx) (uint64, bool) {
+	next := chainSequence
+	for _, pendingTx := range pendingTxs {
+		switch {
+		case pendingTx.Sequence < next:
+			// the tx is stale or duplicated, it will be evicted from the mempool
+		case pendingTx.Sequence == next:
+			next++
+		default:
+			return next, true
+		}
+	}
+	return next, false
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// fakeBankQueryClient serves the balance queries by the balance set, the other queries panic.
+type fakeBankQueryClient struct {
+	banktypes.QueryClient
+	balance atomic.Int64
+}
+
+func (f *fakeBankQueryClient) Balance(context.Context, *banktypes.QueryBalanceRequest, ...grpc.CallOption) (*banktypes.QueryBalanceResponse, error) {
+	balance := sdk.NewInt64Coin(gnfdsdk.Denom, f.balance.Load())
+	return &banktypes.QueryBalanceResponse{Balance: &balance}, nil
+}
+
+func TestNextAccountSequence(t *testing.T) {
+	pending := func(sequences ...uint64) []*types.PendingTx {
+		pendingTxs := make([]*types.PendingTx, 0, len(sequences))
+		for _, sequence := range sequences {
+			pendingTxs = append(pendingTxs, &types.PendingTx{Sequence: sequence})
+		}
+		return pendingTxs
+	}
+
+	cases := []struct {
+		name          string
+		chainSequence uint64
+		pendingTxs    []*types.PendingTx
+		next          uint64
+		stuck         bool
+	}{
+		{"no pending txs", 5, pending(), 5, false},
+		{"contiguous pending txs", 5, pending(5, 6, 7), 8, false},
+		{"stale pending txs", 5, pending(3, 4, 5), 6, false},
+		{"gap before pending txs", 5, pending(7, 8), 5, true},
+		{"gap among pending txs", 5, pending(5, 6, 8), 7, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, stuck := nextAccountSequence(tc.chainSequence, tc.pendingTxs)
+			require.Equal(t, tc.next, next)
+			require.Equal(t, tc.stuck, stuck)
+		})
+	}
+}
+
+func TestRequestFaucetFunds(t *testing.T) {
+	testCases := []struct {
+		name        string
+		chainID     string
+		status      int
+		header      http.Header
+		body        string
+		funded      bool
+		errIs       error
+		errContains string
+	}{
+		{name: "funded", status: http.StatusOK, body: `{"txHash":"0xabc"}`, funded: true},
+		{name: "funded with empty body", status: http.StatusOK, funded: true},
+		{name: "mainnet", chainID: types.MainnetChainID, errContains: "the faucet is only available on the testnet"},
+		{name: "non-2xx", status: http.StatusBadRequest, body: "invalid address", errContains: "faucet request failed with status code 400: invalid address"},
+		{name: "malformed body", status: http.StatusOK, body: "<html>", errContains: "fail to decode the faucet response"},
+		{name: "refused", status: http.StatusOK, body: `{"error":"insufficient faucet balance"}`, errContains: "the faucet refused the request: insufficient faucet balance"},
+		{name: "rate limited", status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"60"}}, errIs: types.ErrFaucetRateLimited, errContains: `retry after: "60"`},
+		{name: "balance not increased", status: http.StatusOK, errIs: context.DeadlineExceeded},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bankQuery := &fakeBankQueryClient{}
+			bankQuery.balance.Store(100)
+			chainID := tc.chainID
+			if chainID == "" {
+				chainID = "greenfield_5600-1"
+			}
+			c := newTestClient(t, func(c *Client) {
+				c.chainClient.BankQueryClient = bankQuery
+				c.chainID = chainID
+			})
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				require.Equal(t, http.MethodPost, r.Method)
+				var req map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				require.Equal(t, c.defaultAccount.GetAddress().String(), req["address"])
+				for key, values := range tc.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+				if tc.funded {
+					bankQuery.balance.Add(10)
+				}
+			}))
+			defer server.Close()
+			c.httpClient = server.Client()
+
+			balance, err := c.RequestFaucetFunds(context.Background(), "", types.RequestFaucetOptions{
+				FaucetURL:    server.URL,
+				WaitTimeout:  100 * time.Millisecond,
+				PollInterval: time.Millisecond,
+			})
+			if tc.errContains == "" && tc.errIs == nil {
+				require.NoError(t, err)
+				require.Equal(t, gnfdsdk.Denom, balance.Denom)
+				require.Equal(t, int64(110), balance.Amount.Int64())
+				return
+			}
+			if tc.errIs != nil {
+				require.True(t, errors.Is(err, tc.errIs), "unexpected error: %v", err)
+			}
+			require.ErrorContains(t, err, tc.errContains)
+			if tc.chainID == types.MainnetChainID {
+				require.Zero(t, requests.Load())
+			}
+		})
+	}
+}
+
+// roundTripFunc is the http.RoundTripper serving the requests by the function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRequestFaucetFundsDefaultURL(t *testing.T) {
+	bankQuery := &fakeBankQueryClient{}
+	bankQuery.balance.Store(100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bankQuery.balance.Add(10)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	// the requests to the public faucet are served by the test server
+	var requested string
+	c := newTestClient(t, func(c *Client) {
+		c.chainClient.BankQueryClient = bankQuery
+		c.chainID = "greenfield_5600-1"
+		c.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requested = req.URL.String()
+			req = req.Clone(req.Context())
+			req.URL.Scheme, req.URL.Host = serverURL.Scheme, serverURL.Host
+			return http.DefaultTransport.RoundTrip(req)
+		})}
+	})
+	balance, err := c.RequestFaucetFunds(context.Background(), "", types.RequestFaucetOptions{
+		WaitTimeout:  100 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(110), balance.Amount.Int64())
+	require.Equal(t, types.TestnetFaucetURL, requested)
+}
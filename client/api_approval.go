@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bnb-chain/greenfield/types/common"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IApprovalClient interface defines functions related to the expiry of the SP approvals.
+//
+// The SP approval carried by the createBucket and createObject msgs is only valid before its expired height, the
+// transaction fails if it is broadcast after that, e.g. when the hash computing of a large object takes a long time.
+type IApprovalClient interface {
+	CheckApprovalExpiry(ctx context.Context, approval *common.Approval) error
+	RefreshCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket) (*storageTypes.MsgCreateBucket, error)
+	RefreshCreateObjectApproval(ctx context.Context, createObjectMsg *storageTypes.MsgCreateObject) (*storageTypes.MsgCreateObject, error)
+	VerifyCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket) error
+	VerifyCreateObjectApproval(ctx context.Context, createObjectMsg *storageTypes.MsgCreateObject) error
+}
+
+// CheckApprovalExpiry - Check whether the SP approval is still valid for broadcasting.
+//
+// The approval is treated as expired when the latest block height of the chain is within types.ApprovalExpiryMargin
+// blocks of its expired height.
+//
+// - ctx: Context variables for the current API call.
+//
+// - approval: The SP approval to be checked.
+//
+// - ret: Return types.ErrApprovalExpired when the approval is missing or expired, return other error when the chain
+// status can not be queried, otherwise return nil.
+func (c *Client) CheckApprovalExpiry(ctx context.Context, approval *common.Approval) error {
+	if approval == nil || len(approval.Sig) == 0 {
+		return fmt.Errorf("%w: no approval signature", types.ErrApprovalExpired)
+	}
+	latestHeight, err := c.GetLatestBlockHeight(ctx)
+	if err != nil {
+		return err
+	}
+	return checkApprovalExpiry(approval, uint64(latestHeight))
+}
+
+// checkApprovalExpiry returns error wrapping types.ErrApprovalExpired if the approval is missing or expires within
+// types.ApprovalExpiryMargin blocks of latestHeight.
+func checkApprovalExpiry(approval *common.Approval, latestHeight uint64) error {
+	if approval == nil || len(approval.Sig) == 0 {
+		return fmt.Errorf("%w: no approval signature", types.ErrApprovalExpired)
+	}
+	if latestHeight+types.ApprovalExpiryMargin >= approval.ExpiredHeight {
+		return fmt.Errorf("%w: expired height %d, latest height %d", types.ErrApprovalExpired,
+			approval.ExpiredHeight, latestHeight)
+	}
+	return nil
+}
+
+// RefreshCreateBucketApproval - Re-request the SP approval of the createBucket msg if it is missing or expired.
+//
+// It should be called right before broadcasting the msg signed by GetCreateBucketApproval, BroadcastTx calls it by itself
+// if Option.RefreshExpiredApprovals is set.
+//
+// - ctx: Context variables for the current API call.
+//
+// - createBucketMsg: The msg of create bucket, which may carry an approval signature from the storage provider.
+//
+// - ret1: The msg itself if its approval is still valid, otherwise the msg with a new approval signature.
+//
+// - ret2: Return error when the approval can not be checked or re-requested, otherwise return nil.
+func (c *Client) RefreshCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket) (*storageTypes.MsgCreateBucket, error) {
+	latestHeight, err := c.GetLatestBlockHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.refreshCreateBucketApproval(ctx, createBucketMsg, uint64(latestHeight))
+}
+
+func (c *Client) refreshCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket,
+	latestHeight uint64,
+) (*storageTypes.MsgCreateBucket, error) {
+	if checkApprovalExpiry(createBucketMsg.GetPrimarySpApproval(), latestHeight) == nil {
+		return createBucketMsg, nil
+	}
+
+	// the approval info is reset so that the SP signs a new one
+	unsignedMsg := *createBucketMsg
+	unsignedMsg.PrimarySpApproval = &common.Approval{}
+	if approval := createBucketMsg.GetPrimarySpApproval(); approval != nil {
+		unsignedMsg.PrimarySpApproval.GlobalVirtualGroupFamilyId = approval.GlobalVirtualGroupFamilyId
+	}
+	signedMsg, err := c.GetCreateBucketApproval(ctx, &unsignedMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkApprovalExpiry(signedMsg.GetPrimarySpApproval(), latestHeight); err != nil {
+		return nil, err
+	}
+	return signedMsg, nil
+}
+
+// RefreshCreateObjectApproval - Re-request the SP approval of the createObject msg if it is missing or expired.
+//
+// It should be called right before broadcasting the msg signed by GetCreateObjectApproval, BroadcastTx calls it by itself
+// if Option.RefreshExpiredApprovals is set.
+//
+// - ctx: Context variables for the current API call.
+//
+// - createObjectMsg: The msg of create object, which may carry an approval signature from the storage provider.
+//
+// - ret1: The msg itself if its approval is still valid, otherwise the msg with a new approval signature.
+//
+// - ret2: Return error when the approval can not be checked or re-requested, otherwise return nil.
+func (c *Client) RefreshCreateObjectApproval(ctx context.Context, createObjectMsg *storageTypes.MsgCreateObject) (*storageTypes.MsgCreateObject, error) {
+	latestHeight, err := c.GetLatestBlockHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.refreshCreateObjectApproval(ctx, createObjectMsg, uint64(latestHeight))
+}
+
+func (c *Client) refreshCreateObjectApproval(ctx context.Context, createObjectMsg *storageTypes.MsgCreateObject,
+	latestHeight uint64,
+) (*storageTypes.MsgCreateObject, error) {
+	if checkApprovalExpiry(createObjectMsg.GetPrimarySpApproval(), latestHeight) == nil {
+		return createObjectMsg, nil
+	}
+
+	unsignedMsg := *createObjectMsg
+	unsignedMsg.PrimarySpApproval = &common.Approval{}
+	signedMsg, err := c.GetCreateObjectApproval(ctx, &unsignedMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkApprovalExpiry(signedMsg.GetPrimarySpApproval(), latestHeight); err != nil {
+		return nil, err
+	}
+	return signedMsg, nil
+}
+
+// refreshSignedApprovals returns the msgs whose expired SP approvals are re-requested, the createBucket and
+// createObject msgs without the approval signatures are kept as they are, since the chain does not require them.
+func (c *Client) refreshSignedApprovals(ctx context.Context, msgs []sdk.Msg) ([]sdk.Msg, error) {
+	signed := false
+	for _, msg := range msgs {
+		signed = signed || hasApprovalSignature(msg)
+	}
+	if !signed {
+		return msgs, nil
+	}
+	latestHeight, err := c.GetLatestBlockHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.refreshApprovalsAtHeight(ctx, msgs, uint64(latestHeight))
+}
+
+func (c *Client) refreshApprovalsAtHeight(ctx context.Context, msgs []sdk.Msg, latestHeight uint64) ([]sdk.Msg, error) {
+	refreshed := make([]sdk.Msg, len(msgs))
+	for i, msg := range msgs {
+		refreshed[i] = msg
+		if !hasApprovalSignature(msg) {
+			continue
+		}
+		var err error
+		switch m := msg.(type) {
+		case *storageTypes.MsgCreateBucket:
+			refreshed[i], err = c.refreshCreateBucketApproval(ctx, m, latestHeight)
+		case *storageTypes.MsgCreateObject:
+			refreshed[i], err = c.refreshCreateObjectApproval(ctx, m, latestHeight)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return refreshed, nil
+}
+
+// hasApprovalSignature reports whether msg is a createBucket or createObject msg carrying the SP approval signature.
+func hasApprovalSignature(msg sdk.Msg) bool {
+	switch m := msg.(type) {
+	case *storageTypes.MsgCreateBucket:
+		return len(m.GetPrimarySpApproval().GetSig()) > 0
+	case *storageTypes.MsgCreateObject:
+		return len(m.GetPrimarySpApproval().GetSig()) > 0
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bnb-chain/greenfield/types/common"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestCheckApprovalExpiry(t *testing.T) {
+	testCases := []struct {
+		name         string
+		approval     *common.Approval
+		latestHeight uint64
+		expired      bool
+	}{
+		{"missing approval", nil, 100, true},
+		{"unsigned approval", &common.Approval{ExpiredHeight: 1000}, 100, true},
+		{"valid approval", &common.Approval{ExpiredHeight: 1000, Sig: []byte("sig")}, 100, false},
+		{"approval expiring within the margin", &common.Approval{ExpiredHeight: 100 + types.ApprovalExpiryMargin, Sig: []byte("sig")}, 100, true},
+		{"approval beyond the margin", &common.Approval{ExpiredHeight: 101 + types.ApprovalExpiryMargin, Sig: []byte("sig")}, 100, false},
+		{"expired approval", &common.Approval{ExpiredHeight: 50, Sig: []byte("sig")}, 100, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkApprovalExpiry(tc.approval, tc.latestHeight)
+			if !tc.expired {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, types.ErrApprovalExpired), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestRefreshApprovals(t *testing.T) {
+	const latestHeight = 1000
+	var approvals atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, types.CreateBucketAction, r.URL.Query().Get("action"))
+		unsignedBytes, err := hex.DecodeString(r.Header.Get(types.HTTPHeaderUnsignedMsg))
+		require.NoError(t, err)
+		var msg storageTypes.MsgCreateBucket
+		storageTypes.ModuleCdc.MustUnmarshalJSON(unsignedBytes, &msg)
+		// the SP is asked to sign a new approval of the same family
+		require.Empty(t, msg.PrimarySpApproval.Sig)
+		require.Equal(t, uint32(7), msg.PrimarySpApproval.GlobalVirtualGroupFamilyId)
+
+		approvals.Add(1)
+		msg.PrimarySpApproval.ExpiredHeight = latestHeight + types.ApprovalValidBlocks
+		msg.PrimarySpApproval.Sig = []byte("new-sig")
+		w.Header().Set(types.HTTPHeaderSignedMsg, hex.EncodeToString(storageTypes.ModuleCdc.MustMarshalJSON(&msg)))
+	}))
+	defer server.Close()
+
+	spAccount, _, err := types.NewAccount("sp")
+	require.NoError(t, err)
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c := newTestClient(t, withTestServer(server))
+	account := c.defaultAccount
+	c.setStorageProviders(map[uint32]*types.StorageProvider{1: {Id: 1, OperatorAddress: spAccount.GetAddress(), EndPoint: endpoint}})
+
+	newCreateBucketMsg := func(expiredHeight uint64, sig []byte) *storageTypes.MsgCreateBucket {
+		msg := storageTypes.NewMsgCreateBucket(account.GetAddress(), "bucket", storageTypes.VISIBILITY_TYPE_PRIVATE,
+			spAccount.GetAddress(), nil, expiredHeight, sig, 0)
+		msg.PrimarySpApproval.GlobalVirtualGroupFamilyId = 7
+		return msg
+	}
+	validMsg := newCreateBucketMsg(latestHeight+types.ApprovalValidBlocks, []byte("sig"))
+	expiredMsg := newCreateBucketMsg(latestHeight, []byte("old-sig"))
+	unsignedMsg := newCreateBucketMsg(0, nil)
+	setTagMsg := storageTypes.NewMsgSetTag(account.GetAddress(), "grn", nil)
+	require.True(t, hasApprovalSignature(validMsg))
+	require.False(t, hasApprovalSignature(unsignedMsg))
+	require.False(t, hasApprovalSignature(setTagMsg))
+
+	// the valid approvals, the unsigned msgs and the other msgs are kept as they are
+	msgs := []sdk.Msg{validMsg, unsignedMsg, setTagMsg}
+	refreshed, err := c.refreshApprovalsAtHeight(context.Background(), msgs, latestHeight)
+	require.NoError(t, err)
+	require.Equal(t, msgs, refreshed)
+	require.Equal(t, int64(0), approvals.Load())
+
+	// the expired approval is signed again by the SP, the msgs of the caller are not modified
+	msgs = []sdk.Msg{expiredMsg, setTagMsg}
+	refreshed, err = c.refreshApprovalsAtHeight(context.Background(), msgs, latestHeight)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), approvals.Load())
+	require.Same(t, expiredMsg, msgs[0])
+	require.Equal(t, []byte("old-sig"), expiredMsg.PrimarySpApproval.Sig)
+	refreshedMsg := refreshed[0].(*storageTypes.MsgCreateBucket)
+	require.Equal(t, []byte("new-sig"), refreshedMsg.PrimarySpApproval.Sig)
+	require.Equal(t, uint64(latestHeight+types.ApprovalValidBlocks), refreshedMsg.PrimarySpApproval.ExpiredHeight)
+	require.Same(t, setTagMsg, refreshed[1])
+
+	// the approval expired again by the time it is signed is not broadcast
+	_, err = c.refreshApprovalsAtHeight(context.Background(), []sdk.Msg{expiredMsg}, latestHeight+types.ApprovalValidBlocks)
+	require.True(t, errors.Is(err, types.ErrApprovalExpired), "unexpected error: %v", err)
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+
+	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IAuditClient - Client APIs for auditing the integrity of the objects stored by the SPs.
+type IAuditClient interface {
+	AuditBucket(ctx context.Context, bucketName string, sampleRate float64, opts types.AuditBucketOptions) (*types.AuditReport, error)
+}
+
+// auditCheck is a check of an object against an SP, failure is nil if it passes.
+type auditCheck struct {
+	spID            uint32
+	redundancyIndex int
+	failure         *types.AuditFailure
+}
+
+// AuditBucket - Randomly sample the sealed objects of the bucket and verify the data served by the SPs against the
+// checksums on chain, for the owners who want an independent verification of their SPs. It is meant to be run
+// periodically, e.g. by a cron job, and each run samples a different set of objects.
+//
+// In the AuditFullRead mode, the sampled objects are downloaded from the primary SP and all the checksums are
+// recomputed from the contents. In the AuditChallenge mode, a random piece of each sampled object is downloaded from
+// every SP of the object's global virtual group by the challenge API, and verified against the piece hashes and the
+// checksum of the SP on chain.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - sampleRate: The probability of each object to be sampled, it should be in (0, 1].
+//
+// - opts: The options to limit the audited objects and to choose the audit mode.
+//
+// - ret1: The audit report with the failures attributed to the SPs.
+//
+// - ret2: Return error when the objects can not be listed, otherwise return nil.
+func (c *Client) AuditBucket(ctx context.Context, bucketName string, sampleRate float64, opts types.AuditBucketOptions) (*types.AuditReport, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if sampleRate <= 0 || sampleRate > 1 {
+		return nil, fmt.Errorf("the sample rate %v should be in (0, 1]", sampleRate)
+	}
+	if opts.Mode == "" {
+		opts.Mode = types.AuditFullRead
+	}
+	objects, err := c.listObjectInfos(ctx, bucketName, opts.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	params, err := c.getRedundancyParams()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(objects))
+	for name, objectInfo := range objects {
+		if objectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	rand.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	report := &types.AuditReport{
+		BucketName: bucketName,
+		Mode:       opts.Mode,
+		Objects:    len(names),
+		Failures:   make([]types.AuditFailure, 0),
+		SPs:        make([]types.AuditSPStats, 0),
+	}
+	stats := make(map[uint32]*types.AuditSPStats)
+	for _, name := range names {
+		if opts.MaxSampledCount > 0 && report.Sampled >= opts.MaxSampledCount {
+			break
+		}
+		if rand.Float64() >= sampleRate {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		checks, err := c.auditObject(ctx, bucketName, objects[name], params, opts)
+		if err != nil {
+			// the object may be deleted since listed
+			log.Error().Msg(fmt.Sprintf("skip auditing object %s, err: %s", name, err))
+			continue
+		}
+
+		report.Sampled++
+		passed := true
+		for _, check := range checks {
+			stat, ok := stats[check.spID]
+			if !ok {
+				stat = &types.AuditSPStats{SPID: check.spID, SPAddress: c.spOperatorAddress(check.spID)}
+				stats[check.spID] = stat
+			}
+			stat.Checked++
+			if check.failure == nil {
+				continue
+			}
+			passed = false
+			stat.Failed++
+			failure := *check.failure
+			failure.ObjectName = name
+			failure.SPID = check.spID
+			failure.SPAddress = stat.SPAddress
+			failure.RedundancyIndex = check.redundancyIndex
+			report.Failures = append(report.Failures, failure)
+		}
+		if passed {
+			report.Passed++
+		}
+	}
+
+	for _, stat := range stats {
+		report.SPs = append(report.SPs, *stat)
+	}
+	sort.Slice(report.SPs, func(i, j int) bool { return report.SPs[i].SPID < report.SPs[j].SPID })
+	sort.Slice(report.Failures, func(i, j int) bool {
+		if report.Failures[i].ObjectName != report.Failures[j].ObjectName {
+			return report.Failures[i].ObjectName < report.Failures[j].ObjectName
+		}
+		return report.Failures[i].RedundancyIndex < report.Failures[j].RedundancyIndex
+	})
+	return report, nil
+}
+
+// auditObject checks the object against its SPs, it returns error only if the SPs of the object can not be resolved.
+func (c *Client) auditObject(ctx context.Context, bucketName string, objectInfo *storageTypes.ObjectInfo,
+	params redundancyParams, opts types.AuditBucketOptions,
+) ([]auditCheck, error) {
+	detail, err := c.HeadObject(ctx, bucketName, objectInfo.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+	if detail.GlobalVirtualGroup == nil {
+		return nil, errors.New("the global virtual group of the object is not found")
+	}
+	gvg := detail.GlobalVirtualGroup
+
+	if opts.Mode == types.AuditFullRead {
+		return []auditCheck{{
+			spID:            gvg.PrimarySpId,
+			redundancyIndex: types.PrimaryRedundancyIndex,
+			failure:         c.auditFullRead(ctx, bucketName, objectInfo, params),
+		}}, nil
+	}
+
+	checks := make([]auditCheck, 0, len(gvg.SecondarySpIds)+1)
+	checks = append(checks, auditCheck{
+		spID:            gvg.PrimarySpId,
+		redundancyIndex: types.PrimaryRedundancyIndex,
+		failure:         c.auditChallenge(ctx, objectInfo, types.PrimaryRedundancyIndex, gvg.PrimarySpId, params, opts.UseV2Challenge),
+	})
+	for i, spID := range gvg.SecondarySpIds {
+		checks = append(checks, auditCheck{
+			spID:            spID,
+			redundancyIndex: i,
+			failure:         c.auditChallenge(ctx, objectInfo, i, spID, params, opts.UseV2Challenge),
+		})
+	}
+	return checks, nil
+}
+
+// auditFullRead downloads the object and verifies the checksums of the content, it returns nil if they match.
+func (c *Client) auditFullRead(ctx context.Context, bucketName string, objectInfo *storageTypes.ObjectInfo, params redundancyParams) *types.AuditFailure {
+	body, _, err := c.GetObject(ctx, bucketName, objectInfo.ObjectName, types.GetObjectOptions{})
+	if err != nil {
+		return &types.AuditFailure{Kind: types.AuditUnavailable, Detail: fmt.Sprintf("fail to download the object: %s", err)}
+	}
+	defer body.Close()
+	checksums, err := params.computeChecksums(ctx, body, false)
+	if err != nil {
+		return &types.AuditFailure{Kind: types.AuditUnavailable, Detail: fmt.Sprintf("fail to read the object: %s", err)}
+	}
+	if !equalChecksums(checksums, objectInfo.Checksums) {
+		return &types.AuditFailure{Kind: types.AuditCorrupted, Detail: "the checksums of the content do not match the chain"}
+	}
+	return nil
+}
+
+// auditChallenge downloads a random piece of the object from the SP by the challenge API and verifies it against the
+// checksum of the SP on chain, it returns nil if they match.
+func (c *Client) auditChallenge(ctx context.Context, objectInfo *storageTypes.ObjectInfo, redundancyIndex int, spID uint32,
+	params redundancyParams, useV2 bool,
+) *types.AuditFailure {
+	checksumIndex := redundancyIndex + 1
+	if checksumIndex >= len(objectInfo.Checksums) {
+		return &types.AuditFailure{Kind: types.AuditCorrupted, Detail: fmt.Sprintf("the checksum of redundancy index %d is not found on chain", redundancyIndex)}
+	}
+	segments := int((objectInfo.PayloadSize + uint64(params.segmentSize) - 1) / uint64(params.segmentSize))
+	if segments == 0 {
+		segments = 1
+	}
+	pieceIndex := rand.Intn(segments)
+
+	result, err := c.GetChallengeInfo(ctx, objectInfo.Id.String(), pieceIndex, redundancyIndex, types.GetChallengeInfoOptions{
+		SPAddress:    c.spOperatorAddress(spID),
+		UseV2version: useV2,
+	})
+	if err != nil {
+		return &types.AuditFailure{Kind: types.AuditUnavailable, Detail: fmt.Sprintf("fail to get piece %d: %s", pieceIndex, err)}
+	}
+	defer result.PieceData.Close()
+	pieceData, err := io.ReadAll(result.PieceData)
+	if err != nil {
+		return &types.AuditFailure{Kind: types.AuditUnavailable, Detail: fmt.Sprintf("fail to read piece %d: %s", pieceIndex, err)}
+	}
+
+	pieceHashes := make([][]byte, len(result.PiecesHash))
+	for i, pieceHash := range result.PiecesHash {
+		if pieceHashes[i], err = hex.DecodeString(pieceHash); err != nil {
+			return &types.AuditFailure{Kind: types.AuditCorrupted, Detail: fmt.Sprintf("the piece hash %d is not HEX-encoded", i)}
+		}
+	}
+	if pieceIndex >= len(pieceHashes) {
+		return &types.AuditFailure{Kind: types.AuditCorrupted, Detail: fmt.Sprintf("got %d piece hashes, expect more than %d", len(pieceHashes), pieceIndex)}
+	}
+	if !bytes.Equal(hashlib.GenerateChecksum(pieceData), pieceHashes[pieceIndex]) {
+		return &types.AuditFailure{Kind: types.AuditCorrupted, Detail: fmt.Sprintf("piece %d does not match its hash", pieceIndex)}
+	}
+	if !bytes.Equal(hashlib.GenerateIntegrityHash(pieceHashes), objectInfo.Checksums[checksumIndex]) {
+		return &types.AuditFailure{Kind: types.AuditCorrupted, Detail: "the piece hashes do not match the checksum on chain"}
+	}
+	return nil
+}
+
+// spOperatorAddress returns the operator address of the SP, or empty if the SP is unknown.
+func (c *Client) spOperatorAddress(spID uint32) string {
+	if sp, ok := c.getStorageProviders()[spID]; ok {
+		return sp.OperatorAddress.String()
+	}
+	return ""
+}
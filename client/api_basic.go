@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"cosmossdk.io/errors"
@@ -15,11 +18,16 @@ import (
 	"github.com/cometbft/cometbft/votepool"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
 
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
 	gosdktypes "github.com/bnb-chain/greenfield-go-sdk/types"
+	sdkclient "github.com/bnb-chain/greenfield/sdk/client"
 	"github.com/bnb-chain/greenfield/sdk/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
 	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
 )
 
@@ -43,15 +51,25 @@ type IBasicClient interface {
 	WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx, error)
 	WaitForNBlocks(ctx context.Context, n int64) error
 	WaitForNextBlock(ctx context.Context) error
+	WaitForHeightWithProgress(ctx context.Context, height int64, progress func(current, target int64)) error
 
 	SimulateTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption, opts ...grpc.CallOption) (*tx.SimulateResponse, error)
+	EstimateFee(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption) (uint64, sdk.Coins, error)
 	SimulateRawTx(ctx context.Context, txBytes []byte, opts ...grpc.CallOption) (*tx.SimulateResponse, error)
 	BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error)
+	NewTxBuilder() *TxBuilder
 	BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool) (*sdk.TxResponse, error)
 
 	BroadcastVote(ctx context.Context, vote votepool.Vote) error
 	QueryVote(ctx context.Context, eventType int, eventHash []byte) (*ctypes.ResultQueryVote, error)
 	SetTag(ctx context.Context, resourceGRN string, tags storageTypes.ResourceTags, opts gosdktypes.SetTagsOptions) (string, error)
+
+	HealthCheck(ctx context.Context, opts gosdktypes.HealthCheckOptions) (*gosdktypes.HealthReport, error)
+	ClockSkew() time.Duration
+	Explorer() (gosdktypes.ExplorerTemplates, error)
+	GetSPHealth() []gosdktypes.SPHealthScore
+	AtHeight(height int64) (IClient, error)
+	PinnedHeight() int64
 }
 
 // EnableTrace support trace error info the request and the response
@@ -66,6 +84,14 @@ func (c *Client) EnableTrace(output io.Writer, onlyTraceErr bool) {
 	c.isTraceEnabled = true
 }
 
+// ClockSkew - Get the drift of the SP clock from the local clock measured by the latest SP response, it is used to
+// shift the timestamps of the requests when Option.CompensateClockSkew is set.
+//
+// - ret: The drift, it is positive if the SP clock is ahead of the local clock.
+func (c *Client) ClockSkew() time.Duration {
+	return time.Duration(c.state.clockSkew.Load())
+}
+
 // GetNodeInfo - Get the current node info of the greenfield that the Client is connected to.
 //
 // - ctx: Context variables for the current API call.
@@ -119,19 +145,74 @@ func (c *Client) GetCommit(ctx context.Context, height int64) (*ctypes.ResultCom
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool) (*sdk.TxResponse, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
 	var mode tx.BroadcastMode
 	if sync {
 		mode = tx.BroadcastMode_BROADCAST_MODE_SYNC
 	} else {
 		mode = tx.BroadcastMode_BROADCAST_MODE_ASYNC
 	}
-	broadcastTxResponse, err := c.chainClient.TxClient.BroadcastTx(ctx, &tx.BroadcastTxRequest{TxBytes: txBytes, Mode: mode})
+	request := &tx.BroadcastTxRequest{TxBytes: txBytes, Mode: mode}
+	if len(c.broadcastClients) > 0 {
+		return c.broadcastRawTxToNodes(ctx, request)
+	}
+	broadcastTxResponse, err := c.chainClient.TxClient.BroadcastTx(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 	return broadcastTxResponse.TxResponse, nil
 }
 
+// broadcastRawTxToNodes broadcasts the tx to the primary and the additional nodes concurrently. The first response
+// accepting the tx is returned, the tx is identified by its hash so the duplicated deliveries are harmless. If none of
+// the nodes accepts the tx, the response rejecting it or the error of the last node is returned.
+func (c *Client) broadcastRawTxToNodes(ctx context.Context, request *tx.BroadcastTxRequest) (*sdk.TxResponse, error) {
+	nodes := append([]*sdkclient.GreenfieldClient{c.chainClient}, c.broadcastClients...)
+	type broadcastResult struct {
+		resp *sdk.TxResponse
+		err  error
+	}
+	results := make(chan broadcastResult, len(nodes))
+	for _, node := range nodes {
+		go func(node *sdkclient.GreenfieldClient) {
+			broadcastTxResponse, err := node.TxClient.BroadcastTx(ctx, request)
+			if err != nil {
+				results <- broadcastResult{err: err}
+				return
+			}
+			results <- broadcastResult{resp: broadcastTxResponse.TxResponse}
+		}(node)
+	}
+
+	var (
+		rejected *sdk.TxResponse
+		lastErr  error
+	)
+	for range nodes {
+		result := <-results
+		switch {
+		case result.err != nil:
+			lastErr = result.err
+		case isTxAccepted(result.resp):
+			return result.resp, nil
+		case rejected == nil:
+			rejected = result.resp
+		}
+	}
+	if rejected != nil {
+		return rejected, nil
+	}
+	return nil, lastErr
+}
+
+// isTxAccepted reports whether the tx has been accepted by the node, or by another node whose mempool is gossiped to
+// it. The codes of the other codespaces may collide with the one of ErrTxInMempoolCache.
+func isTxAccepted(resp *sdk.TxResponse) bool {
+	return resp.Code == 0 || (resp.Codespace == sdkerrors.RootCodespace && resp.Code == sdkerrors.ErrTxInMempoolCache.ABCICode())
+}
+
 // SimulateRawTx - Simulate the execution of a raw transaction on the blockchain without broadcasting it to the network.
 //
 // - ctx: Context variables for the current API call.
@@ -193,25 +274,84 @@ func (c *Client) GetLatestBlockHeight(ctx context.Context) (int64, error) {
 //
 // - ret: Return error when the request failed, otherwise return nil.
 func (c *Client) WaitForBlockHeight(ctx context.Context, h int64) error {
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
+	return c.WaitForHeightWithProgress(ctx, h, nil)
+}
 
+// WaitForHeightWithProgress - Wait until a specified block height is committed and report the progress on each poll.
+//
+// The polling interval adapts to the average block time of the chain, the long waits poll sparsely at first and
+// densely when approaching the height, which reduces the RPC load.
+//
+// - ctx: Context variables for the current API call.
+//
+// - height: The block height to wait for.
+//
+// - progress: The callback invoked with the latest and the target block heights on each poll, it can be nil.
+//
+// - ret: Return error when the request failed or ctx is done, otherwise return nil.
+func (c *Client) WaitForHeightWithProgress(ctx context.Context, height int64, progress func(current, target int64)) error {
+	var blockTime time.Duration
 	for {
 		latestBlockHeight, err := c.GetLatestBlockHeight(ctx)
 		if err != nil {
 			return err
 		}
-		if latestBlockHeight >= h {
+		if progress != nil {
+			progress(latestBlockHeight, height)
+		}
+		if latestBlockHeight >= height {
 			return nil
 		}
+		if blockTime == 0 {
+			blockTime = c.averageBlockTime(ctx)
+		}
+
+		timer := time.NewTimer(nextBlockPollInterval(height-latestBlockHeight, blockTime))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return errors.Wrap(ctx.Err(), "timeout exceeded waiting for block")
-		case <-ticker.C:
+		case <-timer.C:
 		}
 	}
 }
 
+// nextBlockPollInterval returns the interval before polling the height again. It covers half of the expected time of
+// the remaining blocks, so that the polls converge exponentially to the target height.
+func nextBlockPollInterval(remaining int64, blockTime time.Duration) time.Duration {
+	interval := time.Duration(remaining) * blockTime / 2
+	if interval < gosdktypes.MinBlockPollInterval {
+		interval = gosdktypes.MinBlockPollInterval
+	}
+	return interval
+}
+
+// averageBlockTime returns the average block time of the recent blocks, the measured value is cached by the client.
+// gosdktypes.DefaultBlockTime is returned if it can not be measured.
+func (c *Client) averageBlockTime(ctx context.Context) time.Duration {
+	if cached := c.state.blockTime.Load(); cached > 0 {
+		return time.Duration(cached)
+	}
+	latest, err := c.GetLatestBlock(ctx)
+	if err != nil {
+		return gosdktypes.DefaultBlockTime
+	}
+	sampleHeight := latest.Header.Height - gosdktypes.BlockTimeSampleBlocks
+	if sampleHeight < 1 {
+		return gosdktypes.DefaultBlockTime
+	}
+	sample, err := c.GetBlockByHeight(ctx, sampleHeight)
+	if err != nil {
+		return gosdktypes.DefaultBlockTime
+	}
+	blockTime := latest.Header.Time.Sub(sample.Header.Time) / gosdktypes.BlockTimeSampleBlocks
+	if blockTime <= 0 {
+		return gosdktypes.DefaultBlockTime
+	}
+	c.state.blockTime.Store(int64(blockTime))
+	return blockTime
+}
+
 // WaitForNextBlock - Wait until the next block is committed since current block.
 //
 // - ctx: Context variables for the current API call.
@@ -246,6 +386,14 @@ func (c *Client) WaitForNBlocks(ctx context.Context, n int64) error {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx, error) {
+	ctx, span := c.startSpan(ctx, "greenfield.chain/WaitForTx", attribute.String("greenfield.tx.hash", hash))
+	txResponse, err := c.waitForTx(ctx, hash)
+	endSpan(span, err)
+	return txResponse, err
+}
+
+// waitForTx polls the tx until it is committed, it is the body of WaitForTx.
+func (c *Client) waitForTx(ctx context.Context, hash string) (*ctypes.ResultTx, error) {
 	for {
 		var (
 			txResponse *ctypes.ResultTx
@@ -284,12 +432,19 @@ func (c *Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx,
 			continue
 		}
 		// Tx found
+		if txResponse.TxResult.Code == 0 {
+			c.recordWriteHeight(txResponse.Height)
+		}
 		return txResponse, nil
 	}
 }
 
 // BroadcastTx - Broadcast a transaction containing the provided message(s) to the chain.
 //
+// The gas limit and the fee are set from the simulation and bumped on the insufficient fee rejections if the dynamic
+// fee mode is enabled by Option.DynamicFee, unless they are set by txOpt or txOpt.NoSimulate is set. The expired SP approvals of the msgs are
+// re-requested if Option.RefreshExpiredApprovals is set.
+//
 // - ctx: Context variables for the current API call.
 //
 // - msgs: Message(s) to be broadcast to blockchain.
@@ -302,6 +457,9 @@ func (c *Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx,
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	if err := c.checkWritable(); err != nil {
+		return nil, err
+	}
 	if len(msgs) == 0 {
 		return nil, fmt.Errorf("msg is not provided in the transaction")
 	}
@@ -310,16 +468,74 @@ func (c *Client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.T
 			return nil, err
 		}
 	}
+	if err := c.guardDeletion(ctx, msgs); err != nil {
+		return nil, err
+	}
+	if c.refreshExpiredApprovals {
+		var err error
+		if msgs, err = c.refreshSignedApprovals(ctx, msgs); err != nil {
+			return nil, fmt.Errorf("fail to refresh the SP approvals: %w", err)
+		}
+	}
+	if c.useDynamicFee(txOpt) {
+		return c.broadcastTxWithDynamicFee(ctx, msgs, txOpt, opts...)
+	}
+	return c.broadcastTx(ctx, msgs, txOpt, opts...)
+}
+
+// broadcastTx broadcasts the transaction of the validated msgs, the response is returned with the error if the
+// transaction is rejected.
+func (c *Client) broadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	ctx, span := c.startSpan(ctx, "greenfield.chain/BroadcastTx", attribute.Int("greenfield.tx.msgs", len(msgs)))
+	start := time.Now()
 	resp, err := c.chainClient.BroadcastTx(ctx, msgs, txOpt, opts...)
+	c.observeTxBroadcast(len(msgs), resp, start)
 	if err != nil {
+		endSpan(span, err)
 		return nil, err
 	}
+	span.SetAttributes(attribute.String("greenfield.tx.hash", resp.TxResponse.TxHash))
 	if resp.TxResponse.Code != 0 {
-		return resp, fmt.Errorf("the tx has failed with response code: %d, codespace:%s", resp.TxResponse.Code, resp.TxResponse.Codespace)
+		err = fmt.Errorf("the tx has failed with response code: %d, codespace:%s", resp.TxResponse.Code, resp.TxResponse.Codespace)
+		endSpan(span, err)
+		return resp, err
 	}
+	endSpan(span, nil)
 	return resp, nil
 }
 
+// guardDeletion invokes the deletion guard with the buckets and objects deleted by msgs, it returns the error of the
+// guard if the deletion is refused.
+func (c *Client) guardDeletion(ctx context.Context, msgs []sdk.Msg) error {
+	if c.deletionGuard == nil {
+		return nil
+	}
+	req := gosdktypes.DeletionRequest{}
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case *storageTypes.MsgDeleteBucket:
+			target := gosdktypes.DeletionTarget{BucketName: m.BucketName}
+			// the missing bucket is passed without info, the tx fails on chain anyway
+			if bucketInfo, err := c.HeadBucket(ctx, m.BucketName); err == nil {
+				target.BucketInfo = bucketInfo
+			}
+			req.Operator = m.Operator
+			req.Targets = append(req.Targets, target)
+		case *storageTypes.MsgDeleteObject:
+			target := gosdktypes.DeletionTarget{BucketName: m.BucketName, ObjectName: m.ObjectName}
+			if objectDetail, err := c.HeadObject(ctx, m.BucketName, m.ObjectName); err == nil {
+				target.ObjectInfo = objectDetail.ObjectInfo
+			}
+			req.Operator = m.Operator
+			req.Targets = append(req.Targets, target)
+		}
+	}
+	if len(req.Targets) == 0 {
+		return nil
+	}
+	return c.deletionGuard(ctx, req)
+}
+
 // SimulateTx - Simulate a transaction containing the provided message(s) on the chain.
 //
 // - ctx: Context variables for the current API call.
@@ -337,6 +553,74 @@ func (c *Client) SimulateTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxO
 	return c.chainClient.SimulateTx(ctx, msgs, &txOpt, opts...)
 }
 
+// EstimateFee - Estimate the gas limit and the fee of a transaction containing the provided message(s) without
+// broadcasting it, e.g. to show the cost to the users before they confirm the transaction.
+//
+// The transaction is simulated, the gas used is multiplied by the gas adjustment of the client, and the fee is the
+// gas limit multiplied by the min gas price returned by the simulation. The gas limit of txOpt is used instead if it
+// is set. Broadcast with the gas limit and the fee amount of the estimate in txOpt to pay the estimated fee.
+//
+// - ctx: Context variables for the current API call.
+//
+// - msgs: Message(s) to be broadcast to blockchain.
+//
+// - txOpt: TxOpt contains options for customizing the transaction.
+//
+// - ret1: The estimated gas limit of the transaction.
+//
+// - ret2: The estimated fee of the transaction.
+//
+// - ret3: Return error when the msgs are invalid or the transaction fails in the simulation, otherwise return nil.
+func (c *Client) EstimateFee(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption) (uint64, sdk.Coins, error) {
+	if len(msgs) == 0 {
+		return 0, nil, fmt.Errorf("msg is not provided in the transaction")
+	}
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return 0, nil, err
+		}
+	}
+	gasLimit, gasPrice, err := c.estimateGas(ctx, msgs, txOpt)
+	if err != nil {
+		return 0, nil, err
+	}
+	return gasLimit, txFee(gasPrice, gasLimit), nil
+}
+
+// estimateGas simulates the transaction and returns the adjusted gas limit, or the gas limit of txOpt if it is set,
+// and the min gas price of the chain returned by the simulation.
+func (c *Client) estimateGas(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption) (uint64, sdk.Coin, error) {
+	resp, err := c.SimulateTx(ctx, msgs, txOpt)
+	if err != nil {
+		return 0, sdk.Coin{}, err
+	}
+	if resp.GasInfo == nil {
+		return 0, sdk.Coin{}, fmt.Errorf("the gas info is not returned by the simulation")
+	}
+	gasLimit := txOpt.GasLimit
+	if gasLimit == 0 {
+		gasLimit = adjustGas(resp.GasInfo.GetGasUsed(), c.gasAdjustment)
+	}
+	gasPrice, err := sdk.ParseCoinNormalized(resp.GasInfo.GetMinGasPrice())
+	if err != nil {
+		return 0, sdk.Coin{}, fmt.Errorf("fail to parse the min gas price %q: %w", resp.GasInfo.GetMinGasPrice(), err)
+	}
+	return gasLimit, gasPrice, nil
+}
+
+// txFee returns the fee of the gas limit at the gas price.
+func txFee(gasPrice sdk.Coin, gasLimit uint64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(sdk.NewIntFromUint64(gasLimit))))
+}
+
+// adjustGas multiplies the gas by the adjustment and rounds it up.
+func adjustGas(gas uint64, adjustment float64) uint64 {
+	if adjustment <= 1 {
+		return gas
+	}
+	return uint64(math.Ceil(float64(gas) * adjustment))
+}
+
 // GetSyncing - Retrieve the syncing status of the node.
 //
 // - ctx: Context variables for the current API call.
@@ -424,6 +708,9 @@ func (c *Client) GetValidatorsByHeight(ctx context.Context, height int64) ([]*bf
 //
 // - ret: Return error when the request failed, otherwise return nil.
 func (c *Client) BroadcastVote(ctx context.Context, vote votepool.Vote) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
 	return c.chainClient.BroadcastVote(ctx, vote)
 }
 
@@ -465,3 +752,165 @@ func (c *Client) SetTag(ctx context.Context, resourceGRN string, tags storageTyp
 	}
 	return resp.TxResponse.TxHash, err
 }
+
+// HealthCheck - Check the health of the chain node, the storage providers and the default account.
+//
+// It verifies the chain node is reachable and not catching up, lists the in-service SPs, probes the SP endpoints
+// and checks the balance of the default account. The failed items are recorded in the report instead of returned.
+//
+// - ctx: Context variables for the current API call.
+//
+// - opts: The options to set the SPs to be probed and the required balance.
+//
+// - ret1: The health report, its Healthy field is true if all the checked items are healthy.
+//
+// - ret2: Return error when the options are invalid, otherwise return nil.
+func (c *Client) HealthCheck(ctx context.Context, opts gosdktypes.HealthCheckOptions) (*gosdktypes.HealthReport, error) {
+	report := &gosdktypes.HealthReport{CheckedAt: c.clock.Now(), Healthy: true}
+	probeTimeout := opts.ProbeTimeout
+	if probeTimeout == 0 {
+		probeTimeout = 5 * time.Second
+	}
+
+	// check the chain node
+	status, err := c.chainClient.GetStatus(ctx)
+	if err != nil {
+		report.Chain.Error = err.Error()
+		report.Healthy = false
+	} else {
+		report.Chain.Reachable = true
+		report.Chain.CatchingUp = status.SyncInfo.CatchingUp
+		report.Chain.LatestBlockHeight = status.SyncInfo.LatestBlockHeight
+		report.Chain.LatestBlockTime = status.SyncInfo.LatestBlockTime
+		if status.SyncInfo.CatchingUp {
+			report.Chain.Error = "the chain node is catching up"
+			report.Healthy = false
+		}
+	}
+
+	// select the SPs to be probed
+	var probes []gosdktypes.SPHealth
+	spList, err := c.ListStorageProviders(ctx, true)
+	if err != nil {
+		report.Healthy = false
+		probes = append(probes, gosdktypes.SPHealth{Error: fmt.Sprintf("fail to list in-service SPs: %s", err.Error())})
+	} else {
+		report.InServiceSPCount = len(spList)
+		switch {
+		case opts.BucketName != "":
+			sp, err := c.pickStorageProviderByBucket(ctx, opts.BucketName)
+			if err != nil {
+				probes = append(probes, gosdktypes.SPHealth{Error: fmt.Sprintf("fail to route SP of bucket %s: %s", opts.BucketName, err.Error())})
+			} else {
+				probes = append(probes, gosdktypes.SPHealth{ID: sp.Id, OperatorAddress: sp.OperatorAddress.String(), Endpoint: sp.EndPoint.String()})
+			}
+		case opts.ProbeAllSPs:
+			for _, sp := range spList {
+				probes = append(probes, c.newSPHealth(sp))
+			}
+		case len(spList) > 0:
+			probes = append(probes, c.newSPHealth(spList[0]))
+		default:
+			probes = append(probes, gosdktypes.SPHealth{Error: "no in-service SP"})
+		}
+	}
+
+	// probe the SPs concurrently
+	var wg sync.WaitGroup
+	for i := range probes {
+		if probes[i].Error != "" {
+			continue
+		}
+		wg.Add(1)
+		go func(probe *gosdktypes.SPHealth) {
+			defer wg.Done()
+			c.probeSP(ctx, probe, probeTimeout)
+		}(&probes[i])
+	}
+	wg.Wait()
+	for _, probe := range probes {
+		if !probe.Reachable {
+			report.Healthy = false
+		}
+	}
+	report.StorageProviders = probes
+
+	// check the balance of the default account
+	if c.defaultAccount != nil {
+		address := c.defaultAccount.GetAddress().String()
+		report.Account = &gosdktypes.AccountHealth{Address: address}
+		balance, err := c.GetAccountBalance(ctx, address)
+		if err != nil {
+			report.Account.Error = err.Error()
+		} else {
+			report.Account.Balance = balance.String()
+			report.Account.Sufficient = opts.MinBalance.IsNil() || balance.Amount.GTE(opts.MinBalance)
+			if !report.Account.Sufficient {
+				report.Account.Error = fmt.Sprintf("the balance is less than %s", opts.MinBalance.String())
+			}
+		}
+		if report.Account.Error != "" {
+			report.Healthy = false
+		}
+	}
+
+	return report, nil
+}
+
+// newSPHealth constructs the SPHealth of the SP to be probed
+func (c *Client) newSPHealth(sp spTypes.StorageProvider) gosdktypes.SPHealth {
+	health := gosdktypes.SPHealth{ID: sp.Id, OperatorAddress: sp.OperatorAddress}
+	useHttps := c.secure
+	if strings.Contains(sp.Endpoint, "https") {
+		useHttps = true
+	}
+	endpoint, err := utils.GetEndpointURL(sp.Endpoint, useHttps)
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Endpoint = endpoint.String()
+	return health
+}
+
+// probeSP sends a request to the SP endpoint, the SP is reachable if it responds without a server error
+func (c *Client) probeSP(ctx context.Context, probe *gosdktypes.SPHealth, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.Endpoint, nil)
+	if err != nil {
+		probe.Error = err.Error()
+		return
+	}
+	req.Header.Set(gosdktypes.HTTPHeaderUserAgent, c.userAgent)
+	start := c.clock.Now()
+	resp, err := c.doSPRequest(req)
+	probe.Latency = c.clock.Now().Sub(start)
+	if err != nil {
+		probe.Error = err.Error()
+		return
+	}
+	utils.CloseResponse(resp)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		probe.Error = fmt.Sprintf("the SP responds with status code %d", resp.StatusCode)
+		return
+	}
+	probe.Reachable = true
+}
+
+// Explorer - Get the URL templates of the block explorer of the connected network, e.g. to render the "view on
+// explorer" links of the txs, buckets, objects and accounts.
+//
+// - ret1: The URL templates configured by Option.Explorer, or those of the preset matching the chain id.
+//
+// - ret2: Return ErrNoExplorer when no block explorer is known for the network, otherwise return nil.
+func (c *Client) Explorer() (gosdktypes.ExplorerTemplates, error) {
+	if c.explorer != nil {
+		return *c.explorer, nil
+	}
+	preset, ok := gosdktypes.PresetByChainID(c.chainID)
+	if !ok || preset.Explorer.Tx == "" {
+		return gosdktypes.ExplorerTemplates{}, fmt.Errorf("%w: %s", gosdktypes.ErrNoExplorer, c.chainID)
+	}
+	return preset.Explorer, nil
+}
@@ -0,0 +1,291 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdkclient "github.com/bnb-chain/greenfield/sdk/client"
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// fakeTxClient serves the tx broadcasts and simulations by the functions set, the other calls panic.
+type fakeTxClient struct {
+	tx.ServiceClient
+	broadcastTx func(req *tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error)
+	simulate    func(req *tx.SimulateRequest) (*tx.SimulateResponse, error)
+}
+
+func (f *fakeTxClient) BroadcastTx(_ context.Context, req *tx.BroadcastTxRequest, _ ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	return f.broadcastTx(req)
+}
+
+func (f *fakeTxClient) Simulate(_ context.Context, req *tx.SimulateRequest, _ ...grpc.CallOption) (*tx.SimulateResponse, error) {
+	return f.simulate(req)
+}
+
+// fakeAuthQueryClient serves the account queries of the signer, the other queries panic.
+type fakeAuthQueryClient struct {
+	authtypes.QueryClient
+	account *authtypes.BaseAccount
+}
+
+func (f *fakeAuthQueryClient) Account(context.Context, *authtypes.QueryAccountRequest, ...grpc.CallOption) (*authtypes.QueryAccountResponse, error) {
+	account, err := codectypes.NewAnyWithValue(f.account)
+	if err != nil {
+		return nil, err
+	}
+	return &authtypes.QueryAccountResponse{Account: account}, nil
+}
+
+// newTxTestClient returns the client signing the txs by a new account and broadcasting them by txClient, the txs
+// should skip the simulation unless txClient serves the simulations.
+func newTxTestClient(t *testing.T, txClient *fakeTxClient) *Client {
+	return newTxTestClientWithRPC(t, "http://127.0.0.1:26657", txClient)
+}
+
+// newTxTestClientWithRPC returns the tx test client whose tendermint queries, e.g. the tx queries of WaitForTx, are
+// served by the RPC server of rpcAddr.
+func newTxTestClientWithRPC(t *testing.T, rpcAddr string, txClient *fakeTxClient) *Client {
+	account, _, err := types.NewAccount("test")
+	require.NoError(t, err)
+	chainClient, err := sdkclient.NewGreenfieldClient(rpcAddr, "greenfield_9000-121",
+		sdkclient.WithKeyManager(account.GetKeyManager()))
+	require.NoError(t, err)
+	chainClient.TxClient = txClient
+	chainClient.AuthQueryClient = &fakeAuthQueryClient{account: authtypes.NewBaseAccount(account.GetAddress(), nil, 1, 0)}
+	return newTestClient(t, func(c *Client) {
+		c.chainClient = chainClient
+		c.defaultAccount = account
+	})
+}
+
+func TestNextBlockPollInterval(t *testing.T) {
+	blockTime := 2 * time.Second
+	// the long waits poll sparsely and converge to the target height
+	require.Equal(t, 100*time.Second, nextBlockPollInterval(100, blockTime))
+	require.Equal(t, 2*time.Second, nextBlockPollInterval(2, blockTime))
+	require.Equal(t, time.Second, nextBlockPollInterval(1, blockTime))
+	// the fast chains are not polled more often than the min interval
+	require.Equal(t, types.MinBlockPollInterval, nextBlockPollInterval(1, 50*time.Millisecond))
+}
+
+func TestGuardDeletion(t *testing.T) {
+	var guarded types.DeletionRequest
+	c := newTestClient(t, func(c *Client) {
+		c.deletionGuard = func(ctx context.Context, req types.DeletionRequest) error {
+			guarded = req
+			return types.ProtectPrefixes("backup/")(ctx, req)
+		}
+	})
+	operator := sdk.AccAddress(make([]byte, 20))
+	ctx := WithHeadCache(context.Background())
+	objectInfo := &storageTypes.ObjectInfo{BucketName: "bucket", ObjectName: "tmp/a"}
+	headCacheFromContext(ctx).setObject("bucket", "tmp/a", &types.ObjectDetail{ObjectInfo: objectInfo})
+	headCacheFromContext(ctx).setObject("bucket", "backup/b", &types.ObjectDetail{ObjectInfo: &storageTypes.ObjectInfo{}})
+
+	// the txs not deleting anything are not guarded
+	require.NoError(t, c.guardDeletion(ctx, []sdk.Msg{storageTypes.NewMsgSetTag(operator, "grn", nil)}))
+	require.Empty(t, guarded.Targets)
+
+	require.NoError(t, c.guardDeletion(ctx, []sdk.Msg{storageTypes.NewMsgDeleteObject(operator, "bucket", "tmp/a")}))
+	require.Equal(t, operator.String(), guarded.Operator)
+	require.Len(t, guarded.Targets, 1)
+	require.Same(t, objectInfo, guarded.Targets[0].ObjectInfo)
+
+	// the batched deletes are refused as a whole
+	err := c.guardDeletion(ctx, []sdk.Msg{
+		storageTypes.NewMsgDeleteObject(operator, "bucket", "tmp/a"),
+		storageTypes.NewMsgDeleteObject(operator, "bucket", "backup/b"),
+	})
+	require.ErrorIs(t, err, types.ErrDeletionDenied)
+	require.Len(t, guarded.Targets, 2)
+}
+
+func TestAdjustGas(t *testing.T) {
+	require.Equal(t, uint64(1200), adjustGas(1000, types.DefaultGasAdjustment))
+	// the adjusted gas is rounded up
+	require.Equal(t, uint64(1501), adjustGas(1000, 1.5001))
+	// the gas is not reduced by the adjustments less than 1
+	require.Equal(t, uint64(1000), adjustGas(1000, 0.5))
+	require.Equal(t, uint64(0), adjustGas(0, 2))
+}
+
+func TestBroadcastRawTxToNodes(t *testing.T) {
+	var broadcasts atomic.Int32
+	newNode := func(delay time.Duration, resp *sdk.TxResponse, err error) *sdkclient.GreenfieldClient {
+		return &sdkclient.GreenfieldClient{TxClient: &fakeTxClient{broadcastTx: func(req *tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error) {
+			broadcasts.Add(1)
+			require.Equal(t, []byte("tx"), req.TxBytes)
+			time.Sleep(delay)
+			if err != nil {
+				return nil, err
+			}
+			return &tx.BroadcastTxResponse{TxResponse: resp}, nil
+		}}}
+	}
+	accepted := &sdk.TxResponse{TxHash: "hash"}
+	inMempool := &sdk.TxResponse{TxHash: "hash", Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrTxInMempoolCache.ABCICode()}
+	rejected := &sdk.TxResponse{TxHash: "hash", Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrInsufficientFee.ABCICode()}
+	// the code of ErrTxInMempoolCache in another codespace does not accept the tx
+	collided := &sdk.TxResponse{TxHash: "hash", Codespace: "storage", Code: sdkerrors.ErrTxInMempoolCache.ABCICode()}
+
+	testCases := []struct {
+		name       string
+		nodes      []*sdkclient.GreenfieldClient
+		resp       *sdk.TxResponse
+		err        bool
+		broadcasts int32
+	}{
+		{"tx in the mempool of a node", []*sdkclient.GreenfieldClient{
+			newNode(0, rejected, nil), newNode(10*time.Millisecond, inMempool, nil),
+		}, inMempool, false, 2},
+		{"collided code of another codespace", []*sdkclient.GreenfieldClient{
+			newNode(0, collided, nil), newNode(10*time.Millisecond, nil, errors.New("unavailable")),
+		}, collided, false, 2},
+		{"all nodes rejected", []*sdkclient.GreenfieldClient{
+			newNode(0, rejected, nil), newNode(0, nil, errors.New("unavailable")), newNode(0, rejected, nil),
+		}, rejected, false, 3},
+		{"all nodes failed", []*sdkclient.GreenfieldClient{
+			newNode(0, nil, errors.New("unavailable")), newNode(0, nil, errors.New("unavailable")),
+		}, nil, true, 2},
+		// the slow nodes are still broadcasting after the case returns, so it is the last one
+		{"first success wins", []*sdkclient.GreenfieldClient{
+			newNode(time.Second, accepted, nil), newNode(0, accepted, nil), newNode(time.Second, rejected, nil),
+		}, accepted, false, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broadcasts.Store(0)
+			c := newTestClient(t, func(c *Client) {
+				c.chainClient, c.broadcastClients = tc.nodes[0], tc.nodes[1:]
+			})
+			start := time.Now()
+			resp, err := c.BroadcastRawTx(context.Background(), []byte("tx"), true)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.resp, resp)
+			if tc.broadcasts > 0 {
+				// the tx is broadcast to all the nodes when none of them accepts it at first
+				require.Equal(t, tc.broadcasts, broadcasts.Load())
+			} else {
+				// the accepting response is returned without waiting for the slow nodes
+				require.Less(t, time.Since(start), time.Second)
+			}
+		})
+	}
+}
+
+// steppingClock advances by step on each reading, so the duration between two readings is step.
+type steppingClock struct {
+	start time.Time
+	step  time.Duration
+	reads atomic.Int64
+}
+
+func (c *steppingClock) Now() time.Time {
+	return c.start.Add(time.Duration(c.reads.Add(1)-1) * c.step)
+}
+
+func TestHealthCheck(t *testing.T) {
+	blockTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		catchingUp  bool
+		spStatus    int
+		balance     int64
+		healthy     bool
+		chainError  string
+		spError     string
+		balanceLess bool
+	}{
+		{name: "healthy", spStatus: http.StatusOK, balance: 100, healthy: true},
+		{name: "client error of SP", spStatus: http.StatusNotFound, balance: 100, healthy: true},
+		{name: "catching up", catchingUp: true, spStatus: http.StatusOK, balance: 100, chainError: "the chain node is catching up"},
+		{name: "server error of SP", spStatus: http.StatusServiceUnavailable, balance: 100, spError: "the SP responds with status code 503"},
+		{name: "insufficient balance", spStatus: http.StatusOK, balance: 99, balanceLess: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					ID     json.RawMessage `json:"id"`
+					Method string          `json:"method"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				require.Equal(t, "status", req.Method)
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"sync_info":{"latest_block_height":"100","latest_block_time":%q,"catching_up":%t}}}`,
+					req.ID, blockTime.Format(time.RFC3339), tc.catchingUp)
+			}))
+			defer rpcServer.Close()
+			spServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.spStatus)
+			}))
+			defer spServer.Close()
+
+			c := newTxTestClientWithRPC(t, rpcServer.URL, &fakeTxClient{})
+			clock := &steppingClock{start: blockTime.Add(time.Minute), step: 20 * time.Millisecond}
+			c.clock = clock
+			c.httpClient = spServer.Client()
+			c.userAgent = types.UserAgent
+			c.chainClient.SpQueryClient = &fakeSpQueryClient{storageProviders: []*spTypes.StorageProvider{
+				{Id: 1, Endpoint: "http://127.0.0.1:1", Status: spTypes.STATUS_IN_JAILED},
+				{Id: 2, Endpoint: spServer.URL, Status: spTypes.STATUS_IN_SERVICE},
+			}}
+			bankQuery := &fakeBankQueryClient{}
+			bankQuery.balance.Store(tc.balance)
+			c.chainClient.BankQueryClient = bankQuery
+
+			report, err := c.HealthCheck(context.Background(), types.HealthCheckOptions{MinBalance: sdkmath.NewInt(100)})
+			require.NoError(t, err)
+			require.Equal(t, tc.healthy, report.Healthy)
+			// the check time and the latency are read from the client clock
+			require.Equal(t, clock.start, report.CheckedAt)
+
+			require.True(t, report.Chain.Reachable)
+			require.Equal(t, tc.catchingUp, report.Chain.CatchingUp)
+			require.Equal(t, int64(100), report.Chain.LatestBlockHeight)
+			require.True(t, blockTime.Equal(report.Chain.LatestBlockTime))
+			require.Equal(t, tc.chainError, report.Chain.Error)
+
+			// only the in-service SP is probed
+			require.Equal(t, 1, report.InServiceSPCount)
+			require.Len(t, report.StorageProviders, 1)
+			probe := report.StorageProviders[0]
+			require.Equal(t, uint32(2), probe.ID)
+			require.Equal(t, tc.spError == "", probe.Reachable)
+			require.Equal(t, tc.spError, probe.Error)
+			require.Equal(t, clock.step, probe.Latency)
+
+			require.Equal(t, c.defaultAccount.GetAddress().String(), report.Account.Address)
+			require.Equal(t, sdk.NewInt64Coin(gnfdsdk.Denom, tc.balance).String(), report.Account.Balance)
+			require.Equal(t, !tc.balanceLess, report.Account.Sufficient)
+			if tc.balanceLess {
+				require.Equal(t, "the balance is less than 100", report.Account.Error)
+			} else {
+				require.Empty(t, report.Account.Error)
+			}
+		})
+	}
+}
@@ -61,6 +61,9 @@ type IBucketClient interface {
 	SetBucketFlowRateLimit(ctx context.Context, bucketName string, paymentAddr, bucketOwner sdk.AccAddress, flowRateLimit sdkmath.Int, opt types.SetBucketFlowRateLimitOption) (string, error)
 	GetPaymentAccountFlowRateLimit(ctx context.Context, paymentAddr, bucketOwner sdk.AccAddress, bucketName string) (*storageTypes.QueryPaymentAccountBucketFlowRateLimitResponse, error)
 	GetRecommendedVirtualGroupFamilyIDBySPID(ctx context.Context, spID uint32) (uint32, error)
+	SetBucketDefaults(bucketName string, defaults types.BucketDefaults) error
+	RemoveBucketDefaults(bucketName string)
+	GetBucketDefaults(bucketName string) (types.BucketDefaults, bool)
 }
 
 // GetCreateBucketApproval - Send create bucket approval request to SP and returns the signature info for the approval of preCreating resources.
@@ -120,6 +123,11 @@ func (c *Client) GetCreateBucketApproval(ctx context.Context, createBucketMsg *s
 	var signedMsg storageTypes.MsgCreateBucket
 	storageTypes.ModuleCdc.MustUnmarshalJSON(signedMsgBytes, &signedMsg)
 
+	if c.spResponseVerification != types.SPResponseVerificationOff {
+		if err = c.checkSPVerification("createBucket approval", c.VerifyCreateBucketApproval(ctx, &signedMsg)); err != nil {
+			return nil, err
+		}
+	}
 	return &signedMsg, nil
 }
 
@@ -139,11 +147,56 @@ func (c *Client) GetCreateBucketApproval(ctx context.Context, createBucketMsg *s
 //
 // - ret2: Return error if create bucket failed, otherwise return nil.
 func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (string, error) {
-	address, err := sdk.AccAddressFromHexUnsafe(primaryAddr)
+	msgs, err := c.createBucketMsgs(ctx, bucketName, primaryAddr, opts)
 	if err != nil {
 		return "", err
 	}
 
+	// set the default txn broadcast mode as block mode
+	if opts.TxOpts == nil {
+		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
+		opts.TxOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
+	}
+	resp, err := c.BroadcastTx(ctx, msgs, opts.TxOpts)
+	if err != nil {
+		return "", err
+	}
+	txnHash := resp.TxResponse.TxHash
+	if !opts.IsAsyncMode {
+		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
+		defer cancel()
+		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
+		if err != nil {
+			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+		}
+		if txnResponse.TxResult.Code != 0 {
+			return txnHash, fmt.Errorf("the createBucket txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+		}
+	}
+	return txnHash, nil
+}
+
+// createBucketMsgs builds the msgs to create the bucket, i.e. the createBucket msg and the setTag msg if opts.Tags is set.
+func (c *Client) createBucketMsgs(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) ([]sdk.Msg, error) {
+	if defaults, ok := c.GetBucketDefaults(bucketName); ok {
+		defaults.ApplyToBucket(&opts)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	address, err := sdk.AccAddressFromHexUnsafe(primaryAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	storageClass, err := c.getStorageClass(opts.StorageClass)
+	if err != nil {
+		return nil, err
+	}
+	if storageClass != nil {
+		storageClass.ApplyToBucket(&opts)
+	}
+
 	var visibility storageTypes.VisibilityType
 	if opts.Visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
 		visibility = storageTypes.VISIBILITY_TYPE_PRIVATE // set default visibility type
@@ -155,7 +208,7 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 	if opts.PaymentAddress != "" {
 		paymentAddr, err = sdk.AccAddressFromHexUnsafe(opts.PaymentAddress)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
@@ -163,17 +216,17 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 
 	err = createBucketMsg.ValidateBasic()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	accAddress, err := sdk.AccAddressFromHexUnsafe(primaryAddr)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	sp, err := c.GetStorageProviderInfo(ctx, accAddress)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	familyID, err := c.GetRecommendedVirtualGroupFamilyIDBySPID(ctx, sp.Id)
@@ -182,18 +235,13 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 		var signedMsg *storageTypes.MsgCreateBucket
 		signedMsg, err = c.GetCreateBucketApproval(ctx, createBucketMsg)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		familyID = signedMsg.PrimarySpApproval.GlobalVirtualGroupFamilyId
 	}
 
 	createBucketMsg.PrimarySpApproval.GlobalVirtualGroupFamilyId = familyID
 
-	// set the default txn broadcast mode as block mode
-	if opts.TxOpts == nil {
-		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
-		opts.TxOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
-	}
 	msgs := []sdk.Msg{createBucketMsg}
 
 	if opts.Tags != nil {
@@ -202,23 +250,7 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 		msgSetTag := storageTypes.NewMsgSetTag(c.MustGetDefaultAccount().GetAddress(), grn.String(), opts.Tags)
 		msgs = append(msgs, msgSetTag)
 	}
-	resp, err := c.BroadcastTx(ctx, msgs, opts.TxOpts)
-	if err != nil {
-		return "", err
-	}
-	txnHash := resp.TxResponse.TxHash
-	if !opts.IsAsyncMode {
-		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
-		defer cancel()
-		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
-		if err != nil {
-			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
-		}
-		if txnResponse.TxResult.Code != 0 {
-			return txnHash, fmt.Errorf("the createBucket txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
-		}
-	}
-	return txnHash, nil
+	return msgs, nil
 }
 
 // DeleteBucket - Send DeleteBucket msg to greenfield chain and return txn hash.
@@ -339,7 +371,7 @@ func (c *Client) GetPaymentAccountFlowRateLimit(ctx context.Context, paymentAddr
 		BucketName:     bucketName,
 	}
 
-	queryFlowRateLimitResp, err := c.chainClient.QueryPaymentAccountBucketFlowRateLimit(ctx, &queryFlowRateLimit)
+	queryFlowRateLimitResp, err := c.chainClient.QueryPaymentAccountBucketFlowRateLimit(c.queryContext(ctx), &queryFlowRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -361,6 +393,11 @@ func (c *Client) GetPaymentAccountFlowRateLimit(ctx context.Context, paymentAddr
 //
 // - ret2: Return error if update bucket meta failed, otherwise return nil.
 func (c *Client) UpdateBucketInfo(ctx context.Context, bucketName string, opts types.UpdateBucketOptions) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+	// memoize the bucket and object lookups of the routing
+	ctx = WithHeadCache(ctx)
 	bucketInfo, err := c.HeadBucket(ctx, bucketName)
 	if err != nil {
 		return "", err
@@ -430,15 +467,33 @@ func (c *Client) ToggleSPAsDelegatedAgent(ctx context.Context, bucketName string
 //
 // - ret2: Return error if bucket not exist, otherwise return nil.
 func (c *Client) HeadBucket(ctx context.Context, bucketName string) (*storageTypes.BucketInfo, error) {
-	queryHeadBucketRequest := storageTypes.QueryHeadBucketRequest{
-		BucketName: bucketName,
+	cache := headCacheFromContext(ctx)
+	if cache != nil {
+		if bucketInfo, ok := cache.getBucket(bucketName); ok {
+			return bucketInfo, nil
+		}
 	}
-	queryHeadBucketResponse, err := c.chainClient.HeadBucket(ctx, &queryHeadBucketRequest)
+
+	// the concurrent queries of the same bucket share one query
+	val, err := c.dedupQuery(ctx, "HeadBucket", bucketName, func() (interface{}, error) {
+		queryHeadBucketRequest := storageTypes.QueryHeadBucketRequest{
+			BucketName: bucketName,
+		}
+		queryHeadBucketResponse, err := c.chainClient.HeadBucket(c.queryContext(ctx), &queryHeadBucketRequest)
+		if err != nil {
+			return nil, err
+		}
+		return queryHeadBucketResponse.BucketInfo, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	bucketInfo := val.(*storageTypes.BucketInfo)
 
-	return queryHeadBucketResponse.BucketInfo, nil
+	if cache != nil {
+		cache.setBucket(bucketName, bucketInfo)
+	}
+	return bucketInfo, nil
 }
 
 // HeadBucketByID - query the bucketInfo on chain by the bucket id, return the bucket info if exists.
@@ -455,7 +510,7 @@ func (c *Client) HeadBucketByID(ctx context.Context, bucketID string) (*storageT
 		BucketId: bucketID,
 	}
 
-	headBucketResponse, err := c.chainClient.HeadBucketById(ctx, headBucketRequest)
+	headBucketResponse, err := c.chainClient.HeadBucketById(c.queryContext(ctx), headBucketRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -542,7 +597,7 @@ func (c *Client) IsBucketPermissionAllowed(ctx context.Context, userAddr string,
 		ActionType: action,
 	}
 
-	verifyResp, err := c.chainClient.VerifyPermission(ctx, &verifyReq)
+	verifyResp, err := c.chainClient.VerifyPermission(c.queryContext(ctx), &verifyReq)
 	if err != nil {
 		return permTypes.EFFECT_DENY, err
 	}
@@ -571,7 +626,7 @@ func (c *Client) GetBucketPolicy(ctx context.Context, bucketName string, princip
 		PrincipalAddress: principalAddr,
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(ctx, &queryPolicy)
+	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(c.queryContext(ctx), &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -644,39 +699,44 @@ func (c *Client) ListBuckets(ctx context.Context, opts types.ListBucketsOptions)
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getEndpointByOpt(&types.EndPointOptions{
+	endpoints, err := c.getEndpointsByOpt(&types.EndPointOptions{
 		Endpoint:  opts.Endpoint,
 		SPAddress: opts.SPAddress,
-	})
+	}, opts.FanOut)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
 		return types.ListBucketsResult{}, err
 	}
 
-	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
-	if err != nil {
-		log.Error().Msg("the list of user's buckets failed: " + err.Error())
-		return types.ListBucketsResult{}, err
-	}
-	defer utils.CloseResponse(resp)
-
-	listBucketsResult := types.ListBucketsResult{}
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
+	results := make([]types.ListBucketsResult, len(endpoints))
+	infos := make([]types.ResponseInfo, len(endpoints))
+	var index int
+	err = c.queryConsistent(ctx, opts.Consistency, func() error {
+		index, err = c.queryFirstSP(ctx, endpoints, func(ctx context.Context, index int, endpoint *url.URL) error {
+			resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+			if err != nil {
+				log.Error().Msg("the list of user's buckets failed: " + err.Error())
+				return err
+			}
+			defer utils.CloseResponse(resp)
+			setResponseInfo(&infos[index], resp)
+
+			if err = c.checkMetaFreshness(ctx, resp, opts.Consistency); err != nil {
+				return err
+			}
+			// decode the xml content from response body
+			return c.decodeXMLResponse(resp, &results[index])
+		})
+		return err
+	})
 	if err != nil {
-		log.Error().Msg("the list of user's buckets failed: " + err.Error())
 		return types.ListBucketsResult{}, err
 	}
-
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &listBucketsResult)
-	// TODO(annie) remove tolerance for unmarshal err after structs got stabilized
-	if err != nil {
-		return types.ListBucketsResult{}, err
+	if opts.ResponseInfo != nil {
+		*opts.ResponseInfo = infos[index]
 	}
 
-	return listBucketsResult, nil
+	return results[index], nil
 }
 
 // ListBucketReadRecord - List the download record info of the specific bucket of the current month.
@@ -694,11 +754,19 @@ func (c *Client) ListBucketReadRecord(ctx context.Context, bucketName string, op
 	if err := s3util.CheckValidBucketName(bucketName); err != nil {
 		return types.QuotaRecordInfo{}, err
 	}
+	if err := opts.Validate(); err != nil {
+		return types.QuotaRecordInfo{}, err
+	}
+	if opts.PageToken != "" {
+		position, _ := opts.PageToken.Position(types.PageReadRecords)
+		startTimeStamp, err := strconv.ParseInt(position, 10, 64)
+		if err != nil {
+			return types.QuotaRecordInfo{}, fmt.Errorf("%w: %v", types.ErrInvalidPageToken, err)
+		}
+		opts.StartTimeStamp = startTimeStamp
+	}
 	timeNow := time.Now()
 	timeToday := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, timeNow.Location())
-	if opts.StartTimeStamp < 0 {
-		return types.QuotaRecordInfo{}, errors.New("start timestamp  less than 0")
-	}
 	var startTimeStamp int64
 	if opts.StartTimeStamp == 0 {
 		// the timestamp of the first day of this month
@@ -735,7 +803,7 @@ func (c *Client) ListBucketReadRecord(ctx context.Context, bucketName string, op
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return types.QuotaRecordInfo{}, err
@@ -749,10 +817,13 @@ func (c *Client) ListBucketReadRecord(ctx context.Context, bucketName string, op
 
 	QuotaRecords := types.QuotaRecordInfo{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(resp.Body).Decode(&QuotaRecords)
+	err = c.decodeXMLResponse(resp, &QuotaRecords)
 	if err != nil {
 		return types.QuotaRecordInfo{}, err
 	}
+	if QuotaRecords.NextStartTimestampUs != 0 {
+		QuotaRecords.NextPageToken = types.NewPageToken(types.PageReadRecords, strconv.FormatInt(QuotaRecords.NextStartTimestampUs, 10))
+	}
 
 	return QuotaRecords, nil
 }
@@ -794,7 +865,7 @@ func (c *Client) GetBucketReadQuota(ctx context.Context, bucketName string) (typ
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return types.QuotaInfo{}, err
@@ -808,7 +879,7 @@ func (c *Client) GetBucketReadQuota(ctx context.Context, bucketName string) (typ
 
 	QuotaResult := types.QuotaInfo{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(resp.Body).Decode(&QuotaResult)
+	err = c.decodeXMLResponse(resp, &QuotaResult)
 	if err != nil {
 		return types.QuotaInfo{}, err
 	}
@@ -826,7 +897,7 @@ func (c *Client) GetBucketReadQuota(ctx context.Context, bucketName string) (typ
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetQuotaUpdateTime(ctx context.Context, bucketName string) (int64, error) {
-	resp, err := c.chainClient.QueryQuotaUpdateTime(ctx, &storageTypes.QueryQuoteUpdateTimeRequest{
+	resp, err := c.chainClient.QueryQuotaUpdateTime(c.queryContext(ctx), &storageTypes.QueryQuoteUpdateTimeRequest{
 		BucketName: bucketName,
 	})
 	if err != nil {
@@ -914,36 +985,42 @@ func (c *Client) ListBucketsByBucketID(ctx context.Context, bucketIds []uint64,
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getEndpointByOpt(&opts)
+	endpoints, err := c.getEndpointsByOpt(&opts, opts.FanOut)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
 		return types.ListBucketsByBucketIDResponse{}, err
 
 	}
 
-	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
-	if err != nil {
-		return types.ListBucketsByBucketIDResponse{}, err
-	}
-	defer utils.CloseResponse(resp)
-
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
+	results := make([]types.ListBucketsByBucketIDResponse, len(endpoints))
+	infos := make([]types.ResponseInfo, len(endpoints))
+	var index int
+	err = c.queryConsistent(ctx, opts.Consistency, func() error {
+		index, err = c.queryFirstSP(ctx, endpoints, func(ctx context.Context, index int, endpoint *url.URL) error {
+			resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+			if err != nil {
+				return err
+			}
+			defer utils.CloseResponse(resp)
+			setResponseInfo(&infos[index], resp)
+
+			if err = c.checkMetaFreshness(ctx, resp, opts.Consistency); err != nil {
+				return err
+			}
+			// decode the xml content from response body
+			return c.decodeXMLResponse(resp, (*listBucketsByIDsResponse)(&results[index].Buckets))
+		})
+		return err
+	})
 	if err != nil {
 		log.Error().Msgf("the list of buckets in bucket ids:%v failed: %s", bucketIds, err.Error())
 		return types.ListBucketsByBucketIDResponse{}, err
 	}
-
-	buckets := types.ListBucketsByBucketIDResponse{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), (*listBucketsByIDsResponse)(&buckets.Buckets))
-	if err != nil && buckets.Buckets == nil {
-		log.Error().Msgf("the list of buckets in bucket ids:%v failed: %s", bucketIds, err.Error())
-		return types.ListBucketsByBucketIDResponse{}, err
+	if opts.ResponseInfo != nil {
+		*opts.ResponseInfo = infos[index]
 	}
 
-	return buckets, nil
+	return results[index], nil
 }
 
 // GetMigrateBucketApproval - Send migrate get approval request to the storage provider and return the signed MsgMigrateBucket by SP.
@@ -1153,17 +1230,11 @@ func (c *Client) ListBucketsByPaymentAccount(ctx context.Context, paymentAccount
 	}
 	defer utils.CloseResponse(resp)
 
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		return types.ListBucketsByPaymentAccountResult{}, errors.New("copy the response error" + err.Error())
-	}
-
 	buckets := types.ListBucketsByPaymentAccountResult{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &buckets)
+	// decode the xml content from response body
+	err = c.decodeXMLResponse(resp, &buckets)
 	if err != nil {
-		return types.ListBucketsByPaymentAccountResult{}, errors.New("unmarshal response error" + err.Error())
+		return types.ListBucketsByPaymentAccountResult{}, fmt.Errorf("unmarshal response error: %w", err)
 	}
 
 	return buckets, nil
@@ -1223,7 +1294,7 @@ func (c *Client) getMigrationStateFromSP(ctx context.Context, bucketName string,
 
 	migrationProgress := types.MigrationProgress{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(resp.Body).Decode(&migrationProgress)
+	err = c.decodeXMLResponse(resp, &migrationProgress)
 	if err != nil {
 		return types.MigrationProgress{}, err
 	}
@@ -1257,7 +1328,7 @@ func (c *Client) getRecommendedVirtualGroupFamilyIDBySPEndpoint(ctx context.Cont
 		return 0, err
 	}
 	vgf := types.VirtualGroupFamily{}
-	err = xml.NewDecoder(resp.Body).Decode(&vgf)
+	err = c.decodeXMLResponse(resp, &vgf)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// fakeSpQueryClient serves the SP queries by the operator address and the SP list, the other queries panic.
+type fakeSpQueryClient struct {
+	spTypes.QueryClient
+	storageProvider  *spTypes.StorageProvider
+	storageProviders []*spTypes.StorageProvider
+}
+
+func (f *fakeSpQueryClient) StorageProviders(context.Context, *spTypes.QueryStorageProvidersRequest, ...grpc.CallOption) (*spTypes.QueryStorageProvidersResponse, error) {
+	return &spTypes.QueryStorageProvidersResponse{Sps: f.storageProviders}, nil
+}
+
+func (f *fakeSpQueryClient) StorageProviderByOperatorAddress(context.Context, *spTypes.QueryStorageProviderByOperatorAddressRequest, ...grpc.CallOption) (*spTypes.QueryStorageProviderByOperatorAddressResponse, error) {
+	return &spTypes.QueryStorageProviderByOperatorAddressResponse{StorageProvider: f.storageProvider}, nil
+}
+
+// newCreateBucketTestClient returns the tx test client whose primary SP recommends the virtual group family 7, and the
+// operator address of the SP.
+func newCreateBucketTestClient(t *testing.T, txClient *fakeTxClient) (*Client, string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasSuffix(r.URL.Path, "get-recommended-vgf"), r.URL.Path)
+		w.Write([]byte("<VirtualGroupFamily><Id>7</Id></VirtualGroupFamily>"))
+	}))
+	t.Cleanup(server.Close)
+
+	spAccount, _, err := types.NewAccount("sp")
+	require.NoError(t, err)
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c := newTxTestClient(t, txClient)
+	c.chainClient.SpQueryClient = &fakeSpQueryClient{storageProvider: &spTypes.StorageProvider{Id: 1, OperatorAddress: spAccount.GetAddress().String()}}
+	c.httpClient = server.Client()
+	c.setStorageProviders(map[uint32]*types.StorageProvider{1: {Id: 1, OperatorAddress: spAccount.GetAddress(), EndPoint: endpoint}})
+	return c, spAccount.GetAddress().String()
+}
+
+func TestCreateBucketMsgs(t *testing.T) {
+	c, primaryAddr := newCreateBucketTestClient(t, &fakeTxClient{})
+	c.storageClasses = map[string]types.StorageClass{"public": {Name: "public", BucketVisibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ}}
+	paymentAddr := c.defaultAccount.GetAddress().String()
+	tags := &storageTypes.ResourceTags{Tags: []storageTypes.ResourceTags_Tag{{Key: "team", Value: "storage"}}}
+
+	testCases := []struct {
+		name        string
+		bucketName  string
+		primaryAddr string
+		opts        types.CreateBucketOptions
+		visibility  storageTypes.VisibilityType
+		payment     string
+		errContains string
+	}{
+		{name: "default options", bucketName: "bucket", primaryAddr: primaryAddr, visibility: storageTypes.VISIBILITY_TYPE_PRIVATE},
+		{
+			name: "custom options", bucketName: "bucket", primaryAddr: primaryAddr,
+			opts:       types.CreateBucketOptions{Visibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ, PaymentAddress: paymentAddr, Tags: tags},
+			visibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ, payment: paymentAddr,
+		},
+		{
+			name: "storage class", bucketName: "bucket", primaryAddr: primaryAddr,
+			opts: types.CreateBucketOptions{StorageClass: "public"}, visibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ,
+		},
+		{name: "invalid primary address", bucketName: "bucket", primaryAddr: "invalid", errContains: "invalid address hex length"},
+		{name: "invalid payment address", bucketName: "bucket", primaryAddr: primaryAddr, opts: types.CreateBucketOptions{PaymentAddress: "invalid"}, errContains: "PaymentAddress"},
+		{name: "unknown storage class", bucketName: "bucket", primaryAddr: primaryAddr, opts: types.CreateBucketOptions{StorageClass: "cold"}, errContains: "the storage class cold is not defined"},
+		{name: "invalid bucket name", bucketName: "B", primaryAddr: primaryAddr, errContains: "Bucket name cannot be shorter than 3 bytes"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msgs, err := c.createBucketMsgs(context.Background(), tc.bucketName, tc.primaryAddr, tc.opts)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			createBucketMsg, ok := msgs[0].(*storageTypes.MsgCreateBucket)
+			require.True(t, ok)
+			require.Equal(t, c.defaultAccount.GetAddress().String(), createBucketMsg.Creator)
+			require.Equal(t, tc.bucketName, createBucketMsg.BucketName)
+			require.Equal(t, primaryAddr, createBucketMsg.PrimarySpAddress)
+			require.Equal(t, tc.visibility, createBucketMsg.Visibility)
+			require.Equal(t, tc.payment, createBucketMsg.PaymentAddress)
+			// the family recommended by the primary SP is used
+			require.Equal(t, uint32(7), createBucketMsg.PrimarySpApproval.GlobalVirtualGroupFamilyId)
+			if tc.opts.Tags == nil {
+				require.Len(t, msgs, 1)
+				return
+			}
+			require.Len(t, msgs, 2)
+			setTagMsg, ok := msgs[1].(*storageTypes.MsgSetTag)
+			require.True(t, ok)
+			require.Equal(t, gnfdTypes.NewBucketGRN(tc.bucketName).String(), setTagMsg.Resource)
+			require.Equal(t, tags, setTagMsg.Tags)
+		})
+	}
+}
+
+func TestCreateBucket(t *testing.T) {
+	var broadcasts []*tx.BroadcastTxRequest
+	c, primaryAddr := newCreateBucketTestClient(t, &fakeTxClient{broadcastTx: func(req *tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error) {
+		broadcasts = append(broadcasts, req)
+		return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "0xabc"}}, nil
+	}})
+	broadcastMode := tx.BroadcastMode_BROADCAST_MODE_ASYNC
+	txOpts := &gnfdsdk.TxOption{
+		Mode:       &broadcastMode,
+		NoSimulate: true,
+		GasLimit:   1000,
+		FeeAmount:  sdk.NewCoins(sdk.NewInt64Coin(gnfdsdk.Denom, 5000000000000)),
+	}
+
+	txHash, err := c.CreateBucket(context.Background(), "bucket", primaryAddr, types.CreateBucketOptions{
+		Tags:        &storageTypes.ResourceTags{Tags: []storageTypes.ResourceTags_Tag{{Key: "team", Value: "storage"}}},
+		TxOpts:      txOpts,
+		IsAsyncMode: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", txHash)
+	require.Len(t, broadcasts, 1)
+	require.Equal(t, broadcastMode, broadcasts[0].Mode)
+	// the bucket is created and tagged in one tx
+	body := decodeTxBody(t, broadcasts[0].TxBytes)
+	require.Len(t, body.Messages, 2)
+	require.Equal(t, sdk.MsgTypeURL(&storageTypes.MsgCreateBucket{}), body.Messages[0].TypeUrl)
+	require.Equal(t, sdk.MsgTypeURL(&storageTypes.MsgSetTag{}), body.Messages[1].TypeUrl)
+
+	// the msgs are not broadcast if they fail to build
+	_, err = c.CreateBucket(context.Background(), "bucket", "invalid", types.CreateBucketOptions{TxOpts: txOpts, IsAsyncMode: true})
+	require.Error(t, err)
+	require.Len(t, broadcasts, 1)
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// ICapabilityClient - Client APIs for discovering the capabilities of the SPs.
+type ICapabilityClient interface {
+	Capabilities(ctx context.Context, spAddr string) (*types.SPCapabilities, error)
+}
+
+const (
+	// capabilityProbeBucket and capabilityProbeObject are the fake resources which the probes refer to
+	capabilityProbeBucket = "gnfd-capability-probe"
+	capabilityProbeObject = "probe"
+	// capabilityProbeTimeout is the timeout of each probe
+	capabilityProbeTimeout = 5 * time.Second
+)
+
+// capabilityProbe is the request whose routing by SP tells whether SP supports the capability.
+type capabilityProbe struct {
+	method string
+	meta   requestMeta
+}
+
+var capabilityProbes = map[types.Capability]capabilityProbe{
+	types.CapabilityResumableUpload: {method: http.MethodGet, meta: requestMeta{
+		bucketName: capabilityProbeBucket, objectName: capabilityProbeObject,
+		urlValues: url.Values{"upload-context": []string{""}},
+	}},
+	types.CapabilityDelegatedUpload: {method: http.MethodPut, meta: requestMeta{
+		bucketName: capabilityProbeBucket, objectName: capabilityProbeObject,
+		urlValues: url.Values{"delegate": []string{""}, "is_update": []string{"false"}, "payload_size": []string{"0"}},
+	}},
+	types.CapabilityOffChainAuthV1: {method: http.MethodGet, meta: requestMeta{urlRelPath: "auth/request_nonce"}},
+	types.CapabilityOffChainAuthV2: {method: http.MethodGet, meta: requestMeta{urlRelPath: "auth/keys_v2"}},
+}
+
+// Capabilities - Discover which API capabilities the SP supports, so that the applications can branch gracefully
+// across the SPs of different versions, e.g. fall back to the single request upload if the resumable upload is not
+// supported.
+//
+// Each capability is probed by an unsigned request to its API referring to the fake resources, it is supported if SP
+// routes the request to the API. The capabilities which the SDK does not implement are reported unknown. The probed
+// capabilities are cached for types.DefaultCapabilityTTL.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddr: The HEX-encoded operator address of the SP.
+//
+// - ret1: The capability set of the SP, the failed probes are reported as unknown with the errors.
+//
+// - ret2: Return error when the SP is not found on chain, otherwise return nil.
+func (c *Client) Capabilities(ctx context.Context, spAddr string) (*types.SPCapabilities, error) {
+	if cached, ok := c.state.capabilities.Load(spAddr); ok {
+		capabilities := cached.(*types.SPCapabilities)
+		if time.Since(capabilities.ProbedAt) < types.DefaultCapabilityTTL {
+			return capabilities, nil
+		}
+	}
+	endpoint, err := c.getSPUrlByAddr(spAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &types.SPCapabilities{
+		OperatorAddress: spAddr,
+		Endpoint:        endpoint.String(),
+		ProbedAt:        time.Now(),
+		Statuses:        make(map[types.Capability]types.CapabilityStatus),
+		Errors:          make(map[types.Capability]string),
+		SDKSupported:    make(map[types.Capability]bool),
+	}
+	for _, capability := range types.SDKCapabilities {
+		capabilities.SDKSupported[capability] = true
+	}
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, capability := range types.KnownCapabilities {
+		probe, ok := capabilityProbes[capability]
+		if !ok {
+			capabilities.Statuses[capability] = types.CapabilityUnknown
+			capabilities.Errors[capability] = "the SDK does not implement the capability"
+			continue
+		}
+		wg.Add(1)
+		go func(capability types.Capability, probe capabilityProbe) {
+			defer wg.Done()
+			status, err := c.probeCapability(ctx, probe, endpoint)
+			mu.Lock()
+			defer mu.Unlock()
+			capabilities.Statuses[capability] = status
+			if err != nil {
+				capabilities.Errors[capability] = err.Error()
+			}
+		}(capability, probe)
+	}
+	wg.Wait()
+
+	// the capabilities are not cached if some probes fail, so that they are probed again
+	if len(capabilities.Errors) == len(types.KnownCapabilities)-len(types.SDKCapabilities) {
+		c.state.capabilities.Store(spAddr, capabilities)
+	}
+	return capabilities, nil
+}
+
+func (c *Client) probeCapability(ctx context.Context, probe capabilityProbe, endpoint *url.URL) (types.CapabilityStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, capabilityProbeTimeout)
+	defer cancel()
+	req, err := c.buildRequest(ctx, probe.method, probe.meta, nil, "", AdminAPIInfo{}, endpoint)
+	if err != nil {
+		return types.CapabilityUnknown, err
+	}
+	resp, err := c.doSPRequest(req)
+	if err != nil {
+		return types.CapabilityUnknown, err
+	}
+	defer utils.CloseResponse(resp)
+	body, err := types.ReadLimitedBody(resp.Body, types.MaxErrResponseBodySize)
+	if err != nil {
+		return types.CapabilityUnknown, err
+	}
+	status := types.CapabilityStatusFromProbe(resp.StatusCode, body)
+	if status == types.CapabilityUnknown {
+		return status, fmt.Errorf("the SP responds with status code %d", resp.StatusCode)
+	}
+	return status, nil
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
@@ -128,7 +127,7 @@ func (c *Client) GetChallengeInfo(ctx context.Context, objectID string, pieceInd
 
 		if redundancyIndex == types.PrimaryRedundancyIndex {
 			// get endpoint of primary sp
-			endpoint, err = c.getSPUrlByBucket(objectDetail.ObjectInfo.BucketName)
+			endpoint, err = c.getSPUrlByBucket(ctx, objectDetail.ObjectInfo.BucketName)
 			if err != nil {
 				log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %v", objectDetail.ObjectInfo.BucketName, err))
 				return types.ChallengeResult{}, err
@@ -155,7 +154,7 @@ func (c *Client) GetChallengeInfo(ctx context.Context, objectID string, pieceInd
 	if opts.UseV2version {
 		challengeV2Info := types.ChallengeV2Result{}
 		// decode the xml content from response body
-		err = xml.NewDecoder(resp.Body).Decode(&challengeV2Info)
+		err = c.decodeXMLResponse(resp, &challengeV2Info)
 		if err != nil {
 			utils.CloseResponse(resp)
 			return types.ChallengeResult{}, err
@@ -204,6 +203,13 @@ func (c *Client) GetChallengeInfo(ctx context.Context, objectID string, pieceInd
 		}
 	}
 
+	if c.spResponseVerification != types.SPResponseVerificationOff {
+		err = c.checkSPVerification("challenge piece", c.verifyChallengeResult(ctx, objectID, pieceIndex, redundancyIndex, &result))
+		if err != nil {
+			result.PieceData.Close()
+			return types.ChallengeResult{}, err
+		}
+	}
 	return result, nil
 }
 
@@ -315,7 +321,7 @@ func (c *Client) AttestChallenge(ctx context.Context, submitterAddress, challeng
 //
 // - ret2: Return error when getting latest attested challenges failed, otherwise return nil.
 func (c *Client) LatestAttestedChallenges(ctx context.Context, req *challengetypes.QueryLatestAttestedChallengesRequest) (*challengetypes.QueryLatestAttestedChallengesResponse, error) {
-	return c.chainClient.LatestAttestedChallenges(ctx, req)
+	return c.chainClient.LatestAttestedChallenges(c.queryContext(ctx), req)
 }
 
 // InturnAttestationSubmitter - Query the in-turn validator to submit challenge attestation.
@@ -330,7 +336,7 @@ func (c *Client) LatestAttestedChallenges(ctx context.Context, req *challengetyp
 //
 // - ret2: Return error when getting in-turn attestation submitter failed, otherwise return nil.
 func (c *Client) InturnAttestationSubmitter(ctx context.Context, req *challengetypes.QueryInturnAttestationSubmitterRequest) (*challengetypes.QueryInturnAttestationSubmitterResponse, error) {
-	return c.chainClient.InturnAttestationSubmitter(ctx, req)
+	return c.chainClient.InturnAttestationSubmitter(c.queryContext(ctx), req)
 }
 
 // ChallengeParams - Get challenge module's parameters of Greenfield blockchain.
@@ -343,5 +349,5 @@ func (c *Client) InturnAttestationSubmitter(ctx context.Context, req *challenget
 //
 // - ret2: Return error when getting parameters failed, otherwise return nil.
 func (c *Client) ChallengeParams(ctx context.Context, req *challengetypes.QueryParamsRequest) (*challengetypes.QueryParamsResponse, error) {
-	return c.chainClient.ChallengeQueryClient.Params(ctx, req)
+	return c.chainClient.ChallengeQueryClient.Params(c.queryContext(ctx), req)
 }
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// ICheckpointClient - Client APIs for inspecting and aborting the pending resumable transfers recorded by the
+// checkpoint store.
+type ICheckpointClient interface {
+	ListCheckpoints(ctx context.Context) ([]*types.CheckpointInfo, error)
+	InspectCheckpoint(ctx context.Context, key string) (*types.CheckpointInfo, error)
+	AbortCheckpoint(ctx context.Context, key string, opts types.AbortCheckpointOptions) error
+}
+
+// ListCheckpoints - List the pending resumable transfers recorded by the checkpoint store of the client.
+//
+// - ctx: Context variables for the current API call.
+//
+// - ret1: The pending transfers, the corrupted checkpoints are included with Corrupted set.
+//
+// - ret2: Return types.ErrCheckpointListUnsupported if the store can not list the checkpoints, e.g. the default
+// store keeping the checkpoints next to the temp files, otherwise return the error of the store or nil.
+func (c *Client) ListCheckpoints(ctx context.Context) ([]*types.CheckpointInfo, error) {
+	checkpoints := c.resolveCheckpointStore()
+	keys, err := checkpoints.List()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*types.CheckpointInfo, 0, len(keys))
+	for _, key := range keys {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		content, err := checkpoints.Load(key)
+		if err != nil {
+			// the checkpoint is removed by the finished transfer
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		infos = append(infos, types.DecodeCheckpointInfo(key, content))
+	}
+	return infos, nil
+}
+
+// InspectCheckpoint - Describe the pending resumable transfer recorded by the checkpoint of key.
+//
+// - ctx: Context variables for the current API call.
+//
+// - key: The key of the checkpoint, i.e. the temp file path of the download, or types.UploadCheckpointKey of the upload.
+//
+// - ret1: The pending transfer.
+//
+// - ret2: Return error wrapping fs.ErrNotExist if there is no such checkpoint, otherwise return nil.
+func (c *Client) InspectCheckpoint(ctx context.Context, key string) (*types.CheckpointInfo, error) {
+	content, err := c.resolveCheckpointStore().Load(key)
+	if err != nil {
+		return nil, err
+	}
+	return types.DecodeCheckpointInfo(key, content), nil
+}
+
+// AbortCheckpoint - Abort the pending resumable transfer recorded by the checkpoint of key, and remove the checkpoint.
+//
+// The temp file of the download is removed. The object of the upload is canceled on chain if it is not sealed yet
+// and opts.CancelCreateObject is set, so that the quota held by it is released.
+//
+// - ctx: Context variables for the current API call.
+//
+// - key: The key of the checkpoint.
+//
+// - opts: The options to abort the transfer.
+//
+// - ret: Return error when the checkpoint does not exist or the transfer fails to abort, otherwise return nil.
+func (c *Client) AbortCheckpoint(ctx context.Context, key string, opts types.AbortCheckpointOptions) error {
+	checkpoints := c.resolveCheckpointStore()
+	info, err := c.InspectCheckpoint(ctx, key)
+	if err != nil {
+		return err
+	}
+	switch {
+	case info.Kind == types.CheckpointDownload:
+		if err = c.fileSystem.Remove(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	case opts.CancelCreateObject && !info.Corrupted:
+		detail, err := c.HeadObject(ctx, info.BucketName, info.ObjectName)
+		if err != nil {
+			return err
+		}
+		if detail.ObjectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_CREATED {
+			txnHash, err := c.CancelCreateObject(ctx, info.BucketName, info.ObjectName, types.CancelCreateOption{TxOpts: opts.TxOpts})
+			if err != nil {
+				return fmt.Errorf("fail to cancel the creation of object %s: %w", info.ObjectName, err)
+			}
+			if _, err = c.WaitForTx(ctx, txnHash); err != nil {
+				return err
+			}
+		}
+	}
+	if err = checkpoints.Delete(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// resolveCheckpointStore returns the checkpoint store of the client, the download checkpoints are kept next to the
+// temp files by default.
+func (c *Client) resolveCheckpointStore() types.CheckpointStore {
+	if c.checkpointStore != nil {
+		return c.checkpointStore
+	}
+	return types.FileCheckpointStore{FileSystem: c.fileSystem}
+}
+
+// loadUploadCheckpoint returns the checkpoint of the upload, it returns nil if the upload checkpoints are not
+// recorded. The checkpoint recorded for another object, e.g. recreated with another content, or another part size is
+// replaced.
+func (c *Client) loadUploadCheckpoint(bucketName, objectName string, objectSize int64, partSize uint64,
+	objectID, integrityHash string,
+) *types.UploadCheckpoint {
+	if c.checkpointStore == nil {
+		return nil
+	}
+	checkpoint := &types.UploadCheckpoint{
+		BucketName: bucketName, ObjectName: objectName, ObjectID: objectID, IntegrityHash: integrityHash,
+		ObjectSize: objectSize, PartSize: partSize,
+	}
+	content, err := c.checkpointStore.Load(types.UploadCheckpointKey(bucketName, objectName))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Warn().Msg(fmt.Sprintf("fail to load the upload checkpoint of object %s: %s", objectName, err.Error()))
+		}
+		return checkpoint
+	}
+	loaded, err := types.DecodeUploadCheckpoint(content)
+	if err != nil {
+		log.Warn().Msg(fmt.Sprintf("restart the upload checkpoint of object %s: %s", objectName, err.Error()))
+		return checkpoint
+	}
+	if loaded.SameUpload(checkpoint) {
+		loaded.ObjectID, loaded.IntegrityHash = objectID, integrityHash
+		return loaded
+	}
+	return checkpoint
+}
+
+// uploadCheckpointLease is the lock of the upload checkpoint held by the client, it does nothing if the checkpoint
+// store does not implement types.CheckpointLocker.
+type uploadCheckpointLease struct {
+	locker types.CheckpointLocker
+	key    string
+	holder string
+}
+
+// lockUploadCheckpoint locks the upload checkpoint, so that the upload is not resumed by two workers sharing the
+// checkpoint store at the same time. The error wraps types.ErrCheckpointLocked if another worker holds the lock.
+func (c *Client) lockUploadCheckpoint(bucketName, objectName string) (*uploadCheckpointLease, error) {
+	lease := &uploadCheckpointLease{key: types.UploadCheckpointKey(bucketName, objectName)}
+	locker, ok := c.checkpointStore.(types.CheckpointLocker)
+	if !ok {
+		return lease, nil
+	}
+	lease.locker, lease.holder = locker, newCheckpointHolder()
+	if err := lease.renew(); err != nil {
+		return nil, fmt.Errorf("fail to lock the upload checkpoint of object %s: %w", objectName, err)
+	}
+	return lease, nil
+}
+
+// renew extends the lock by types.DefaultCheckpointLockTTL.
+func (l *uploadCheckpointLease) renew() error {
+	if l.locker == nil {
+		return nil
+	}
+	return l.locker.TryLock(l.key, l.holder, types.DefaultCheckpointLockTTL)
+}
+
+func (l *uploadCheckpointLease) release() {
+	if l.locker == nil {
+		return
+	}
+	if err := l.locker.Unlock(l.key, l.holder); err != nil {
+		log.Warn().Msg(fmt.Sprintf("fail to unlock the upload checkpoint %s: %s", l.key, err.Error()))
+	}
+}
+
+// newCheckpointHolder identifies the holder of the checkpoint lock by the host, the process and a random suffix, so
+// that the uploads in the same process are distinguished.
+func newCheckpointHolder() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+func (c *Client) saveUploadCheckpoint(checkpoint *types.UploadCheckpoint) error {
+	content, err := checkpoint.Encode()
+	if err != nil {
+		return err
+	}
+	return c.checkpointStore.Save(types.UploadCheckpointKey(checkpoint.BucketName, checkpoint.ObjectName), content)
+}
+
+func (c *Client) deleteUploadCheckpoint(bucketName, objectName string) {
+	err := c.checkpointStore.Delete(types.UploadCheckpointKey(bucketName, objectName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warn().Msg(fmt.Sprintf("fail to remove the upload checkpoint of object %s: %s", objectName, err.Error()))
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/xml"
 	"errors"
@@ -15,11 +16,18 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
 	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
 	httplib "github.com/bnb-chain/greenfield-common/go/http"
@@ -49,16 +57,33 @@ type IClient interface {
 	IFeeGrantClient
 	IVirtualGroupClient
 	IAuthClient
+	IUploadAuthClient
+	IApprovalClient
+	IStorageProofClient
+	IParamsClient
+	IEventClient
+	IReplicaClient
+	IRawClient
+	ISyncClient
+	IInventoryClient
+	IAuditClient
+	IDiscontinueClient
+	ICapabilityClient
+	IEncryptionClient
+	IDatasetClient
+	ICheckpointClient
 }
 
 // Client - The implementation for IClient, implement all Client APIs for Greenfield SDK.
 type Client struct {
 	// The chain Client is used to interact with the blockchain
 	chainClient *sdkclient.GreenfieldClient
+	// The chain Clients of the additional nodes which the raw txs are broadcast to
+	broadcastClients []*sdkclient.GreenfieldClient
 	// The HTTP Client is used to send HTTP requests to the greenfield blockchain and sp
 	httpClient *http.Client
-	// Service provider endpoints
-	storageProviders map[uint32]*types.StorageProvider
+	// state is the runtime state cached or measured by the client, e.g. the service provider endpoints
+	state *clientState
 	// The default account to use when sending transactions.
 	defaultAccount *types.Account
 	// Whether the connection to the blockchain node is secure (HTTPS) or not (HTTP).
@@ -78,11 +103,112 @@ type Client struct {
 	// forceToUseSpecifiedSpEndpointForDownloadOnly indicates a fixed SP endpoint to which to send the download request
 	// If this option is set, the client can only make download requests, and can only download from the fixed endpoint
 	forceToUseSpecifiedSpEndpointForDownloadOnly *url.URL
+	// the size limits of the list and meta responses returned by SP
+	maxResponseBodySize    int64
+	responseSpillThreshold int64
+	responseSpillDir       string
+	// xmlDecoder decodes the xml responses returned by SP
+	xmlDecoder types.XMLDecoder
+	// fileSystem isolates the file operations of the client
+	fileSystem types.FileSystem
+	// checkpointStore persists the checkpoints of the resumable transfers, the upload checkpoints are recorded only if
+	// it is set by the option
+	checkpointStore types.CheckpointStore
+	// maxMetaBlockLag is the max number of blocks the meta service of SP can lag behind the chain
+	maxMetaBlockLag int64
+	// the storage classes applied when creating buckets and objects
+	storageClasses      map[string]types.StorageClass
+	defaultStorageClass string
+	// defaultPartSize is the part size used when the options do not specify one
+	defaultPartSize uint64
+	// cdnEndpoints indicates the CDN or custom domains fronting the buckets
+	cdnEndpoints map[string]*url.URL
+	// the rate limiters shared by the uploads and the downloads of the client, they are nil if not limited
+	uploadLimiter   *rateLimiter
+	downloadLimiter *rateLimiter
+	// bucketDefaults holds the default options registered for the buckets
+	bucketDefaults *bucketDefaultsRegistry
+	// the clock and its drift from SP measured by the Date headers of the responses
+	clock               types.Clock
+	maxClockSkew        time.Duration
+	compensateClockSkew bool
+	// retryPolicy decides how the failed SP requests are retried, they are not retried if it is nil
+	retryPolicy *types.RetryPolicy
+	// deletionGuard approves the txs deleting buckets or objects if it is not nil
+	deletionGuard types.DeletionGuard
+	// downloadAuditHook receives the receipts of the downloads if it is not nil
+	downloadAuditHook types.DownloadAuditHook
+	// chainID is the chain id of the blockchain the Client interacts with
+	chainID string
+	// explorer formats the URLs of the block explorer if it is not nil
+	explorer *types.ExplorerTemplates
+	// metrics receives the measurements of the client if it is not nil
+	metrics types.Metrics
+	// tracer starts the spans of the client if it is not nil
+	tracer trace.Tracer
+	// propagator injects the trace context into the SP requests if it is not nil
+	propagator propagation.TextMapPropagator
+	// middlewares wrap the requests sent to SP
+	middlewares []types.Middleware
+	// spHealthPolicy decides when the failing SPs are ejected from the routing
+	spHealthPolicy types.SPHealthPolicy
+	// circuitBreakerPolicy decides when the requests to the failing SPs fail fast, the circuit breakers are not
+	// enabled if it is nil
+	circuitBreakerPolicy *types.CircuitBreakerPolicy
+	// refreshExpiredApprovals indicates whether BroadcastTx re-requests the expired SP approvals of the msgs
+	refreshExpiredApprovals bool
+	// gasAdjustment is the multiplier applied to the simulated gas by EstimateFee
+	gasAdjustment float64
+	// dynamicFeePolicy decides how the fees are set by BroadcastTx, the fees are left to the chain client if it is nil
+	dynamicFeePolicy *types.DynamicFeePolicy
+	// pinnedHeight is the block height which the chain queries are pinned to by AtHeight, the client is read-only if
+	// it is not 0
+	pinnedHeight int64
+	// spResponseVerification indicates how the contents signed or sealed by SP are verified
+	spResponseVerification types.SPResponseVerification
+}
+
+// clientState is the runtime state cached or measured by the client, it is shared by the clients of a ClientPool.
+type clientState struct {
+	// Service provider endpoints, the map is replaced as a whole when refreshed and never modified after it is
+	// published, so the snapshot returned by getStorageProviders can be read without locking.
+	storageProviders map[uint32]*types.StorageProvider
+	spMu             sync.RWMutex
+	// spRefreshMu serializes the refreshes of the service provider endpoints
+	spRefreshMu sync.Mutex
+	// clockSkew is the drift of the SP clock from the local clock in nanoseconds
+	clockSkew atomic.Int64
+	// blockTime is the average block time of the chain in nanoseconds, it is measured when waiting for blocks
+	blockTime atomic.Int64
+	// capabilities caches the *types.SPCapabilities probed by Capabilities, keyed by the SP operator address
+	capabilities sync.Map
+	// lastWriteHeight is the height of the last successful tx confirmed by WaitForTx, the list queries of the
+	// ReadYourWrites consistency wait for the meta services of SP to sync it
+	lastWriteHeight atomic.Int64
+	// spHealth records the error rates and the latencies of the SPs
+	spHealth *spHealthTracker
+	// queries deduplicates the concurrent identical chain queries, e.g. HeadBucket and HeadObject
+	queries *flightGroup
+	// circuitBreakers are the circuit breakers of the SP endpoints
+	circuitBreakers *circuitBreakers
+}
+
+func newClientState() *clientState {
+	return &clientState{
+		storageProviders: make(map[uint32]*types.StorageProvider),
+		spHealth:         newSPHealthTracker(),
+		queries:          newFlightGroup(),
+		circuitBreakers:  newCircuitBreakers(),
+	}
 }
 
 // Option - Configurations for providing optional parameters for the Greenfield SDK Client.
 type Option struct {
-	// GrpcDialOption is the list of gRPC dial options used to configure the connection to the blockchain node.
+	// GrpcAddress is the gRPC address of the blockchain node. If it is set, the chain queries and the transactions
+	// are sent via gRPC instead of the RPC endpoint.
+	GrpcAddress string
+	// GrpcDialOption is the gRPC dial option used to configure the connection to the blockchain node, it takes
+	// effect only if GrpcAddress is set.
 	GrpcDialOption grpc.DialOption
 	// DefaultAccount is the default account of Client.
 	DefaultAccount *types.Account
@@ -109,6 +235,116 @@ type Option struct {
 	// ForceToUseSpecifiedSpEndpointForDownloadOnly indicates a fixed SP endpoint to which to send the download request
 	// If this option is set, the client can only make download requests, and can only download from the fixed endpoint
 	ForceToUseSpecifiedSpEndpointForDownloadOnly string
+	// MaxResponseBodySize is the max size in bytes of the list and meta responses returned by SP, the default value is 64MB.
+	// The request fails with types.ErrResponseBodyTooLarge if the response exceeds it.
+	MaxResponseBodySize int64
+	// ResponseSpillThreshold is the size in bytes above which the list and meta responses are spilled to a temp file and
+	// decoded in streaming way rather than being held in memory, it helps the small-memory environments to list large
+	// namespaces. The default value 0 disables the spilling.
+	ResponseSpillThreshold int64
+	// ResponseSpillDir is the directory where the spilled responses are stored, os.TempDir() is used if it is empty.
+	ResponseSpillDir string
+	// FileSystem is used for the file operations of the client, e.g. FPutObject, FGetObject and the spilled responses.
+	// types.DefaultFileSystem() is used if it is nil, types.NewMemFileSystem() keeps the files in memory instead.
+	FileSystem types.FileSystem
+	// CheckpointStore persists the checkpoints of the resumable downloads and uploads, e.g. in a shared volume so that
+	// the CI jobs on the ephemeral machines can resume the transfers. The download checkpoints are kept next to their
+	// temp files by FileSystem if it is nil, and the upload checkpoints are not recorded. An upload is resumed by one
+	// worker at a time if the store implements types.CheckpointLocker.
+	CheckpointStore types.CheckpointStore
+	// MaxMetaBlockLag is the max number of blocks which the meta service of SP can lag behind the chain when serving the
+	// list responses. A *types.StaleMetaError is returned if it is exceeded, and the other SPs are tried when the query
+	// fans out. The check is disabled if it is 0, and it is overridden by the Consistency of the list options.
+	MaxMetaBlockLag int64
+	// StorageClasses defines the storage classes in addition to the built-in ones of types.DefaultStorageClasses, the
+	// built-in ones are overridden by the ones with the same names.
+	StorageClasses map[string]types.StorageClass
+	// DefaultStorageClass is the name of the storage class applied by CreateBucket and CreateObject when the options
+	// do not specify one. No storage class is applied if it is empty.
+	DefaultStorageClass string
+	// DefaultPartSize is the part size used by the resumable uploads and downloads when the options do not specify
+	// one, types.MinPartSize is used if it is 0. It should be an integer multiple of the max segment size on chain.
+	DefaultPartSize uint64
+	// CDNEndpoints maps the bucket names to the CDN or custom domains fronting the buckets, GetObject downloads the
+	// objects of these buckets via the domains. The domain should forward the requests to the virtual-hosted style
+	// endpoint of the bucket on its primary SP with the Host header of the endpoint, which the requests are signed for.
+	CDNEndpoints map[string]string
+	// BucketDefaults maps the bucket names to their default options, which are applied to the options of CreateBucket,
+	// CreateObject and the uploads on the buckets which the caller does not set explicitly. More defaults can be
+	// registered by SetBucketDefaults.
+	BucketDefaults map[string]types.BucketDefaults
+	// Clock provides the timestamps of the requests sent to SP, types.SystemClock is used if it is nil.
+	Clock types.Clock
+	// MaxClockSkew is the max drift of the clock from the Date headers of the SP responses, a warning is logged if it is
+	// exceeded. types.DefaultMaxClockSkew is used if it is 0.
+	MaxClockSkew time.Duration
+	// CompensateClockSkew indicates whether to shift the timestamps of the requests by the drift measured from the SP
+	// responses, so that the requests are not rejected by SP for the skewed timestamps.
+	CompensateClockSkew bool
+	// BroadcastEndpoints are the RPC URLs of the additional blockchain nodes, BroadcastRawTx broadcasts the tx bytes to
+	// them together with the primary node and returns the first accepted response, so that the tx is delivered even if
+	// some nodes are congested or misbehaving.
+	BroadcastEndpoints []string
+	// RetryPolicy indicates how the transient failures of the SP requests are retried, e.g. the 5xx responses and the
+	// connection resets. The requests are not retried if it is nil, types.DefaultRetryPolicy provides the common one.
+	RetryPolicy *types.RetryPolicy
+	// DeletionGuard is invoked with the details of the buckets and objects before the txs deleting them are broadcast,
+	// e.g. by DeleteBucket, DeleteObject and the batched deletes of BroadcastTx, the tx is refused if it returns error.
+	DeletionGuard types.DeletionGuard
+	// StrictXMLDecoding rejects the SP responses carrying the elements unknown to the response structs with
+	// types.ErrUnknownElements rather than ignoring them, e.g. in the tests which verify the SDK is in sync with SP.
+	StrictXMLDecoding bool
+	// OnUnknownXMLElements is called with the response type and the paths of the elements unknown to it when they are
+	// ignored, so that the callers can detect the SP upgrades which the SDK is not aware of.
+	OnUnknownXMLElements func(target string, paths []string)
+	// UploadRateLimit is the max number of bytes uploaded per second by all the uploads of the client, so that the
+	// background transfers do not saturate the network link of the host. The uploads are not limited if it is 0.
+	UploadRateLimit int64
+	// DownloadRateLimit is the max number of bytes downloaded per second by all the downloads of the client, the
+	// downloads are not limited if it is 0.
+	DownloadRateLimit int64
+	// DownloadAuditHook is invoked with the receipt of each successful GetObject once its payload is consumed, e.g.
+	// to meter the downstream usage by the object ids, the bytes, the requester and the SP serving the payload.
+	DownloadAuditHook types.DownloadAuditHook
+	// Explorer contains the URL templates of the block explorer of the network, the templates of the preset matching
+	// the chain id are used if it is nil.
+	Explorer *types.ExplorerTemplates
+	// Metrics receives the measurements of the SP requests, the transferred bytes and the tx broadcasts, e.g. the
+	// Prometheus implementation of pkg/metrics. The client is not instrumented if it is nil.
+	Metrics types.Metrics
+	// TracerProvider provides the OpenTelemetry tracer starting the spans of the SP requests, the tx broadcasts and the
+	// tx waits. The client is not traced if it is nil.
+	TracerProvider trace.TracerProvider
+	// Propagator injects the trace context into the headers of the SP requests, so that the traces are continued by SP.
+	// The W3C trace context propagator is used if it is nil and the TracerProvider is set.
+	Propagator propagation.TextMapPropagator
+	// Middlewares wrap every request sent to SP, e.g. to add custom headers or to log the requests, the first one is
+	// the outermost. See types.Middleware.
+	Middlewares []types.Middleware
+	// SPHealthPolicy decides when the SPs failing too often are skipped by the requests which can be served by any SP,
+	// e.g. the list and the metadata queries, types.DefaultSPHealthPolicy is used if it is nil.
+	SPHealthPolicy *types.SPHealthPolicy
+	// CircuitBreaker enables the circuit breakers of the SP endpoints if it is not nil, so that the requests to an SP
+	// fail fast with types.ErrCircuitOpen after it fails consecutively, and a probe request is sent once the circuit
+	// has been open for a while. The zero fields take the values of types.DefaultCircuitBreakerPolicy.
+	CircuitBreaker *types.CircuitBreakerPolicy
+	// RefreshExpiredApprovals indicates whether BroadcastTx re-requests the SP approvals carried by the createBucket and
+	// createObject msgs when they are expired or about to expire, e.g. the approvals signed by GetCreateObjectApproval
+	// before the hashes of a large object are computed, or the msgs of a TxBuilder built long before broadcasting.
+	RefreshExpiredApprovals bool
+	// GasAdjustment is the multiplier applied to the simulated gas by EstimateFee, so that the estimated gas limit
+	// covers the gas varying between the simulation and the execution. types.DefaultGasAdjustment is used if it is 0.
+	GasAdjustment float64
+	// DynamicFee enables the dynamic fee mode of BroadcastTx if it is not nil, the gas limits and the fees of the txs
+	// are set from the simulation, the fee is the min gas price of the chain multiplied by the Multiplier, and it is
+	// bumped when the tx is rejected for the insufficient fee. The txs whose gas limits or fees are set by the TxOption,
+	// or whose simulations are skipped by TxOption.NoSimulate, are broadcast as they are. The zero fields take the values of types.DefaultDynamicFeePolicy.
+	DynamicFee *types.DynamicFeePolicy
+	// SPResponseVerification indicates how the contents signed or sealed by SP are verified before they are trusted,
+	// e.g. the approvals of the createBucket and createObject msgs are verified against the approval keys of the SPs
+	// on chain, and the piece data of the challenge API against the checksums sealed on chain. The validators judging
+	// the challenges should not enable it, since they need the invalid piece data to attest the challenges.
+	SPResponseVerification types.SPResponseVerification
 }
 
 // OffChainAuthOption - The optional configurations for off-chain-auth.
@@ -141,6 +377,10 @@ type OffChainAuthOptionV2 struct {
 	ShouldRegisterPubKey bool
 	// PublicKey This will be set automatically once the public key is registered.
 	PublicKey string
+	// UserAddress is the address of the user who registers the public key and on whose behalf the requests are signed
+	// by WithOffChainAuth. It is required by WithOffChainAuth and NewOffChainAuthKeyRegistration, and the default
+	// account is used in the other cases.
+	UserAddress string
 }
 
 // New - New Greenfield Go SDK Client.
@@ -158,36 +398,170 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 	if endpoint == "" || chainID == "" {
 		return nil, errors.New("fail to get grpcAddress and chainID to construct Client")
 	}
-	var (
-		cc  *sdkclient.GreenfieldClient
-		err error
-	)
+	var chainOpts []sdkclient.GreenfieldClientOption
 	if option.UseWebSocketConn {
-		cc, err = sdkclient.NewGreenfieldClient(endpoint, chainID, sdkclient.WithWebSocketClient())
-	} else {
-		cc, err = sdkclient.NewGreenfieldClient(endpoint, chainID)
+		chainOpts = append(chainOpts, sdkclient.WithWebSocketClient())
+	}
+	if option.GrpcAddress != "" {
+		// the transport credentials follow the Secure flag, they can be overridden by the GrpcDialOption
+		transportCredentials := insecure.NewCredentials()
+		if option.Secure {
+			transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+		dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+		if option.GrpcDialOption != nil {
+			dialOpts = append(dialOpts, option.GrpcDialOption)
+		}
+		chainOpts = append(chainOpts, sdkclient.WithGrpcConnectionAndDialOption(option.GrpcAddress, dialOpts...))
 	}
+	cc, err := sdkclient.NewGreenfieldClient(endpoint, chainID, chainOpts...)
 	if err != nil {
 		return nil, err
 	}
 	if option.DefaultAccount != nil {
 		cc.SetKeyManager(option.DefaultAccount.GetKeyManager())
 	}
+	var broadcastClients []*sdkclient.GreenfieldClient
+	for _, broadcastEndpoint := range option.BroadcastEndpoints {
+		var broadcastOpts []sdkclient.GreenfieldClientOption
+		if option.UseWebSocketConn {
+			broadcastOpts = append(broadcastOpts, sdkclient.WithWebSocketClient())
+		}
+		bc, err := sdkclient.NewGreenfieldClient(broadcastEndpoint, chainID, broadcastOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("fail to connect broadcast endpoint %s: %w", broadcastEndpoint, err)
+		}
+		broadcastClients = append(broadcastClients, bc)
+	}
 
 	if option.ExpireSeconds > httplib.MaxExpiryAgeInSec {
 		return nil, errors.New("the configured expire time exceeds max expire time")
 	}
+	if option.MaxResponseBodySize < 0 || option.ResponseSpillThreshold < 0 {
+		return nil, errors.New("the configured response body size limits should not be negative")
+	}
+	if option.MaxMetaBlockLag < 0 {
+		return nil, errors.New("the configured max meta block lag should not be negative")
+	}
+	if option.MaxClockSkew < 0 {
+		return nil, errors.New("the configured max clock skew should not be negative")
+	}
+	if option.UploadRateLimit < 0 || option.DownloadRateLimit < 0 {
+		return nil, errors.New("the configured rate limits should not be negative")
+	}
+	if option.GasAdjustment < 0 || (option.GasAdjustment > 0 && option.GasAdjustment < 1) {
+		return nil, errors.New("the configured gas adjustment should not be less than 1")
+	}
 
 	c := Client{
 		chainClient:      cc,
+		broadcastClients: broadcastClients,
 		httpClient:       &http.Client{Transport: option.Transport},
 		userAgent:        types.UserAgent,
 		defaultAccount:   option.DefaultAccount, // it allows to be nil
 		secure:           option.Secure,
 		host:             option.Host,
-		storageProviders: make(map[uint32]*types.StorageProvider),
+		state:            newClientState(),
 		useWebsocketConn: option.UseWebSocketConn,
 		expireSeconds:    option.ExpireSeconds,
+
+		maxResponseBodySize:     option.MaxResponseBodySize,
+		responseSpillThreshold:  option.ResponseSpillThreshold,
+		responseSpillDir:        option.ResponseSpillDir,
+		xmlDecoder:              types.XMLDecoder{Strict: option.StrictXMLDecoding, OnUnknownElements: option.OnUnknownXMLElements},
+		fileSystem:              option.FileSystem,
+		checkpointStore:         option.CheckpointStore,
+		maxMetaBlockLag:         option.MaxMetaBlockLag,
+		defaultPartSize:         option.DefaultPartSize,
+		clock:                   option.Clock,
+		maxClockSkew:            option.MaxClockSkew,
+		compensateClockSkew:     option.CompensateClockSkew,
+		retryPolicy:             option.RetryPolicy,
+		deletionGuard:           option.DeletionGuard,
+		downloadAuditHook:       option.DownloadAuditHook,
+		chainID:                 chainID,
+		explorer:                option.Explorer,
+		metrics:                 option.Metrics,
+		tracer:                  newTracer(option.TracerProvider),
+		propagator:              option.Propagator,
+		middlewares:             option.Middlewares,
+		spResponseVerification:  option.SPResponseVerification,
+		uploadLimiter:           newRateLimiter(option.UploadRateLimit),
+		downloadLimiter:         newRateLimiter(option.DownloadRateLimit),
+		gasAdjustment:           option.GasAdjustment,
+		refreshExpiredApprovals: option.RefreshExpiredApprovals,
+	}
+	if c.fileSystem == nil {
+		c.fileSystem = types.DefaultFileSystem()
+	}
+	if c.maxResponseBodySize == 0 {
+		c.maxResponseBodySize = types.MaxResponseBodySize
+	}
+	if c.defaultPartSize == 0 {
+		c.defaultPartSize = types.MinPartSize
+	}
+	if c.clock == nil {
+		c.clock = types.SystemClock{}
+	}
+	if c.maxClockSkew == 0 {
+		c.maxClockSkew = types.DefaultMaxClockSkew
+	}
+	if c.gasAdjustment == 0 {
+		c.gasAdjustment = types.DefaultGasAdjustment
+	}
+	c.spHealthPolicy = types.DefaultSPHealthPolicy()
+	if option.SPHealthPolicy != nil {
+		c.spHealthPolicy = *option.SPHealthPolicy
+	}
+	if option.CircuitBreaker != nil {
+		policy, defaults := *option.CircuitBreaker, types.DefaultCircuitBreakerPolicy()
+		if policy.FailureThreshold <= 0 {
+			policy.FailureThreshold = defaults.FailureThreshold
+		}
+		if policy.OpenDuration <= 0 {
+			policy.OpenDuration = defaults.OpenDuration
+		}
+		c.circuitBreakerPolicy = &policy
+	}
+	if option.DynamicFee != nil {
+		policy, defaults := *option.DynamicFee, types.DefaultDynamicFeePolicy()
+		if policy.Multiplier == 0 {
+			policy.Multiplier = defaults.Multiplier
+		}
+		if policy.BumpMultiplier == 0 {
+			policy.BumpMultiplier = defaults.BumpMultiplier
+		}
+		if policy.MaxBumps <= 0 {
+			policy.MaxBumps = defaults.MaxBumps
+		}
+		if policy.Multiplier < 1 || policy.BumpMultiplier <= 1 {
+			return nil, errors.New("the configured fee multiplier should not be less than 1 and the bump multiplier should be more than 1")
+		}
+		c.dynamicFeePolicy = &policy
+	}
+	c.storageClasses = types.DefaultStorageClasses()
+	for name, class := range option.StorageClasses {
+		c.storageClasses[name] = class
+	}
+	if option.DefaultStorageClass != "" {
+		if _, ok := c.storageClasses[option.DefaultStorageClass]; !ok {
+			return nil, fmt.Errorf("the default storage class %s is not defined", option.DefaultStorageClass)
+		}
+		c.defaultStorageClass = option.DefaultStorageClass
+	}
+
+	for bucketName, defaults := range option.BucketDefaults {
+		if err = defaults.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid default options of bucket %s: %w", bucketName, err)
+		}
+	}
+	c.bucketDefaults = newBucketDefaultsRegistry(option.BucketDefaults)
+
+	c.cdnEndpoints = make(map[string]*url.URL, len(option.CDNEndpoints))
+	for bucketName, domain := range option.CDNEndpoints {
+		if c.cdnEndpoints[bucketName], err = c.parseCDNEndpoint(domain); err != nil {
+			return nil, fmt.Errorf("invalid CDN endpoint of bucket %s: %w", bucketName, err)
+		}
 	}
 
 	if option.ForceToUseSpecifiedSpEndpointForDownloadOnly != "" {
@@ -220,7 +594,7 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 		}
 		c.offChainAuthOption = option.OffChainAuthOption
 		if option.OffChainAuthOption.ShouldRegisterPubKey {
-			for _, sp := range c.storageProviders {
+			for _, sp := range c.getStorageProviders() {
 				registerResult, err := c.RegisterEDDSAPublicKey(sp.OperatorAddress.String(), sp.EndPoint.Scheme+"://"+sp.EndPoint.Host)
 				if err != nil {
 					log.Error().Msg(fmt.Sprintf("Fail to RegisterEDDSAPublicKey for sp : %s", sp.EndPoint))
@@ -241,7 +615,7 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 
 		c.offChainAuthOptionV2 = option.OffChainAuthOptionV2
 		if option.OffChainAuthOptionV2.ShouldRegisterPubKey {
-			for _, sp := range c.storageProviders {
+			for _, sp := range c.getStorageProviders() {
 				registerResult, err := c.RegisterEDDSAPublicKeyV2(sp.EndPoint.Scheme + "://" + sp.EndPoint.Host)
 				if err != nil {
 					log.Error().Msg(fmt.Sprintf("Fail to RegisterEDDSAPublicKeyV2 for sp : %s", sp.EndPoint))
@@ -255,27 +629,30 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 	return &c, nil
 }
 
-func (c *Client) getSPUrlByBucket(bucketName string) (*url.URL, error) {
-	sp, err := c.pickStorageProviderByBucket(bucketName)
+func (c *Client) getSPUrlByBucket(ctx context.Context, bucketName string) (*url.URL, error) {
+	sp, err := c.pickStorageProviderByBucket(ctx, bucketName)
 	if err != nil {
 		return nil, err
 	}
 	return sp.EndPoint, nil
 }
 
-func (c *Client) pickStorageProviderByBucket(bucketName string) (*types.StorageProvider, error) {
-	ctx := context.Background()
+func (c *Client) pickStorageProviderByBucket(ctx context.Context, bucketName string) (*types.StorageProvider, error) {
 	bucketInfo, err := c.HeadBucket(ctx, bucketName)
 	if err != nil {
 		return nil, err
 	}
+	return c.pickStorageProviderByBucketInfo(ctx, bucketInfo)
+}
 
-	familyResp, err := c.chainClient.GlobalVirtualGroupFamily(ctx, &types2.QueryGlobalVirtualGroupFamilyRequest{FamilyId: bucketInfo.GlobalVirtualGroupFamilyId})
+// pickStorageProviderByBucketInfo returns the primary SP of the global virtual group family of the bucket.
+func (c *Client) pickStorageProviderByBucketInfo(ctx context.Context, bucketInfo *storageTypes.BucketInfo) (*types.StorageProvider, error) {
+	familyResp, err := c.chainClient.GlobalVirtualGroupFamily(c.queryContext(ctx), &types2.QueryGlobalVirtualGroupFamilyRequest{FamilyId: bucketInfo.GlobalVirtualGroupFamilyId})
 	if err != nil {
 		return nil, err
 	}
 
-	sp, ok := c.storageProviders[familyResp.GlobalVirtualGroupFamily.PrimarySpId]
+	sp, ok := c.getStorageProviders()[familyResp.GlobalVirtualGroupFamily.PrimarySpId]
 	if ok {
 		return sp, nil
 	}
@@ -285,16 +662,30 @@ func (c *Client) pickStorageProviderByBucket(bucketName string) (*types.StorageP
 		return nil, err
 	}
 
-	sp, ok = c.storageProviders[familyResp.GlobalVirtualGroupFamily.PrimarySpId]
+	sp, ok = c.getStorageProviders()[familyResp.GlobalVirtualGroupFamily.PrimarySpId]
 	if ok {
 		return sp, nil
 	}
 	return nil, fmt.Errorf("the storage provider %d not exists on chain", familyResp.GlobalVirtualGroupFamily.PrimarySpId)
 }
 
+// getStorageProviders returns the current snapshot of the service provider endpoints, it must not be modified.
+func (c *Client) getStorageProviders() map[uint32]*types.StorageProvider {
+	c.state.spMu.RLock()
+	defer c.state.spMu.RUnlock()
+	return c.state.storageProviders
+}
+
+// setStorageProviders publishes a new snapshot of the service provider endpoints.
+func (c *Client) setStorageProviders(storageProviders map[uint32]*types.StorageProvider) {
+	c.state.spMu.Lock()
+	defer c.state.spMu.Unlock()
+	c.state.storageProviders = storageProviders
+}
+
 // getSPUrlByID route url of the sp from sp id
 func (c *Client) getSPUrlByID(id uint32) (*url.URL, error) {
-	sp, ok := c.storageProviders[id]
+	sp, ok := c.getStorageProviders()[id]
 	if ok {
 		return sp.EndPoint, nil
 	}
@@ -308,7 +699,7 @@ func (c *Client) getSPUrlByAddr(address string) (*url.URL, error) {
 	if err != nil {
 		return nil, err
 	}
-	for _, sp := range c.storageProviders {
+	for _, sp := range c.getStorageProviders() {
 		if sp.OperatorAddress.Equals(acc) {
 			return sp.EndPoint, nil
 		}
@@ -317,7 +708,8 @@ func (c *Client) getSPUrlByAddr(address string) (*url.URL, error) {
 	return nil, fmt.Errorf("the SP endpoint %s not exists on chain", address)
 }
 
-// getInServiceSP return the first SP endpoint which is in service in SP list
+// getInServiceSP return the first SP endpoint which is in service in SP list, the SPs ejected for failing too often
+// are skipped unless all of them are ejected
 func (c *Client) getInServiceSP() (*url.URL, error) {
 	ctx := context.Background()
 	spList, err := c.ListStorageProviders(ctx, true)
@@ -329,20 +721,31 @@ func (c *Client) getInServiceSP() (*url.URL, error) {
 		return nil, errors.New("fail to get SP endpoint")
 	}
 
-	var useHttps bool
-	SPEndpoint := spList[0].Endpoint
-	if strings.Contains(SPEndpoint, "https") {
-		useHttps = true
-	} else {
-		useHttps = c.secure
-	}
+	var first *url.URL
+	now := c.clock.Now()
+	for _, sp := range spList {
+		var useHttps bool
+		SPEndpoint := sp.Endpoint
+		if strings.Contains(SPEndpoint, "https") {
+			useHttps = true
+		} else {
+			useHttps = c.secure
+		}
 
-	urlInfo, urlErr := utils.GetEndpointURL(spList[0].Endpoint, useHttps)
-	if urlErr != nil {
-		return nil, urlErr
+		urlInfo, urlErr := utils.GetEndpointURL(SPEndpoint, useHttps)
+		if urlErr != nil {
+			return nil, urlErr
+		}
+		ejected := !c.spHealthPolicy.Disabled && c.state.spHealth.isEjected(urlInfo.Host, now)
+		if !ejected && c.circuitState(urlInfo.Host) != types.CircuitOpen {
+			return urlInfo, nil
+		}
+		if first == nil {
+			first = urlInfo
+		}
 	}
 
-	return urlInfo, nil
+	return first, nil
 }
 
 // requestMeta - contains the metadata to construct the http request.
@@ -359,6 +762,7 @@ type requestMeta struct {
 	contentSHA256    string // hex encoded sha256sum
 	pieceInfo        types.QueryPieceInfo
 	userAddress      string
+	header           http.Header // extra headers set before signing
 }
 
 // SendOptions -  options to use to send the http message
@@ -385,9 +789,27 @@ func DefaultDownloadSegmentHook(seg int64) error {
 	return nil
 }
 
-// newRequest constructs the http request, set url, body and headers
+// newRequest constructs the http request, set url, body and headers, and signs it
 func (c *Client) newRequest(ctx context.Context, method string, meta requestMeta,
 	body interface{}, txnHash string, adminAPIInfo AdminAPIInfo, endpoint *url.URL,
+) (req *http.Request, err error) {
+	req, err = c.buildRequest(ctx, method, meta, body, txnHash, adminAPIInfo, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// sign the total http request info when auth type v1
+	err = c.signRequest(req)
+	if err != nil {
+		return req, err
+	}
+
+	return
+}
+
+// buildRequest constructs the unsigned http request, set url, body and headers
+func (c *Client) buildRequest(ctx context.Context, method string, meta requestMeta,
+	body interface{}, txnHash string, adminAPIInfo AdminAPIInfo, endpoint *url.URL,
 ) (req *http.Request, err error) {
 	isVirtualHost := c.isVirtualHostStyleUrl(*endpoint, meta.bucketName)
 
@@ -467,6 +889,10 @@ func (c *Client) newRequest(ctx context.Context, method string, meta requestMeta
 		req.Header.Set(types.HTTPHeaderRange, meta.rangeInfo)
 	}
 
+	if c.pinnedHeight != 0 {
+		req.Header.Set(types.HTTPHeaderQueryHeight, strconv.FormatInt(c.pinnedHeight, 10))
+	}
+
 	// if pieceInfo.ObjectId is not empty, other field should be set as well
 	if meta.pieceInfo.ObjectId != "" {
 		info := meta.pieceInfo
@@ -492,8 +918,12 @@ func (c *Client) newRequest(ctx context.Context, method string, meta requestMeta
 		req.Header.Set(types.HTTPHeaderUserAddress, meta.userAddress)
 	}
 
+	for key, values := range meta.header {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+
 	// set date header
-	stNow := time.Now().UTC()
+	stNow := c.now().UTC()
 	req.Header.Set(types.HTTPHeaderDate, stNow.Format(types.Iso8601DateFormatSecond))
 
 	// set expiry for authorization
@@ -507,12 +937,6 @@ func (c *Client) newRequest(ctx context.Context, method string, meta requestMeta
 	// set user-agent
 	req.Header.Set(types.HTTPHeaderUserAgent, c.userAgent)
 
-	// sign the total http request info when auth type v1
-	err = c.signRequest(req)
-	if err != nil {
-		return req, err
-	}
-
 	return
 }
 
@@ -525,8 +949,15 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 	}
 	req = req.WithContext(ctx)
 
-	resp, err := c.httpClient.Do(req)
+	c.injectTraceContext(ctx, req.Header)
+	if err := c.allowSPRequest(req.URL.Host); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := c.doSPRequest(req)
+	c.recordSPHealth(req, resp, err, time.Since(start))
 	if err != nil {
+		c.observeSPRequest(req, nil, err, start)
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
 		select {
@@ -551,8 +982,11 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 		}
 	}()
 
+	c.measureClockSkew(resp)
+
 	// construct err responses and messages
 	err = types.ConstructErrResponse(resp, meta.bucketName, meta.objectName)
+	resp = c.observeSPRequest(req, resp, err, start)
 	if err != nil {
 		// dump error msg
 		if c.isTraceEnabled {
@@ -572,14 +1006,262 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 	return resp, nil
 }
 
+// now returns the current time of the clock, which is shifted by the measured drift from SP if the compensation is
+// enabled.
+func (c *Client) now() time.Time {
+	now := c.clock.Now()
+	if c.compensateClockSkew {
+		now = now.Add(time.Duration(c.state.clockSkew.Load()))
+	}
+	return now
+}
+
+// measureClockSkew measures the drift of the clock from the Date header of the SP response. The Date header is in
+// seconds, so the drift within a second is ignored.
+func (c *Client) measureClockSkew(resp *http.Response) {
+	date, err := http.ParseTime(resp.Header.Get(types.HTTPHeaderServerDate))
+	if err != nil {
+		return
+	}
+	skew := date.Sub(c.clock.Now()).Round(time.Second)
+	if skew > -time.Second && skew < time.Second {
+		skew = 0
+	}
+	previous := time.Duration(c.state.clockSkew.Swap(int64(skew)))
+	// warn once when the drift exceeds the limit rather than on every response
+	if !c.compensateClockSkew && exceedsClockSkew(skew, c.maxClockSkew) && !exceedsClockSkew(previous, c.maxClockSkew) {
+		log.Warn().Msg(fmt.Sprintf("the local clock drifts %s from SP, the requests may be rejected for the skewed "+
+			"timestamps, please sync the clock or enable CompensateClockSkew", -skew))
+	}
+}
+
+func exceedsClockSkew(skew, maxClockSkew time.Duration) bool {
+	return skew > maxClockSkew || skew < -maxClockSkew
+}
+
+// decodeXMLResponse reads the response body within the size limit and decodes the xml content into v,
+// the response larger than the spill threshold is decoded from a temp file.
+func (c *Client) decodeXMLResponse(resp *http.Response, v interface{}) error {
+	return c.xmlDecoder.DecodeBody(resp.Body, v, c.maxResponseBodySize, c.responseSpillThreshold,
+		c.fileSystem, c.responseSpillDir)
+}
+
+// setResponseInfo fills info with the headers of the SP response if info is not nil.
+func setResponseInfo(info *types.ResponseInfo, resp *http.Response) {
+	if info != nil {
+		*info = types.NewResponseInfo(resp)
+	}
+}
+
+// checkMetaFreshness checks the block height which the meta service of SP has synced to by the response header
+// against the consistency, it returns *types.StaleMetaError if the response is too stale. The responses of the SPs
+// which do not report the height are only accepted by the consistencies other than types.ConsistencyReadYourWrites and
+// types.ConsistencyMinHeight.
+func (c *Client) checkMetaFreshness(ctx context.Context, resp *http.Response, consistency types.Consistency) error {
+	maxLag := c.maxMetaBlockLag
+	switch consistency.Mode {
+	case types.ConsistencyAny:
+		return nil
+	case types.ConsistencyBoundedStaleness:
+		maxLag = consistency.MaxBlocks
+	case types.ConsistencyReadYourWrites:
+		return c.checkReadYourWrites(resp)
+	case types.ConsistencyMinHeight:
+		return checkMinHeight(resp, consistency.MinHeight)
+	default:
+		if maxLag == 0 {
+			return nil
+		}
+	}
+	syncedHeight, reported, err := metaSyncedHeight(resp)
+	if err != nil || !reported {
+		return err
+	}
+	status, err := c.chainClient.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	latestHeight := status.SyncInfo.LatestBlockHeight
+	if latestHeight-syncedHeight > maxLag {
+		staleErr := &types.StaleMetaError{
+			Endpoint:     responseHost(resp),
+			SyncedHeight: syncedHeight,
+			LatestHeight: latestHeight,
+		}
+		log.Warn().Msg(staleErr.Error())
+		return staleErr
+	}
+	return nil
+}
+
+// checkReadYourWrites checks the meta service of SP has synced the last write of the client.
+func (c *Client) checkReadYourWrites(resp *http.Response) error {
+	writeHeight := c.state.lastWriteHeight.Load()
+	if writeHeight == 0 {
+		return nil
+	}
+	syncedHeight, reported, err := metaSyncedHeight(resp)
+	if err != nil {
+		return err
+	}
+	if !reported {
+		return fmt.Errorf("SP %s does not report the synced block height, the read-your-writes consistency can not be ensured",
+			responseHost(resp))
+	}
+	if syncedHeight < writeHeight {
+		return &types.StaleMetaError{Endpoint: responseHost(resp), SyncedHeight: syncedHeight, WriteHeight: writeHeight}
+	}
+	return nil
+}
+
+// checkMinHeight checks the meta service of SP has synced to minHeight.
+func checkMinHeight(resp *http.Response, minHeight int64) error {
+	syncedHeight, reported, err := metaSyncedHeight(resp)
+	if err != nil {
+		return err
+	}
+	if !reported {
+		return fmt.Errorf("SP %s does not report the synced block height, the min height %d can not be ensured",
+			responseHost(resp), minHeight)
+	}
+	if syncedHeight < minHeight {
+		return &types.StaleMetaError{Endpoint: responseHost(resp), SyncedHeight: syncedHeight, MinHeight: minHeight}
+	}
+	return nil
+}
+
+// queryConsistent runs the query and retries it every block while the meta services of SP have not synced the last
+// write of the client for the types.ConsistencyReadYourWrites consistency, or the min height for the
+// types.ConsistencyMinHeight consistency, at most for types.ReadYourWritesTimeout.
+func (c *Client) queryConsistent(ctx context.Context, consistency types.Consistency, query func() error) error {
+	deadline := time.Now().Add(types.ReadYourWritesTimeout)
+	awaiting := consistency.Mode == types.ConsistencyReadYourWrites || consistency.Mode == types.ConsistencyMinHeight
+	for {
+		err := query()
+		var staleErr *types.StaleMetaError
+		if !awaiting || !errors.As(err, &staleErr) || time.Now().After(deadline) {
+			return err
+		}
+		timer := time.NewTimer(c.averageBlockTime(ctx))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// recordWriteHeight records the height of a successful tx of the client.
+func (c *Client) recordWriteHeight(height int64) {
+	for {
+		last := c.state.lastWriteHeight.Load()
+		if height <= last || c.state.lastWriteHeight.CompareAndSwap(last, height) {
+			return
+		}
+	}
+}
+
+// metaSyncedHeight returns the block height which the meta service of SP has synced to, reported is false if the
+// response does not carry it.
+func metaSyncedHeight(resp *http.Response) (height int64, reported bool, err error) {
+	heightStr := resp.Header.Get(types.HTTPHeaderBlockHeight)
+	if heightStr == "" {
+		return 0, false, nil
+	}
+	height, err = strconv.ParseInt(heightStr, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid block height header %q: %w", heightStr, err)
+	}
+	return height, true, nil
+}
+
+func responseHost(resp *http.Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+	return resp.Request.URL.Host
+}
+
 // sendReq sends the message via REST and handles the response
 func (c *Client) sendReq(ctx context.Context, metadata requestMeta, opt *sendOptions, endpoint *url.URL) (res *http.Response, err error) {
+	ctx, span := c.startSpan(ctx, "greenfield.sp/"+opt.method,
+		attribute.String("http.method", opt.method),
+		attribute.String("server.address", endpoint.Host),
+		attribute.String("greenfield.bucket", metadata.bucketName),
+		attribute.String("greenfield.object", metadata.objectName),
+	)
+	defer func() { endSpan(span, err) }()
+	if err = c.checkWritableMethod(opt.method); err != nil {
+		return nil, err
+	}
+	rewind, canRewind := bodyRewinder(opt.body)
+	resp, err := c.sendReqToEndpoint(ctx, metadata, opt, endpoint)
+	if err == nil || !canRewind {
+		return resp, err
+	}
+	newEndpoint, ok := c.rerouteBucket(ctx, metadata.bucketName, endpoint, err)
+	if !ok {
+		return nil, err
+	}
+	log.Warn().Msg(fmt.Sprintf("the primary SP of bucket %s has changed from %s to %s, resend the request, err: %s",
+		metadata.bucketName, endpoint.Host, newEndpoint.Host, err))
+	if err = rewind(); err != nil {
+		return nil, err
+	}
+	return c.sendReqToEndpoint(ctx, metadata, opt, newEndpoint)
+}
+
+// rerouteBucket returns the new endpoint of the bucket if the request routed by the bucket memoized by the head cache
+// is rejected by its former primary SP, e.g. the bucket has been migrated to another SP during the operation. The
+// memoized bucket is dropped, so that the following requests of the operation are routed to the new SP as well.
+func (c *Client) rerouteBucket(ctx context.Context, bucketName string, endpoint *url.URL, reqErr error) (*url.URL, bool) {
+	cache := headCacheFromContext(ctx)
+	if cache == nil || bucketName == "" || c.forceToUseSpecifiedSpEndpointForDownloadOnly != nil || !isRoutingError(reqErr) {
+		return nil, false
+	}
+	cached, ok := cache.getBucket(bucketName)
+	if !ok {
+		return nil, false
+	}
+	// the requests sent to the endpoints specified by the callers are not rerouted
+	formerSP, err := c.pickStorageProviderByBucketInfo(ctx, cached)
+	if err != nil || formerSP.EndPoint.Host != endpoint.Host {
+		return nil, false
+	}
+	cache.deleteBucket(bucketName)
+	sp, err := c.pickStorageProviderByBucket(ctx, bucketName)
+	if err != nil || sp.EndPoint.Host == endpoint.Host {
+		return nil, false
+	}
+	return sp.EndPoint, true
+}
+
+// isRoutingError reports whether the SP rejects the request as the bucket is not served by it.
+func isRoutingError(err error) bool {
+	spErr, ok := types.AsSPError(err)
+	if !ok {
+		return false
+	}
+	switch spErr.Code {
+	case types.SPErrCodeNoSuchBucket, types.SPErrCodeAccessDenied:
+		return true
+	case types.SPErrCodeNoSuchObject:
+		return false
+	}
+	return spErr.HTTPStatus == http.StatusNotFound
+}
+
+// sendReqToEndpoint sends the request to the endpoint with the retries of the transient failures.
+func (c *Client) sendReqToEndpoint(ctx context.Context, metadata requestMeta, opt *sendOptions, endpoint *url.URL) (*http.Response, error) {
 	req, err := c.newRequest(ctx, opt.method, metadata, opt.body, opt.txnHash, opt.adminInfo, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.doAPI(ctx, req, metadata, !opt.disableCloseBody)
+	resp, err := c.doAPIWithRetry(ctx, req, metadata, opt, func() (*http.Request, error) {
+		return c.newRequest(ctx, opt.method, metadata, opt.body, opt.txnHash, opt.adminInfo, endpoint)
+	})
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("do API error, url: %s, err: %s", req.URL.String(), err))
 		return nil, err
@@ -587,6 +1269,124 @@ func (c *Client) sendReq(ctx context.Context, metadata requestMeta, opt *sendOpt
 	return resp, nil
 }
 
+// sendReqViaCDN signs the request for the SP endpoint and sends it to the CDN or custom domain fronting the bucket.
+// The request is signed for the virtual-hosted style url of the bucket, so that the signature is still valid when the
+// domain forwards the request to the SP with the Host header of the bucket endpoint.
+func (c *Client) sendReqViaCDN(ctx context.Context, metadata requestMeta, opt *sendOptions, endpoint, cdnEndpoint *url.URL) (*http.Response, error) {
+	if !c.isVirtualHostStyleUrl(*endpoint, metadata.bucketName) {
+		return nil, fmt.Errorf("the SP endpoint %s of bucket %s does not support virtual-hosted style requests via CDN",
+			endpoint.Host, metadata.bucketName)
+	}
+	newCDNRequest := func() (*http.Request, error) {
+		req, err := c.newRequest(ctx, opt.method, metadata, opt.body, opt.txnHash, opt.adminInfo, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		// the path and query of the virtual-hosted style url are kept, only the target host is overridden
+		req.URL.Scheme = cdnEndpoint.Scheme
+		req.URL.Host = cdnEndpoint.Host
+		req.Host = cdnEndpoint.Host
+		return req, nil
+	}
+	req, err := newCDNRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAPIWithRetry(ctx, req, metadata, opt, newCDNRequest)
+	if err != nil {
+		log.Error().Msg(fmt.Sprintf("do API via CDN error, url: %s, err: %s", req.URL.String(), err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// doAPIWithRetry sends req by doAPI, the transient failures are retried by the retry policy of the client with the
+// requests rebuilt by newReq, so that they are signed with the fresh timestamps. The requests with a body which can
+// not be rewound are not retried.
+func (c *Client) doAPIWithRetry(ctx context.Context, req *http.Request, metadata requestMeta, opt *sendOptions,
+	newReq func() (*http.Request, error),
+) (*http.Response, error) {
+	maxAttempts := 1
+	if c.retryPolicy != nil && c.retryPolicy.MaxAttempts > 1 {
+		maxAttempts = c.retryPolicy.MaxAttempts
+	}
+	rewind, canRewind := bodyRewinder(opt.body)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doAPI(ctx, req, metadata, !opt.disableCloseBody)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= maxAttempts || !canRewind || !c.isRetryable(resp, err) {
+			return nil, err
+		}
+		log.Warn().Msg(fmt.Sprintf("retry the request %s after %d failed attempts, err: %s", req.URL.String(), attempt, err))
+		select {
+		case <-time.After(c.retryPolicy.Delay(attempt)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if err = rewind(); err != nil {
+			return nil, err
+		}
+		if req, err = newReq(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isRetryable reports whether the failed request should be retried, the responses with the retryable status codes
+// and the connection failures, e.g. the connection reset and the timeout, are retried.
+func (c *Client) isRetryable(resp *http.Response, err error) bool {
+	if resp != nil {
+		return c.retryPolicy.IsRetryableStatus(resp.StatusCode)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// bodyRewinder returns the function to rewind the request body to the current position for the retries, and whether
+// the body can be rewound. The xml bodies are marshaled for each request, so they need not be rewound.
+func bodyRewinder(body interface{}) (func() error, bool) {
+	noop := func() error { return nil }
+	reader, ok := body.(io.Reader)
+	if !ok {
+		return noop, true
+	}
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return noop, false
+	}
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return noop, false
+	}
+	return func() error {
+		_, err := seeker.Seek(offset, io.SeekStart)
+		return err
+	}, true
+}
+
+// getCDNEndpoint returns the CDN or custom domain to download the objects of the bucket, the domain specified by the
+// options takes precedence over the configured one. It returns nil if neither of them is set.
+func (c *Client) getCDNEndpoint(bucketName, domain string) (*url.URL, error) {
+	if domain != "" {
+		return c.parseCDNEndpoint(domain)
+	}
+	return c.cdnEndpoints[bucketName], nil
+}
+
+func (c *Client) parseCDNEndpoint(domain string) (*url.URL, error) {
+	useHttps := c.secure
+	if strings.HasPrefix(domain, "https") {
+		useHttps = true
+	}
+	return utils.GetEndpointURL(domain, useHttps)
+}
+
 func (c *Client) SplitPartInfo(objectSize int64, configuredPartSize uint64) (totalPartsCount int, partSize int64, lastPartSize int64, err error) {
 	partSizeFlt := float64(configuredPartSize)
 	// Total parts count.
@@ -661,6 +1461,24 @@ func (c *Client) generateURL(bucketName string, objectName string, relativePath
 
 // signRequest signs the request and set authorization before send to server
 func (c *Client) signRequest(req *http.Request) error {
+	// use the offChainAuth of the request if it is set by WithOffChainAuth
+	if auth := offChainAuthFromContext(req.Context()); auth != nil {
+		if auth.UserAddress == "" {
+			return errors.New("user address can't be empty in the OffChainAuthOptionV2 of WithOffChainAuth")
+		}
+		userAddress, err := sdk.AccAddressFromHexUnsafe(auth.UserAddress)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Gnfd-User-Address", userAddress.String())
+		req.Header.Set("X-Gnfd-App-Domain", auth.Domain)
+		req.Header.Set("X-Gnfd-App-Reg-Public-Key", auth.PublicKey)
+		unsignedMsg := httplib.GetMsgToSignInGNFD1Auth(req)
+		// set auth header
+		req.Header.Set(types.HTTPHeaderAuthorization, offChainAuthSignV2(auth.Seed, unsignedMsg))
+		return nil
+	}
+
 	// use offChainAuth if OffChainAuthOption is set
 	if c.offChainAuthOption != nil {
 		req.Header.Set("X-Gnfd-User-Address", c.defaultAccount.GetAddress().String())
@@ -834,6 +1652,11 @@ func (c *Client) getEndpointByOpt(opts *types.EndPointOptions) (*url.URL, error)
 		useHttps bool
 		err      error
 	)
+	if opts != nil {
+		if err = opts.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	if opts == nil || (opts.Endpoint == "" && opts.SPAddress == "") {
 		endpoint, err = c.getInServiceSP()
 		if err != nil {
@@ -862,3 +1685,115 @@ func (c *Client) getEndpointByOpt(opts *types.EndPointOptions) (*url.URL, error)
 	}
 	return endpoint, nil
 }
+
+// resolvePartSize returns the part size of the resumable upload or download, the default part size of the client is
+// used if partSize is 0. A types.ErrInvalidPartSize is returned if the part size is not an integer multiple of the max
+// segment size or exceeds the max payload size on chain, so that the request is not rejected by SP halfway.
+func (c *Client) resolvePartSize(partSize uint64, params storageTypes.Params) (uint64, error) {
+	if partSize == 0 {
+		partSize = c.defaultPartSize
+	}
+	segmentSize := params.GetMaxSegmentSize()
+	if segmentSize == 0 || partSize%segmentSize != 0 {
+		return 0, fmt.Errorf("%w: part size %d should be an integer multiple of the segment size %d",
+			types.ErrInvalidPartSize, partSize, segmentSize)
+	}
+	if maxPayloadSize := params.GetMaxPayloadSize(); maxPayloadSize != 0 && partSize > maxPayloadSize {
+		return 0, fmt.Errorf("%w: part size %d exceeds the max payload size %d",
+			types.ErrInvalidPartSize, partSize, maxPayloadSize)
+	}
+	return partSize, nil
+}
+
+// getStorageClass return the storage class by name, the default storage class is returned if the name is empty.
+// It returns nil if neither of them is set.
+func (c *Client) getStorageClass(name string) (*types.StorageClass, error) {
+	if name == "" {
+		name = c.defaultStorageClass
+	}
+	if name == "" {
+		return nil, nil
+	}
+	class, ok := c.storageClasses[name]
+	if !ok {
+		return nil, fmt.Errorf("the storage class %s is not defined", name)
+	}
+	return &class, nil
+}
+
+// getEndpointsByOpt return the SP endpoints to be queried concurrently by listOptions, only one endpoint is returned
+// if fanOut is not more than 1 or the SP is specified by opts
+func (c *Client) getEndpointsByOpt(opts *types.EndPointOptions, fanOut int) ([]*url.URL, error) {
+	if fanOut <= 1 || (opts != nil && (opts.Endpoint != "" || opts.SPAddress != "")) {
+		endpoint, err := c.getEndpointByOpt(opts)
+		if err != nil {
+			return nil, err
+		}
+		return []*url.URL{endpoint}, nil
+	}
+
+	spList, err := c.ListStorageProviders(context.Background(), true)
+	if err != nil {
+		return nil, err
+	}
+	if len(spList) == 0 {
+		return nil, errors.New("fail to get SP endpoint")
+	}
+
+	endpoints := make([]*url.URL, 0, fanOut)
+	for _, sp := range spList {
+		if len(endpoints) == fanOut {
+			break
+		}
+		useHttps := c.secure
+		if strings.Contains(sp.Endpoint, "https") {
+			useHttps = true
+		}
+		endpoint, err := utils.GetEndpointURL(sp.Endpoint, useHttps)
+		if err != nil {
+			log.Error().Msg(fmt.Sprintf("fetch endpoint of sp %s fail:%v", sp.OperatorAddress, err))
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("fail to get SP endpoint")
+	}
+	return endpoints, nil
+}
+
+// queryFirstSP sends the query to the SP endpoints concurrently and returns the index of the endpoint which completes
+// the query successfully first, the queries to the other endpoints are canceled then. The query should keep its
+// result per index. The error of the last failed query is returned if all of them failed.
+func (c *Client) queryFirstSP(ctx context.Context, endpoints []*url.URL,
+	query func(ctx context.Context, index int, endpoint *url.URL) error,
+) (int, error) {
+	if len(endpoints) == 1 {
+		return 0, query(ctx, 0, endpoints[0])
+	}
+
+	queryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type queryResult struct {
+		index int
+		err   error
+	}
+	results := make(chan queryResult, len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(index int, endpoint *url.URL) {
+			results <- queryResult{index: index, err: query(queryCtx, index, endpoint)}
+		}(i, endpoint)
+	}
+
+	var lastErr error
+	for range endpoints {
+		result := <-results
+		if result.err == nil {
+			return result.index, nil
+		}
+		log.Error().Msg(fmt.Sprintf("query sp %s failed, err: %s", endpoints[result.index].Host, result.err.Error()))
+		lastErr = result.err
+	}
+	return -1, lastErr
+}
@@ -0,0 +1,342 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkclient "github.com/bnb-chain/greenfield/sdk/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// newTestClient returns the client with the defaults of New and a new default account, it has no SP and no chain
+// endpoint unless they are set by opts.
+func newTestClient(t *testing.T, opts ...func(c *Client)) *Client {
+	account, _, err := types.NewAccount("test")
+	require.NoError(t, err)
+	c := &Client{
+		chainClient:         &sdkclient.GreenfieldClient{},
+		httpClient:          &http.Client{},
+		userAgent:           types.UserAgent,
+		defaultAccount:      account,
+		state:               newClientState(),
+		maxResponseBodySize: types.MaxResponseBodySize,
+		fileSystem:          types.DefaultFileSystem(),
+		defaultPartSize:     types.MinPartSize,
+		clock:               types.SystemClock{},
+		maxClockSkew:        types.DefaultMaxClockSkew,
+		gasAdjustment:       types.DefaultGasAdjustment,
+		spHealthPolicy:      types.DefaultSPHealthPolicy(),
+		storageClasses:      types.DefaultStorageClasses(),
+		bucketDefaults:      newBucketDefaultsRegistry(nil),
+		cdnEndpoints:        map[string]*url.URL{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// withTestServer sends the SP requests of the client by the http client of server.
+func withTestServer(server *httptest.Server) func(c *Client) {
+	return func(c *Client) {
+		c.httpClient = server.Client()
+	}
+}
+
+func TestQueryFirstSP(t *testing.T) {
+	endpoints := []*url.URL{{Host: "sp0"}, {Host: "sp1"}, {Host: "sp2"}}
+	c := newTestClient(t)
+
+	// the fastest successful SP wins and the slow ones are canceled
+	results := make([]string, len(endpoints))
+	index, err := c.queryFirstSP(context.Background(), endpoints, func(ctx context.Context, index int, endpoint *url.URL) error {
+		switch endpoint.Host {
+		case "sp0":
+			return errors.New("sp0 is down")
+		case "sp1":
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		results[index] = endpoint.Host
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, index)
+	require.Equal(t, "sp2", results[index])
+
+	// the error is returned if all the SPs failed
+	_, err = c.queryFirstSP(context.Background(), endpoints, func(ctx context.Context, index int, endpoint *url.URL) error {
+		time.Sleep(time.Duration(index) * time.Millisecond)
+		return errors.New(endpoint.Host + " is down")
+	})
+	require.Error(t, err)
+}
+
+func newTestSPInfos(round int) []*spTypes.StorageProvider {
+	spInfos := make([]*spTypes.StorageProvider, 0, 3)
+	for id := uint32(1); id <= 3; id++ {
+		address := fmt.Sprintf("0x%040x", id)
+		spInfos = append(spInfos, &spTypes.StorageProvider{
+			Id:              id,
+			OperatorAddress: address,
+			ApprovalAddress: address,
+			SealAddress:     address,
+			GcAddress:       address,
+			Endpoint:        fmt.Sprintf("https://sp%d-%d.example.com", id, round),
+		})
+	}
+	return spInfos
+}
+
+// TestStorageProvidersConcurrentAccess should be run with -race, it routes the requests while the SP endpoints are
+// refreshed concurrently.
+func TestStorageProvidersConcurrentAccess(t *testing.T) {
+	c := newTestClient(t)
+	require.NoError(t, c.updateStorageProviders(newTestSPInfos(0)))
+
+	const rounds = 100
+	var wg sync.WaitGroup
+	errCh := make(chan error, 4*rounds)
+	for round := 1; round <= rounds; round++ {
+		wg.Add(4)
+		go func(round int) {
+			defer wg.Done()
+			errCh <- c.updateStorageProviders(newTestSPInfos(round))
+		}(round)
+		// the routing of uploads and downloads
+		go func() {
+			defer wg.Done()
+			_, err := c.getSPUrlByID(2)
+			errCh <- err
+		}()
+		// the routing of list calls
+		go func() {
+			defer wg.Done()
+			_, err := c.getEndpointByOpt(&types.EndPointOptions{SPAddress: fmt.Sprintf("0x%040x", 3)})
+			errCh <- err
+		}()
+		go func() {
+			defer wg.Done()
+			endpoints := make([]*url.URL, 0, 3)
+			for _, sp := range c.getStorageProviders() {
+				endpoints = append(endpoints, sp.EndPoint)
+			}
+			_, err := c.queryFirstSP(context.Background(), endpoints, func(ctx context.Context, index int, endpoint *url.URL) error {
+				return nil
+			})
+			errCh <- err
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	// the endpoints stay consistent after the concurrent refreshes
+	require.Len(t, c.getStorageProviders(), 3)
+	_, err := c.getSPUrlByID(4)
+	require.Error(t, err)
+}
+
+func TestResolvePartSize(t *testing.T) {
+	const segmentSize = 16 * 1024 * 1024
+	params := storageTypes.Params{
+		VersionedParams: storageTypes.VersionedParams{MaxSegmentSize: segmentSize},
+		MaxPayloadSize:  64 * 1024 * 1024,
+	}
+	c := newTestClient(t, func(c *Client) { c.defaultPartSize = 2 * segmentSize })
+
+	testCases := []struct {
+		name     string
+		partSize uint64
+		expected uint64
+		valid    bool
+	}{
+		{"default part size", 0, 2 * segmentSize, true},
+		{"one segment", segmentSize, segmentSize, true},
+		{"max payload size", 4 * segmentSize, 4 * segmentSize, true},
+		{"not multiple of segment", segmentSize + 1, 0, false},
+		{"smaller than segment", segmentSize / 2, 0, false},
+		{"exceeds max payload size", 8 * segmentSize, 0, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			partSize, err := c.resolvePartSize(tc.partSize, params)
+			if !tc.valid {
+				require.True(t, errors.Is(err, types.ErrInvalidPartSize), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, partSize)
+		})
+	}
+}
+
+func TestSendReqViaCDN(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request signed for the bucket endpoint is sent to the domain in the virtual-hosted style path
+		if r.URL.Path != "/dir/object" || r.Header.Get(types.HTTPHeaderAuthorization) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, withTestServer(server))
+	cdnEndpoint, err := c.getCDNEndpoint("bucket", server.URL)
+	require.NoError(t, err)
+
+	meta := requestMeta{bucketName: "bucket", objectName: "dir/object", contentSHA256: types.EmptyStringSHA256}
+	sendOpt := &sendOptions{method: http.MethodGet}
+	resp, err := c.sendReqViaCDN(context.Background(), meta, sendOpt, &url.URL{Scheme: "https", Host: "sp.example.com"}, cdnEndpoint)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the path-style endpoint can not be fronted by the domain
+	_, err = c.sendReqViaCDN(context.Background(), meta, sendOpt, &url.URL{Scheme: "http", Host: "127.0.0.1:9033"}, cdnEndpoint)
+	require.Error(t, err)
+}
+
+type fixedClock time.Time
+
+func (f fixedClock) Now() time.Time {
+	return time.Time(f)
+}
+
+func TestClockSkew(t *testing.T) {
+	local := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestClient(t, func(c *Client) { c.clock = fixedClock(local) })
+	respWithDate := func(date time.Time) *http.Response {
+		return &http.Response{Header: http.Header{types.HTTPHeaderServerDate: []string{date.Format(http.TimeFormat)}}}
+	}
+
+	c.measureClockSkew(respWithDate(local.Add(2 * time.Minute)))
+	require.Equal(t, 2*time.Minute, c.ClockSkew())
+	// the timestamps are not shifted unless the compensation is enabled
+	require.Equal(t, local, c.now())
+	c.compensateClockSkew = true
+	require.Equal(t, local.Add(2*time.Minute), c.now())
+
+	// the drift within the resolution of the Date header is ignored
+	c.measureClockSkew(respWithDate(local.Add(500 * time.Millisecond)))
+	require.Equal(t, time.Duration(0), c.ClockSkew())
+
+	// the responses without a valid Date header are not measured
+	c.measureClockSkew(respWithDate(local.Add(-time.Hour)))
+	c.measureClockSkew(&http.Response{Header: http.Header{}})
+	require.Equal(t, -time.Hour, c.ClockSkew())
+}
+
+func TestReadYourWritesConsistency(t *testing.T) {
+	c := newTestClient(t)
+	c.state.blockTime.Store(int64(time.Millisecond))
+	respAtHeight := func(height string) *http.Response {
+		header := http.Header{}
+		if height != "" {
+			header.Set(types.HTTPHeaderBlockHeight, height)
+		}
+		return &http.Response{Header: header, Request: &http.Request{URL: &url.URL{Host: "sp0"}}}
+	}
+	ctx := context.Background()
+
+	// nothing to wait for before the first write
+	require.NoError(t, c.checkMetaFreshness(ctx, respAtHeight("5"), types.ReadYourWrites))
+	c.recordWriteHeight(10)
+	c.recordWriteHeight(8)
+
+	err := c.checkMetaFreshness(ctx, respAtHeight("9"), types.ReadYourWrites)
+	var staleErr *types.StaleMetaError
+	require.True(t, errors.As(err, &staleErr))
+	require.Equal(t, int64(10), staleErr.WriteHeight)
+	require.Equal(t, "sp0", staleErr.Endpoint)
+	require.NoError(t, c.checkMetaFreshness(ctx, respAtHeight("10"), types.ReadYourWrites))
+	require.NoError(t, c.checkMetaFreshness(ctx, respAtHeight("9"), types.AnyConsistency))
+
+	// the SPs not reporting the height can not ensure the consistency
+	err = c.checkMetaFreshness(ctx, respAtHeight(""), types.ReadYourWrites)
+	require.Error(t, err)
+	require.False(t, errors.As(err, &staleErr))
+
+	// the stale responses are retried until the SP catches up
+	heights := []string{"8", "9", "11"}
+	calls := 0
+	err = c.queryConsistent(ctx, types.ReadYourWrites, func() error {
+		calls++
+		return c.checkMetaFreshness(ctx, respAtHeight(heights[calls-1]), types.ReadYourWrites)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	// the min height is awaited regardless of the writes of the client
+	err = c.checkMetaFreshness(ctx, respAtHeight("19"), types.AtLeastHeight(20))
+	require.True(t, errors.As(err, &staleErr))
+	require.Equal(t, int64(20), staleErr.MinHeight)
+	require.NoError(t, c.checkMetaFreshness(ctx, respAtHeight("20"), types.AtLeastHeight(20)))
+	require.Error(t, c.checkMetaFreshness(ctx, respAtHeight(""), types.AtLeastHeight(20)))
+	calls = 0
+	err = c.queryConsistent(ctx, types.AtLeastHeight(11), func() error {
+		calls++
+		return c.checkMetaFreshness(ctx, respAtHeight(heights[calls-1]), types.AtLeastHeight(11))
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestSendReqRetry(t *testing.T) {
+	var attempts, failures atomic.Int64
+	failures.Store(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if attempts.Add(1) <= failures.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, withTestServer(server), func(c *Client) {
+		c.retryPolicy = &types.RetryPolicy{MaxAttempts: 3, Backoff: func(int) time.Duration { return 0 }}
+	})
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	meta := requestMeta{bucketName: "bucket", objectName: "object", contentSHA256: types.EmptyStringSHA256, contentLength: 7}
+
+	// the seekable body is rewound for the retries
+	resp, err := c.sendReq(context.Background(), meta, &sendOptions{method: http.MethodPut, body: strings.NewReader("content"), disableCloseBody: true}, endpoint)
+	require.NoError(t, err)
+	content, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+	require.Equal(t, int64(3), attempts.Load())
+
+	// the request is not retried beyond the max attempts
+	attempts.Store(0)
+	failures.Store(3)
+	_, err = c.sendReq(context.Background(), meta, &sendOptions{method: http.MethodPut, body: strings.NewReader("content")}, endpoint)
+	require.Error(t, err)
+	require.Equal(t, int64(3), attempts.Load())
+
+	// the body which can not be rewound is sent only once
+	attempts.Store(0)
+	_, err = c.sendReq(context.Background(), meta, &sendOptions{method: http.MethodPut, body: io.LimitReader(strings.NewReader("content"), 7)}, endpoint)
+	require.Error(t, err)
+	require.Equal(t, int64(1), attempts.Load())
+}
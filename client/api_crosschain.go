@@ -108,7 +108,7 @@ func (c *Client) Claims(ctx context.Context, srcChainId, destChainId uint32, seq
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetChannelSendSequence(ctx context.Context, destChainId sdk.ChainID, channelId uint32) (uint64, error) {
 	resp, err := c.chainClient.CrosschainQueryClient.SendSequence(
-		ctx,
+		c.queryContext(ctx),
 		&crosschaintypes.QuerySendSequenceRequest{
 			DestChainId: uint32(destChainId),
 			ChannelId:   channelId,
@@ -133,7 +133,7 @@ func (c *Client) GetChannelSendSequence(ctx context.Context, destChainId sdk.Cha
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetChannelReceiveSequence(ctx context.Context, destChainId sdk.ChainID, channelId uint32) (uint64, error) {
 	resp, err := c.chainClient.CrosschainQueryClient.ReceiveSequence(
-		ctx,
+		c.queryContext(ctx),
 		&crosschaintypes.QueryReceiveSequenceRequest{
 			DestChainId: uint32(destChainId),
 			ChannelId:   channelId,
@@ -155,7 +155,7 @@ func (c *Client) GetChannelReceiveSequence(ctx context.Context, destChainId sdk.
 //
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetInturnRelayer(ctx context.Context, req *oracletypes.QueryInturnRelayerRequest) (*oracletypes.QueryInturnRelayerResponse, error) {
-	return c.chainClient.InturnRelayer(ctx, req)
+	return c.chainClient.InturnRelayer(c.queryContext(ctx), req)
 }
 
 // GetCrossChainPackage - Get the cross-chain package by sequence.
@@ -173,7 +173,7 @@ func (c *Client) GetInturnRelayer(ctx context.Context, req *oracletypes.QueryInt
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetCrossChainPackage(ctx context.Context, destChainId sdk.ChainID, channelId uint32, sequence uint64) ([]byte, error) {
 	resp, err := c.chainClient.CrossChainPackage(
-		ctx,
+		c.queryContext(ctx),
 		&crosschaintypes.QueryCrossChainPackageRequest{
 			DestChainId: uint32(destChainId),
 			ChannelId:   channelId,
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IDatasetClient - Client APIs for pinning the versions of the datasets made of many objects.
+type IDatasetClient interface {
+	BuildDatasetManifest(ctx context.Context, bucketName, manifestName string, objectNames []string, opts types.BuildDatasetManifestOptions) (*types.DatasetManifest, error)
+	GetDatasetManifest(ctx context.Context, bucketName, manifestName string) (*types.DatasetManifest, error)
+	VerifyDataset(ctx context.Context, bucketName, manifestName, expectedRoot string) (*types.DatasetVerifyResult, error)
+}
+
+// BuildDatasetManifest - Build the manifest of the dataset made of the sealed objects, and store it as the object
+// named manifestName.
+//
+// The manifest holds the checksums on chain of each object and the Merkle root over them, so the root identifies the
+// version of the dataset by a single hash, and the membership of an object can be proved by DatasetManifest.Proof.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket of the objects and the manifest.
+//
+// - manifestName: The name of the manifest object.
+//
+// - objectNames: The objects of the dataset, the objects under opts.Prefix are used if it is empty.
+//
+// - opts: The options to list the objects and to upload the manifest.
+//
+// - ret1: The manifest, Root is the hash to pin.
+//
+// - ret2: Return error when any object is not sealed or the manifest failed to upload, otherwise return nil.
+func (c *Client) BuildDatasetManifest(ctx context.Context, bucketName, manifestName string, objectNames []string,
+	opts types.BuildDatasetManifestOptions,
+) (*types.DatasetManifest, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	entries := make([]types.DatasetEntry, 0, len(objectNames))
+	if len(objectNames) == 0 {
+		listed, err := c.listDatasetEntries(ctx, bucketName, manifestName, opts.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		entries = listed
+	}
+	for _, objectName := range objectNames {
+		detail, err := c.HeadObject(ctx, bucketName, objectName)
+		if err != nil {
+			return nil, err
+		}
+		info := detail.ObjectInfo
+		if info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED || info.IsUpdating {
+			return nil, fmt.Errorf("the object %s of the dataset is not sealed", objectName)
+		}
+		entries = append(entries, newDatasetEntry(info))
+	}
+	manifest, err := types.NewDatasetManifest(bucketName, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	createOpts := opts.CreateOptions
+	createOpts.ContentType = "application/json"
+	if _, err = c.CreateObject(ctx, bucketName, manifestName, bytes.NewReader(content), createOpts); err != nil {
+		return nil, err
+	}
+	putOpts := opts.PutOptions
+	putOpts.ContentType = "application/json"
+	if err = c.PutObject(ctx, bucketName, manifestName, int64(len(content)), bytes.NewReader(content), putOpts); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// GetDatasetManifest - Download the manifest of the dataset.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - manifestName: The name of the manifest object.
+//
+// - ret1: The manifest.
+//
+// - ret2: Return error when the manifest can not be downloaded or decoded, otherwise return nil.
+func (c *Client) GetDatasetManifest(ctx context.Context, bucketName, manifestName string) (*types.DatasetManifest, error) {
+	body, _, err := c.GetObject(ctx, bucketName, manifestName, types.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	manifest := &types.DatasetManifest{}
+	if err = json.NewDecoder(body).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("fail to decode the dataset manifest %s: %w", manifestName, err)
+	}
+	if manifest.Version != types.DatasetManifestVersion {
+		return nil, fmt.Errorf("unsupported dataset manifest version %d", manifest.Version)
+	}
+	return manifest, nil
+}
+
+// VerifyDataset - Re-check the objects of the dataset against its manifest.
+//
+// The Merkle root is recomputed from the manifest and compared with the recorded root and expectedRoot, then each
+// object is checked to be still sealed with the size and the checksums in the manifest.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - manifestName: The name of the manifest object.
+//
+// - expectedRoot: The HEX-encoded root pinned by the caller, it is not compared if it is empty.
+//
+// - ret1: The result listing the missing and the changed objects.
+//
+// - ret2: Return error when the manifest does not match the root or the objects can not be checked, otherwise
+// return nil. The changed objects are reported by the result rather than the error.
+func (c *Client) VerifyDataset(ctx context.Context, bucketName, manifestName, expectedRoot string) (*types.DatasetVerifyResult, error) {
+	manifest, err := c.GetDatasetManifest(ctx, bucketName, manifestName)
+	if err != nil {
+		return nil, err
+	}
+	root, err := manifest.ComputeRoot()
+	if err != nil {
+		return nil, err
+	}
+	result := &types.DatasetVerifyResult{Root: hex.EncodeToString(root)}
+	if !strings.EqualFold(result.Root, manifest.Root) {
+		return nil, fmt.Errorf("the dataset manifest %s is corrupted, its root %s does not match the entries %s", manifestName, manifest.Root, result.Root)
+	}
+	if expectedRoot != "" && !strings.EqualFold(result.Root, expectedRoot) {
+		return nil, fmt.Errorf("the root %s of the dataset manifest %s is not the expected %s", result.Root, manifestName, expectedRoot)
+	}
+
+	for _, entry := range manifest.Entries {
+		detail, err := c.HeadObject(ctx, manifest.BucketName, entry.ObjectName)
+		if err != nil {
+			if strings.Contains(err.Error(), storageTypes.ErrNoSuchObject.Error()) {
+				result.Missing = append(result.Missing, entry.ObjectName)
+				continue
+			}
+			return nil, err
+		}
+		info := detail.ObjectInfo
+		if info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+			result.Missing = append(result.Missing, entry.ObjectName)
+			continue
+		}
+		current := newDatasetEntry(info)
+		if current.Size != entry.Size || !equalFoldAll(current.Checksums, entry.Checksums) {
+			result.Changed = append(result.Changed, entry.ObjectName)
+			continue
+		}
+		result.Verified++
+	}
+	return result, nil
+}
+
+// listDatasetEntries lists the sealed objects under the prefix, excluding the manifest itself.
+func (c *Client) listDatasetEntries(ctx context.Context, bucketName, manifestName, prefix string) ([]types.DatasetEntry, error) {
+	entries := make([]types.DatasetEntry, 0)
+	opts := types.ListObjectsOptions{Prefix: prefix}
+	for {
+		result, err := c.ListObjects(ctx, bucketName, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range result.Objects {
+			info := object.ObjectInfo
+			if object.Removed || info.ObjectName == manifestName || info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+				continue
+			}
+			entries = append(entries, newDatasetEntry(info))
+		}
+		if !result.IsTruncated {
+			return entries, nil
+		}
+		opts.ContinuationToken = result.NextContinuationToken
+	}
+}
+
+func newDatasetEntry(info *storageTypes.ObjectInfo) types.DatasetEntry {
+	checksums := make([]string, 0, len(info.Checksums))
+	for _, checksum := range info.Checksums {
+		checksums = append(checksums, hex.EncodeToString(checksum))
+	}
+	return types.DatasetEntry{ObjectName: info.ObjectName, Size: info.PayloadSize, Checksums: checksums}
+}
+
+func equalFoldAll(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IDiscontinueClient - Client APIs for watching the discontinued objects and rescuing them before the garbage collection.
+type IDiscontinueClient interface {
+	WatchDiscontinues(ctx context.Context, opts types.WatchDiscontinuesOptions) (<-chan types.DiscontinueWarning, error)
+	RescueDiscontinuedObjects(ctx context.Context, warning types.DiscontinueWarning, localDir string, opts types.SyncDirOptions) (*types.SyncResult, error)
+}
+
+// WatchDiscontinues - Watch the objects and the buckets of the owner discontinued by the SPs, the discontinued objects
+// are deleted by the garbage collection at the deadline of the warning, so they should be rescued before it, e.g. by
+// RescueDiscontinuedObjects.
+//
+// - ctx: Context variables for the current API call, the watching stops when it is done.
+//
+// - opts: The options to set the first block and the watched owner and buckets.
+//
+// - ret1: The channel receiving the warnings in the order the events are emitted, it is closed when ctx is done.
+//
+// - ret2: Return error when the owner or the first block can not be determined, otherwise return nil.
+func (c *Client) WatchDiscontinues(ctx context.Context, opts types.WatchDiscontinuesOptions) (<-chan types.DiscontinueWarning, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	owner := opts.Owner
+	if owner == "" {
+		account, err := c.GetDefaultAccount()
+		if err != nil {
+			return nil, err
+		}
+		owner = account.GetAddress().String()
+	}
+	watchedBuckets := make(map[string]bool, len(opts.BucketNames))
+	for _, bucketName := range opts.BucketNames {
+		watchedBuckets[bucketName] = true
+	}
+
+	events, err := c.WatchEvents(ctx, types.WatchEventsOptions{
+		StartHeight: opts.StartHeight,
+		EventTypes:  []string{types.EventDiscontinueBucket, types.EventDiscontinueObject},
+	})
+	if err != nil {
+		return nil, err
+	}
+	warnings := make(chan types.DiscontinueWarning)
+	go func() {
+		defer close(warnings)
+		// the owners of the buckets, a bucket is not transferable so its owner is cached
+		owners := make(map[string]string)
+		for event := range events {
+			warning, err := types.ParseDiscontinueWarning(event)
+			if err != nil {
+				log.Warn().Msg(fmt.Sprintf("skip the discontinue event of block %d: %s", event.Height, err.Error()))
+				continue
+			}
+			if len(watchedBuckets) > 0 && !watchedBuckets[warning.BucketName] {
+				continue
+			}
+			bucketOwner, ok := owners[warning.BucketName]
+			if !ok {
+				bucketInfo, err := c.HeadBucket(ctx, warning.BucketName)
+				if err != nil {
+					log.Warn().Msg(fmt.Sprintf("fail to head the discontinued bucket %s: %s", warning.BucketName, err.Error()))
+					continue
+				}
+				bucketOwner = bucketInfo.Owner
+				owners[warning.BucketName] = bucketOwner
+			}
+			if !strings.EqualFold(bucketOwner, owner) {
+				continue
+			}
+			select {
+			case warnings <- warning:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return warnings, nil
+}
+
+// RescueDiscontinuedObjects - Download the discontinued objects of the warning to the local directory before they are
+// deleted, while the SPs still serve them.
+//
+// The objects are downloaded in the same way as SyncDirDownload, the file of an object is located by the object name
+// in localDir. All the sealed objects of the bucket are downloaded if the whole bucket is discontinued.
+//
+// - ctx: Context variables for the current API call.
+//
+// - warning: The discontinue warning received from WatchDiscontinues.
+//
+// - localDir: The local directory to download to.
+//
+// - opts: The options to download the objects, the upload options are ignored.
+//
+// - ret1: The result of the download, the failed objects are reported in Failed.
+//
+// - ret2: Return error when the objects can not be found, or some objects fail to download, otherwise return nil.
+func (c *Client) RescueDiscontinuedObjects(ctx context.Context, warning types.DiscontinueWarning, localDir string,
+	opts types.SyncDirOptions,
+) (*types.SyncResult, error) {
+	if warning.WholeBucket() {
+		return c.SyncDirDownload(ctx, warning.BucketName, "", localDir, opts)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := c.getRedundancyParams()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[string]*storageTypes.ObjectInfo, len(warning.ObjectIDs))
+	for _, objectID := range warning.ObjectIDs {
+		objectDetail, err := c.HeadObjectByID(ctx, objectID)
+		if err != nil {
+			return nil, fmt.Errorf("fail to head the discontinued object %s: %w", objectID, err)
+		}
+		objects[objectDetail.ObjectInfo.ObjectName] = objectDetail.ObjectInfo
+	}
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	return c.runSyncTasks(ctx, names, opts.Concurrency, nil, func(ctx context.Context, objectName string) (bool, error) {
+		filePath, err := syncFilePath(localDir, objectName)
+		if err != nil {
+			return false, err
+		}
+		return c.downloadSyncObject(ctx, warning.BucketName, objectName, filePath, objects[objectName], params, opts)
+	})
+}
@@ -0,0 +1,360 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IEncryptionClient - Client APIs for the client-side encrypted objects shared with the members of groups.
+//
+// The content is encrypted by a random data key, and the data key is wrapped for the X25519 public key of each
+// recipient. The wrapped keys are stored in the KeyEnvelope object named by the object name with
+// types.KeyEnvelopeSuffix rather than the object tags, since the tags are too small to hold the keys of large groups.
+type IEncryptionClient interface {
+	PutEncryptedObject(ctx context.Context, bucketName, objectName string, reader io.ReadSeeker, objectSize int64, opts types.PutEncryptedObjectOptions) error
+	GetEncryptedObject(ctx context.Context, bucketName, objectName string, key *ecdh.PrivateKey) (io.ReadCloser, error)
+	GetKeyEnvelope(ctx context.Context, bucketName, objectName string) (*types.KeyEnvelope, error)
+	RewrapObjectKeys(ctx context.Context, bucketName, objectName string, key *ecdh.PrivateKey, opts types.RewrapObjectKeysOptions) (*types.KeyEnvelope, error)
+}
+
+// PutEncryptedObject - Encrypt the content on the client side and upload it, the data key is wrapped for the members
+// of the group, the extra recipients and the default account.
+//
+// The object is created and uploaded with the encrypted content, then its KeyEnvelope is created and uploaded. Unless
+// opts.SkipGroupPolicy is set, the group is granted the permission to get both of them, so the members can download
+// and decrypt the object without a separate key management service.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - reader: The content to encrypt, it is read twice to compute the checksums and to upload.
+//
+// - objectSize: The size of the plaintext content.
+//
+// - opts: The options to share and upload the object.
+//
+// - ret: Return error when the recipients can not be resolved or the upload failed, otherwise return nil.
+func (c *Client) PutEncryptedObject(ctx context.Context, bucketName, objectName string, reader io.ReadSeeker,
+	objectSize int64, opts types.PutEncryptedObjectOptions,
+) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if reader == nil {
+		return errors.New("fail to encrypt the object, reader is nil")
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = types.DefaultEncryptionChunkSize
+	}
+	dataKey, err := types.NewDataKey()
+	if err != nil {
+		return err
+	}
+	envelope := &types.KeyEnvelope{
+		Version:   types.KeyEnvelopeVersion,
+		Algorithm: types.EncryptionAlgorithm,
+		ChunkSize: chunkSize,
+	}
+	groupID, err := c.wrapForRecipients(ctx, envelope, dataKey, opts.Sharing)
+	if err != nil {
+		return err
+	}
+	envelopeContent, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	// the encryption is deterministic, so the content is encrypted again to upload after computing the checksums
+	encrypt := func() (io.Reader, error) {
+		if _, err := reader.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return types.NewEncryptReader(dataKey, reader, chunkSize)
+	}
+	encrypted, err := encrypt()
+	if err != nil {
+		return err
+	}
+	if _, err = c.CreateObject(ctx, bucketName, objectName, encrypted, opts.CreateOptions); err != nil {
+		return err
+	}
+	if encrypted, err = encrypt(); err != nil {
+		return err
+	}
+	if err = c.PutObject(ctx, bucketName, objectName, types.EncryptedSize(objectSize, chunkSize), encrypted, opts.PutOptions); err != nil {
+		return err
+	}
+
+	envelopeName := objectName + types.KeyEnvelopeSuffix
+	envelopeOpts := opts.CreateOptions
+	envelopeOpts.ContentType = "application/json"
+	if _, err = c.CreateObject(ctx, bucketName, envelopeName, bytes.NewReader(envelopeContent), envelopeOpts); err != nil {
+		return err
+	}
+	putOpts := opts.PutOptions
+	putOpts.ContentType = "application/json"
+	if err = c.PutObject(ctx, bucketName, envelopeName, int64(len(envelopeContent)), bytes.NewReader(envelopeContent), putOpts); err != nil {
+		return err
+	}
+
+	if groupID == 0 || opts.SkipGroupPolicy {
+		return nil
+	}
+	principal, err := utils.NewPrincipalWithGroupId(groupID)
+	if err != nil {
+		return err
+	}
+	statement := utils.NewStatement([]permTypes.ActionType{permTypes.ACTION_GET_OBJECT}, permTypes.EFFECT_ALLOW,
+		nil, types.NewStatementOptions{})
+	for _, name := range []string{objectName, envelopeName} {
+		txnHash, err := c.PutObjectPolicy(ctx, bucketName, name, principal, []*permTypes.Statement{&statement},
+			types.PutPolicyOption{TxOpts: opts.CreateOptions.TxOpts})
+		if err != nil {
+			return fmt.Errorf("fail to grant the group %s the permission to get %s: %w", opts.Sharing.GroupName, name, err)
+		}
+		if _, err = c.WaitForTx(ctx, txnHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEncryptedObject - Download the encrypted object and decrypt it by the data key wrapped for the default account.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - key: The encryption private key of the default account, see types.NewEncryptionKey.
+//
+// - ret1: The decrypted content, reading it fails if the content is modified or truncated.
+//
+// - ret2: Return error when the default account is not a recipient or the download failed, otherwise return nil.
+func (c *Client) GetEncryptedObject(ctx context.Context, bucketName, objectName string, key *ecdh.PrivateKey) (io.ReadCloser, error) {
+	account, err := c.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := c.GetKeyEnvelope(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := envelope.DataKey(account.GetAddress().String(), key)
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := c.GetObject(ctx, bucketName, objectName, types.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := types.NewDecryptReader(dataKey, body, envelope.ChunkSize)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{decrypted, body}, nil
+}
+
+// GetKeyEnvelope - Get the KeyEnvelope of the encrypted object.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the encrypted object, not the envelope.
+//
+// - ret1: The key envelope of the object.
+//
+// - ret2: Return error when the envelope can not be downloaded or decoded, otherwise return nil.
+func (c *Client) GetKeyEnvelope(ctx context.Context, bucketName, objectName string) (*types.KeyEnvelope, error) {
+	body, _, err := c.GetObject(ctx, bucketName, objectName+types.KeyEnvelopeSuffix, types.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	envelope := &types.KeyEnvelope{}
+	if err = json.NewDecoder(body).Decode(envelope); err != nil {
+		return nil, fmt.Errorf("fail to decode the key envelope of %s: %w", objectName, err)
+	}
+	return envelope, nil
+}
+
+// RewrapObjectKeys - Rewrap the data key of the encrypted object for the current recipients, e.g. after the members
+// of the group change, and replace its KeyEnvelope.
+//
+// The data key is unwrapped by the key of the default account, which should be a recipient and have the permission
+// to update the envelope. The removed members can no longer get the data key from the envelope, but the object is
+// not re-encrypted, so the members who kept the data key or the content before they were removed can still read it.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the encrypted object.
+//
+// - key: The encryption private key of the default account.
+//
+// - opts: The recipients and the options to update the envelope.
+//
+// - ret1: The new key envelope.
+//
+// - ret2: Return error when the data key can not be unwrapped or the envelope failed to update, otherwise return nil.
+func (c *Client) RewrapObjectKeys(ctx context.Context, bucketName, objectName string, key *ecdh.PrivateKey,
+	opts types.RewrapObjectKeysOptions,
+) (*types.KeyEnvelope, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := c.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+	old, err := c.GetKeyEnvelope(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := old.DataKey(account.GetAddress().String(), key)
+	if err != nil {
+		return nil, err
+	}
+	sharing := opts.Sharing
+	if sharing.GroupName == "" {
+		sharing.GroupName, sharing.GroupOwner = old.GroupName, old.GroupOwner
+	}
+	envelope := &types.KeyEnvelope{
+		Version:   old.Version,
+		Algorithm: old.Algorithm,
+		ChunkSize: old.ChunkSize,
+	}
+	if _, err = c.wrapForRecipients(ctx, envelope, dataKey, sharing); err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeName := objectName + types.KeyEnvelopeSuffix
+	if _, err = c.UpdateObjectContent(ctx, bucketName, envelopeName, bytes.NewReader(content), types.UpdateObjectOptions{
+		TxOpts:      opts.TxOpts,
+		ContentType: "application/json",
+	}); err != nil {
+		return nil, err
+	}
+	putOpts := opts.PutOptions
+	putOpts.ContentType = "application/json"
+	if err = c.PutObject(ctx, bucketName, envelopeName, int64(len(content)), bytes.NewReader(content), putOpts); err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// wrapForRecipients wraps the data key for the group members, the extra recipients and the default account, it
+// returns the id of the group, or 0 if the object is not shared with a group.
+func (c *Client) wrapForRecipients(ctx context.Context, envelope *types.KeyEnvelope, dataKey []byte,
+	sharing types.EncryptionSharingOptions,
+) (uint64, error) {
+	account, err := c.GetDefaultAccount()
+	if err != nil {
+		return 0, err
+	}
+	// the missing keys of the required recipients fail the call
+	required := append([]string{account.GetAddress().String()}, sharing.Recipients...)
+
+	var groupID uint64
+	members := make([]string, 0)
+	if sharing.GroupName != "" {
+		if sharing.GroupOwner == "" {
+			sharing.GroupOwner = account.GetAddress().String()
+		}
+		group, err := c.HeadGroup(ctx, sharing.GroupName, sharing.GroupOwner)
+		if err != nil {
+			return 0, err
+		}
+		groupID = group.Id.Uint64()
+		if members, err = c.listAllGroupMembers(ctx, groupID, sharing); err != nil {
+			return 0, err
+		}
+		envelope.GroupName, envelope.GroupOwner = sharing.GroupName, sharing.GroupOwner
+	}
+
+	for i, address := range append(required, members...) {
+		if _, ok := envelope.WrappedKeys[strings.ToLower(address)]; ok {
+			continue
+		}
+		publicKey, err := sharing.KeyDirectory.EncryptionPublicKey(ctx, address)
+		if err != nil {
+			if i >= len(required) && sharing.SkipMissingKeys {
+				log.Warn().Msg(fmt.Sprintf("skip the group member %s without encryption public key: %s", address, err.Error()))
+				continue
+			}
+			return 0, fmt.Errorf("fail to get the encryption public key of %s: %w", address, err)
+		}
+		if err = envelope.Wrap(dataKey, address, publicKey); err != nil {
+			return 0, err
+		}
+	}
+	return groupID, nil
+}
+
+// listAllGroupMembers lists the addresses of the group members page by page, the expired members are excluded.
+func (c *Client) listAllGroupMembers(ctx context.Context, groupID uint64, sharing types.EncryptionSharingOptions) ([]string, error) {
+	members := make([]string, 0)
+	startAfter := ""
+	for {
+		result, err := c.ListGroupMembers(ctx, int64(groupID), types.GroupMembersPaginationOptions{
+			Limit:      groupMembersPageSize,
+			StartAfter: startAfter,
+			Endpoint:   sharing.Endpoint,
+			SPAddress:  sharing.SPAddress,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range result.Groups {
+			if !member.Removed && !groupMemberExpired(member.ExpirationTime) {
+				members = append(members, member.AccountID)
+			}
+		}
+		if len(result.Groups) < groupMembersPageSize {
+			return members, nil
+		}
+		startAfter = result.Groups[len(result.Groups)-1].AccountID
+	}
+}
+
+// groupMembersPageSize is the page size of listing the group members, it is the max limit of SP.
+const groupMembersPageSize = 1000
+
+// groupMemberExpired reports whether the membership expired, expirationTime is the unix timestamp in seconds, and the
+// membership never expires if it is empty or 0.
+func groupMemberExpired(expirationTime string) bool {
+	expiration, err := strconv.ParseInt(expirationTime, 10, 64)
+	if err != nil || expiration <= 0 {
+		return false
+	}
+	return time.Unix(expiration, 0).Before(time.Now())
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IEventClient - Client APIs for watching and exporting the Greenfield chain events.
+type IEventClient interface {
+	WatchEvents(ctx context.Context, opts types.WatchEventsOptions) (<-chan types.ChainEvent, error)
+	ExportEvents(ctx context.Context, sink types.EventSink, offsets types.EventOffsetStore, opts types.WatchEventsOptions) error
+}
+
+// WatchEvents - Watch the events emitted by the txs of the new blocks, the bucket and group events are watched by default.
+//
+// The failed queries are logged and retried, so no block is skipped. Use ExportEvents instead if the events should
+// survive the restarts of the process.
+//
+// - ctx: Context variables for the current API call, the watching stops when it is done.
+//
+// - opts: The options to set the first block and the types of the events to watch.
+//
+// - ret1: The channel receiving the events in the order they are emitted, it is closed when ctx is done.
+//
+// - ret2: Return error when the first block can not be determined, otherwise return nil.
+func (c *Client) WatchEvents(ctx context.Context, opts types.WatchEventsOptions) (<-chan types.ChainEvent, error) {
+	height, err := c.watchStartHeight(ctx, opts.StartHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan types.ChainEvent)
+	go func() {
+		defer close(events)
+		for {
+			blockEvents, err := c.waitBlockEvents(ctx, height, opts.EventTypes)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warn().Msg(fmt.Sprintf("fail to query the events of block %d: %s", height, err.Error()))
+				select {
+				case <-time.After(types.DefaultBlockTime):
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			for _, event := range blockEvents {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			height++
+		}
+	}()
+	return events, nil
+}
+
+// ExportEvents - Export the events emitted by the txs of the new blocks to the sink with at-least-once delivery, the
+// bucket and group events are exported by default.
+//
+// The events of each block are published in one batch, and the block height is saved to offsets after the sink
+// accepts them. It returns when any of them fails, calling it again resumes from the block after the stored offset,
+// so the events of a block may be delivered more than once and the sink should deduplicate them if needed.
+//
+// - ctx: Context variables for the current API call, the exporting stops when it is done.
+//
+// - sink: The sink receiving the events, e.g. a Kafka or NATS producer, or types.WebhookEventSink.
+//
+// - offsets: The store persisting the height of the last exported block.
+//
+// - opts: The options to set the first block when no offset is stored and the types of the events to export.
+//
+// - ret: Return error when the events can not be queried, published or the offset can not be saved, or ctx is done.
+func (c *Client) ExportEvents(ctx context.Context, sink types.EventSink, offsets types.EventOffsetStore, opts types.WatchEventsOptions) error {
+	offset, err := offsets.LoadOffset(ctx)
+	if err != nil {
+		return err
+	}
+	height := offset + 1
+	if offset == 0 {
+		if height, err = c.watchStartHeight(ctx, opts.StartHeight); err != nil {
+			return err
+		}
+	}
+
+	for {
+		blockEvents, err := c.waitBlockEvents(ctx, height, opts.EventTypes)
+		if err != nil {
+			return err
+		}
+		if len(blockEvents) > 0 {
+			if err = sink.Publish(ctx, blockEvents); err != nil {
+				return fmt.Errorf("fail to publish the events of block %d: %w", height, err)
+			}
+		}
+		if err = offsets.SaveOffset(ctx, height); err != nil {
+			return fmt.Errorf("fail to save the offset %d: %w", height, err)
+		}
+		height++
+	}
+}
+
+// watchStartHeight returns startHeight if it is set, otherwise the height of the next block.
+func (c *Client) watchStartHeight(ctx context.Context, startHeight int64) (int64, error) {
+	if startHeight > 0 {
+		return startHeight, nil
+	}
+	latestHeight, err := c.GetLatestBlockHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return latestHeight + 1, nil
+}
+
+// waitBlockEvents waits until the block of height is committed and returns its events of eventTypes.
+func (c *Client) waitBlockEvents(ctx context.Context, height int64, eventTypes []string) ([]types.ChainEvent, error) {
+	if len(eventTypes) == 0 {
+		eventTypes = types.DefaultWatchedEventTypes
+	}
+	watched := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		watched[eventType] = true
+	}
+
+	if err := c.WaitForBlockHeight(ctx, height); err != nil {
+		return nil, err
+	}
+	blockResults, err := c.GetBlockResultByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]types.ChainEvent, 0)
+	for txIndex, txResult := range blockResults.TxsResults {
+		if txResult == nil || txResult.Code != 0 {
+			continue
+		}
+		for eventIndex, event := range txResult.Events {
+			if !watched[event.Type] {
+				continue
+			}
+			attributes := make(map[string]string, len(event.Attributes))
+			for _, attribute := range event.Attributes {
+				attributes[attribute.Key] = attribute.Value
+			}
+			events = append(events, types.ChainEvent{
+				Height:     height,
+				TxIndex:    txIndex,
+				EventIndex: eventIndex,
+				Type:       event.Type,
+				Attributes: attributes,
+			})
+		}
+	}
+	return events, nil
+}
@@ -104,7 +104,7 @@ func (c *Client) QueryAllowance(ctx context.Context, granterAddr, granteeAddr st
 		Granter: granterAddr,
 		Grantee: granteeAddr,
 	}
-	response, err := c.chainClient.FeegrantQueryClient.Allowance(ctx, req)
+	response, err := c.chainClient.FeegrantQueryClient.Allowance(c.queryContext(ctx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func (c *Client) QueryAllowances(ctx context.Context, granteeAddr string) ([]*fe
 	req := &feegrant.QueryAllowancesRequest{
 		Grantee: granteeAddr,
 	}
-	response, err := c.chainClient.FeegrantQueryClient.Allowances(ctx, req)
+	response, err := c.chainClient.FeegrantQueryClient.Allowances(c.queryContext(ctx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +134,7 @@ func (c *Client) QueryGranterAllowances(ctx context.Context, granterAddr string)
 	req := &feegrant.QueryAllowancesByGranterRequest{
 		Granter: granterAddr,
 	}
-	response, err := c.chainClient.FeegrantQueryClient.AllowancesByGranter(ctx, req)
+	response, err := c.chainClient.FeegrantQueryClient.AllowancesByGranter(c.queryContext(ctx), req)
 	if err != nil {
 		return nil, err
 	}
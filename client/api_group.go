@@ -45,6 +45,10 @@ type IGroupClient interface {
 	ListGroupsByAccount(ctx context.Context, opts types.GroupsPaginationOptions) (*types.GroupsResult, error)
 	ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPaginationOptions) (*types.GroupsResult, error)
 	ListGroupsByGroupID(ctx context.Context, groupIDs []uint64, opts types.EndPointOptions) (types.ListGroupsByGroupIDResponse, error)
+	FindOrphanedGroups(ctx context.Context, owner string, opts types.FindOrphanedGroupsOptions) (*types.OrphanedGroupsResult, error)
+	FindDanglingPolicies(ctx context.Context, bucketName string, opts types.FindDanglingPoliciesOptions) (*types.DanglingPoliciesResult, error)
+	IssueGroupMembershipToken(ctx context.Context, memberAddr, groupOwnerAddr, groupName string, ttl time.Duration) (*types.GroupMembershipToken, error)
+	VerifyGroupMembershipToken(token *types.GroupMembershipToken, issuerAddr, groupOwnerAddr, groupName string) error
 }
 
 // CreateGroup - Create a new group without group members on Greenfield blockchain, and group members can be added by UpdateGroupMember transaction.
@@ -222,7 +226,7 @@ func (c *Client) HeadGroup(ctx context.Context, groupName string, groupOwnerAddr
 		GroupName:  groupName,
 	}
 
-	headGroupResponse, err := c.chainClient.HeadGroup(ctx, &headGroupRequest)
+	headGroupResponse, err := c.chainClient.HeadGroup(c.queryContext(ctx), &headGroupRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +252,7 @@ func (c *Client) HeadGroupMember(ctx context.Context, groupName string, groupOwn
 		Member:     headMemberAddr,
 	}
 
-	_, err := c.chainClient.HeadGroupMember(ctx, &headGroupRequest)
+	_, err := c.chainClient.HeadGroupMember(c.queryContext(ctx), &headGroupRequest)
 	return err == nil
 }
 
@@ -304,7 +308,7 @@ func (c *Client) GetBucketPolicyOfGroup(ctx context.Context, bucketName string,
 		PrincipalGroupId: sdkmath.NewUint(groupId).String(),
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForGroup(ctx, &queryPolicy)
+	queryPolicyResp, err := c.chainClient.QueryPolicyForGroup(c.queryContext(ctx), &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +336,7 @@ func (c *Client) GetObjectPolicyOfGroup(ctx context.Context, bucketName, objectN
 		PrincipalGroupId: sdkmath.NewUint(groupId).String(),
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForGroup(ctx, &queryPolicy)
+	queryPolicyResp, err := c.chainClient.QueryPolicyForGroup(c.queryContext(ctx), &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -391,7 +395,7 @@ func (c *Client) GetGroupPolicy(ctx context.Context, groupName string, principal
 		PrincipalAddress: principalAddr,
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(ctx, &queryPolicy)
+	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(c.queryContext(ctx), &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -409,6 +413,9 @@ func (c *Client) GetGroupPolicy(ctx context.Context, groupName string, principal
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) ListGroup(ctx context.Context, name, prefix string, opts types.ListGroupsOptions) (types.ListGroupsResult, error) {
+	if err := opts.Validate(); err != nil {
+		return types.ListGroupsResult{}, err
+	}
 	const (
 		MaximumGetGroupListLimit  = 1000
 		MaximumGetGroupListOffset = 100000
@@ -431,6 +438,15 @@ func (c *Client) ListGroup(ctx context.Context, name, prefix string, opts types.
 		opts.Limit = DefaultGetGroupListLimit
 	}
 
+	if opts.PageToken != "" {
+		position, _ := opts.PageToken.Position(types.PageGroups)
+		offset, err := strconv.ParseInt(position, 10, 64)
+		if err != nil {
+			return types.ListGroupsResult{}, fmt.Errorf("%w: %v", types.ErrInvalidPageToken, err)
+		}
+		opts.Offset = offset
+	}
+
 	if opts.Offset < 0 || opts.Offset > MaximumGetGroupListOffset {
 		return types.ListGroupsResult{}, nil
 	}
@@ -474,21 +490,16 @@ func (c *Client) ListGroup(ctx context.Context, name, prefix string, opts types.
 	}
 	defer utils.CloseResponse(resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		log.Error().Msg("the list of groups failed: " + err.Error())
-		return types.ListGroupsResult{}, err
-	}
-
 	listGroupsResult := types.ListGroupsResult{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &listGroupsResult)
+	// decode the xml content from response body
+	err = c.decodeXMLResponse(resp, &listGroupsResult)
 	if err != nil {
 		log.Error().Msg("the list of groups failed: " + err.Error())
 		return types.ListGroupsResult{}, err
 	}
+	if next := opts.Offset + int64(len(listGroupsResult.Groups)); len(listGroupsResult.Groups) > 0 && next < listGroupsResult.Count {
+		listGroupsResult.NextPageToken = types.NewPageToken(types.PageGroups, strconv.FormatInt(next, 10))
+	}
 
 	return listGroupsResult, nil
 }
@@ -551,7 +562,13 @@ func (c *Client) RenewGroupMember(ctx context.Context, groupOwnerAddr, groupName
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) ListGroupMembers(ctx context.Context, groupID int64, opts types.GroupMembersPaginationOptions) (*types.GroupMembersResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
+	if opts.PageToken != "" {
+		opts.StartAfter, _ = opts.PageToken.Position(types.PageGroupMembers)
+	}
 	params.Set("group-members", "")
 	params.Set("group-id", strconv.FormatInt(groupID, 10))
 	params.Set("start-after", opts.StartAfter)
@@ -582,22 +599,20 @@ func (c *Client) ListGroupMembers(ctx context.Context, groupID int64, opts types
 	}
 	defer utils.CloseResponse(resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		log.Error().Msgf("get groups info by a user address in group id:%v failed: %s", groupID, err.Error())
-		return &types.GroupMembersResult{}, err
-	}
-
 	var groups *types.GroupMembersResult
-	bufStr := buf.String()
+	// decode the xml content from response body
 	// TODO change the format to XML later
-	err = xml.Unmarshal([]byte(bufStr), &groups)
+	err = c.decodeXMLResponse(resp, &groups)
 	if err != nil {
 		log.Error().Msgf("get groups info by a user address in group id:%v failed: %s", groupID, err.Error())
 		return &types.GroupMembersResult{}, err
 	}
+	if groups == nil {
+		return &types.GroupMembersResult{}, nil
+	}
+	if n := len(groups.Groups); n > 0 {
+		groups.NextPageToken = types.NextStartAfterToken(types.PageGroupMembers, opts.Limit, n, groups.Groups[n-1].AccountID)
+	}
 
 	return groups, nil
 }
@@ -612,7 +627,13 @@ func (c *Client) ListGroupMembers(ctx context.Context, groupID int64, opts types
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPaginationOptions) (*types.GroupsResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
+	if opts.PageToken != "" {
+		opts.StartAfter, _ = opts.PageToken.Position(types.PageUserGroups)
+	}
 	params.Set("user-groups", "")
 	params.Set("start-after", opts.StartAfter)
 	params.Set("limit", strconv.FormatInt(opts.Limit, 10))
@@ -653,22 +674,18 @@ func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPagin
 	}
 	defer utils.CloseResponse(resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		log.Error().Msgf("get group members by group id in account id:%v failed: %s", account, err.Error())
-		return &types.GroupsResult{}, err
-	}
-
 	var groups *types.GroupsResult
-	bufStr := buf.String()
+	// decode the xml content from response body
 	// TODO change the format to XML later
-	err = xml.Unmarshal([]byte(bufStr), &groups)
+	err = c.decodeXMLResponse(resp, &groups)
 	if err != nil {
 		log.Error().Msgf("get group members by group id in account id:%v failed: %s", account, err.Error())
 		return &types.GroupsResult{}, err
 	}
+	if groups == nil {
+		return &types.GroupsResult{}, nil
+	}
+	groups.NextPageToken = types.NextStartAfterToken(types.PageUserGroups, opts.Limit, len(groups.Groups), lastGroupID(groups.Groups))
 
 	return groups, nil
 }
@@ -683,7 +700,13 @@ func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPagin
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPaginationOptions) (*types.GroupsResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	params := url.Values{}
+	if opts.PageToken != "" {
+		opts.StartAfter, _ = opts.PageToken.Position(types.PageOwnedGroups)
+	}
 	params.Set("owned-groups", "")
 	params.Set("start-after", opts.StartAfter)
 	params.Set("limit", strconv.FormatInt(opts.Limit, 10))
@@ -724,22 +747,18 @@ func (c *Client) ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPa
 	}
 	defer utils.CloseResponse(resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		log.Error().Msgf("retrieve groups where the user is the owner in account id:%v failed: %s", owner, err.Error())
-		return &types.GroupsResult{}, err
-	}
-
 	var groups *types.GroupsResult
+	// decode the xml content from response body
 	// TODO change the format to XML later
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &groups)
+	err = c.decodeXMLResponse(resp, &groups)
 	if err != nil {
 		log.Error().Msgf("retrieve groups where the user is the owner in account id:%v failed: %s", owner, err.Error())
 		return &types.GroupsResult{}, err
 	}
+	if groups == nil {
+		return &types.GroupsResult{}, nil
+	}
+	groups.NextPageToken = types.NextStartAfterToken(types.PageOwnedGroups, opts.Limit, len(groups.Groups), lastGroupID(groups.Groups))
 
 	return groups, nil
 }
@@ -829,21 +848,87 @@ func (c *Client) ListGroupsByGroupID(ctx context.Context, groupIDs []uint64, opt
 		return types.ListGroupsByGroupIDResponse{}, err
 	}
 	defer utils.CloseResponse(resp)
+	setResponseInfo(opts.ResponseInfo, resp)
 
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
+	groups := types.ListGroupsByGroupIDResponse{}
+	// decode the xml content from response body
+	err = c.decodeXMLResponse(resp, (*gfSpListGroupsByGroupIDsResponse)(&groups.Groups))
 	if err != nil {
 		log.Error().Msgf("the list of groups in group ids:%v failed: %s", groupIDs, err.Error())
 		return types.ListGroupsByGroupIDResponse{}, err
 	}
 
-	groups := types.ListGroupsByGroupIDResponse{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), (*gfSpListGroupsByGroupIDsResponse)(&groups.Groups))
-	if err != nil && groups.Groups == nil {
-		log.Error().Msgf("the list of groups in group ids:%v failed: %s", groups, err.Error())
-		return types.ListGroupsByGroupIDResponse{}, err
+	return groups, nil
+}
+
+// lastGroupID returns the id of the last group of the page, the next page starts after it.
+func lastGroupID(groups []*types.GroupMembers) string {
+	if len(groups) == 0 || groups[len(groups)-1].Group == nil {
+		return ""
 	}
+	return groups[len(groups)-1].Group.Id.String()
+}
 
-	return groups, nil
+// IssueGroupMembershipToken - Check the membership of the account in the group on chain, and sign a short-lived token
+// asserting it by the default account, e.g. for a gateway service which gates the contents by the group membership and
+// verifies the token by VerifyGroupMembershipToken for the following requests without querying the chain.
+//
+// - ctx: Context variables for the current API call.
+//
+// - memberAddr: The HEX-encoded string of the member address.
+//
+// - groupOwnerAddr: The HEX-encoded string of the group owner address.
+//
+// - groupName: The group name identifies the group.
+//
+// - ttl: The lifetime of the token, it is shortened to the expiration time of the membership if that comes earlier.
+//
+// - ret1: The token signed by the default account.
+//
+// - ret2: Return error when the account is not a member of the group or the signing failed, otherwise return nil.
+func (c *Client) IssueGroupMembershipToken(ctx context.Context, memberAddr, groupOwnerAddr, groupName string,
+	ttl time.Duration,
+) (*types.GroupMembershipToken, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("the ttl %s of the token should be positive", ttl)
+	}
+	acc, err := c.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.chainClient.HeadGroupMember(c.queryContext(ctx), &storageTypes.QueryHeadGroupMemberRequest{
+		GroupName:  groupName,
+		GroupOwner: groupOwnerAddr,
+		Member:     memberAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("the account %s is not a member of group %s: %w", memberAddr, groupName, err)
+	}
+	issuedAt := c.now()
+	expiresAt := issuedAt.Add(ttl)
+	if member := resp.GroupMember; member != nil && member.ExpirationTime != nil {
+		if !member.ExpirationTime.After(issuedAt) {
+			return nil, fmt.Errorf("the membership of account %s in group %s expired at %s", memberAddr, groupName, member.ExpirationTime)
+		}
+		if member.ExpirationTime.Before(expiresAt) {
+			expiresAt = *member.ExpirationTime
+		}
+	}
+	return types.NewGroupMembershipToken(acc, memberAddr, groupOwnerAddr, groupName, issuedAt, expiresAt)
+}
+
+// VerifyGroupMembershipToken - Verify the token is signed by the issuer, it is not expired and it asserts the
+// membership in the group, the chain is not queried.
+//
+// - token: The token presented by the member, types.ParseGroupMembershipToken parses the encoded token.
+//
+// - issuerAddr: The HEX-encoded string of the address trusted to issue the tokens.
+//
+// - groupOwnerAddr: The HEX-encoded string of the group owner address.
+//
+// - groupName: The group name identifies the group.
+//
+// - ret: Return error wrapping types.ErrInvalidMembershipToken if the token is invalid, otherwise return nil.
+func (c *Client) VerifyGroupMembershipToken(token *types.GroupMembershipToken, issuerAddr, groupOwnerAddr, groupName string) error {
+	return types.VerifyGroupMembershipToken(token, issuerAddr, groupOwnerAddr, groupName, c.now())
 }
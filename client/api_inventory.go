@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IInventoryClient - Client APIs for exporting the object inventories of buckets.
+type IInventoryClient interface {
+	ExportBucketInventory(ctx context.Context, bucketName string, w io.Writer, format types.InventoryFormat, opts types.ExportInventoryOptions) (*types.ListCursor, error)
+}
+
+// ExportBucketInventory - Stream the inventory of the objects in the bucket to w, e.g. to feed the data catalogs and
+// the billing systems.
+//
+// The objects are listed page by page in the lexicographic order of their names, and each page is written and flushed
+// before the progress is persisted at opts.CursorPath. To resume an interrupted export, call it again with the same
+// cursor path and w appending to the previous output, the records of the page being written when the process stopped
+// may be repeated.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - w: The destination of the inventory.
+//
+// - format: The output format, CSV or JSON lines.
+//
+// - opts: The options to limit the objects and to persist the progress.
+//
+// - ret1: The progress of the export, Listed indicates the number of the exported objects.
+//
+// - ret2: Return error when the objects can not be listed or the records can not be written, otherwise return nil.
+func (c *Client) ExportBucketInventory(ctx context.Context, bucketName string, w io.Writer, format types.InventoryFormat,
+	opts types.ExportInventoryOptions,
+) (*types.ListCursor, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	encoder, err := types.NewInventoryEncoder(w, format)
+	if err != nil {
+		return nil, err
+	}
+
+	resumed := false
+	if opts.CursorPath != "" {
+		_, err = types.LoadListCursor(c.fileSystem, opts.CursorPath)
+		switch {
+		case err == nil:
+			resumed = true
+		case errors.Is(err, fs.ErrNotExist):
+		default:
+			return nil, err
+		}
+	}
+	if !resumed {
+		if err = encoder.WriteHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.ListObjectsWithCursor(ctx, bucketName, types.ListObjectsWithCursorOptions{
+		CursorPath:        opts.CursorPath,
+		ShowRemovedObject: opts.ShowRemovedObject,
+		Prefix:            opts.Prefix,
+		MaxKeys:           opts.MaxKeys,
+		Endpoint:          opts.Endpoint,
+		SPAddress:         opts.SPAddress,
+	}, func(result types.ListObjectsResult) error {
+		for _, object := range result.Objects {
+			if object == nil || object.ObjectInfo == nil {
+				continue
+			}
+			if err := encoder.Encode(types.NewInventoryRecord(object)); err != nil {
+				return err
+			}
+		}
+		return encoder.Flush()
+	})
+}
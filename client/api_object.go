@@ -3,18 +3,20 @@ package client
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"io/fs"
 	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -43,13 +45,17 @@ type IObjectClient interface {
 	DelegatePutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error
 	DelegateUpdateObjectContent(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error
 	FPutObject(ctx context.Context, bucketName, objectName, filePath string, opts types.PutObjectOptions) (err error)
+	PutObjectFromChunks(ctx context.Context, bucketName, objectName string, chunks <-chan []byte, totalSize int64, opts types.PutObjectOptions) error
 	CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error)
 	DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error)
 	GetObject(ctx context.Context, bucketName, objectName string, opts types.GetObjectOptions) (io.ReadCloser, types.ObjectStat, error)
 	FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error
 	FGetObjectResumable(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error
+	GetObjectToWriterAt(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts types.GetObjectOptions) (types.ObjectStat, error)
 	HeadObject(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error)
 	HeadObjectByID(ctx context.Context, objID string) (*types.ObjectDetail, error)
+	WaitForObjectSeal(ctx context.Context, bucketName, objectName string, opts types.WaitForObjectSealOptions) (*types.ObjectDetail, error)
+	GetObjectUniversalURL(ctx context.Context, bucketName, objectName string, opts types.UniversalURLOptions) (string, error)
 	UpdateObjectVisibility(ctx context.Context, bucketName, objectName string, visibility storageTypes.VisibilityType, opt types.UpdateObjectOption) (string, error)
 	PutObjectPolicy(ctx context.Context, bucketName, objectName string, principal types.Principal,
 		statements []*permTypes.Statement, opt types.PutPolicyOption) (string, error)
@@ -57,10 +63,21 @@ type IObjectClient interface {
 	GetObjectPolicy(ctx context.Context, bucketName, objectName string, principalAddr string) (*permTypes.Policy, error)
 	IsObjectPermissionAllowed(ctx context.Context, userAddr string, bucketName, objectName string, action permTypes.ActionType) (permTypes.Effect, error)
 	ListObjects(ctx context.Context, bucketName string, opts types.ListObjectsOptions) (types.ListObjectsResult, error)
+	ListObjectsParallel(ctx context.Context, bucketName string, prefixes []string, opts types.ListObjectsParallelOptions) (types.ListObjectsResult, error)
+	PlanUpload(ctx context.Context, objectSize int64, partSize uint64, bandwidthHint int64) (*types.UploadPlan, error)
+	CopyObject(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts types.CopyObjectOptions) (string, error)
+	UploadObject(ctx context.Context, bucketName, objectName string, reader io.ReadSeeker, opts types.UploadObjectOptions) (*types.UploadObjectResult, error)
+	SetObjectTags(ctx context.Context, bucketName, objectName string, tags map[string]string, opts types.SetTagsOptions) (string, error)
+	GetObjectTags(ctx context.Context, bucketName, objectName string) (map[string]string, error)
+	PutIfAbsent(ctx context.Context, bucketName string, reader io.ReadSeeker, opts types.PutIfAbsentOptions) (*types.PutIfAbsentResult, error)
+	ListObjectsWithCursor(ctx context.Context, bucketName string, opts types.ListObjectsWithCursorOptions,
+		handler func(result types.ListObjectsResult) error) (*types.ListCursor, error)
 	ComputeHashRoots(reader io.Reader, isSerial bool) ([][]byte, int64, storageTypes.RedundancyType, error)
+	ComputeHashRootsWithContext(ctx context.Context, reader io.Reader, isSerial bool) ([][]byte, int64, storageTypes.RedundancyType, error)
 	CreateFolder(ctx context.Context, bucketName, objectName string, opts types.CreateObjectOptions) (string, error)
 	DelegateCreateFolder(ctx context.Context, bucketName, objectName string, opts types.PutObjectOptions) error
 	GetObjectUploadProgress(ctx context.Context, bucketName, objectName string) (string, error)
+	GetObjectResumableUploadOffset(ctx context.Context, bucketName, objectName string, opts types.UploadOffsetOptions) (uint64, error)
 	ListObjectsByObjectID(ctx context.Context, objectIds []uint64, opts types.EndPointOptions) (types.ListObjectsByObjectIDResponse, error)
 	ListObjectPolicies(ctx context.Context, objectName, bucketName string, actionType uint32, opts types.ListObjectPoliciesOptions) (types.ListObjectPoliciesResponse, error)
 }
@@ -68,8 +85,12 @@ type IObjectClient interface {
 // GetRedundancyParams query and return the data shards, parity shards and segment size of redundancy
 // configuration on chain
 func (c *Client) GetRedundancyParams() (uint32, uint32, uint64, error) {
+	return c.queryRedundancyParams(context.Background())
+}
+
+func (c *Client) queryRedundancyParams(ctx context.Context) (uint32, uint32, uint64, error) {
 	query := storageTypes.QueryParamsRequest{}
-	queryResp, err := c.chainClient.StorageQueryClient.Params(context.Background(), &query)
+	queryResp, err := c.chainClient.StorageQueryClient.Params(c.queryContext(ctx), &query)
 	if err != nil {
 		return 0, 0, 0, err
 	}
@@ -82,7 +103,7 @@ func (c *Client) GetRedundancyParams() (uint32, uint32, uint64, error) {
 // configuration on chain
 func (c *Client) GetParams() (storageTypes.Params, error) {
 	query := storageTypes.QueryParamsRequest{}
-	queryResp, err := c.chainClient.StorageQueryClient.Params(context.Background(), &query)
+	queryResp, err := c.chainClient.StorageQueryClient.Params(c.queryContext(context.Background()), &query)
 	if err != nil {
 		return storageTypes.Params{}, err
 	}
@@ -92,7 +113,28 @@ func (c *Client) GetParams() (storageTypes.Params, error) {
 
 // ComputeHashRoots return the integrity hash, content size and the redundancy type of the file
 func (c *Client) ComputeHashRoots(reader io.Reader, isSerial bool) ([][]byte, int64, storageTypes.RedundancyType, error) {
-	dataBlocks, parityBlocks, segSize, err := c.GetRedundancyParams()
+	return c.ComputeHashRootsWithContext(context.Background(), reader, isSerial)
+}
+
+// ComputeHashRootsWithContext - Compute the integrity hash, content size and the redundancy type of the file, the
+// hashing of a large file stops once ctx is done.
+//
+// - ctx: Context variables for the current API call, the hashing stops at the next segment once it is done.
+//
+// - reader: The reader of the file content.
+//
+// - isSerial: Whether to compute the hash of the segments serially.
+//
+// - ret1: The integrity hashes of the primary and the secondary pieces.
+//
+// - ret2: The content size of the file.
+//
+// - ret3: The redundancy type of the file.
+//
+// - ret4: Return ctx.Err() if ctx is done before the hashing finishes, return other error when the hashing failed,
+// otherwise return nil.
+func (c *Client) ComputeHashRootsWithContext(ctx context.Context, reader io.Reader, isSerial bool) ([][]byte, int64, storageTypes.RedundancyType, error) {
+	dataBlocks, parityBlocks, segSize, err := c.queryRedundancyParams(ctx)
 	if reader == nil {
 		return nil, 0, storageTypes.REDUNDANCY_EC_TYPE, errors.New("fail to compute hash, reader is nil")
 	}
@@ -100,7 +142,7 @@ func (c *Client) ComputeHashRoots(reader io.Reader, isSerial bool) ([][]byte, in
 		return nil, 0, storageTypes.REDUNDANCY_EC_TYPE, err
 	}
 
-	return hashlib.ComputeIntegrityHash(reader, int64(segSize), int(dataBlocks), int(parityBlocks), isSerial)
+	return computeIntegrityHash(ctx, reader, int64(segSize), int(dataBlocks), int(parityBlocks), isSerial)
 }
 
 // CreateObject get approval of creating object and send createObject txn to greenfield chain,
@@ -108,6 +150,12 @@ func (c *Client) ComputeHashRoots(reader io.Reader, isSerial bool) ([][]byte, in
 func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string,
 	reader io.Reader, opts types.CreateObjectOptions,
 ) (string, error) {
+	if defaults, ok := c.GetBucketDefaults(bucketName); ok {
+		defaults.ApplyToObject(objectName, &opts)
+	}
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
 	if reader == nil {
 		return "", errors.New("fail to compute hash of payload, reader is nil")
 	}
@@ -125,7 +173,7 @@ func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string
 	}
 
 	// compute hash root of payload
-	expectCheckSums, size, redundancyType, err := c.ComputeHashRoots(reader, opts.IsSerialComputeMode)
+	expectCheckSums, size, redundancyType, err := c.ComputeHashRootsWithContext(ctx, reader, opts.IsSerialComputeMode)
 	if err != nil {
 		return "", err
 	}
@@ -137,6 +185,14 @@ func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string
 		contentType = types.ContentDefault
 	}
 
+	storageClass, err := c.getStorageClass(opts.StorageClass)
+	if err != nil {
+		return "", err
+	}
+	if storageClass != nil {
+		storageClass.ApplyToObject(&opts)
+	}
+
 	var visibility storageTypes.VisibilityType
 	if opts.Visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
 		visibility = storageTypes.VISIBILITY_TYPE_INHERIT // set default visibility type
@@ -191,6 +247,9 @@ func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string
 func (c *Client) UpdateObjectContent(ctx context.Context, bucketName, objectName string,
 	reader io.Reader, opts types.UpdateObjectOptions,
 ) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
 	if reader == nil {
 		return "", errors.New("fail to compute hash of payload, reader is nil")
 	}
@@ -202,7 +261,7 @@ func (c *Client) UpdateObjectContent(ctx context.Context, bucketName, objectName
 		return "", errors.New("object not sealed can not be updated")
 	}
 	// compute hash root of payload
-	expectCheckSums, size, _, err := c.ComputeHashRoots(reader, opts.IsSerialComputeMode)
+	expectCheckSums, size, _, err := c.ComputeHashRootsWithContext(ctx, reader, opts.IsSerialComputeMode)
 	if err != nil {
 		return "", err
 	}
@@ -291,6 +350,14 @@ func (c *Client) CancelCreateObject(ctx context.Context, bucketName, objectName
 func (c *Client) PutObject(ctx context.Context, bucketName, objectName string, objectSize int64,
 	reader io.Reader, opts types.PutObjectOptions,
 ) (err error) {
+	if defaults, ok := c.GetBucketDefaults(bucketName); ok {
+		defaults.ApplyToPut(objectName, &opts)
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	// memoize the bucket and object lookups of the routing
+	ctx = WithHeadCache(ctx)
 	if objectSize <= 0 {
 		return errors.New("object size should be more than 0")
 	}
@@ -298,13 +365,11 @@ func (c *Client) PutObject(ctx context.Context, bucketName, objectName string, o
 	if err != nil {
 		return err
 	}
-	// minPartSize: 16MB
-	if opts.PartSize == 0 {
-		opts.PartSize = types.MinPartSize
-	}
-	if opts.PartSize%params.GetMaxSegmentSize() != 0 {
-		return errors.New("part size should be an integer multiple of the segment size")
+	if opts.PartSize, err = c.resolvePartSize(opts.PartSize, params); err != nil {
+		return err
 	}
+	reader = withRateLimit(ctx, reader, c.uploadLimiter, newRateLimiter(opts.RateLimit))
+	reader = withProgress(reader, newProgressTracker(opts.Progress, 0, objectSize))
 
 	// upload an entire object to the storage provider in a single request
 	if objectSize <= int64(opts.PartSize) || opts.DisableResumable {
@@ -342,10 +407,15 @@ func (c *Client) putObject(ctx context.Context, bucketName, objectName string, o
 		}
 	}
 
+	// the payload hash known by the producer is passed on, the payload is not hashed for the header
+	contentSHA256 := types.EmptyStringSHA256
+	if opts.ContentSHA256 != "" {
+		contentSHA256 = strings.ToLower(opts.ContentSHA256)
+	}
 	reqMeta := requestMeta{
 		bucketName:    bucketName,
 		objectName:    objectName,
-		contentSHA256: types.EmptyStringSHA256,
+		contentSHA256: contentSHA256,
 		contentLength: objectSize,
 		contentType:   contentType,
 		urlValues:     urlValues,
@@ -365,17 +435,33 @@ func (c *Client) putObject(ctx context.Context, bucketName, objectName string, o
 		}
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	var hasher *segmentHasher
+	if opts.VerifyChecksumAck {
+		params, err := c.getRedundancyParams()
+		if err != nil {
+			return err
+		}
+		hasher, sendOpt.body = newSegmentHasher(reader, params.segmentSize)
+	}
+
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return err
 	}
 
-	_, err = c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
 	if err != nil {
 		return err
 	}
 
+	if hasher != nil {
+		checksums := hasher.Checksums()
+		if err = verifyChecksumAck(resp.Header, objectName, 0, checksums, hashlib.GenerateIntegrityHash(checksums)); err != nil {
+			log.Error().Msg(fmt.Sprintf("verify the checksums of object %s fail, err: %s", objectName, err))
+			return err
+		}
+	}
 	return nil
 }
 
@@ -405,7 +491,7 @@ func (c *Client) delegateCreateFolder(ctx context.Context, bucketName, objectNam
 		method: http.MethodPost,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return err
@@ -431,14 +517,45 @@ func DefaultUploadSegment(id int) error {
 func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName string, objectSize int64,
 	reader io.Reader, opts types.PutObjectOptions,
 ) (err error) {
-	var offset uint64
+	var (
+		offset          uint64
+		checkpoint      *types.UploadCheckpoint
+		checkpointLease *uploadCheckpointLease
+	)
 
 	if !opts.Delegated {
 		if err = c.headSPObjectInfo(ctx, bucketName, objectName); err != nil {
 			return err
 		}
-		offset, err = c.getObjectResumableUploadOffset(ctx, bucketName, objectName)
-		if err != nil {
+		// the checkpoint recorded by the previous attempt, possibly on another machine, tells the offset to wait for
+		minOffset := opts.MinResumeOffset
+		if c.checkpointStore != nil {
+			lease, err := c.lockUploadCheckpoint(bucketName, objectName)
+			if err != nil {
+				return err
+			}
+			defer lease.release()
+			checkpointLease = lease
+
+			detail, err := c.HeadObject(ctx, bucketName, objectName)
+			if err != nil {
+				return err
+			}
+			var integrityHash string
+			if len(detail.ObjectInfo.Checksums) > 0 {
+				integrityHash = hex.EncodeToString(detail.ObjectInfo.Checksums[0])
+			}
+			checkpoint = c.loadUploadCheckpoint(bucketName, objectName, objectSize, opts.PartSize,
+				detail.ObjectInfo.Id.String(), integrityHash)
+		}
+		if checkpoint != nil && checkpoint.Offset > minOffset {
+			minOffset = checkpoint.Offset
+		}
+		offset, err = c.GetObjectResumableUploadOffset(ctx, bucketName, objectName, types.UploadOffsetOptions{MinOffset: minOffset})
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			log.Warn().Msg(fmt.Sprintf("SP does not persist the offset %d of object %s in time, resume from the offset %d",
+				minOffset, objectName, offset))
+		} else if err != nil {
 			return err
 		}
 	} else {
@@ -463,12 +580,28 @@ func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName
 	buf := make([]byte, partSize)
 	complete := false
 
+	// the part size is a multiple of the segment size, so the parts are hashed separately
+	var (
+		segmentSize  int64
+		allChecksums [][]byte
+	)
+	if opts.VerifyChecksumAck {
+		params, err := c.getRedundancyParams()
+		if err != nil {
+			return err
+		}
+		segmentSize = params.segmentSize
+	}
+
 	//  TODO(chris): Skip successful segments or add a verification file check.
 	for partNumber < startPartNumber {
 		length, rErr := utils.ReadFull(reader, buf)
 		if rErr == io.EOF && partNumber > 1 {
 			break
 		}
+		if opts.VerifyChecksumAck {
+			allChecksums = append(allChecksums, segmentChecksums(buf[:length], segmentSize)...)
+		}
 		// Increment part number.
 		log.Debug().Msg(fmt.Sprintf("skip partNumber:%d, length:%d", partNumber, length))
 		// Save successfully uploaded size.
@@ -540,20 +673,44 @@ func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName
 			}
 		}
 
-		endpoint, err := c.getSPUrlByBucket(bucketName)
+		endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 		if err != nil {
 			log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 			return err
 		}
 
 		// Proceed to upload the part.
-		_, err = c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+		resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
 		if err != nil {
 			return err
 		}
 
+		if opts.VerifyChecksumAck {
+			partChecksums := segmentChecksums(buf[:length], segmentSize)
+			allChecksums = append(allChecksums, partChecksums...)
+			var integrityHash []byte
+			if complete {
+				integrityHash = hashlib.GenerateIntegrityHash(allChecksums)
+			}
+			err = verifyChecksumAck(resp.Header, objectName, int(totalUploadedSize/segmentSize), partChecksums, integrityHash)
+			if err != nil {
+				log.Error().Msg(fmt.Sprintf("verify the checksums of part %d of object %s fail, err: %s", partNumber, objectName, err))
+				return err
+			}
+		}
+
 		// Save successfully uploaded size.
 		totalUploadedSize += int64(length)
+		if checkpoint != nil && !complete {
+			// renew the lock before saving, the upload stops if another worker has taken over it
+			if err = checkpointLease.renew(); err != nil {
+				return err
+			}
+			checkpoint.Offset = uint64(totalUploadedSize)
+			if err = c.saveUploadCheckpoint(checkpoint); err != nil {
+				return err
+			}
+		}
 
 		// Increment part number.
 		partNumber++
@@ -565,6 +722,9 @@ func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName
 		}
 	}
 
+	if checkpoint != nil {
+		c.deleteUploadCheckpoint(bucketName, objectName)
+	}
 	return nil
 }
 
@@ -591,9 +751,25 @@ func (c *Client) headSPObjectInfo(ctx context.Context, bucketName, objectName st
 	return nil
 }
 
-// FPutObject supports uploading object from local file
+// FPutObject - Upload the object payload from the local file.
+//
+// The content type is detected by the file extension or the leading bytes of the file if opts.ContentType is not set.
+// If opts.CreateOptions is set, the object is created on chain with the checksums computed by streaming the file
+// before uploading it, so that the file is uploaded by a single call.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - filePath: The path of the local file.
+//
+// - opts: The options for creating and uploading the object.
+//
+// - ret: Return error when the object failed to create or upload, otherwise return nil.
 func (c *Client) FPutObject(ctx context.Context, bucketName, objectName, filePath string, opts types.PutObjectOptions) (err error) {
-	fReader, err := os.Open(filePath)
+	fReader, err := c.fileSystem.Open(filePath)
 	// If any error fail quickly here.
 	if err != nil {
 		return err
@@ -606,13 +782,91 @@ func (c *Client) FPutObject(ctx context.Context, bucketName, objectName, filePat
 		return err
 	}
 
+	if opts.ContentType == "" {
+		if opts.ContentType, err = detectFileContentType(fReader, filePath); err != nil {
+			return err
+		}
+	}
+	if opts.CreateOptions != nil && !opts.Delegated {
+		createOpts := *opts.CreateOptions
+		if createOpts.ContentType == "" {
+			createOpts.ContentType = opts.ContentType
+		}
+		// the object should be on chain before the upload
+		createOpts.IsAsyncMode = false
+		if _, err = c.CreateObject(ctx, bucketName, objectName, fReader, createOpts); err != nil {
+			return err
+		}
+		// the empty object is sealed once created
+		if stat.Size() == 0 {
+			return nil
+		}
+		if _, err = fReader.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
 	return c.PutObject(ctx, bucketName, objectName, stat.Size(), fReader, opts)
 }
 
+// detectFileContentType detects the content type of the file by its extension and its leading bytes, the file is
+// rewound to the start.
+func detectFileContentType(file types.File, filePath string) (string, error) {
+	head := make([]byte, types.ContentSniffSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return types.DetectContentType(filePath, head[:n]), nil
+}
+
+// PutObjectFromChunks - Upload the object payload generated in chunks by a producer, e.g. an encoder or a compressor,
+// without assembling the whole payload in memory.
+//
+// The chunks are received ahead of the upload until opts.ChunkBufferSize bytes are buffered, then the producer is
+// blocked until the upload catches up. The chunks are concatenated as a continuous stream, so their boundaries need
+// not align with the segments or the parts. The producer should stop sending when ctx is done, since the channel is
+// no longer received after the upload fails.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object, it should be created on chain with the checksums of the payload.
+//
+// - chunks: The channel of the payload chunks, the producer closes it after sending the last chunk.
+//
+// - totalSize: The size of the payload, the upload fails if the chunks sum up to a different size.
+//
+// - opts: The options for uploading the object.
+//
+// - ret: Return error when the upload failed, otherwise return nil.
+func (c *Client) PutObjectFromChunks(ctx context.Context, bucketName, objectName string, chunks <-chan []byte, totalSize int64,
+	opts types.PutObjectOptions,
+) error {
+	bufferSize := opts.ChunkBufferSize
+	if bufferSize <= 0 {
+		bufferSize = types.DefaultChunkBufferSize
+	}
+	reader := newChunkReader(ctx, chunks, totalSize, bufferSize)
+	defer reader.Close()
+	return c.PutObject(ctx, bucketName, objectName, totalSize, reader, opts)
+}
+
 // GetObject download s3 object payload and return the related object info
 func (c *Client) GetObject(ctx context.Context, bucketName, objectName string,
 	opts types.GetObjectOptions,
 ) (io.ReadCloser, types.ObjectStat, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	// memoize the bucket and object lookups of the routing
+	ctx = WithHeadCache(ctx)
+	// the parts downloaded in parallel share the rate limit of the call
+	ctx = withCallRateLimiter(ctx, opts.RateLimit)
 	var err error
 	if err = s3util.CheckValidBucketName(bucketName); err != nil {
 		return nil, types.ObjectStat{}, err
@@ -622,6 +876,14 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string,
 		return nil, types.ObjectStat{}, err
 	}
 
+	if opts.VerifyIntegrity {
+		return c.getObjectWithVerification(ctx, bucketName, objectName, opts)
+	}
+
+	if opts.Concurrency > 1 || opts.AdaptiveConcurrency != nil {
+		return c.getObjectInParallel(ctx, bucketName, objectName, opts)
+	}
+
 	reqMeta := requestMeta{
 		bucketName:    bucketName,
 		objectName:    objectName,
@@ -642,31 +904,86 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string,
 	if c.forceToUseSpecifiedSpEndpointForDownloadOnly != nil {
 		endpoint = c.forceToUseSpecifiedSpEndpointForDownloadOnly
 	} else {
-		endpoint, err = c.getSPUrlByBucket(bucketName)
+		endpoint, err = c.getSPUrlByBucket(ctx, bucketName)
 		if err != nil {
 			log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed,  err: %s", bucketName, err.Error()))
 			return nil, types.ObjectStat{}, err
 		}
 	}
 
-	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+	cdnEndpoint, err := c.getCDNEndpoint(bucketName, opts.CDNEndpoint)
 	if err != nil {
 		return nil, types.ObjectStat{}, err
 	}
 
+	var resp *http.Response
+	if cdnEndpoint != nil {
+		resp, err = c.sendReqViaCDN(ctx, reqMeta, &sendOpt, endpoint, cdnEndpoint)
+	} else {
+		resp, err = c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+	}
+	if err != nil {
+		if opts.SecondaryFailover && isSPUnavailable(err) {
+			log.Warn().Msg(fmt.Sprintf("the primary SP %s of bucket %s is unavailable, reconstruct object %s from the "+
+				"secondary SPs, err: %s", endpoint.Host, bucketName, objectName, err))
+			return c.getObjectFromSecondaries(ctx, bucketName, objectName, opts)
+		}
+		return nil, types.ObjectStat{}, err
+	}
+	setResponseInfo(opts.ResponseInfo, resp)
+
 	objStat, err := getObjInfo(objectName, resp.Header)
 	if err != nil {
 		utils.CloseResponse(resp)
 		return nil, types.ObjectStat{}, err
 	}
 
-	return resp.Body, objStat, nil
+	body := c.auditDownload(ctx, resp, bucketName, objectName, opts.Range, endpoint, cdnEndpoint)
+	body = readCloserWithRateLimit(ctx, body, c.downloadLimiter, callRateLimiterFromContext(ctx))
+	return readCloserWithProgress(body, newProgressTracker(opts.Progress, 0, resp.ContentLength)), objStat, nil
+}
+
+// getObjectInParallel downloads the parts of the object in parallel and returns a reader reassembling them in order,
+// at most opts.Concurrency parts, or the MaxConcurrency of opts.AdaptiveConcurrency if it is set, are buffered in
+// memory. opts.ResponseInfo is not filled since the parts are served by several responses.
+func (c *Client) getObjectInParallel(ctx context.Context, bucketName, objectName string,
+	opts types.GetObjectOptions,
+) (io.ReadCloser, types.ObjectStat, error) {
+	plan, err := c.planParallelDownload(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	if plan.endOffset < plan.startOffset {
+		// nothing to download for the empty object
+		return io.NopCloser(bytes.NewReader(nil)), plan.objStat, nil
+	}
+
+	fetch := func(ctx context.Context, partStart, partEnd int64) ([]byte, error) {
+		partOption := types.GetObjectOptions{CDNEndpoint: opts.CDNEndpoint, SecondaryFailover: opts.SecondaryFailover}
+		if err := partOption.SetRange(partStart, partEnd); err != nil {
+			return nil, err
+		}
+		rd, _, err := c.GetObject(ctx, bucketName, objectName, partOption)
+		if err != nil {
+			return nil, err
+		}
+		defer rd.Close()
+		data := make([]byte, partEnd-partStart+1)
+		if _, err = io.ReadFull(rd, data); err != nil {
+			return nil, fmt.Errorf("the part [%d, %d] of object %s is incomplete: %w", partStart, partEnd, objectName, err)
+		}
+		return data, nil
+	}
+	limiter := newAIMDLimiter(opts.AdaptiveConcurrency, opts.Concurrency)
+	reader := newParallelReader(ctx, plan.startOffset, plan.endOffset, plan.partSize, opts.Concurrency, limiter, fetch)
+	tracker := newProgressTracker(opts.Progress, 0, plan.endOffset-plan.startOffset+1)
+	return readCloserWithProgress(reader, tracker), plan.objStat, nil
 }
 
 // FGetObject download s3 object payload adn write the object content into local file specified by filePath
 func (c *Client) FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error {
 	// Verify if destination already exists.
-	st, err := os.Stat(filePath)
+	st, err := c.fileSystem.Stat(filePath)
 	if err == nil {
 		// If the destination exists and is a directory.
 		if st.IsDir() {
@@ -675,7 +992,7 @@ func (c *Client) FGetObject(ctx context.Context, bucketName, objectName, filePat
 		return errors.New("download file already exist")
 	}
 
-	fd, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o660)
+	fd, err := c.fileSystem.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o660)
 	if err != nil {
 		return err
 	}
@@ -705,6 +1022,15 @@ func getSegmentEnd(begin int64, total int64, per int64) int64 {
 
 // FGetObjectResumable download s3 object payload with resumable download
 func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.AdaptiveConcurrency != nil {
+		return types.ToInvalidArgumentResp("AdaptiveConcurrency is not supported by FGetObjectResumable, which downloads the parts sequentially")
+	}
+	// memoize the bucket and object lookups of the routing
+	ctx = WithHeadCache(ctx)
+	ctx = withCallRateLimiter(ctx, opts.RateLimit)
 	// Get the object detailed meta for object whole size
 	meta, err := c.HeadObject(ctx, bucketName, objectName)
 	if err != nil {
@@ -714,13 +1040,12 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 	tempFilePath := filePath + "_" + c.defaultAccount.GetAddress().String() + opts.Range + types.TempFileSuffix
 
 	var (
-		startOffset    int64
-		endOffset      int64
-		partEndOffset  int64
-		maxSegmentSize int64
-		objectOption   types.GetObjectOptions
-		segNum         int64
-		partSize       int64
+		startOffset   int64
+		endOffset     int64
+		partEndOffset int64
+		objectOption  types.GetObjectOptions
+		segNum        int64
+		partSize      int64
 	)
 
 	// 1) check paramter
@@ -728,18 +1053,11 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 	if err != nil {
 		return err
 	}
-	maxSegmentSize = int64(params.GetMaxSegmentSize())
-
-	// minPartSize: 32MB
-	if opts.PartSize == 0 {
-		partSize = types.MinPartSize
-	} else {
-		partSize = int64(opts.PartSize)
-	}
-
-	if partSize%maxSegmentSize != 0 {
-		return errors.New("part size should be an integer multiple of the segment size")
+	resolvedPartSize, err := c.resolvePartSize(opts.PartSize, params)
+	if err != nil {
+		return err
 	}
+	partSize = int64(resolvedPartSize)
 
 	isRange, rangeStart, rangeEnd := utils.ParseRange(opts.Range)
 	if isRange && (rangeEnd < 0 || rangeEnd >= int64(meta.ObjectInfo.GetPayloadSize())) {
@@ -754,75 +1072,36 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 		endOffset = int64(meta.ObjectInfo.GetPayloadSize()) - 1
 	}
 
-	// 2)prepare and check temp file
-	fileInfo, err := os.Stat(tempFilePath)
-	if err != nil {
-		if !strings.Contains(err.Error(), "no such file or directory") {
-			return err
-		}
-	} else {
-		/*
-				| -----seg1 ------ | -----seg2 ------ | -----seg3 ------ | -----seg4 ------ |
-				        ^                                                         ^
-				        |													      |
-				 startOffset													  endOffset
-			            |----------|
-		*/
-		fileSize := fileInfo.Size()
-		var (
-			firstSegSize   int64
-			file           *os.File
-			truncateOffset int64
-		)
-
-		if isRange {
-			firstSegSize = partSize - rangeStart%partSize
-		} else {
-			firstSegSize = partSize
-		}
-		fileSizeWithoutFirstSeg := fileSize - firstSegSize
-		if fileSize > firstSegSize {
-			truncateOffset = ((fileSize-firstSegSize)/partSize)*partSize + firstSegSize
-			startOffset = ((fileSize-firstSegSize)/partSize)*partSize + partSize
-		} else {
-			truncateOffset = 0
-			startOffset = 0
-		}
-		log.Debug().Msgf("The file:%s size:%d, startOffset:%d, range:%s\n", tempFilePath, fileSize, startOffset, opts.Range)
-
-		// truncated file to part size integer multiples
-		if fileSizeWithoutFirstSeg%partSize != 0 {
-			file, err = os.OpenFile(tempFilePath, os.O_RDWR, 0o644)
-			if err != nil {
-				return err
-			}
-			defer file.Close()
-
-			err = file.Truncate(truncateOffset)
-			if err != nil {
-				return err
-			}
-			log.Debug().Msgf("The file was truncated to the specified size.%d\n", truncateOffset)
-			// TODO(chris): verify file's segment
-		}
+	// 2) prepare and check the temp file by the checkpoint, the content not verified by the checkpoint is discarded
+	checkpoints := c.resolveCheckpointStore()
+	checkpoint := &types.DownloadCheckpoint{
+		BucketName:  bucketName,
+		ObjectName:  objectName,
+		ObjectID:    meta.ObjectInfo.Id.String(),
+		Range:       opts.Range,
+		PartSize:    partSize,
+		StartOffset: startOffset,
+		Total:       endOffset - startOffset + 1,
 	}
-
-	// Create the file if not exists. Otherwise the segments download will overwrite it.
-	fd, err := os.OpenFile(tempFilePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, types.FilePermMode)
-	if err != nil {
+	if err = c.resumeDownloadCheckpoint(checkpoints, checkpoint, tempFilePath, opts.RestartOnCorruption); err != nil {
 		return err
 	}
-	_, err = fd.Seek(startOffset, io.SeekStart)
+	startOffset += checkpoint.Size()
+	tracker := newProgressTracker(opts.Progress, 0, checkpoint.Total)
+	tracker.add(checkpoint.Size())
+
+	// Create the file if not exists. Otherwise the parts are appended after the verified ones.
+	fd, err := c.fileSystem.OpenFile(tempFilePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, types.FilePermMode)
 	if err != nil {
-		fd.Close()
 		return err
 	}
+	defer fd.Close()
 
 	log.Debug().Msg(fmt.Sprintf("get object resumeable begin segment Range: %s, startOffset: %d, endOffset:%d", opts.Range, startOffset, endOffset))
 
-	// 3) Downloading Parts Sequentially based on partSize
-	segNum = startOffset / partSize
-	for partStartOffset := startOffset; partStartOffset < endOffset; partStartOffset += partSize {
+	// 3) Downloading Parts Sequentially based on partSize, the checkpoint is saved after each part is written
+	segNum = int64(len(checkpoint.Parts))
+	for partStartOffset := startOffset; partStartOffset <= endOffset; partStartOffset = partEndOffset + 1 {
 		// hook for test
 		if err = DownloadSegmentHooker(segNum); err != nil {
 			return err
@@ -840,30 +1119,321 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 		if err != nil {
 			return err
 		}
-		defer rd.Close()
 
-		_, err = io.Copy(fd, rd)
+		hash := crc32.NewIEEE()
+		n, err := io.Copy(io.MultiWriter(fd, hash), rd)
+		rd.Close()
 		log.Debug().Msg(fmt.Sprintf("get object for segment Range: %s, current partStartOffset: %d, segNum: %d", objectOption.Range, partStartOffset, segNum))
 		endT := time.Now().UnixNano() / 1000 / 1000 / 1000
 		if err != nil {
 			log.Error().Msg(fmt.Sprintf("get seg error,cost:%d second,seg number:%d,error:%s.\n", endT-startT, segNum, err.Error()))
-			fd.Close()
+			return err
 		}
 
+		checkpoint.AddPart(n, hash.Sum32())
+		tracker.add(n)
+		content, err := checkpoint.Encode()
+		if err != nil {
+			return err
+		}
+		if err = checkpoints.Save(tempFilePath, content); err != nil {
+			return err
+		}
 		segNum++
 	}
 
-	fd.Close()
+	if err = fd.Close(); err != nil {
+		return err
+	}
+
+	// 4) verify the whole payload in the temp file, the corrupted temp file is discarded to download it again
+	if opts.VerifyIntegrity {
+		if err = c.verifyDownloadedFile(ctx, bucketName, objectName, tempFilePath); err != nil {
+			var integrityErr *types.IntegrityError
+			if errors.As(err, &integrityErr) {
+				_ = c.fileSystem.Remove(tempFilePath)
+				_ = checkpoints.Delete(tempFilePath)
+			}
+			return err
+		}
+	}
 
-	// 4) rename temp file
-	err = os.Rename(tempFilePath, filePath)
+	// 5) rename temp file and remove the checkpoint
+	err = c.fileSystem.Rename(tempFilePath, filePath)
 	if err != nil {
 		return err
 	}
+	if err = checkpoints.Delete(tempFilePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warn().Msg(fmt.Sprintf("fail to remove the download checkpoint of %s: %s", tempFilePath, err.Error()))
+	}
 
 	return nil
 }
 
+// resumeDownloadCheckpoint verifies the temp file of the resumable download by the checkpoint persisted in the store
+// by the temp file path, the verified parts are kept in checkpoint and the temp file is truncated to them. The temp
+// file is discarded if the checkpoint is missing, corrupted or recorded for another download, or if
+// restartOnCorruption is set and some parts are broken.
+func (c *Client) resumeDownloadCheckpoint(checkpoints types.CheckpointStore, checkpoint *types.DownloadCheckpoint,
+	tempFilePath string, restartOnCorruption bool,
+) error {
+	var loaded *types.DownloadCheckpoint
+	content, err := checkpoints.Load(tempFilePath)
+	if err == nil {
+		loaded, err = types.DecodeDownloadCheckpoint(content)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, types.ErrCheckpointCorrupted) {
+		return err
+	}
+	if err != nil || !loaded.SameDownload(checkpoint) {
+		if errors.Is(err, types.ErrCheckpointCorrupted) {
+			log.Warn().Msg(fmt.Sprintf("restart the download of %s: %s", tempFilePath, err.Error()))
+		}
+		if err = c.fileSystem.Remove(tempFilePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	file, err := c.fileSystem.OpenFile(tempFilePath, os.O_RDWR, types.FilePermMode)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	recorded := len(loaded.Parts)
+	preserved, err := loaded.Verify(file)
+	if err != nil {
+		return err
+	}
+	if preserved < recorded {
+		log.Warn().Msg(fmt.Sprintf("the temp file %s is corrupted, %d of %d parts are verified", tempFilePath, preserved, recorded))
+		if restartOnCorruption {
+			loaded.Parts = nil
+		}
+	}
+	checkpoint.Parts = loaded.Parts
+	return file.Truncate(checkpoint.Size())
+}
+
+// GetObjectToWriterAt - Download the object payload and write it into w, the parts of the object are downloaded in
+// parallel and written directly at their own offsets.
+//
+// It allows writing the object into the user-managed sinks, e.g. the pre-allocated buffers, the block devices or the
+// piece stores, rather than the local files or a sequential reader.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - w: The sink of the object payload. If opts.Range is set, the first byte of the range is written at offset 0.
+//
+// - opts: The options for downloading the object, opts.PartSize and opts.Concurrency control the parallel downloading,
+// opts.AdaptiveConcurrency adjusts the concurrency by the latencies and the failures of the parts.
+// opts.VerifyIntegrity hashes the segments of the parts as they are written and checks them against the checksum on
+// chain after all the parts are downloaded.
+//
+// - ret1: The info of the downloaded object, the Size is the number of bytes written into w.
+//
+// - ret2: Return error when the download failed, or a *types.IntegrityError when the data written into w mismatches
+// the checksum on chain, otherwise return nil.
+func (c *Client) GetObjectToWriterAt(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts types.GetObjectOptions) (types.ObjectStat, error) {
+	if err := opts.Validate(); err != nil {
+		return types.ObjectStat{}, err
+	}
+	// memoize the bucket and object lookups of the routing
+	ctx = WithHeadCache(ctx)
+	// the parts share the rate limit of the call
+	ctx = withCallRateLimiter(ctx, opts.RateLimit)
+	plan, err := c.planParallelDownload(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return types.ObjectStat{}, err
+	}
+	objStat, startOffset, endOffset, partSize := plan.objStat, plan.startOffset, plan.endOffset, plan.partSize
+	if endOffset < startOffset {
+		// nothing to download for the empty object
+		return objStat, nil
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = types.DefaultDownloadConcurrency
+	}
+	limiter := newAIMDLimiter(opts.AdaptiveConcurrency, concurrency)
+
+	// the segment checksums of each part are combined in order after all the parts are downloaded
+	var (
+		expected      []byte
+		segmentSize   int64
+		partChecksums [][][]byte
+	)
+	if opts.VerifyIntegrity {
+		if expected, segmentSize, err = c.getIntegrityChecksum(ctx, bucketName, objectName); err != nil {
+			return types.ObjectStat{}, err
+		}
+		partChecksums = make([][][]byte, (endOffset-startOffset)/partSize+1)
+	}
+
+	tracker := newProgressTracker(opts.Progress, 0, endOffset-startOffset+1)
+
+	downloadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	parts := make(chan int64)
+	for i := 0; i < limiter.workers(concurrency); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partStart := range parts {
+				partEnd := getSegmentEnd(partStart, endOffset+1, partSize)
+				var checksums [][]byte
+				release, err := limiter.acquire(downloadCtx)
+				if err == nil {
+					checksums, err = c.downloadPartToWriterAt(downloadCtx, bucketName, objectName, w, partStart, partEnd, startOffset, segmentSize)
+					release(err)
+				}
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				if opts.VerifyIntegrity {
+					partChecksums[(partStart-startOffset)/partSize] = checksums
+				}
+				tracker.add(partEnd - partStart + 1)
+			}
+		}()
+	}
+
+feedParts:
+	for partStart := startOffset; partStart <= endOffset; partStart += partSize {
+		select {
+		case parts <- partStart:
+		case <-downloadCtx.Done():
+			break feedParts
+		}
+	}
+	close(parts)
+	wg.Wait()
+
+	if firstErr != nil {
+		return types.ObjectStat{}, firstErr
+	}
+	if err = ctx.Err(); err != nil {
+		return types.ObjectStat{}, err
+	}
+	if opts.VerifyIntegrity {
+		var checksums [][]byte
+		for _, partChecksum := range partChecksums {
+			checksums = append(checksums, partChecksum...)
+		}
+		if err = checkIntegrity(objectName, checksums, objStat.Size, expected); err != nil {
+			return types.ObjectStat{}, err
+		}
+	}
+	return objStat, nil
+}
+
+// parallelDownloadPlan describes how the object range is split into the parts downloaded in parallel.
+type parallelDownloadPlan struct {
+	objStat     types.ObjectStat
+	startOffset int64 // startOffset is the offset of the first byte to download.
+	endOffset   int64 // endOffset is the offset of the last byte to download, it is less than startOffset if nothing to download.
+	partSize    int64
+}
+
+// planParallelDownload resolves the range to download and the part size of the parallel download by the object meta.
+func (c *Client) planParallelDownload(ctx context.Context, bucketName, objectName string, opts types.GetObjectOptions) (*parallelDownloadPlan, error) {
+	meta, err := c.HeadObject(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	payloadSize := int64(meta.ObjectInfo.GetPayloadSize())
+	contentType := meta.ObjectInfo.GetContentType()
+	if contentType == "" {
+		contentType = types.ContentDefault
+	}
+	plan := &parallelDownloadPlan{
+		objStat: types.ObjectStat{
+			ObjectName:  objectName,
+			ContentType: contentType,
+		},
+		endOffset: payloadSize - 1,
+	}
+
+	if opts.Range != "" {
+		isRange, rangeStart, rangeEnd := utils.ParseRange(opts.Range)
+		if !isRange || rangeStart < 0 || rangeStart >= payloadSize || (rangeEnd >= 0 && rangeEnd < rangeStart) {
+			return nil, types.ToInvalidArgumentResp(fmt.Sprintf("invalid range %s of object size %d", opts.Range, payloadSize))
+		}
+		plan.startOffset = rangeStart
+		if rangeEnd >= 0 && rangeEnd < payloadSize {
+			plan.endOffset = rangeEnd
+		}
+	}
+	if plan.endOffset < plan.startOffset {
+		return plan, nil
+	}
+	plan.objStat.Size = plan.endOffset - plan.startOffset + 1
+
+	params, err := c.GetParams()
+	if err != nil {
+		return nil, err
+	}
+	partSize, err := c.resolvePartSize(opts.PartSize, params)
+	if err != nil {
+		return nil, err
+	}
+	plan.partSize = int64(partSize)
+	return plan, nil
+}
+
+// downloadPartToWriterAt downloads the range [partStart, partEnd] of the object and writes it into w at the offset
+// relative to baseOffset, the checksums of the segments of the part are returned if segmentSize is greater than 0.
+func (c *Client) downloadPartToWriterAt(ctx context.Context, bucketName, objectName string, w io.WriterAt,
+	partStart, partEnd, baseOffset, segmentSize int64,
+) ([][]byte, error) {
+	partOption := types.GetObjectOptions{}
+	if err := partOption.SetRange(partStart, partEnd); err != nil {
+		return nil, err
+	}
+	rd, _, err := c.GetObject(ctx, bucketName, objectName, partOption)
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close()
+
+	var (
+		src    io.Reader = rd
+		hasher *segmentHasher
+	)
+	if segmentSize > 0 {
+		hasher, src = newSegmentHasher(rd, segmentSize)
+	}
+	n, err := io.Copy(io.NewOffsetWriter(w, partStart-baseOffset), src)
+	if err != nil {
+		return nil, err
+	}
+	if n != partEnd-partStart+1 {
+		return nil, fmt.Errorf("the part [%d, %d] of object %s is incomplete, %d bytes received", partStart, partEnd, objectName, n)
+	}
+	if hasher == nil {
+		return nil, nil
+	}
+	return hasher.Checksums(), nil
+}
+
 // getObjInfo generates objectInfo base on the response http header content
 func getObjInfo(objectName string, h http.Header) (types.ObjectStat, error) {
 	// Parse content length is exists
@@ -896,19 +1466,36 @@ func getObjInfo(objectName string, h http.Header) (types.ObjectStat, error) {
 // HeadObject query the objectInfo on chain to check th object id, return the object info if exists
 // return err info if object not exist
 func (c *Client) HeadObject(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
-	queryHeadObjectRequest := storageTypes.QueryHeadObjectRequest{
-		BucketName: bucketName,
-		ObjectName: objectName,
+	cache := headCacheFromContext(ctx)
+	if cache != nil {
+		if objectDetail, ok := cache.getObject(bucketName, objectName); ok {
+			return objectDetail, nil
+		}
 	}
-	queryHeadObjectResponse, err := c.chainClient.HeadObject(ctx, &queryHeadObjectRequest)
+
+	// the concurrent queries of the same object share one query
+	val, err := c.dedupQuery(ctx, "HeadObject", bucketName+"/"+objectName, func() (interface{}, error) {
+		queryHeadObjectRequest := storageTypes.QueryHeadObjectRequest{
+			BucketName: bucketName,
+			ObjectName: objectName,
+		}
+		queryHeadObjectResponse, err := c.chainClient.HeadObject(c.queryContext(ctx), &queryHeadObjectRequest)
+		if err != nil {
+			return nil, err
+		}
+		return &types.ObjectDetail{
+			ObjectInfo:         queryHeadObjectResponse.ObjectInfo,
+			GlobalVirtualGroup: queryHeadObjectResponse.GlobalVirtualGroup,
+		}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return &types.ObjectDetail{
-		ObjectInfo:         queryHeadObjectResponse.ObjectInfo,
-		GlobalVirtualGroup: queryHeadObjectResponse.GlobalVirtualGroup,
-	}, nil
+	objectDetail := val.(*types.ObjectDetail)
+	if cache != nil {
+		cache.setObject(bucketName, objectName, objectDetail)
+	}
+	return objectDetail, nil
 }
 
 // HeadObjectByID query the objectInfo on chain by object id, return the object info if exists
@@ -917,7 +1504,7 @@ func (c *Client) HeadObjectByID(ctx context.Context, objID string) (*types.Objec
 	headObjectRequest := storageTypes.QueryHeadObjectByIdRequest{
 		ObjectId: objID,
 	}
-	queryHeadObjectResponse, err := c.chainClient.HeadObjectById(ctx, &headObjectRequest)
+	queryHeadObjectResponse, err := c.chainClient.HeadObjectById(c.queryContext(ctx), &headObjectRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -928,6 +1515,109 @@ func (c *Client) HeadObjectByID(ctx context.Context, objID string) (*types.Objec
 	}, nil
 }
 
+// WaitForObjectSeal - Wait until the object is sealed by polling its status on chain with the exponential backoff,
+// instead of sleeping for a fixed duration after uploading.
+//
+// The object being updated is not taken as sealed until the new content is sealed. The status is queried on chain at
+// every poll even if ctx memoizes the lookups by WithHeadCache, and the sealed object is memoized once returned.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - opts: The options to define the timeout and the poll intervals.
+//
+// - ret1: The object detail once sealed.
+//
+// - ret2: Return error wrapping types.ErrObjectRejected if the object is removed before sealed, e.g. the SP rejects
+// to seal it, or error wrapping context.DeadlineExceeded if it is not sealed before the timeout, otherwise return nil.
+func (c *Client) WaitForObjectSeal(ctx context.Context, bucketName, objectName string, opts types.WaitForObjectSealOptions) (*types.ObjectDetail, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = types.DefaultSealTimeout
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = types.DefaultSealPollInterval
+	}
+	if opts.MaxPollInterval == 0 {
+		opts.MaxPollInterval = types.MaxSealPollInterval
+	}
+	// the memoized lookups of the head cache never turn sealed, the status is polled on chain every time and the
+	// sealed object is memoized for the following APIs sharing the cache
+	cache := headCacheFromContext(ctx)
+	ctx, cancel := context.WithTimeout(withoutHeadCache(ctx), opts.Timeout)
+	defer cancel()
+
+	backoff := types.ExponentialBackoff(opts.PollInterval, opts.MaxPollInterval)
+	status := "unknown"
+	for attempt := 1; ; attempt++ {
+		objectDetail, err := c.HeadObject(ctx, bucketName, objectName)
+		switch {
+		case err == nil:
+			objectInfo := objectDetail.ObjectInfo
+			if objectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED && !objectInfo.IsUpdating {
+				if cache != nil {
+					cache.setObject(bucketName, objectName, objectDetail)
+				}
+				return objectDetail, nil
+			}
+			if objectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_DISCONTINUED {
+				return nil, fmt.Errorf("object %s is discontinued", objectName)
+			}
+			status = objectInfo.ObjectStatus.String()
+		case strings.Contains(err.Error(), storageTypes.ErrNoSuchObject.Error()):
+			return nil, fmt.Errorf("object %s: %w", objectName, types.ErrObjectRejected)
+		case ctx.Err() == nil:
+			log.Error().Msg(fmt.Sprintf("head object %s when waiting for the seal fail, err: %s", objectName, err))
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("object %s is not sealed in %s, the last status is %s: %w", objectName, opts.Timeout, status, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// GetObjectUniversalURL - Return the url of the object on the universal endpoint of the primary SP of the bucket, the
+// public objects can be viewed or downloaded by it without any authentication, e.g. to be linked by web pages.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - opts: The options to choose between viewing and downloading.
+//
+// - ret1: The universal endpoint url of the object.
+//
+// - ret2: Return error when the primary SP of the bucket can not be found, otherwise return nil.
+func (c *Client) GetObjectUniversalURL(ctx context.Context, bucketName, objectName string, opts types.UniversalURLOptions) (string, error) {
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
+	if err != nil {
+		return "", err
+	}
+	action := types.UniversalViewPath
+	if opts.Download {
+		action = types.UniversalDownloadPath
+	}
+	segments := strings.Split(objectName, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	universalURL := *endpoint
+	universalURL.Path = "/" + action + "/" + bucketName + "/" + objectName
+	universalURL.RawPath = "/" + action + "/" + url.PathEscape(bucketName) + "/" + strings.Join(segments, "/")
+	return universalURL.String(), nil
+}
+
 // PutObjectPolicy apply object policy to the principal, return the txn hash
 func (c *Client) PutObjectPolicy(ctx context.Context, bucketName, objectName string, principalStr types.Principal,
 	statements []*permTypes.Statement, opt types.PutPolicyOption,
@@ -971,7 +1661,7 @@ func (c *Client) IsObjectPermissionAllowed(ctx context.Context, userAddr string,
 		ActionType: action,
 	}
 
-	verifyResp, err := c.chainClient.VerifyPermission(ctx, &verifyReq)
+	verifyResp, err := c.chainClient.VerifyPermission(c.queryContext(ctx), &verifyReq)
 	if err != nil {
 		return permTypes.EFFECT_DENY, err
 	}
@@ -992,7 +1682,7 @@ func (c *Client) GetObjectPolicy(ctx context.Context, bucketName, objectName str
 		PrincipalAddress: principalAddr,
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(ctx, &queryPolicy)
+	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(c.queryContext(ctx), &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -1021,29 +1711,16 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts types.
 		opts.MaxKeys = listObjectsDefaultMaxKeys
 	}
 
-	if opts.StartAfter != "" {
-		if err := s3util.CheckValidObjectName(opts.StartAfter); err != nil {
-			return types.ListObjectsResult{}, err
-		}
-	}
-
-	if opts.ContinuationToken != "" {
-		decodedContinuationToken, err := base64.StdEncoding.DecodeString(opts.ContinuationToken)
-		if err != nil {
-			return types.ListObjectsResult{}, err
-		}
-		objectName := string(decodedContinuationToken)
-		if err = s3util.CheckValidObjectName(objectName); err != nil {
-			return types.ListObjectsResult{}, err
-		}
-		if !strings.HasPrefix(objectName, opts.Prefix) {
-			return types.ListObjectsResult{}, fmt.Errorf("continuation-token does not match the input prefix")
-		}
+	if err := opts.Validate(); err != nil {
+		return types.ListObjectsResult{}, err
 	}
 
 	if ok := utils.IsValidObjectPrefix(opts.Prefix); !ok {
 		return types.ListObjectsResult{}, fmt.Errorf("invalid object prefix")
 	}
+	if opts.PageToken != "" {
+		opts.ContinuationToken, _ = opts.PageToken.Position(types.PageObjects)
+	}
 
 	params := url.Values{}
 	params.Set("max-keys", strconv.FormatUint(opts.MaxKeys, 10))
@@ -1072,37 +1749,46 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts types.
 		return types.ListObjectsResult{}, err
 	}
 
-	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+	var resp *http.Response
+	err = c.queryConsistent(ctx, opts.Consistency, func() error {
+		resp, err = c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+		if err != nil {
+			return err
+		}
+		setResponseInfo(opts.ResponseInfo, resp)
+		if err = c.checkMetaFreshness(ctx, resp, opts.Consistency); err != nil {
+			utils.CloseResponse(resp)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
 		return types.ListObjectsResult{}, err
 	}
 	defer utils.CloseResponse(resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
+	listObjectsResult := types.ListObjectsResult{}
+	// decode the xml content from response body
+	err = c.decodeXMLResponse(resp, &listObjectsResult)
 	if err != nil {
 		log.Error().Msg("the list of objects in user's bucket:" + bucketName + " failed: " + err.Error())
 		return types.ListObjectsResult{}, err
 	}
-
-	listObjectsResult := types.ListObjectsResult{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &listObjectsResult)
-	// TODO(annie) remove tolerance for unmarshal err after structs got stabilized
-	if err != nil && listObjectsResult.Objects == nil {
-		log.Error().Msg("the list of objects in user's bucket:" + bucketName + " failed: " + err.Error())
-		return types.ListObjectsResult{}, err
+	if listObjectsResult.IsTruncated {
+		listObjectsResult.NextPageToken = types.NewPageToken(types.PageObjects, listObjectsResult.NextContinuationToken)
 	}
 
-	if opts.ShowRemovedObject {
+	if opts.ShowRemovedObject && len(opts.TagFilter) == 0 {
 		return listObjectsResult, nil
 	}
 
 	// default only return the object that has not been removed
 	objectMetaList := make([]*types.ObjectMeta, 0)
 	for _, objectInfo := range listObjectsResult.Objects {
-		if objectInfo.Removed {
+		if objectInfo.Removed && !opts.ShowRemovedObject {
+			continue
+		}
+		if len(opts.TagFilter) > 0 && (objectInfo.ObjectInfo == nil || !types.MatchTags(objectInfo.ObjectInfo.Tags, opts.TagFilter)) {
 			continue
 		}
 
@@ -1139,7 +1825,7 @@ func (c *Client) GetCreateObjectApproval(ctx context.Context, createObjectMsg *s
 	}
 
 	bucketName := createObjectMsg.BucketName
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return nil, err
@@ -1164,6 +1850,11 @@ func (c *Client) GetCreateObjectApproval(ctx context.Context, createObjectMsg *s
 	var signedMsg storageTypes.MsgCreateObject
 	storageTypes.ModuleCdc.MustUnmarshalJSON(signedMsgBytes, &signedMsg)
 
+	if c.spResponseVerification != types.SPResponseVerificationOff {
+		if err = c.checkSPVerification("createObject approval", c.VerifyCreateObjectApproval(ctx, &signedMsg)); err != nil {
+			return nil, err
+		}
+	}
 	return &signedMsg, nil
 }
 
@@ -1178,7 +1869,18 @@ func (c *Client) CreateFolder(ctx context.Context, bucketName, objectName string
 	return txHash, err
 }
 
-// DelegateCreateFolder send create empty object txn to greenfield chain
+// DelegateCreateFolder - Create the folder by SP on behalf of the uploader, no createObject tx is sent by the
+// client. The bucket should allow the SP as the delegated agent, see ToggleSPAsDelegatedAgent.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The name of the folder, it should end with a forward slash (/).
+//
+// - opts: The options for creating the folder, e.g. the visibility and the content type.
+//
+// - ret: Return error when the folder name is invalid or SP fails to create it, otherwise return nil.
 func (c *Client) DelegateCreateFolder(ctx context.Context, bucketName, objectName string, opts types.PutObjectOptions) error {
 	if !strings.HasSuffix(objectName, "/") {
 		return errors.New("failed to create folder. Folder names must end with a forward slash (/) character")
@@ -1207,6 +1909,69 @@ func (c *Client) GetObjectUploadProgress(ctx context.Context, bucketName, object
 	return status.ObjectInfo.ObjectStatus.String(), nil
 }
 
+// GetObjectResumableUploadOffset - Get the offset of the resumable upload of the object persisted by SP, the upload
+// can be resumed from it.
+//
+// SP may not have persisted the parts sent right before a crash when the upload is resumed immediately, the stale
+// offset makes the parts uploaded again. The callers which know the offset they have sent can set opts.MinOffset to
+// poll the offset until SP catches up.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - opts: The options to define the offset to wait for, the timeout and the poll intervals.
+//
+// - ret1: The offset persisted by SP, it is the last queried offset if SP does not catch up in time.
+//
+// - ret2: Return error wrapping context.DeadlineExceeded if SP does not reach opts.MinOffset before the timeout, or
+// the error of querying the offset, otherwise return nil.
+func (c *Client) GetObjectResumableUploadOffset(ctx context.Context, bucketName, objectName string, opts types.UploadOffsetOptions) (uint64, error) {
+	if err := opts.Validate(); err != nil {
+		return 0, err
+	}
+	if opts.MinOffset == 0 {
+		return c.getObjectResumableUploadOffset(ctx, bucketName, objectName)
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = types.DefaultUploadOffsetTimeout
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = types.DefaultUploadOffsetPollInterval
+	}
+	if opts.MaxPollInterval == 0 {
+		opts.MaxPollInterval = types.MaxUploadOffsetPollInterval
+	}
+	pollCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	backoff := types.ExponentialBackoff(opts.PollInterval, opts.MaxPollInterval)
+	var offset uint64
+	for attempt := 1; ; attempt++ {
+		current, err := c.getObjectResumableUploadOffset(pollCtx, bucketName, objectName)
+		switch {
+		case err == nil:
+			offset = current
+			if offset >= opts.MinOffset {
+				return offset, nil
+			}
+		case pollCtx.Err() == nil:
+			return offset, err
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+			return offset, fmt.Errorf("the upload offset of object %s is %d, not reaching %d in %s: %w",
+				objectName, offset, opts.MinOffset, opts.Timeout, pollCtx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
 // getObjectResumableUploadOffset return the status of object including the uploading progress
 func (c *Client) getObjectResumableUploadOffset(ctx context.Context, bucketName, objectName string) (uint64, error) {
 	status, err := c.HeadObject(ctx, bucketName, objectName)
@@ -1243,7 +2008,7 @@ func (c *Client) getObjectOffsetFromSP(ctx context.Context, bucketName, objectNa
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		return types.UploadOffset{}, err
 	}
@@ -1262,7 +2027,7 @@ func (c *Client) getObjectOffsetFromSP(ctx context.Context, bucketName, objectNa
 
 	objectOffset := types.UploadOffset{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(resp.Body).Decode(&objectOffset)
+	err = c.decodeXMLResponse(resp, &objectOffset)
 	if err != nil {
 		return types.UploadOffset{}, err
 	}
@@ -1286,7 +2051,7 @@ func (c *Client) getObjectStatusFromSP(ctx context.Context, bucketName, objectNa
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		return types.UploadProgress{}, err
 	}
@@ -1300,7 +2065,7 @@ func (c *Client) getObjectStatusFromSP(ctx context.Context, bucketName, objectNa
 
 	objectStatus := types.UploadProgress{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(resp.Body).Decode(&objectStatus)
+	err = c.decodeXMLResponse(resp, &objectStatus)
 	if err != nil {
 		return types.UploadProgress{}, err
 	}
@@ -1403,35 +2168,135 @@ func (c *Client) ListObjectsByObjectID(ctx context.Context, objectIds []uint64,
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getEndpointByOpt(&opts)
+	endpoints, err := c.getEndpointsByOpt(&opts, opts.FanOut)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
 		return types.ListObjectsByObjectIDResponse{}, err
 	}
 
-	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
-	if err != nil {
-		return types.ListObjectsByObjectIDResponse{}, err
-	}
-	defer utils.CloseResponse(resp)
+	results := make([]types.ListObjectsByObjectIDResponse, len(endpoints))
+	infos := make([]types.ResponseInfo, len(endpoints))
+	var index int
+	err = c.queryConsistent(ctx, opts.Consistency, func() error {
+		index, err = c.queryFirstSP(ctx, endpoints, func(ctx context.Context, index int, endpoint *url.URL) error {
+			resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+			if err != nil {
+				return err
+			}
+			defer utils.CloseResponse(resp)
+			setResponseInfo(&infos[index], resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
+			if err = c.checkMetaFreshness(ctx, resp, opts.Consistency); err != nil {
+				return err
+			}
+			// decode the xml content from response body
+			return c.decodeXMLResponse(resp, (*listObjectsByIDsResponse)(&results[index].Objects))
+		})
+		return err
+	})
 	if err != nil {
 		log.Error().Msgf("the list of objects in object ids:%v failed: %s", objectIds, err.Error())
 		return types.ListObjectsByObjectIDResponse{}, err
 	}
+	if opts.ResponseInfo != nil {
+		*opts.ResponseInfo = infos[index]
+	}
 
-	objects := types.ListObjectsByObjectIDResponse{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), (*listObjectsByIDsResponse)(&objects.Objects))
-	if err != nil && objects.Objects == nil {
-		log.Error().Msgf("the list of objects in object ids:%v failed: %s", objectIds, err.Error())
-		return types.ListObjectsByObjectIDResponse{}, err
+	return results[index], nil
+}
+
+// ListObjectsWithCursor - Lists all the objects of the bucket page by page, the progress is persisted as a cursor so
+// that the listing of a large bucket can be resumed after the process restarts.
+//
+// The cursor is persisted after the handler returns for each page, the page is handled again after resuming if the
+// process exits during the handler, so the handler should be idempotent. The pages following the first one are only
+// accepted from the meta services of SP which have synced to the SyncHeight of the cursor, the stale ones are retried
+// until types.ReadYourWritesTimeout, so that none of the objects existing when the listing started is skipped.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - opts: The options to set the cursor path and the meta to list the objects.
+//
+// - handler: The function to handle each page of the listing, the listing stops if it returns error.
+//
+// - ret1: The cursor of the listing, its Done field is true if all the objects have been listed.
+//
+// - ret2: Return error when the listing or the handler failed, otherwise return nil.
+func (c *Client) ListObjectsWithCursor(ctx context.Context, bucketName string, opts types.ListObjectsWithCursorOptions,
+	handler func(result types.ListObjectsResult) error,
+) (*types.ListCursor, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if handler == nil {
+		return nil, errors.New("the list handler should not be nil")
+	}
+
+	var cursor *types.ListCursor
+	if opts.CursorPath != "" {
+		loaded, err := types.LoadListCursor(c.fileSystem, opts.CursorPath)
+		switch {
+		case err == nil:
+			if loaded.BucketName != bucketName || loaded.Prefix != opts.Prefix || loaded.Delimiter != opts.Delimiter {
+				return nil, fmt.Errorf("the cursor %s belongs to another listing of bucket %s, prefix %q, delimiter %q",
+					opts.CursorPath, loaded.BucketName, loaded.Prefix, loaded.Delimiter)
+			}
+			cursor = loaded
+		case errors.Is(err, fs.ErrNotExist):
+		default:
+			return nil, err
+		}
+	}
+	if cursor == nil {
+		status, err := c.chainClient.GetStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &types.ListCursor{
+			BucketName: bucketName,
+			Prefix:     opts.Prefix,
+			Delimiter:  opts.Delimiter,
+			SyncHeight: status.SyncInfo.LatestBlockHeight,
+		}
 	}
 
-	return objects, nil
+	for !cursor.Done {
+		if err := ctx.Err(); err != nil {
+			return cursor, err
+		}
+		consistency := types.Consistency{}
+		if cursor.ContinuationToken != "" {
+			consistency = types.AtLeastHeight(cursor.SyncHeight)
+		}
+		result, err := c.ListObjects(ctx, bucketName, types.ListObjectsOptions{
+			ShowRemovedObject: opts.ShowRemovedObject,
+			ContinuationToken: cursor.ContinuationToken,
+			Delimiter:         opts.Delimiter,
+			Prefix:            opts.Prefix,
+			MaxKeys:           opts.MaxKeys,
+			Endpoint:          opts.Endpoint,
+			SPAddress:         opts.SPAddress,
+			Consistency:       consistency,
+		})
+		if err != nil {
+			return cursor, err
+		}
+		if err = handler(result); err != nil {
+			return cursor, err
+		}
+
+		cursor.Listed += uint64(len(result.Objects))
+		cursor.ContinuationToken = result.NextContinuationToken
+		cursor.Done = !result.IsTruncated || result.NextContinuationToken == ""
+		if opts.CursorPath != "" {
+			if err = cursor.Save(c.fileSystem, opts.CursorPath); err != nil {
+				return cursor, err
+			}
+		}
+	}
+	return cursor, nil
 }
 
 // ListObjectPolicies - List object policies by object info and action type.
@@ -1504,17 +2369,9 @@ func (c *Client) ListObjectPolicies(ctx context.Context, objectName, bucketName
 	}
 	defer utils.CloseResponse(resp)
 
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		log.Error().Msgf("the list object policies in bucket name:%s, object name:%s failed: %s", bucketName, objectName, err.Error())
-		return types.ListObjectPoliciesResponse{}, err
-	}
-
 	policies := types.ListObjectPoliciesResponse{}
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &policies)
+	// decode the xml content from response body
+	err = c.decodeXMLResponse(resp, &policies)
 	if err != nil {
 		log.Error().Msgf("the list object policies in bucket name:%s, object name:%s failed: %s", bucketName, objectName, err.Error())
 		return types.ListObjectPoliciesResponse{}, err
@@ -1523,9 +2380,28 @@ func (c *Client) ListObjectPolicies(ctx context.Context, objectName, bucketName
 	return policies, nil
 }
 
+// DelegatePutObject - Upload the object payload to SP which creates the object on behalf of the uploader, so that no
+// createObject tx is sent and paid by the client, and the payload is hashed once by SP rather than twice. The bucket
+// should allow the SP as the delegated agent, see ToggleSPAsDelegatedAgent.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - objectSize: The size of the payload, it should be more than 0.
+//
+// - reader: The reader of the payload.
+//
+// - opts: The options for uploading the object, Delegated is always set.
+//
+// - ret: Return error when the upload failed, otherwise return nil.
 func (c *Client) DelegatePutObject(ctx context.Context, bucketName, objectName string, objectSize int64,
 	reader io.Reader, opts types.PutObjectOptions,
 ) (err error) {
+	// memoize the bucket and object lookups of the routing
+	ctx = WithHeadCache(ctx)
 	if objectSize <= 0 {
 		return errors.New("object size should be more than 0")
 	}
@@ -1534,13 +2410,17 @@ func (c *Client) DelegatePutObject(ctx context.Context, bucketName, objectName s
 		return err
 	}
 	opts.Delegated = true
-	// minPartSize: 16MB
-	if opts.PartSize == 0 {
-		opts.PartSize = types.MinPartSize
+	if defaults, ok := c.GetBucketDefaults(bucketName); ok {
+		defaults.ApplyToPut(objectName, &opts)
+	}
+	if err := opts.Validate(); err != nil {
+		return err
 	}
-	if opts.PartSize%params.GetMaxSegmentSize() != 0 {
-		return errors.New("part size should be an integer multiple of the segment size")
+	if opts.PartSize, err = c.resolvePartSize(opts.PartSize, params); err != nil {
+		return err
 	}
+	reader = withRateLimit(ctx, reader, c.uploadLimiter, newRateLimiter(opts.RateLimit))
+	reader = withProgress(reader, newProgressTracker(opts.Progress, 0, objectSize))
 
 	// upload an entire object to the storage provider in a single request
 	if objectSize <= int64(opts.PartSize) || opts.DisableResumable {
@@ -1551,9 +2431,51 @@ func (c *Client) DelegatePutObject(ctx context.Context, bucketName, objectName s
 	return c.putObjectResumable(ctx, bucketName, objectName, objectSize, reader, opts)
 }
 
+// DelegateUpdateObjectContent - Replace the payload of the sealed object by SP on behalf of the uploader, it is
+// DelegatePutObject with opts.IsUpdate set.
 func (c *Client) DelegateUpdateObjectContent(ctx context.Context, bucketName, objectName string, objectSize int64,
 	reader io.Reader, opts types.PutObjectOptions,
 ) (err error) {
 	opts.IsUpdate = true
 	return c.DelegatePutObject(ctx, bucketName, objectName, objectSize, reader, opts)
 }
+
+// SetObjectTags - Replace the tags of the object on chain, e.g. to label the objects by the cost centers or the
+// lifecycle stages.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - tags: The tags of the object, the existing tags are removed if it is empty.
+//
+// - opts: The options to send the setTag tx.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the tx failed, otherwise return nil.
+func (c *Client) SetObjectTags(ctx context.Context, bucketName, objectName string, tags map[string]string, opts types.SetTagsOptions) (string, error) {
+	grn := gnfdTypes.NewObjectGRN(bucketName, objectName)
+	return c.SetTag(ctx, grn.String(), *types.NewResourceTags(tags), opts)
+}
+
+// GetObjectTags - Query the tags of the object on chain.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - ret1: The tags of the object, it is nil if the object has no tag.
+//
+// - ret2: Return error when the object does not exist, otherwise return nil.
+func (c *Client) GetObjectTags(ctx context.Context, bucketName, objectName string) (map[string]string, error) {
+	detail, err := c.HeadObject(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return types.TagsToMap(detail.ObjectInfo.Tags), nil
+}
@@ -0,0 +1,344 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// fakeStorageQueryClient serves the storage queries by the functions set, the other queries panic.
+type fakeStorageQueryClient struct {
+	storageTypes.QueryClient
+	headObject func(req *storageTypes.QueryHeadObjectRequest) (*storageTypes.QueryHeadObjectResponse, error)
+	headBucket func(req *storageTypes.QueryHeadBucketRequest) (*storageTypes.QueryHeadBucketResponse, error)
+	params     func() (*storageTypes.QueryParamsResponse, error)
+}
+
+func (f *fakeStorageQueryClient) HeadBucket(_ context.Context, req *storageTypes.QueryHeadBucketRequest,
+	_ ...grpc.CallOption,
+) (*storageTypes.QueryHeadBucketResponse, error) {
+	return f.headBucket(req)
+}
+
+func (f *fakeStorageQueryClient) HeadObject(_ context.Context, req *storageTypes.QueryHeadObjectRequest,
+	_ ...grpc.CallOption,
+) (*storageTypes.QueryHeadObjectResponse, error) {
+	return f.headObject(req)
+}
+
+func (f *fakeStorageQueryClient) Params(_ context.Context, _ *storageTypes.QueryParamsRequest,
+	_ ...grpc.CallOption,
+) (*storageTypes.QueryParamsResponse, error) {
+	return f.params()
+}
+
+func newStorageQueryTestClient(t *testing.T, storageQuery *fakeStorageQueryClient) *Client {
+	return newTestClient(t, func(c *Client) { c.chainClient.StorageQueryClient = storageQuery })
+}
+
+func TestWaitForObjectSealWithHeadCache(t *testing.T) {
+	var polls int32
+	c := newStorageQueryTestClient(t, &fakeStorageQueryClient{
+		headObject: func(req *storageTypes.QueryHeadObjectRequest) (*storageTypes.QueryHeadObjectResponse, error) {
+			status := storageTypes.OBJECT_STATUS_CREATED
+			if atomic.AddInt32(&polls, 1) >= 3 {
+				status = storageTypes.OBJECT_STATUS_SEALED
+			}
+			return &storageTypes.QueryHeadObjectResponse{ObjectInfo: &storageTypes.ObjectInfo{
+				BucketName:   req.BucketName,
+				ObjectName:   req.ObjectName,
+				ObjectStatus: status,
+			}}, nil
+		},
+	})
+
+	// the created object memoized by the upload does not stop the wait from seeing the seal
+	ctx := WithHeadCache(context.Background())
+	objectDetail, err := c.HeadObject(ctx, "bucket", "object")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_CREATED, objectDetail.ObjectInfo.ObjectStatus)
+
+	objectDetail, err = c.WaitForObjectSeal(ctx, "bucket", "object", types.WaitForObjectSealOptions{
+		Timeout:         5 * time.Second,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, objectDetail.ObjectInfo.ObjectStatus)
+	require.Equal(t, int32(3), atomic.LoadInt32(&polls))
+
+	// the sealed object is memoized for the following APIs
+	objectDetail, err = c.HeadObject(ctx, "bucket", "object")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, objectDetail.ObjectInfo.ObjectStatus)
+	require.Equal(t, int32(3), atomic.LoadInt32(&polls))
+}
+
+func TestWithoutHeadCache(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, ctx, withoutHeadCache(ctx))
+	require.Nil(t, headCacheFromContext(withoutHeadCache(WithHeadCache(ctx))))
+}
+
+func TestListObjectsWithCursorMinHeight(t *testing.T) {
+	// the SP serves the first page and then lags behind the height the listing started at
+	var syncedHeight, requests atomic.Int64
+	syncedHeight.Store(100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set(types.HTTPHeaderBlockHeight, strconv.FormatInt(syncedHeight.Load(), 10))
+		if r.URL.Query().Get("continuation-token") == "" {
+			w.Write([]byte("<GfSpListObjectsByBucketNameResponse><Objects></Objects><IsTruncated>true</IsTruncated>" +
+				"<NextContinuationToken>dG9rZW4tMQ==</NextContinuationToken></GfSpListObjectsByBucketNameResponse>"))
+			return
+		}
+		// the SP catches up after being asked again
+		syncedHeight.Add(1)
+		w.Write([]byte("<GfSpListObjectsByBucketNameResponse><Objects></Objects><IsTruncated>false</IsTruncated>" +
+			"</GfSpListObjectsByBucketNameResponse>"))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, withTestServer(server))
+	c.state.blockTime.Store(int64(time.Millisecond))
+	cursorPath := filepath.Join(t.TempDir(), "cursor.json")
+	require.NoError(t, (&types.ListCursor{BucketName: "bucket", SyncHeight: 101}).Save(c.fileSystem, cursorPath))
+	opts := types.ListObjectsWithCursorOptions{CursorPath: cursorPath, Endpoint: server.URL}
+
+	// the first page is not checked against the height
+	pages := 0
+	handler := func(result types.ListObjectsResult) error {
+		pages++
+		return errors.New("stop")
+	}
+	_, err := c.ListObjectsWithCursor(context.Background(), "bucket", opts, handler)
+	require.EqualError(t, err, "stop")
+	require.Equal(t, 1, pages)
+	require.NoError(t, (&types.ListCursor{BucketName: "bucket", SyncHeight: 101, ContinuationToken: "dG9rZW4tMQ=="}).Save(c.fileSystem, cursorPath))
+
+	// the resumed page served by the SP lagging behind the sync height of the cursor is retried
+	syncedHeight.Store(99)
+	requests.Store(0)
+	pages = 0
+	cursor, err := c.ListObjectsWithCursor(context.Background(), "bucket", opts, func(result types.ListObjectsResult) error {
+		pages++
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, cursor.Done)
+	require.Equal(t, 1, pages)
+	require.Equal(t, int64(3), requests.Load())
+
+	// the resumed page is not handled if the SP does not catch up
+	require.NoError(t, (&types.ListCursor{BucketName: "bucket", SyncHeight: 1000, ContinuationToken: "dG9rZW4tMQ=="}).Save(c.fileSystem, cursorPath))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	pages = 0
+	_, err = c.ListObjectsWithCursor(ctx, "bucket", opts, handler)
+	require.Error(t, err)
+	require.Equal(t, 0, pages)
+}
+
+// newDownloadTestClient returns the client downloading the object of content from the SP serving the object ranges by
+// serve, the segment size is 4 and the default part size is 8.
+func newDownloadTestClient(t *testing.T, content []byte, serve func(w http.ResponseWriter, part []byte)) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isRange, start, end := utils.ParseRange(r.Header.Get(types.HTTPHeaderRange))
+		require.True(t, isRange)
+		if end < 0 || end >= int64(len(content)) {
+			end = int64(len(content)) - 1
+		}
+		serve(w, content[start:end+1])
+	}))
+	t.Cleanup(server.Close)
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c := newStorageQueryTestClient(t, &fakeStorageQueryClient{
+		params: func() (*storageTypes.QueryParamsResponse, error) {
+			return &storageTypes.QueryParamsResponse{Params: storageTypes.Params{VersionedParams: storageTypes.VersionedParams{
+				MaxSegmentSize: 4,
+			}}}, nil
+		},
+		headObject: func(req *storageTypes.QueryHeadObjectRequest) (*storageTypes.QueryHeadObjectResponse, error) {
+			return &storageTypes.QueryHeadObjectResponse{ObjectInfo: &storageTypes.ObjectInfo{
+				BucketName:  req.BucketName,
+				ObjectName:  req.ObjectName,
+				PayloadSize: uint64(len(content)),
+				Checksums:   [][]byte{integrityHashOf(content, 4)},
+			}}, nil
+		},
+	})
+	c.httpClient = server.Client()
+	c.forceToUseSpecifiedSpEndpointForDownloadOnly = endpoint
+	c.defaultPartSize = 8
+	return c
+}
+
+// writerAtBuffer is the in-memory io.WriterAt growing on demand.
+type writerAtBuffer struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (b *writerAtBuffer) WriteAt(p []byte, off int64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if end := off + int64(len(p)); end > int64(len(b.data)) {
+		b.data = append(b.data, make([]byte, end-int64(len(b.data)))...)
+	}
+	return copy(b.data[off:], p), nil
+}
+
+// failingWriterAt is the io.WriterAt failing every write by err.
+type failingWriterAt struct {
+	err error
+}
+
+func (f failingWriterAt) WriteAt([]byte, int64) (int, error) {
+	return 0, f.err
+}
+
+func TestGetObjectToWriterAt(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	testCases := []struct {
+		name     string
+		rangeStr string
+		expected string
+		err      bool
+	}{
+		{"whole object in partial last part", "", string(content), false},
+		{"range", "bytes=5-13", string(content[5:14]), false},
+		{"range within one part", "bytes=3-4", string(content[3:5]), false},
+		{"range to the end", "bytes=15-", string(content[15:]), false},
+		{"range end beyond the object", "bytes=12-100", string(content[12:]), false},
+		{"range start beyond the object", "bytes=20-21", "", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newDownloadTestClient(t, content, func(w http.ResponseWriter, part []byte) {
+				requests.Add(1)
+				w.Write(part)
+			})
+			sink := &writerAtBuffer{}
+			stat, err := c.GetObjectToWriterAt(context.Background(), "bucket", "object", sink,
+				types.GetObjectOptions{Range: tc.rangeStr, Concurrency: 2})
+			if tc.err {
+				require.Error(t, err)
+				require.Zero(t, requests.Load())
+				return
+			}
+			require.NoError(t, err)
+			// the first byte of the range is written at offset 0
+			require.Equal(t, tc.expected, string(sink.data))
+			require.Equal(t, int64(len(tc.expected)), stat.Size)
+			// the parts are aligned to the part size from the start of the range
+			require.Equal(t, int32((len(tc.expected)+7)/8), requests.Load())
+		})
+	}
+}
+
+func TestGetObjectToWriterAtErrors(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+
+	// the part shorter than its range is reported
+	c := newDownloadTestClient(t, content, func(w http.ResponseWriter, part []byte) {
+		w.Write(part[:len(part)-1])
+	})
+	_, err := c.GetObjectToWriterAt(context.Background(), "bucket", "object", &writerAtBuffer{}, types.GetObjectOptions{})
+	require.ErrorContains(t, err, "is incomplete")
+
+	// the error of the sink fails the download
+	c = newDownloadTestClient(t, content, func(w http.ResponseWriter, part []byte) {
+		w.Write(part)
+	})
+	diskFull := errors.New("disk full")
+	_, err = c.GetObjectToWriterAt(context.Background(), "bucket", "object", failingWriterAt{err: diskFull}, types.GetObjectOptions{})
+	require.ErrorIs(t, err, diskFull)
+}
+
+func TestGetObjectAdaptiveConcurrency(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	var requests atomic.Int32
+	c := newDownloadTestClient(t, content, func(w http.ResponseWriter, part []byte) {
+		requests.Add(1)
+		w.Write(part)
+	})
+	opts := types.GetObjectOptions{AdaptiveConcurrency: &types.AdaptiveConcurrency{MaxConcurrency: 4}}
+
+	// GetObject downloads the parts in parallel by the limiter even if Concurrency is not set
+	body, stat, err := c.GetObject(context.Background(), "bucket", "object", opts)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, content, data)
+	require.Equal(t, int64(len(content)), stat.Size)
+	require.Equal(t, int32(3), requests.Load())
+
+	// FGetObjectResumable downloads the parts sequentially
+	err = c.FGetObjectResumable(context.Background(), "bucket", "object", filepath.Join(t.TempDir(), "object"), opts)
+	require.ErrorContains(t, err, "AdaptiveConcurrency is not supported")
+}
+
+func TestGetObjectToWriterAtVerifyIntegrity(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	honest := func(w http.ResponseWriter, part []byte) {
+		w.Write(part)
+	}
+	// the SP tampers the byte at offset 10, which is in the second part
+	tampering := func(w http.ResponseWriter, part []byte) {
+		if bytes.HasPrefix(part, content[8:11]) {
+			part = append([]byte{}, part...)
+			part[2] ^= 0xff
+		}
+		w.Write(part)
+	}
+	opts := types.GetObjectOptions{Concurrency: 2, VerifyIntegrity: true}
+
+	sink := &writerAtBuffer{}
+	_, err := newDownloadTestClient(t, content, honest).GetObjectToWriterAt(context.Background(), "bucket", "object", sink, opts)
+	require.NoError(t, err)
+	require.Equal(t, content, sink.data)
+
+	_, err = newDownloadTestClient(t, content, tampering).GetObjectToWriterAt(context.Background(), "bucket", "object", &writerAtBuffer{}, opts)
+	var integrityErr *types.IntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	require.Equal(t, 5, integrityErr.Segments)
+	require.Equal(t, int64(len(content)), integrityErr.Size)
+
+	// FGetObjectResumable verifies the downloaded file and discards it on mismatch
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "object")
+	require.NoError(t, newDownloadTestClient(t, content, honest).FGetObjectResumable(context.Background(), "bucket", "object", filePath, opts))
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+
+	dir = t.TempDir()
+	filePath = filepath.Join(dir, "object")
+	err = newDownloadTestClient(t, content, tampering).FGetObjectResumable(context.Background(), "bucket", "object", filePath, opts)
+	require.ErrorAs(t, err, &integrityErr)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
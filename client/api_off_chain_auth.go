@@ -2,11 +2,13 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/hex"
-	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -19,6 +21,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
 	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rs/zerolog/log"
@@ -37,6 +40,11 @@ type IAuthClient interface {
 	RegisterEDDSAPublicKeyV2(spEndpoint string) (string, error)
 	OffChainAuthSignV2(unsignedBytes []byte) string
 
+	GetNextNonceByDomain(ctx context.Context, spEndpoint string, domain string) (string, error)
+	RegisterOffChainAuthKey(ctx context.Context, spEndpoint string, auth OffChainAuthOptionV2) (string, error)
+	NewOffChainAuthKeyRegistration(auth OffChainAuthOptionV2) (*OffChainAuthKeyRegistration, error)
+	RegisterOffChainAuthKeyWithSignature(ctx context.Context, spEndpoint string, registration *OffChainAuthKeyRegistration, signature []byte) (string, error)
+
 	ListUserPublicKeyV2(spEndpoint string, domain string) ([]string, error)
 	DeleteUserPublicKeyV2(spEndpoint string, domain string, publicKeys []string) (bool, error)
 }
@@ -61,13 +69,56 @@ func (c *Client) OffChainAuthSign(unsignedBytes []byte) string {
 //
 // - ret1: The signature made by EdDSA private key of the Client.
 func (c *Client) OffChainAuthSignV2(unsignedBytes []byte) string {
-	sk, _ := GetEd25519PrivateKeyAndPublicKey(c.offChainAuthOptionV2.Seed)
+	return offChainAuthSignV2(c.offChainAuthOptionV2.Seed, unsignedBytes)
+}
+
+func offChainAuthSignV2(seed string, unsignedBytes []byte) string {
+	sk, _ := GetEd25519PrivateKeyAndPublicKey(seed)
 	// Sign the message using the private key
 	sig := ed25519.Sign(sk, unsignedBytes)
 	authString := fmt.Sprintf("%s,Signature=%v", httplib.Gnfd2Eddsa, hex.EncodeToString(sig))
 	return authString
 }
 
+type offChainAuthKey struct{}
+
+// WithOffChainAuth - Return a context whose SP requests are signed by the EdDSA key of auth in off-chain-auth-v2,
+// rather than the configured auth of the client.
+//
+// It allows a client to act for the users of a dApp, each of whom registers the public key of a seed by signing the
+// registration message with the wallet, e.g. by personal_sign, see NewOffChainAuthKeyRegistration. The requests are
+// sent for the UserAddress of auth, the client does not need a default account.
+//
+// - ctx: The parent context.
+//
+// - auth: The seed, the domain and the user address which the public key is registered for, the PublicKey is derived
+// from the seed. The requests fail to be signed if the UserAddress is empty.
+//
+// - ret: The context signing the SP requests by the key.
+func WithOffChainAuth(ctx context.Context, auth OffChainAuthOptionV2) context.Context {
+	_, publicKey := GetEd25519PrivateKeyAndPublicKey(auth.Seed)
+	auth.PublicKey = hex.EncodeToString(publicKey)
+	return context.WithValue(ctx, offChainAuthKey{}, &auth)
+}
+
+func offChainAuthFromContext(ctx context.Context) *OffChainAuthOptionV2 {
+	auth, _ := ctx.Value(offChainAuthKey{}).(*OffChainAuthOptionV2)
+	return auth
+}
+
+// GenerateOffChainAuthSeed - Generate a random seed of the EdDSA key for off-chain-auth-v2.
+//
+// - ret1: The HEX-encoded seed, it should be kept as secret as a private key.
+//
+// - ret2: Return error when the random source fails, otherwise return nil.
+func GenerateOffChainAuthSeed() (string, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(seed), nil
+}
+
 // requestNonceResp is the structure for off chain auth nonce response.
 type requestNonceResp struct {
 	CurrentNonce     int32  `xml:"CurrentNonce"`
@@ -94,17 +145,33 @@ type DeleteUserPublicKeyV2Resp struct {
 //
 // - ret2: Return error when getting next nonce failed, otherwise return nil.
 func (c *Client) GetNextNonce(spEndpoint string) (string, error) {
+	return c.GetNextNonceByDomain(context.Background(), spEndpoint, c.offChainAuthOption.Domain)
+}
+
+// GetNextNonceByDomain - Get the nonce value by giving user account and the domain, the domain does not need to be
+// configured for the client.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spEndpoint: The sp endpoint where the client means to get the next nonce
+//
+// - domain: The domain which the EdDSA public key will be registered for.
+//
+// - ret1: The next nonce value for the Client if it needs to register a new EdDSA public key
+//
+// - ret2: Return error when getting next nonce failed, otherwise return nil.
+func (c *Client) GetNextNonceByDomain(ctx context.Context, spEndpoint string, domain string) (string, error) {
 	header := make(map[string]string)
 	header["X-Gnfd-User-Address"] = c.defaultAccount.GetAddress().String()
-	header["X-Gnfd-App-Domain"] = c.offChainAuthOption.Domain
+	header["X-Gnfd-App-Domain"] = domain
 
-	response, err := httpGetWithHeader(spEndpoint+"/auth/request_nonce", header)
+	response, err := c.httpGetWithHeader(ctx, spEndpoint+"/auth/request_nonce", header)
 	if err != nil {
 		return "0", err
 	}
 	authNonce := requestNonceResp{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(bytes.NewBufferString(response)).Decode(&authNonce)
+	err = c.xmlDecoder.Decode([]byte(response), &authNonce)
 	if err != nil {
 		return "0", err
 	}
@@ -166,9 +233,9 @@ func (c *Client) RegisterEDDSAPublicKey(spAddress string, spEndpoint string) (st
 	userEddsaPublicKeyStr := getEddsaCompressedPublicKey(eddsaSeed)
 	log.Info().Msg("userEddsaPublicKeyStr is " + userEddsaPublicKeyStr)
 
-	IssueDate := time.Now().Format(time.RFC3339)
+	IssueDate := c.now().Format(time.RFC3339)
 	// ExpiryDate format := "2023-06-27T06:35:24Z"
-	ExpiryDate := time.Now().Add(time.Hour * 24).Format(time.RFC3339)
+	ExpiryDate := c.now().Add(time.Hour * 24).Format(time.RFC3339)
 
 	unSignedContent := fmt.Sprintf(unsignedContentTemplate, appDomain, c.defaultAccount.GetAddress().String(), userEddsaPublicKeyStr, appDomain, IssueDate, ExpiryDate, spAddress, nextNonce)
 
@@ -184,7 +251,7 @@ func (c *Client) RegisterEDDSAPublicKey(spAddress string, spEndpoint string) (st
 	headers["authorization"] = authString
 	headers["origin"] = appDomain
 	headers["x-gnfd-user-address"] = c.defaultAccount.GetAddress().String()
-	jsonResult, error1 := httpPostWithHeader(spEndpoint+"/auth/update_key", "{}", headers)
+	jsonResult, error1 := c.httpPostWithHeader(context.Background(), spEndpoint+"/auth/update_key", "{}", headers)
 
 	return jsonResult, error1
 }
@@ -206,34 +273,136 @@ func (c *Client) RegisterEDDSAPublicKey(spAddress string, spEndpoint string) (st
 //
 // - ret2: Return error when registering failed, otherwise return nil.
 func (c *Client) RegisterEDDSAPublicKeyV2(spEndpoint string) (string, error) {
-	appDomain := c.offChainAuthOptionV2.Domain
-	eddsaSeed := c.offChainAuthOptionV2.Seed
+	return c.RegisterOffChainAuthKey(context.Background(), spEndpoint, *c.offChainAuthOptionV2)
+}
+
+// OffChainAuthKeyRegistration - The unsigned registration message of an off-chain-auth-v2 key, which is signed by the
+// wallet of the user, e.g. by personal_sign, and then registered by RegisterOffChainAuthKeyWithSignature.
+type OffChainAuthKeyRegistration struct {
+	// UserAddress is the address of the user who signs the message.
+	UserAddress string
+	// Domain is the domain where the key is registered for.
+	Domain string
+	// PublicKey is the HEX-encoded ed25519 public key to register.
+	PublicKey string
+	// ExpiryDate is the time when the key expires in RFC3339 format.
+	ExpiryDate string
+	// Message is the text to be signed by personal_sign, i.e. the signature is made over its EIP-191 text hash.
+	Message string
+}
+
+// NewOffChainAuthKeyRegistration - Build the unsigned message registering the EdDSA public key of the seed for the
+// domain in off-chain-auth-v2, the private key of the user is not needed, so that the message can be signed by the
+// wallet of the user. The key expires in one day.
+//
+// - auth: The seed of the EdDSA key, the domain to register it for and the address of the user, e.g. the seed is
+// generated by GenerateOffChainAuthSeed.
+//
+// - ret1: The registration whose Message is to be signed by the user.
+//
+// - ret2: Return error when the seed, the domain or the user address is empty or invalid, otherwise return nil.
+func (c *Client) NewOffChainAuthKeyRegistration(auth OffChainAuthOptionV2) (*OffChainAuthKeyRegistration, error) {
+	if auth.Seed == "" || auth.Domain == "" {
+		return nil, errors.New("seed and domain can't be empty in OffChainAuthOptionV2")
+	}
+	if auth.UserAddress == "" {
+		return nil, errors.New("user address can't be empty in OffChainAuthOptionV2")
+	}
+	userAddress, err := sdk.AccAddressFromHexUnsafe(auth.UserAddress)
+	if err != nil {
+		return nil, err
+	}
 
 	// get the EDDSA private and public key
-	_, userEddsaPublicKey := GetEd25519PrivateKeyAndPublicKey(eddsaSeed)
+	_, userEddsaPublicKey := GetEd25519PrivateKeyAndPublicKey(auth.Seed)
 	userEddsaPublicKeyStr := hex.EncodeToString(userEddsaPublicKey)
-	log.Info().Msg("userEddsaPublicKeyStr is " + userEddsaPublicKeyStr)
 
-	IssueDate := time.Now().Format(time.RFC3339)
+	IssueDate := c.now().Format(time.RFC3339)
 	// ExpiryDate format := "2023-06-27T06:35:24Z"
-	ExpiryDate := time.Now().Add(time.Hour * 24).Format(time.RFC3339)
-
-	unSignedContent := fmt.Sprintf(unsignedContentTemplateV2, appDomain, c.defaultAccount.GetAddress().String(), userEddsaPublicKeyStr, appDomain, IssueDate, ExpiryDate)
+	ExpiryDate := c.now().Add(time.Hour * 24).Format(time.RFC3339)
+
+	return &OffChainAuthKeyRegistration{
+		UserAddress: userAddress.String(),
+		Domain:      auth.Domain,
+		PublicKey:   userEddsaPublicKeyStr,
+		ExpiryDate:  ExpiryDate,
+		Message:     fmt.Sprintf(unsignedContentTemplateV2, auth.Domain, userAddress.String(), userEddsaPublicKeyStr, auth.Domain, IssueDate, ExpiryDate),
+	}, nil
+}
 
-	unSignedContentHash := accounts.TextHash([]byte(unSignedContent))
-	sig, _ := c.defaultAccount.GetKeyManager().Sign(unSignedContentHash)
-	authString := fmt.Sprintf("%s,SignedMsg=%s,Signature=%s", httplib.Gnfd1EthPersonalSign, unSignedContent, hexutil.Encode(sig))
+// RegisterOffChainAuthKeyWithSignature - Register the EdDSA public key of the registration to the SP in
+// off-chain-auth-v2 by the signature of its Message made by the user, e.g. by personal_sign of the wallet.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spEndpoint: The sp endpoint, to which this API will register the EdDSA public key.
+//
+// - registration: The registration built by NewOffChainAuthKeyRegistration.
+//
+// - signature: The 65 bytes signature of the EIP-191 text hash of the Message made by the key of the UserAddress.
+//
+// - ret1: The register result when invoking SP UpdateUserPublicKey API.
+//
+// - ret2: Return error when registering failed, otherwise return nil.
+func (c *Client) RegisterOffChainAuthKeyWithSignature(ctx context.Context, spEndpoint string, registration *OffChainAuthKeyRegistration, signature []byte) (string, error) {
+	if registration == nil {
+		return "", errors.New("registration can't be nil")
+	}
+	if len(signature) == 0 {
+		return "", errors.New("signature can't be empty")
+	}
+	authString := fmt.Sprintf("%s,SignedMsg=%s,Signature=%s", httplib.Gnfd1EthPersonalSign, registration.Message, hexutil.Encode(signature))
 	authString = strings.ReplaceAll(authString, "\n", "\\n")
 	headers := make(map[string]string)
-	headers["x-gnfd-app-domain"] = appDomain
-	headers["x-gnfd-app-reg-public-key"] = userEddsaPublicKeyStr
-	headers["X-Gnfd-Expiry-Timestamp"] = ExpiryDate
+	headers["x-gnfd-app-domain"] = registration.Domain
+	headers["x-gnfd-app-reg-public-key"] = registration.PublicKey
+	headers["X-Gnfd-Expiry-Timestamp"] = registration.ExpiryDate
 	headers["authorization"] = authString
-	headers["origin"] = appDomain
-	headers["x-gnfd-user-address"] = c.defaultAccount.GetAddress().String()
-	jsonResult, error1 := httpPostWithHeader(spEndpoint+"/auth/update_key_v2", "{}", headers)
+	headers["origin"] = registration.Domain
+	headers["x-gnfd-user-address"] = registration.UserAddress
+	return c.httpPostWithHeader(ctx, spEndpoint+"/auth/update_key_v2", "{}", headers)
+}
 
-	return jsonResult, error1
+// RegisterOffChainAuthKey - Register the EdDSA public key of the seed for the domain to the SP in off-chain-auth-v2,
+// the seed and the domain do not need to be configured for the client.
+//
+// The registration message is signed by the default account, use NewOffChainAuthKeyRegistration and
+// RegisterOffChainAuthKeyWithSignature instead if the message is signed by the wallet of the user. The requests signed
+// by the key can be sent by WithOffChainAuth once it is registered. The key expires in one day.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spEndpoint: The sp endpoint, to which this API will register the EdDSA public key.
+//
+// - auth: The seed of the EdDSA key and the domain to register it for, e.g. generated by GenerateOffChainAuthSeed.
+// The UserAddress should be empty or the address of the default account.
+//
+// - ret1: The register result when invoking SP UpdateUserPublicKey API.
+//
+// - ret2: Return error when registering failed, otherwise return nil.
+func (c *Client) RegisterOffChainAuthKey(ctx context.Context, spEndpoint string, auth OffChainAuthOptionV2) (string, error) {
+	if c.defaultAccount == nil {
+		return "", types.ErrorDefaultAccountNotExist
+	}
+	defaultAddress := c.defaultAccount.GetAddress()
+	if auth.UserAddress == "" {
+		auth.UserAddress = defaultAddress.String()
+	} else if userAddress, err := sdk.AccAddressFromHexUnsafe(auth.UserAddress); err != nil {
+		return "", err
+	} else if !userAddress.Equals(defaultAddress) {
+		return "", fmt.Errorf("user address %s is not the default account %s", auth.UserAddress, defaultAddress.String())
+	}
+	registration, err := c.NewOffChainAuthKeyRegistration(auth)
+	if err != nil {
+		return "", err
+	}
+	log.Info().Msg("userEddsaPublicKeyStr is " + registration.PublicKey)
+
+	sig, err := c.defaultAccount.GetKeyManager().Sign(accounts.TextHash([]byte(registration.Message)))
+	if err != nil {
+		return "", err
+	}
+	return c.RegisterOffChainAuthKeyWithSignature(ctx, spEndpoint, registration, sig)
 }
 
 // ListUserPublicKeyV2 - List user public keys for off-chain-auth v2
@@ -251,13 +420,13 @@ func (c *Client) ListUserPublicKeyV2(spEndpoint string, domain string) ([]string
 	header["X-Gnfd-User-Address"] = c.defaultAccount.GetAddress().String()
 	header["X-Gnfd-App-Domain"] = domain
 
-	response, err := httpGetWithHeader(spEndpoint+"/auth/keys_v2", header)
+	response, err := c.httpGetWithHeader(context.Background(), spEndpoint+"/auth/keys_v2", header)
 	if err != nil {
 		return nil, err
 	}
 	listResp := ListUserPublicKeyV2Resp{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(bytes.NewBufferString(response)).Decode(&listResp)
+	err = c.xmlDecoder.Decode([]byte(response), &listResp)
 	if err != nil {
 		return nil, err
 	}
@@ -280,7 +449,7 @@ func (c *Client) DeleteUserPublicKeyV2(spEndpoint string, domain string, publicK
 	header := make(map[string]string)
 	header["X-Gnfd-User-Address"] = c.defaultAccount.GetAddress().String()
 	header["X-Gnfd-App-Domain"] = domain
-	stNow := time.Now().UTC()
+	stNow := c.now().UTC()
 	header[httplib.HTTPHeaderExpiryTimestamp] = stNow.Add(time.Second * types.DefaultExpireSeconds).Format(types.Iso8601DateFormatSecond)
 	req, err := http.NewRequest(http.MethodPost, spEndpoint+"/auth/delete_keys_v2", strings.NewReader(strings.Join(publicKeys, ",")))
 	if err != nil {
@@ -295,53 +464,51 @@ func (c *Client) DeleteUserPublicKeyV2(spEndpoint string, domain string, publicK
 		return false, err
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.doSPRequest(req)
 	if err != nil {
 		return false, err
 	}
 	if (nil != resp) && (nil != resp.Body) {
 		defer resp.Body.Close()
 	}
-	body, readErr := io.ReadAll(resp.Body)
+	body, readErr := types.ReadLimitedBody(resp.Body, c.maxResponseBodySize)
 	if readErr != nil {
-		return false, err
+		return false, readErr
 	}
 	deleteResp := DeleteUserPublicKeyV2Resp{}
 	// decode the xml content from response body
-	err = xml.NewDecoder(bytes.NewBufferString(string(body))).Decode(&deleteResp)
+	err = c.xmlDecoder.Decode(body, &deleteResp)
 	if err != nil {
 		return false, err
 	}
 	return deleteResp.Result, nil
 }
 
-func httpGetWithHeader(url string, header map[string]string) (string, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) httpGetWithHeader(ctx context.Context, url string, header map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
 	}
 	for key, value := range header {
 		req.Header.Set(key, value)
 	}
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.doSPRequest(req)
 	if err != nil {
 		return "", err
 	}
 	if (nil != resp) && (nil != resp.Body) {
 		defer resp.Body.Close()
 	}
-	body, readErr := io.ReadAll(resp.Body)
+	body, readErr := types.ReadLimitedBody(resp.Body, c.maxResponseBodySize)
 	if readErr != nil {
-		return "", err
+		return "", readErr
 	}
 	return string(body), err
 }
 
-func httpPostWithHeader(url string, jsonStr string, header map[string]string) (string, error) {
+func (c *Client) httpPostWithHeader(ctx context.Context, url string, jsonStr string, header map[string]string) (string, error) {
 	json := []byte(jsonStr)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(json))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(json))
 	if err != nil {
 		return "", err
 	}
@@ -349,15 +516,14 @@ func httpPostWithHeader(url string, jsonStr string, header map[string]string) (s
 	for key, value := range header {
 		req.Header.Set(key, value)
 	}
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.doSPRequest(req)
 	if err != nil {
 		return "", err
 	}
 	if (nil != resp) && (nil != resp.Body) {
 		defer resp.Body.Close()
 	}
-	body, readErr := io.ReadAll(resp.Body)
+	body, readErr := types.ReadLimitedBody(resp.Body, c.maxResponseBodySize)
 	if readErr != nil {
 		return "", readErr
 	}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httplib "github.com/bnb-chain/greenfield-common/go/http"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestWithOffChainAuth(t *testing.T) {
+	seed, err := GenerateOffChainAuthSeed()
+	require.NoError(t, err)
+	require.Len(t, seed, 64)
+	other, err := GenerateOffChainAuthSeed()
+	require.NoError(t, err)
+	require.NotEqual(t, seed, other)
+
+	require.Nil(t, offChainAuthFromContext(context.Background()))
+	ctx := WithOffChainAuth(context.Background(), OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example"})
+	auth := offChainAuthFromContext(ctx)
+	require.NotNil(t, auth)
+	require.Equal(t, "https://dapp.example", auth.Domain)
+	_, publicKey := GetEd25519PrivateKeyAndPublicKey(seed)
+	require.Equal(t, hex.EncodeToString(publicKey), auth.PublicKey)
+
+	// the signature is verifiable by the registered public key
+	msg := []byte("unsigned message")
+	authStr := offChainAuthSignV2(auth.Seed, msg)
+	_, sig, ok := strings.Cut(authStr, "Signature=")
+	require.True(t, ok)
+	sigBytes, err := hex.DecodeString(sig)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(publicKey, msg, sigBytes))
+}
+
+func TestSignRequestWithOffChainAuth(t *testing.T) {
+	seed, err := GenerateOffChainAuthSeed()
+	require.NoError(t, err)
+	user, _, err := types.NewAccount("user")
+	require.NoError(t, err)
+	// the client acts for the user without a default account
+	c := newTestClient(t, func(c *Client) { c.defaultAccount = nil })
+
+	req, err := http.NewRequestWithContext(WithOffChainAuth(context.Background(),
+		OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example"}), http.MethodGet, "https://sp0/bucket", nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, c.signRequest(req), "user address can't be empty")
+
+	req, err = http.NewRequestWithContext(WithOffChainAuth(context.Background(),
+		OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example", UserAddress: "invalid"}), http.MethodGet, "https://sp0/bucket", nil)
+	require.NoError(t, err)
+	require.Error(t, c.signRequest(req))
+
+	req, err = http.NewRequestWithContext(WithOffChainAuth(context.Background(),
+		OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example", UserAddress: user.GetAddress().String()}), http.MethodGet, "https://sp0/bucket", nil)
+	require.NoError(t, err)
+	require.NoError(t, c.signRequest(req))
+	require.Equal(t, user.GetAddress().String(), req.Header.Get("X-Gnfd-User-Address"))
+	require.Equal(t, "https://dapp.example", req.Header.Get("X-Gnfd-App-Domain"))
+	require.Equal(t, offChainAuthSignV2(seed, httplib.GetMsgToSignInGNFD1Auth(req)), req.Header.Get(types.HTTPHeaderAuthorization))
+}
+
+func TestRegisterOffChainAuthKeyWithSignature(t *testing.T) {
+	seed, err := GenerateOffChainAuthSeed()
+	require.NoError(t, err)
+	user, _, err := types.NewAccount("user")
+	require.NoError(t, err)
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/auth/update_key_v2", r.URL.Path)
+		headers = r.Header
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	c := newTestClient(t, withTestServer(server), func(c *Client) { c.defaultAccount = nil })
+
+	_, err = c.NewOffChainAuthKeyRegistration(OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example"})
+	require.ErrorContains(t, err, "user address can't be empty")
+	_, err = c.RegisterOffChainAuthKey(context.Background(), server.URL, OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example"})
+	require.ErrorIs(t, err, types.ErrorDefaultAccountNotExist)
+
+	// the message is signed by the wallet of the user rather than the client
+	registration, err := c.NewOffChainAuthKeyRegistration(OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example", UserAddress: user.GetAddress().String()})
+	require.NoError(t, err)
+	_, publicKey := GetEd25519PrivateKeyAndPublicKey(seed)
+	require.Equal(t, hex.EncodeToString(publicKey), registration.PublicKey)
+	require.Contains(t, registration.Message, user.GetAddress().String())
+	require.Contains(t, registration.Message, registration.PublicKey)
+
+	_, err = c.RegisterOffChainAuthKeyWithSignature(context.Background(), server.URL, registration, nil)
+	require.ErrorContains(t, err, "signature can't be empty")
+	sig, err := user.GetKeyManager().Sign(accounts.TextHash([]byte(registration.Message)))
+	require.NoError(t, err)
+	_, err = c.RegisterOffChainAuthKeyWithSignature(context.Background(), server.URL, registration, sig)
+	require.NoError(t, err)
+	require.Equal(t, user.GetAddress().String(), headers.Get("X-Gnfd-User-Address"))
+	require.Equal(t, registration.PublicKey, headers.Get("X-Gnfd-App-Reg-Public-Key"))
+
+	// the SP recovers the user from the signed message
+	signedMsg, signature, ok := strings.Cut(strings.TrimPrefix(headers.Get("Authorization"), httplib.Gnfd1EthPersonalSign+",SignedMsg="), ",Signature=")
+	require.True(t, ok)
+	require.Equal(t, registration.Message, strings.ReplaceAll(signedMsg, "\\n", "\n"))
+	sigBytes, err := hexutil.Decode(signature)
+	require.NoError(t, err)
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(registration.Message)), sigBytes)
+	require.NoError(t, err)
+	require.Equal(t, user.GetAddress().Bytes(), crypto.PubkeyToAddress(*pubKey).Bytes())
+
+	// the default account registers for itself only
+	c.defaultAccount = user
+	_, err = c.RegisterOffChainAuthKey(context.Background(), server.URL, OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example"})
+	require.NoError(t, err)
+	require.Equal(t, user.GetAddress().String(), headers.Get("X-Gnfd-User-Address"))
+	other, _, err := types.NewAccount("other")
+	require.NoError(t, err)
+	_, err = c.RegisterOffChainAuthKey(context.Background(), server.URL, OffChainAuthOptionV2{Seed: seed, Domain: "https://dapp.example", UserAddress: other.GetAddress().String()})
+	require.ErrorContains(t, err, "is not the default account")
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	paymentTypes "github.com/bnb-chain/greenfield/x/payment/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IParamsClient - Client APIs for watching the Greenfield chain params.
+type IParamsClient interface {
+	WatchParams(ctx context.Context, module types.ParamsModule, opts types.WatchParamsOptions) (<-chan types.ParamsChange, error)
+}
+
+// WatchParams - Watch the params of a chain module, so that the services depending on them, e.g. the segment size or
+// the prices, adapt to the changes by governance without restarts.
+//
+// The params are polled periodically, the current params are delivered first and the following ones are delivered
+// only when changed. The failed polls are logged and retried at the next interval.
+//
+// - ctx: Context variables for the current API call, the watching stops when it is done.
+//
+// - module: The module whose params are watched, it is one of the storage, payment and sp modules.
+//
+// - opts: The options to set the polling interval.
+//
+// - ret1: The channel receiving the params, it is closed when ctx is done.
+//
+// - ret2: Return error when the module is unsupported or the params can not be queried at first, otherwise return nil.
+func (c *Client) WatchParams(ctx context.Context, module types.ParamsModule, opts types.WatchParamsOptions) (<-chan types.ParamsChange, error) {
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = types.DefaultParamsPollInterval
+	}
+	current, change, err := c.queryModuleParams(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan types.ParamsChange, 1)
+	changes <- change
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			latest, change, err := c.queryModuleParams(ctx, module)
+			if err != nil {
+				log.Warn().Msg(fmt.Sprintf("fail to query the params of module %s: %s", module, err.Error()))
+				continue
+			}
+			if proto.Equal(current, latest) {
+				continue
+			}
+			current = latest
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// queryModuleParams queries the params of the module, it returns the params both as the proto message for comparison
+// and as the change to deliver.
+func (c *Client) queryModuleParams(ctx context.Context, module types.ParamsModule) (proto.Message, types.ParamsChange, error) {
+	change := types.ParamsChange{Module: module}
+	switch module {
+	case types.StorageParamsModule:
+		resp, err := c.chainClient.StorageQueryClient.Params(c.queryContext(ctx), &storageTypes.QueryParamsRequest{})
+		if err != nil {
+			return nil, change, err
+		}
+		change.Storage = &resp.Params
+		return change.Storage, change, nil
+	case types.PaymentParamsModule:
+		resp, err := c.chainClient.PaymentQueryClient.Params(c.queryContext(ctx), &paymentTypes.QueryParamsRequest{})
+		if err != nil {
+			return nil, change, err
+		}
+		change.Payment = &resp.Params
+		return change.Payment, change, nil
+	case types.SPParamsModule:
+		resp, err := c.chainClient.SpQueryClient.Params(c.queryContext(ctx), &spTypes.QueryParamsRequest{})
+		if err != nil {
+			return nil, change, err
+		}
+		change.SP = &resp.Params
+		return change.SP, change, nil
+	default:
+		return nil, change, fmt.Errorf("unsupported params module %s", module)
+	}
+}
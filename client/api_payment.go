@@ -2,18 +2,16 @@ package client
 
 import (
 	"context"
-	"encoding/xml"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"strings"
 
 	"cosmossdk.io/math"
 	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
 	paymentTypes "github.com/bnb-chain/greenfield/x/payment/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/rs/zerolog/log"
 
 	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
@@ -27,6 +25,9 @@ type IPaymentClient interface {
 	Withdraw(ctx context.Context, fromAddress string, amount math.Int, txOption gnfdSdkTypes.TxOption) (string, error)
 	DisableRefund(ctx context.Context, paymentAddress string, txOption gnfdSdkTypes.TxOption) (string, error)
 	ListUserPaymentAccounts(ctx context.Context, opts types.ListUserPaymentAccountsOptions) (types.ListUserPaymentAccountsResult, error)
+	ListAutoSettleRecords(ctx context.Context, pagination *query.PageRequest) ([]paymentTypes.AutoSettleRecord, *query.PageResponse, error)
+	GetOutFlows(ctx context.Context, streamAddress string) ([]paymentTypes.OutFlow, error)
+	GetPaymentAccountCount(ctx context.Context, owner string) (uint64, error)
 }
 
 // GetStreamRecord - Retrieve stream record information for a given stream address.
@@ -43,13 +44,76 @@ func (c *Client) GetStreamRecord(ctx context.Context, streamAddress string) (*pa
 	if err != nil {
 		return nil, err
 	}
-	pa, err := c.chainClient.StreamRecord(ctx, &paymentTypes.QueryGetStreamRecordRequest{Account: accAddress.String()})
+	pa, err := c.chainClient.StreamRecord(c.queryContext(ctx), &paymentTypes.QueryGetStreamRecordRequest{Account: accAddress.String()})
 	if err != nil {
 		return nil, err
 	}
 	return &pa.StreamRecord, nil
 }
 
+// ListAutoSettleRecords - List the auto settle records, which schedule the settlements of the stream records, the
+// stream record of an account is settled at the timestamp of its record.
+//
+// - ctx: Context variables for the current API call.
+//
+// - pagination: The pagination of the records, the first page of the default size is returned if it is nil.
+//
+// - ret1: The auto settle records of the page.
+//
+// - ret2: The pagination of the next page.
+//
+// - ret3: Return error when the request failed, otherwise return nil.
+func (c *Client) ListAutoSettleRecords(ctx context.Context, pagination *query.PageRequest) ([]paymentTypes.AutoSettleRecord, *query.PageResponse, error) {
+	resp, err := c.chainClient.AutoSettleRecords(c.queryContext(ctx), &paymentTypes.QueryAutoSettleRecordsRequest{Pagination: pagination})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.AutoSettleRecords, resp.Pagination, nil
+}
+
+// GetOutFlows - Retrieve the out flows of a stream record, i.e. the flow rates paid to the SPs and the validator tax
+// pool, which make up the net flow rate of the stream record.
+//
+// - ctx: Context variables for the current API call.
+//
+// - streamAddress: The address of the stream record to be queried.
+//
+// - ret1: The out flows of the stream record.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) GetOutFlows(ctx context.Context, streamAddress string) ([]paymentTypes.OutFlow, error) {
+	accAddress, err := sdk.AccAddressFromHexUnsafe(streamAddress)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.chainClient.OutFlows(c.queryContext(ctx), &paymentTypes.QueryOutFlowsRequest{Account: accAddress.String()})
+	if err != nil {
+		return nil, err
+	}
+	return resp.OutFlows, nil
+}
+
+// GetPaymentAccountCount - Retrieve the number of the payment accounts created by an owner.
+//
+// - ctx: Context variables for the current API call.
+//
+// - owner: The address of the owner of the payment accounts.
+//
+// - ret1: The number of the payment accounts.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) GetPaymentAccountCount(ctx context.Context, owner string) (uint64, error) {
+	ownerAddress, err := sdk.AccAddressFromHexUnsafe(owner)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.chainClient.PaymentAccountCount(c.queryContext(ctx), &paymentTypes.QueryPaymentAccountCountRequest{Owner: ownerAddress.String()})
+	if err != nil {
+		return 0, err
+	}
+	return resp.PaymentAccountCount.Count, nil
+}
+
 // Deposit - Deposit BNB to a payment account.
 //
 // - ctx: Context variables for the current API call.
@@ -194,15 +258,8 @@ func (c *Client) ListUserPaymentAccounts(ctx context.Context, opts types.ListUse
 	defer utils.CloseResponse(resp)
 
 	paymentAccounts := types.ListUserPaymentAccountsResult{}
-	// unmarshal the json content from response body
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, resp.Body)
-	if err != nil {
-		return types.ListUserPaymentAccountsResult{}, errors.New("unmarshal response error" + err.Error())
-	}
-
-	bufStr := buf.String()
-	err = xml.Unmarshal([]byte(bufStr), &paymentAccounts)
+	// decode the xml content from response body
+	err = c.decodeXMLResponse(resp, &paymentAccounts)
 	if err != nil {
 		return types.ListUserPaymentAccountsResult{}, err
 	}
@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// groupIDsPerQuery is the number of the group ids queried by a ListGroupsByGroupID request
+const groupIDsPerQuery = 100
+
+// FindOrphanedGroups - Find the groups of the owner which are referenced by no policy of the given buckets and their
+// objects, e.g. the groups left behind by the deleted applications, and optionally delete them in batches.
+//
+// The chain has no index of the policies by the principals, so the references are searched in the bucket policies
+// and, unless opts.SkipObjectPolicies is set, the object policies of the buckets in opts.Buckets. The groups
+// referenced by the policies of the other buckets or of the groups are reported as orphaned as well.
+//
+// - ctx: Context variables for the current API call.
+//
+// - owner: The HEX-encoded string of the group owner address, the default account is used if it is empty.
+//
+// - opts: The options to search and delete the groups.
+//
+// - ret1: The orphaned groups and the hashes of the cleanup transactions.
+//
+// - ret2: Return error when the search or the cleanup failed, otherwise return nil.
+func (c *Client) FindOrphanedGroups(ctx context.Context, owner string, opts types.FindOrphanedGroupsOptions) (*types.OrphanedGroupsResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		owner = c.MustGetDefaultAccount().GetAddress().String()
+	}
+	groupMembers, err := ListAllPages(ctx, func(ctx context.Context, token types.PageToken) ([]*types.GroupMembers, types.PageToken, error) {
+		result, err := c.ListGroupsByOwner(ctx, types.GroupsOwnerPaginationOptions{
+			Owner:     owner,
+			PageToken: token,
+			Limit:     types.MaxListPageLimit,
+			Endpoint:  opts.Endpoint,
+			SPAddress: opts.SPAddress,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Groups, result.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var groups []*storageTypes.GroupInfo
+	for _, member := range groupMembers {
+		if member.Group != nil && !member.Removed {
+			groups = append(groups, member.Group)
+		}
+	}
+
+	referenced := make(map[uint64]bool)
+	for _, bucketName := range opts.Buckets {
+		for _, group := range groups {
+			if referenced[group.Id.Uint64()] {
+				continue
+			}
+			_, err = c.GetBucketPolicyOfGroup(ctx, bucketName, group.Id.Uint64())
+			if err == nil {
+				referenced[group.Id.Uint64()] = true
+			} else if !strings.Contains(err.Error(), storageTypes.ErrNoSuchPolicy.Error()) {
+				return nil, err
+			}
+		}
+		if opts.SkipObjectPolicies {
+			continue
+		}
+		err = c.forEachObjectPolicy(ctx, bucketName, "", opts.Endpoint, opts.SPAddress, func(_ *storageTypes.ObjectInfo, policy *types.PolicyMeta) {
+			if groupID, ok := policyGroupID(policy); ok {
+				referenced[groupID] = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &types.OrphanedGroupsResult{}
+	for _, group := range groups {
+		if !referenced[group.Id.Uint64()] {
+			result.Groups = append(result.Groups, group)
+		}
+	}
+	if !opts.Cleanup || len(result.Groups) == 0 {
+		return result, nil
+	}
+	operator := c.MustGetDefaultAccount().GetAddress()
+	if !strings.EqualFold(operator.String(), owner) {
+		return result, fmt.Errorf("the orphaned groups of %s can only be deleted by the owner, the default account is %s", owner, operator.String())
+	}
+	msgs := make([]sdk.Msg, 0, len(result.Groups))
+	for _, group := range result.Groups {
+		msgs = append(msgs, storageTypes.NewMsgDeleteGroup(operator, group.GroupName))
+	}
+	result.TxHashes, err = c.broadcastInBatches(ctx, msgs, opts.BatchSize, opts.TxOpts)
+	return result, err
+}
+
+// FindDanglingPolicies - Find the object policies of the bucket which reference the deleted groups or the deleted
+// objects, e.g. the object which has been re-created with the same name, and optionally delete the policies of the
+// deleted groups in batches.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - opts: The options to search and delete the policies.
+//
+// - ret1: The dangling policies and the hashes of the cleanup transactions.
+//
+// - ret2: Return error when the search or the cleanup failed, otherwise return nil.
+func (c *Client) FindDanglingPolicies(ctx context.Context, bucketName string, opts types.FindDanglingPoliciesOptions) (*types.DanglingPoliciesResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	result := &types.DanglingPoliciesResult{}
+	var groupPolicies []types.DanglingPolicy
+	err := c.forEachObjectPolicy(ctx, bucketName, opts.Prefix, opts.Endpoint, opts.SPAddress, func(object *storageTypes.ObjectInfo, policy *types.PolicyMeta) {
+		dangling := types.DanglingPolicy{BucketName: bucketName, ObjectName: object.ObjectName, Policy: policy}
+		if policy.ResourceId != object.Id.String() {
+			dangling.Reason = fmt.Sprintf("the policy belongs to the deleted object %s", policy.ResourceId)
+			result.Policies = append(result.Policies, dangling)
+			return
+		}
+		if _, ok := policyGroupID(policy); ok {
+			groupPolicies = append(groupPolicies, dangling)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deletedGroups, err := c.deletedGroups(ctx, groupPolicies, types.EndPointOptions{Endpoint: opts.Endpoint, SPAddress: opts.SPAddress})
+	if err != nil {
+		return nil, err
+	}
+	var msgs []sdk.Msg
+	for _, dangling := range groupPolicies {
+		groupID, _ := policyGroupID(dangling.Policy)
+		if !deletedGroups[groupID] {
+			continue
+		}
+		dangling.Reason = fmt.Sprintf("the group principal %d is deleted", groupID)
+		dangling.Removable = true
+		result.Policies = append(result.Policies, dangling)
+		if opts.Cleanup {
+			grn := gnfdTypes.NewObjectGRN(bucketName, dangling.ObjectName)
+			msgs = append(msgs, storageTypes.NewMsgDeletePolicy(c.MustGetDefaultAccount().GetAddress(), grn.String(),
+				permTypes.NewPrincipalWithGroupId(sdkmath.NewUint(groupID))))
+		}
+	}
+	if len(msgs) == 0 {
+		return result, nil
+	}
+	result.TxHashes, err = c.broadcastInBatches(ctx, msgs, opts.BatchSize, opts.TxOpts)
+	return result, err
+}
+
+// forEachObjectPolicy calls handle with each policy of the objects under the prefix of the bucket
+func (c *Client) forEachObjectPolicy(ctx context.Context, bucketName, prefix, endpoint, spAddress string,
+	handle func(object *storageTypes.ObjectInfo, policy *types.PolicyMeta),
+) error {
+	_, err := c.ListObjectsWithCursor(ctx, bucketName, types.ListObjectsWithCursorOptions{
+		Prefix:    prefix,
+		Endpoint:  endpoint,
+		SPAddress: spAddress,
+	}, func(result types.ListObjectsResult) error {
+		for _, object := range result.Objects {
+			if object.Removed || object.ObjectInfo == nil {
+				continue
+			}
+			policies, err := c.ListObjectPolicies(ctx, object.ObjectInfo.ObjectName, bucketName, uint32(permTypes.ACTION_TYPE_ALL),
+				types.ListObjectPoliciesOptions{Limit: types.MaxObjectPoliciesPerObject, Endpoint: endpoint, SPAddress: spAddress})
+			if err != nil {
+				return err
+			}
+			for _, policy := range policies.Policies {
+				handle(object.ObjectInfo, policy)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// deletedGroups returns the ids of the group principals of the policies which are deleted or missing
+func (c *Client) deletedGroups(ctx context.Context, policies []types.DanglingPolicy, opts types.EndPointOptions) (map[uint64]bool, error) {
+	seen := make(map[uint64]bool)
+	var groupIDs []uint64
+	for _, dangling := range policies {
+		groupID, _ := policyGroupID(dangling.Policy)
+		if !seen[groupID] {
+			seen[groupID] = true
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	deleted := make(map[uint64]bool)
+	for start := 0; start < len(groupIDs); start += groupIDsPerQuery {
+		end := start + groupIDsPerQuery
+		if end > len(groupIDs) {
+			end = len(groupIDs)
+		}
+		resp, err := c.ListGroupsByGroupID(ctx, groupIDs[start:end], opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, groupID := range groupIDs[start:end] {
+			if group, ok := resp.Groups[groupID]; !ok || group == nil || group.Removed {
+				deleted[groupID] = true
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// policyGroupID returns the id of the group principal of the policy, false is returned if the principal is an account
+func policyGroupID(policy *types.PolicyMeta) (uint64, bool) {
+	if policy == nil || policy.PrincipalType != int32(permTypes.PRINCIPAL_TYPE_GNFD_GROUP) {
+		return 0, false
+	}
+	groupID, err := strconv.ParseUint(policy.PrincipalValue, 10, 64)
+	return groupID, err == nil
+}
+
+// broadcastInBatches sends the msgs in the transactions of batchSize msgs, each transaction is waited for before the
+// next one is sent. The hashes of the committed transactions are returned even if a later one fails.
+func (c *Client) broadcastInBatches(ctx context.Context, msgs []sdk.Msg, batchSize int, txOpts *gnfdsdk.TxOption) ([]string, error) {
+	if batchSize <= 0 {
+		batchSize = types.DefaultCleanupBatchSize
+	}
+	if txOpts == nil {
+		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
+		txOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
+	}
+	var txHashes []string
+	for start := 0; start < len(msgs); start += batchSize {
+		end := start + batchSize
+		if end > len(msgs) {
+			end = len(msgs)
+		}
+		resp, err := c.BroadcastTx(ctx, msgs[start:end], txOpts)
+		if err != nil {
+			return txHashes, err
+		}
+		txnHash := resp.TxResponse.TxHash
+		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
+		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
+		cancel()
+		if err != nil {
+			return txHashes, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+		}
+		if txnResponse.TxResult.Code != 0 {
+			return txHashes, fmt.Errorf("the txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+		}
+		txHashes = append(txHashes, txnHash)
+	}
+	return txHashes, nil
+}
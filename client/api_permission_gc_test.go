@@ -0,0 +1,21 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+)
+
+func TestPolicyGroupID(t *testing.T) {
+	groupID, ok := policyGroupID(&types.PolicyMeta{PrincipalType: int32(permTypes.PRINCIPAL_TYPE_GNFD_GROUP), PrincipalValue: "42"})
+	require.True(t, ok)
+	require.Equal(t, uint64(42), groupID)
+
+	_, ok = policyGroupID(&types.PolicyMeta{PrincipalType: int32(permTypes.PRINCIPAL_TYPE_GNFD_ACCOUNT), PrincipalValue: "0x1"})
+	require.False(t, ok)
+	_, ok = policyGroupID(nil)
+	require.False(t, ok)
+}
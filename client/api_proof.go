@@ -0,0 +1,188 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// eventSealObject is the event type emitted by the seal object transaction
+const eventSealObject = "greenfield.storage.EventSealObject"
+
+// IStorageProofClient - Client APIs for generating and verifying the attestations that objects are stored on Greenfield.
+type IStorageProofClient interface {
+	GenerateStorageProof(ctx context.Context, bucketName, objectName string, opts types.GenerateStorageProofOptions) (*types.StorageProof, error)
+	VerifyStorageProof(ctx context.Context, proof *types.StorageProof) error
+}
+
+// GenerateStorageProof - Package the on-chain info of a sealed object and the signed block commit into a proof.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - opts: The options to set the seal transaction which the proof is anchored to.
+//
+// - ret1: The storage proof of the object.
+//
+// - ret2: Return error when the object is not sealed or the chain info can not be queried, otherwise return nil.
+func (c *Client) GenerateStorageProof(ctx context.Context, bucketName, objectName string, opts types.GenerateStorageProofOptions) (*types.StorageProof, error) {
+	objectDetail, err := c.HeadObject(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	if objectDetail.ObjectInfo.GetObjectStatus() != storageTypes.OBJECT_STATUS_SEALED {
+		return nil, fmt.Errorf("object %s is not sealed, status: %s", objectName, objectDetail.ObjectInfo.GetObjectStatus().String())
+	}
+
+	var height int64
+	if opts.SealTxHash != "" {
+		txResult, err := c.chainClient.Tx(ctx, opts.SealTxHash)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkSealTx(txResult.TxResult.Code, txResult.TxResult.Events, objectDetail.ObjectInfo); err != nil {
+			return nil, err
+		}
+		height = txResult.Height
+	} else {
+		status, err := c.chainClient.GetStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		height = status.SyncInfo.LatestBlockHeight
+	}
+
+	commit, err := c.chainClient.GetCommit(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.StorageProof{
+		ChainID:     commit.Header.ChainID,
+		BucketName:  bucketName,
+		ObjectName:  objectName,
+		ObjectInfo:  objectDetail.ObjectInfo,
+		SealTxHash:  opts.SealTxHash,
+		Height:      height,
+		Commit:      commit,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// VerifyStorageProof - Verify the storage proof against the chain which the client is connected to.
+//
+// It verifies the block commit is signed by the validators of the height, the seal transaction is included in the
+// block and seals the object, and the object is still stored with the same checksums.
+//
+// - ctx: Context variables for the current API call.
+//
+// - proof: The storage proof generated by GenerateStorageProof.
+//
+// - ret: Return error when the proof is invalid, otherwise return nil.
+func (c *Client) VerifyStorageProof(ctx context.Context, proof *types.StorageProof) error {
+	if proof == nil || proof.ObjectInfo == nil || proof.Commit == nil || proof.Commit.SignedHeader.Header == nil ||
+		proof.Commit.SignedHeader.Commit == nil {
+		return errors.New("the storage proof is incomplete")
+	}
+	header, commit := proof.Commit.SignedHeader.Header, proof.Commit.SignedHeader.Commit
+	if header.Height != proof.Height || commit.Height != proof.Height {
+		return fmt.Errorf("the commit height %d mismatches the proof height %d", header.Height, proof.Height)
+	}
+	if header.ChainID != proof.ChainID {
+		return fmt.Errorf("the commit chain id %s mismatches the proof chain id %s", header.ChainID, proof.ChainID)
+	}
+	if proof.ObjectInfo.BucketName != proof.BucketName || proof.ObjectInfo.ObjectName != proof.ObjectName {
+		return errors.New("the object info mismatches the bucket and object name of the proof")
+	}
+	if proof.ObjectInfo.GetObjectStatus() != storageTypes.OBJECT_STATUS_SEALED {
+		return errors.New("the object is not sealed in the proof")
+	}
+
+	// verify the commit is signed by the validators
+	status, err := c.chainClient.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status.NodeInfo.Network != proof.ChainID {
+		return fmt.Errorf("the proof chain id %s mismatches the connected chain %s", proof.ChainID, status.NodeInfo.Network)
+	}
+	if !bytes.Equal(header.Hash(), commit.BlockID.Hash) {
+		return errors.New("the header hash mismatches the commit block id")
+	}
+	validators, err := c.GetValidatorsByHeight(ctx, proof.Height)
+	if err != nil {
+		return err
+	}
+	validatorSet := bfttypes.NewValidatorSet(validators)
+	if !bytes.Equal(validatorSet.Hash(), header.ValidatorsHash) {
+		return errors.New("the validator set mismatches the header")
+	}
+	if err = validatorSet.VerifyCommitLight(proof.ChainID, commit.BlockID, proof.Height, commit); err != nil {
+		return fmt.Errorf("fail to verify the commit: %w", err)
+	}
+
+	// verify the seal transaction
+	if proof.SealTxHash != "" {
+		txResult, err := c.chainClient.Tx(ctx, proof.SealTxHash)
+		if err != nil {
+			return err
+		}
+		if txResult.Height != proof.Height {
+			return fmt.Errorf("the seal tx height %d mismatches the proof height %d", txResult.Height, proof.Height)
+		}
+		if err = checkSealTx(txResult.TxResult.Code, txResult.TxResult.Events, proof.ObjectInfo); err != nil {
+			return err
+		}
+	}
+
+	// verify the object is still stored with the same content
+	objectDetail, err := c.HeadObjectByID(ctx, proof.ObjectInfo.Id.String())
+	if err != nil {
+		return err
+	}
+	current := objectDetail.ObjectInfo
+	if current.GetObjectStatus() != storageTypes.OBJECT_STATUS_SEALED || len(current.Checksums) != len(proof.ObjectInfo.Checksums) {
+		return errors.New("the object stored on chain mismatches the proof")
+	}
+	for i := range current.Checksums {
+		if !bytes.Equal(current.Checksums[i], proof.ObjectInfo.Checksums[i]) {
+			return errors.New("the object checksums stored on chain mismatch the proof")
+		}
+	}
+	return nil
+}
+
+// checkSealTx checks the transaction succeeds and emits the seal event of the object in its bucket
+func checkSealTx(code uint32, events []abci.Event, objectInfo *storageTypes.ObjectInfo) error {
+	if code != 0 {
+		return fmt.Errorf("the seal tx has failed with response code: %d", code)
+	}
+	for _, event := range events {
+		if event.Type != eventSealObject {
+			continue
+		}
+		// the attributes of the typed events are json encoded
+		attributes := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attributes[attr.Key] = strings.Trim(attr.Value, `"`)
+		}
+		if attributes["object_id"] == objectInfo.Id.String() && attributes["bucket_name"] == objectInfo.BucketName &&
+			attributes["object_name"] == objectInfo.ObjectName {
+			return nil
+		}
+	}
+	return fmt.Errorf("the tx does not seal object %s of bucket %s, id: %s", objectInfo.ObjectName, objectInfo.BucketName,
+		objectInfo.Id.String())
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newTypedEvent returns the abci event emitted for the typed event.
+func newTypedEvent(t *testing.T, typedEvent *storageTypes.EventSealObject) abci.Event {
+	event, err := sdk.TypedEventToEvent(typedEvent)
+	require.NoError(t, err)
+	return abci.Event(event)
+}
+
+func TestCheckSealTx(t *testing.T) {
+	objectInfo := &storageTypes.ObjectInfo{BucketName: "bucket", ObjectName: "object", Id: sdkmath.NewUint(7)}
+	sealEvent := func(bucketName, objectName string, id uint64) abci.Event {
+		return newTypedEvent(t, &storageTypes.EventSealObject{
+			BucketName: bucketName,
+			ObjectName: objectName,
+			ObjectId:   sdkmath.NewUint(id),
+			Status:     storageTypes.OBJECT_STATUS_SEALED,
+		})
+	}
+	transferEvent := abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "object_id", Value: "7"}}}
+
+	testCases := []struct {
+		name        string
+		code        uint32
+		events      []abci.Event
+		errContains string
+	}{
+		{name: "matching seal", events: []abci.Event{transferEvent, sealEvent("bucket", "object", 7)}},
+		{name: "one of the batched seals", events: []abci.Event{sealEvent("bucket", "other", 6), sealEvent("bucket", "object", 7)}},
+		{name: "wrong object id", events: []abci.Event{sealEvent("bucket", "object", 8)}, errContains: "the tx does not seal object object of bucket bucket"},
+		{name: "wrong object name", events: []abci.Event{sealEvent("bucket", "other", 7)}, errContains: "the tx does not seal object object"},
+		{name: "wrong bucket", events: []abci.Event{sealEvent("other", "object", 7)}, errContains: "the tx does not seal object object"},
+		{name: "non-seal tx", events: []abci.Event{transferEvent}, errContains: "the tx does not seal object object"},
+		{name: "failed tx", code: 5, events: []abci.Event{sealEvent("bucket", "object", 7)}, errContains: "the seal tx has failed with response code: 5"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkSealTx(tc.code, tc.events, objectInfo)
+			if tc.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.errContains)
+		})
+	}
+}
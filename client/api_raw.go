@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IRawClient - Client APIs for sending the raw requests to the REST API of SP, pkg/sprpc builds on them.
+type IRawClient interface {
+	NewSPRequest(ctx context.Context, req types.SPRequest) (*http.Request, error)
+	SendSPRequest(ctx context.Context, req types.SPRequest) (*http.Response, error)
+}
+
+// NewSPRequest - Build and sign the http request to SP without sending it, e.g. to send it by another http client.
+//
+// - ctx: Context variables for the current API call.
+//
+// - req: The description of the request.
+//
+// - ret1: The signed http request, it should be sent before the signature expires.
+//
+// - ret2: Return error when the SP endpoint can not be resolved or the request can not be signed, otherwise return nil.
+func (c *Client) NewSPRequest(ctx context.Context, req types.SPRequest) (*http.Request, error) {
+	meta, opt, endpoint, err := c.resolveSPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.newRequest(ctx, opt.method, meta, opt.body, opt.txnHash, opt.adminInfo, endpoint)
+}
+
+// SendSPRequest - Build, sign and send the http request to SP, the transient failures are retried by the retry policy
+// of the client.
+//
+// - ctx: Context variables for the current API call.
+//
+// - req: The description of the request.
+//
+// - ret1: The SP response, the caller should close the body.
+//
+// - ret2: Return error when the request fails, the error responses of SP are returned as *types.SPError,
+// otherwise return nil.
+func (c *Client) SendSPRequest(ctx context.Context, req types.SPRequest) (*http.Response, error) {
+	meta, opt, endpoint, err := c.resolveSPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendReq(ctx, meta, opt, endpoint)
+}
+
+// resolveSPRequest converts the request description into the metadata and the options of sendReq, and resolves the
+// SP endpoint to send it to.
+func (c *Client) resolveSPRequest(ctx context.Context, req types.SPRequest) (requestMeta, *sendOptions, *url.URL, error) {
+	var (
+		endpoint *url.URL
+		err      error
+	)
+	if req.EndPoint == nil && req.BucketName != "" {
+		endpoint, err = c.getSPUrlByBucket(ctx, req.BucketName)
+	} else {
+		endpoint, err = c.getEndpointByOpt(req.EndPoint)
+	}
+	if err != nil {
+		log.Error().Msg(fmt.Sprintf("resolve SP endpoint for the request %s fail, err: %s", req.Path, err))
+		return requestMeta{}, nil, nil, err
+	}
+
+	meta := requestMeta{
+		bucketName:    req.BucketName,
+		objectName:    req.ObjectName,
+		urlRelPath:    req.Path,
+		urlValues:     req.Query,
+		rangeInfo:     req.Range,
+		contentType:   req.ContentType,
+		contentLength: req.ContentLength,
+		contentSHA256: req.ContentSHA256,
+		userAddress:   req.UserAddress,
+		header:        req.Header,
+	}
+	opt := &sendOptions{
+		method:           req.Method,
+		disableCloseBody: true,
+	}
+	if opt.method == "" {
+		opt.method = http.MethodGet
+	}
+	// a typed nil io.Reader would be taken as a body
+	if req.Body != nil {
+		opt.body = req.Body
+	}
+	return meta, opt, endpoint, nil
+}
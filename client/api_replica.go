@@ -0,0 +1,170 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IReplicaClient - Client APIs for verifying the replicas of buckets.
+type IReplicaClient interface {
+	VerifyReplica(ctx context.Context, srcBucket, dstBucket string, opts types.VerifyReplicaOptions) (*types.ReplicaReport, error)
+}
+
+// VerifyReplica - Verify the destination bucket replicates the source bucket, e.g. for signing off a migration between
+// buckets or SPs.
+//
+// The object inventories of both buckets are compared by the names, the payload sizes and the on-chain checksums,
+// and opts.SampleCount of the matched objects are downloaded from both buckets to compare the contents. Only the
+// sealed objects of the source bucket are expected in the destination bucket.
+//
+// - ctx: Context variables for the current API call.
+//
+// - srcBucket: The name of the source bucket.
+//
+// - dstBucket: The name of the destination bucket.
+//
+// - opts: The options to limit the verified objects and to sample the contents.
+//
+// - ret1: The report of the differences, it is consistent if no difference is found.
+//
+// - ret2: Return error when the objects can not be listed, otherwise return nil.
+func (c *Client) VerifyReplica(ctx context.Context, srcBucket, dstBucket string, opts types.VerifyReplicaOptions) (*types.ReplicaReport, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	srcObjects, err := c.listObjectInfos(ctx, srcBucket, opts.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("fail to list the objects of bucket %s: %w", srcBucket, err)
+	}
+	dstObjects, err := c.listObjectInfos(ctx, dstBucket, opts.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("fail to list the objects of bucket %s: %w", dstBucket, err)
+	}
+
+	report := &types.ReplicaReport{
+		SourceBucket:       srcBucket,
+		DestinationBucket:  dstBucket,
+		DestinationObjects: len(dstObjects),
+		Diffs:              make([]types.ReplicaDiff, 0),
+	}
+	matched := make([]string, 0)
+	for name, src := range srcObjects {
+		if src.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+			continue
+		}
+		report.SourceObjects++
+		dst, ok := dstObjects[name]
+		switch {
+		case !ok:
+			report.Diffs = append(report.Diffs, types.ReplicaDiff{ObjectName: name, Kind: types.ReplicaMissing})
+		case dst.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED:
+			report.Diffs = append(report.Diffs, types.ReplicaDiff{ObjectName: name, Kind: types.ReplicaNotSealed,
+				Detail: dst.ObjectStatus.String()})
+		case src.PayloadSize != dst.PayloadSize:
+			report.Diffs = append(report.Diffs, types.ReplicaDiff{ObjectName: name, Kind: types.ReplicaSizeMismatch,
+				Detail: fmt.Sprintf("source %d bytes, destination %d bytes", src.PayloadSize, dst.PayloadSize)})
+		case !equalChecksums(src.Checksums, dst.Checksums):
+			report.Diffs = append(report.Diffs, types.ReplicaDiff{ObjectName: name, Kind: types.ReplicaChecksumMismatch})
+		default:
+			matched = append(matched, name)
+		}
+	}
+	for name := range dstObjects {
+		if _, ok := srcObjects[name]; !ok {
+			report.Diffs = append(report.Diffs, types.ReplicaDiff{ObjectName: name, Kind: types.ReplicaExtra})
+		}
+	}
+	report.Matched = len(matched)
+
+	sort.Strings(matched)
+	rand.Shuffle(len(matched), func(i, j int) { matched[i], matched[j] = matched[j], matched[i] })
+	for _, name := range matched {
+		if report.Sampled >= opts.SampleCount {
+			break
+		}
+		report.Sampled++
+		if diff := c.compareObjectContents(ctx, srcBucket, dstBucket, name); diff != nil {
+			report.Diffs = append(report.Diffs, *diff)
+		}
+	}
+
+	sort.Slice(report.Diffs, func(i, j int) bool {
+		if report.Diffs[i].ObjectName != report.Diffs[j].ObjectName {
+			return report.Diffs[i].ObjectName < report.Diffs[j].ObjectName
+		}
+		return report.Diffs[i].Kind < report.Diffs[j].Kind
+	})
+	return report, nil
+}
+
+// listObjectInfos lists the object infos of the bucket by the object names, the removed objects are skipped.
+func (c *Client) listObjectInfos(ctx context.Context, bucketName, prefix string) (map[string]*storageTypes.ObjectInfo, error) {
+	objects := make(map[string]*storageTypes.ObjectInfo)
+	_, err := c.ListObjectsWithCursor(ctx, bucketName, types.ListObjectsWithCursorOptions{Prefix: prefix},
+		func(result types.ListObjectsResult) error {
+			for _, object := range result.Objects {
+				if object.Removed || object.ObjectInfo == nil {
+					continue
+				}
+				objects[object.ObjectInfo.ObjectName] = object.ObjectInfo
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// compareObjectContents downloads the object from both buckets and compares the digests of the contents, it returns
+// nil if they are the same.
+func (c *Client) compareObjectContents(ctx context.Context, srcBucket, dstBucket, objectName string) *types.ReplicaDiff {
+	srcDigest, err := c.digestObject(ctx, srcBucket, objectName)
+	if err != nil {
+		return &types.ReplicaDiff{ObjectName: objectName, Kind: types.ReplicaSampleFailed,
+			Detail: fmt.Sprintf("fail to download from bucket %s: %s", srcBucket, err.Error())}
+	}
+	dstDigest, err := c.digestObject(ctx, dstBucket, objectName)
+	if err != nil {
+		return &types.ReplicaDiff{ObjectName: objectName, Kind: types.ReplicaSampleFailed,
+			Detail: fmt.Sprintf("fail to download from bucket %s: %s", dstBucket, err.Error())}
+	}
+	if !bytes.Equal(srcDigest, dstDigest) {
+		return &types.ReplicaDiff{ObjectName: objectName, Kind: types.ReplicaContentMismatch}
+	}
+	return nil
+}
+
+func (c *Client) digestObject(ctx context.Context, bucketName, objectName string) ([]byte, error) {
+	body, _, err := c.GetObject(ctx, bucketName, objectName, types.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, body); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+func equalChecksums(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,14 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualChecksums(t *testing.T) {
+	require.True(t, equalChecksums(nil, [][]byte{}))
+	require.True(t, equalChecksums([][]byte{{1, 2}, {3}}, [][]byte{{1, 2}, {3}}))
+	require.False(t, equalChecksums([][]byte{{1, 2}, {3}}, [][]byte{{1, 2}}))
+	require.False(t, equalChecksums([][]byte{{1, 2}, {3}}, [][]byte{{1, 2}, {4}}))
+}
@@ -46,7 +46,7 @@ func (c *Client) GetStoragePrice(ctx context.Context, spAddr string) (*spTypes.S
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.chainClient.QuerySpStoragePrice(ctx, &spTypes.QuerySpStoragePriceRequest{
+	resp, err := c.chainClient.QuerySpStoragePrice(c.queryContext(ctx), &spTypes.QuerySpStoragePriceRequest{
 		SpAddr: spAcc.String(),
 	})
 	if err != nil {
@@ -63,7 +63,7 @@ func (c *Client) GetStoragePrice(ctx context.Context, spAddr string) (*spTypes.S
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetGlobalSpStorePrice(ctx context.Context) (*spTypes.GlobalSpStorePrice, error) {
-	resp, err := c.chainClient.QueryGlobalSpStorePriceByTime(ctx, &spTypes.QueryGlobalSpStorePriceByTimeRequest{
+	resp, err := c.chainClient.QueryGlobalSpStorePriceByTime(c.queryContext(ctx), &spTypes.QueryGlobalSpStorePriceByTimeRequest{
 		Timestamp: 0,
 	})
 	if err != nil {
@@ -83,7 +83,7 @@ func (c *Client) GetGlobalSpStorePrice(ctx context.Context) (*spTypes.GlobalSpSt
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) ListStorageProviders(ctx context.Context, isInService bool) ([]spTypes.StorageProvider, error) {
 	request := &spTypes.QueryStorageProvidersRequest{}
-	gnfdRep, err := c.chainClient.StorageProviders(ctx, request)
+	gnfdRep, err := c.chainClient.StorageProviders(c.queryContext(ctx), request)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +114,7 @@ func (c *Client) GetStorageProviderInfo(ctx context.Context, spAddr sdk.AccAddre
 		OperatorAddress: spAddr.String(),
 	}
 
-	gnfdRep, err := c.chainClient.StorageProviderByOperatorAddress(ctx, request)
+	gnfdRep, err := c.chainClient.StorageProviderByOperatorAddress(c.queryContext(ctx), request)
 	if err != nil {
 		return nil, err
 	}
@@ -123,11 +123,25 @@ func (c *Client) GetStorageProviderInfo(ctx context.Context, spAddr sdk.AccAddre
 }
 
 func (c *Client) refreshStorageProviders(ctx context.Context) error {
-	gnfdRep, err := c.chainClient.StorageProviders(ctx, &spTypes.QueryStorageProvidersRequest{Pagination: &query.PageRequest{Limit: math2.MaxUint64}})
+	gnfdRep, err := c.chainClient.StorageProviders(c.queryContext(ctx), &spTypes.QueryStorageProvidersRequest{Pagination: &query.PageRequest{Limit: math2.MaxUint64}})
 	if err != nil {
 		return err
 	}
-	for _, spInfo := range gnfdRep.Sps {
+	return c.updateStorageProviders(gnfdRep.Sps)
+}
+
+// updateStorageProviders merges the storage providers queried from chain into a copy of the current endpoints and
+// publishes the copy, the concurrent updates are serialized so that none of them is lost.
+func (c *Client) updateStorageProviders(spInfos []*spTypes.StorageProvider) error {
+	c.state.spRefreshMu.Lock()
+	defer c.state.spRefreshMu.Unlock()
+
+	current := c.getStorageProviders()
+	storageProviders := make(map[uint32]*types.StorageProvider, len(current)+len(spInfos))
+	for id, sp := range current {
+		storageProviders[id] = sp
+	}
+	for _, spInfo := range spInfos {
 		var useHttps bool
 		if strings.Contains(spInfo.Endpoint, "https") {
 			useHttps = true
@@ -149,8 +163,9 @@ func (c *Client) refreshStorageProviders(ctx context.Context) error {
 			Description:     spInfo.Description,
 			BlsKey:          spInfo.BlsKey,
 		}
-		c.storageProviders[sp.Id] = sp
+		storageProviders[sp.Id] = sp
 	}
+	c.setStorageProviders(storageProviders)
 	return nil
 }
 
@@ -0,0 +1,365 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// ISyncClient - Client APIs for synchronizing the local directories with the objects of buckets.
+type ISyncClient interface {
+	SyncDirUpload(ctx context.Context, localDir, bucketName, prefix string, opts types.SyncDirOptions) (*types.SyncResult, error)
+	SyncDirDownload(ctx context.Context, bucketName, prefix, localDir string, opts types.SyncDirOptions) (*types.SyncResult, error)
+}
+
+// redundancyParams is the erasure coding params to compute the checksums of the local files.
+type redundancyParams struct {
+	dataBlocks   int
+	parityBlocks int
+	segmentSize  int64
+}
+
+// SyncDirUpload - Upload the files of the local directory tree to the objects under the prefix, only the new and the
+// changed files are uploaded.
+//
+// The object of a file is named by the prefix followed by the slash separated path of the file relative to localDir,
+// so the prefix should end with "/" to act as a directory. A file is unchanged if the object is sealed with the same
+// payload size and checksums, the new files are created on chain by opts.CreateOptions and the changed ones are
+// updated before uploading. Only the regular files are uploaded, the symbolic links are not followed.
+//
+// - ctx: Context variables for the current API call.
+//
+// - localDir: The local directory to upload.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - prefix: The prefix of the object names.
+//
+// - opts: The options to create and upload the objects.
+//
+// - ret1: The result of the sync, the failed files are reported in Failed.
+//
+// - ret2: Return error when the directory or the objects can not be listed, or some files fail to upload, otherwise
+// return nil.
+func (c *Client) SyncDirUpload(ctx context.Context, localDir, bucketName, prefix string, opts types.SyncDirOptions) (*types.SyncResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	remote, err := c.listObjectInfos(ctx, bucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	err = filepath.WalkDir(localDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		files[prefix+filepath.ToSlash(rel)] = path
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	params, err := c.getRedundancyParams()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	return c.runSyncTasks(ctx, names, opts.Concurrency, newSyncLimiter(opts), func(ctx context.Context, objectName string) (bool, error) {
+		return c.uploadSyncFile(ctx, bucketName, objectName, files[objectName], remote[objectName], params, opts)
+	})
+}
+
+// SyncDirDownload - Download the sealed objects under the prefix to the local directory tree, only the objects which
+// are missing or changed locally are downloaded.
+//
+// The file of an object is located by the object name with the prefix trimmed, the objects whose names end with "/"
+// are skipped. A file is unchanged if it has the same size and checksums as the object. The objects are downloaded to
+// the temp files and renamed to the destination, so the existing files are not truncated if the download fails.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - prefix: The prefix of the object names.
+//
+// - localDir: The local directory to download to, it is created if it does not exist.
+//
+// - opts: The options to download the objects.
+//
+// - ret1: The result of the sync, the failed objects are reported in Failed.
+//
+// - ret2: Return error when the objects can not be listed, or some objects fail to download, otherwise return nil.
+func (c *Client) SyncDirDownload(ctx context.Context, bucketName, prefix, localDir string, opts types.SyncDirOptions) (*types.SyncResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	remote, err := c.listObjectInfos(ctx, bucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+	params, err := c.getRedundancyParams()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(remote))
+	for name, objectInfo := range remote {
+		if objectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED && !strings.HasSuffix(name, "/") &&
+			strings.TrimPrefix(name, prefix) != "" {
+			names = append(names, name)
+		}
+	}
+	return c.runSyncTasks(ctx, names, opts.Concurrency, newSyncLimiter(opts), func(ctx context.Context, objectName string) (bool, error) {
+		filePath, err := syncFilePath(localDir, strings.TrimPrefix(objectName, prefix))
+		if err != nil {
+			return false, err
+		}
+		return c.downloadSyncObject(ctx, bucketName, objectName, filePath, remote[objectName], params, opts)
+	})
+}
+
+// uploadSyncFile uploads the file to the object if they differ, it returns whether the file is transferred.
+func (c *Client) uploadSyncFile(ctx context.Context, bucketName, objectName, filePath string, objectInfo *storageTypes.ObjectInfo,
+	params redundancyParams, opts types.SyncDirOptions,
+) (bool, error) {
+	file, err := c.fileSystem.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	size := stat.Size()
+
+	sameContent := false
+	if objectInfo != nil && uint64(size) == objectInfo.PayloadSize {
+		checksums, err := params.computeChecksums(ctx, file, opts.CreateOptions.IsSerialComputeMode)
+		if err != nil {
+			return false, err
+		}
+		sameContent = equalChecksums(checksums, objectInfo.Checksums)
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+	}
+	sealed := objectInfo != nil && objectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED
+	if sealed && sameContent {
+		return false, nil
+	}
+	if objectInfo != nil && !sealed && !sameContent {
+		return false, fmt.Errorf("object %s is %s with a different content", objectName, objectInfo.ObjectStatus.String())
+	}
+	if opts.DryRun {
+		return true, nil
+	}
+
+	switch {
+	case objectInfo == nil:
+		_, err = c.CreateObject(ctx, bucketName, objectName, file, opts.CreateOptions)
+	case sealed:
+		_, err = c.UpdateObjectContent(ctx, bucketName, objectName, file, types.UpdateObjectOptions{
+			TxOpts:              opts.CreateOptions.TxOpts,
+			ContentType:         opts.CreateOptions.ContentType,
+			IsSerialComputeMode: opts.CreateOptions.IsSerialComputeMode,
+		})
+	}
+	if err != nil {
+		return false, err
+	}
+	// the empty objects are sealed once created
+	if size == 0 {
+		return true, nil
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	if err = c.PutObject(ctx, bucketName, objectName, size, file, opts.PutOptions); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// downloadSyncObject downloads the object to the file if they differ, it returns whether the object is transferred.
+func (c *Client) downloadSyncObject(ctx context.Context, bucketName, objectName, filePath string, objectInfo *storageTypes.ObjectInfo,
+	params redundancyParams, opts types.SyncDirOptions,
+) (bool, error) {
+	if stat, err := c.fileSystem.Stat(filePath); err == nil && stat.Size() == int64(objectInfo.PayloadSize) {
+		same, err := c.sameFileChecksums(ctx, filePath, objectInfo.Checksums, params)
+		if err != nil {
+			return false, err
+		}
+		if same {
+			return false, nil
+		}
+	}
+	if opts.DryRun {
+		return true, nil
+	}
+
+	if maker, ok := c.fileSystem.(types.DirMaker); ok {
+		if err := maker.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			return false, err
+		}
+	}
+	tempPath := filePath + types.TempFileSuffix
+	if err := c.fileSystem.Remove(tempPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if err := c.FGetObject(ctx, bucketName, objectName, tempPath, opts.GetOptions); err != nil {
+		c.fileSystem.Remove(tempPath)
+		return false, err
+	}
+	if err := c.fileSystem.Rename(tempPath, filePath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Client) sameFileChecksums(ctx context.Context, filePath string, checksums [][]byte, params redundancyParams) (bool, error) {
+	file, err := c.fileSystem.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	local, err := params.computeChecksums(ctx, file, false)
+	if err != nil {
+		return false, err
+	}
+	return equalChecksums(local, checksums), nil
+}
+
+// newSyncLimiter returns the limiter of the adaptive concurrency of the sync, it returns nil if it is not configured.
+func newSyncLimiter(opts types.SyncDirOptions) *aimdLimiter {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = types.DefaultSyncConcurrency
+	}
+	limiter := newAIMDLimiter(opts.AdaptiveConcurrency, concurrency)
+	if limiter != nil {
+		// the latencies of the files differ by their sizes, only the failures lower the concurrency
+		limiter.targetLatency = 0
+	}
+	return limiter
+}
+
+// runSyncTasks runs task on the objects with bounded concurrency and collects the results, the remaining objects are
+// skipped once ctx is done. The concurrency is adjusted by limiter if it is not nil.
+func (c *Client) runSyncTasks(ctx context.Context, objectNames []string, concurrency int, limiter *aimdLimiter,
+	task func(ctx context.Context, objectName string) (bool, error),
+) (*types.SyncResult, error) {
+	if concurrency <= 0 {
+		concurrency = types.DefaultSyncConcurrency
+	}
+	concurrency = limiter.workers(concurrency)
+	result := &types.SyncResult{
+		Transferred: make([]string, 0),
+		Unchanged:   make([]string, 0),
+		Failed:      make(map[string]error),
+	}
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	tasks := make(chan string)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for objectName := range tasks {
+				release, err := limiter.acquire(ctx)
+				if err != nil {
+					mu.Lock()
+					result.Failed[objectName] = err
+					mu.Unlock()
+					continue
+				}
+				transferred, err := task(ctx, objectName)
+				release(err)
+				mu.Lock()
+				switch {
+				case err != nil:
+					result.Failed[objectName] = err
+				case transferred:
+					result.Transferred = append(result.Transferred, objectName)
+				default:
+					result.Unchanged = append(result.Unchanged, objectName)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	sort.Strings(objectNames)
+feedTasks:
+	for _, objectName := range objectNames {
+		select {
+		case tasks <- objectName:
+		case <-ctx.Done():
+			break feedTasks
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	sort.Strings(result.Transferred)
+	sort.Strings(result.Unchanged)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if len(result.Failed) > 0 {
+		errs := make([]error, 0, len(result.Failed))
+		for objectName, err := range result.Failed {
+			errs = append(errs, fmt.Errorf("%s: %w", objectName, err))
+		}
+		return result, fmt.Errorf("fail to sync %d objects: %w", len(result.Failed), errors.Join(errs...))
+	}
+	return result, nil
+}
+
+// syncFilePath locates the file of the object by the relative path in the object name, it rejects the paths escaping
+// the directory, e.g. the ones containing "..".
+func syncFilePath(localDir, relPath string) (string, error) {
+	filePath := filepath.Join(localDir, filepath.FromSlash(relPath))
+	rel, err := filepath.Rel(localDir, filePath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the object path %s escapes the directory %s", relPath, localDir)
+	}
+	return filePath, nil
+}
+
+func (c *Client) getRedundancyParams() (redundancyParams, error) {
+	dataBlocks, parityBlocks, segmentSize, err := c.GetRedundancyParams()
+	if err != nil {
+		return redundancyParams{}, err
+	}
+	return redundancyParams{dataBlocks: int(dataBlocks), parityBlocks: int(parityBlocks), segmentSize: int64(segmentSize)}, nil
+}
+
+func (p redundancyParams) computeChecksums(ctx context.Context, reader io.Reader, isSerial bool) ([][]byte, error) {
+	checksums, _, _, err := computeIntegrityHash(ctx, reader, p.segmentSize, p.dataBlocks, p.parityBlocks, isSerial)
+	return checksums, err
+}
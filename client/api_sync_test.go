@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestSyncFilePath(t *testing.T) {
+	dir := filepath.Join("data", "backup")
+	filePath, err := syncFilePath(dir, "a/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "a", "b.txt"), filePath)
+
+	for _, rel := range []string{"../a", "a/../../b", "..", "a/.."} {
+		_, err = syncFilePath(dir, rel)
+		require.Error(t, err, rel)
+	}
+}
+
+func TestRunSyncTasks(t *testing.T) {
+	c := newTestClient(t)
+	names := []string{"c", "a", "b", "d"}
+	result, err := c.runSyncTasks(context.Background(), names, 2, nil, func(_ context.Context, objectName string) (bool, error) {
+		switch objectName {
+		case "a", "c":
+			return true, nil
+		case "d":
+			return false, errors.New("broken")
+		}
+		return false, nil
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"a", "c"}, result.Transferred)
+	require.Equal(t, []string{"b"}, result.Unchanged)
+	require.Len(t, result.Failed, 1)
+	require.EqualError(t, result.Failed["d"], "broken")
+
+	result, err = c.runSyncTasks(context.Background(), names, 0, nil, func(context.Context, string) (bool, error) {
+		return false, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Unchanged, 4)
+}
+
+// dirRecordingFileSystem is the in-memory file system recording the directories created.
+type dirRecordingFileSystem struct {
+	*types.MemFileSystem
+	dirs []string
+}
+
+func (f *dirRecordingFileSystem) MkdirAll(path string, _ os.FileMode) error {
+	f.dirs = append(f.dirs, path)
+	return nil
+}
+
+func TestDownloadSyncObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	filePath := filepath.Join("backup", "a", "b.txt")
+	objectInfo := &storageTypes.ObjectInfo{BucketName: "bucket", ObjectName: "a/b.txt", PayloadSize: 7}
+	for _, fileSystem := range []types.FileSystem{&dirRecordingFileSystem{MemFileSystem: types.NewMemFileSystem()}, types.NewMemFileSystem()} {
+		c := newTestClient(t, withTestServer(server), func(c *Client) {
+			c.fileSystem = fileSystem
+			c.forceToUseSpecifiedSpEndpointForDownloadOnly = endpoint
+		})
+		transferred, err := c.downloadSyncObject(context.Background(), "bucket", "a/b.txt", filePath, objectInfo,
+			redundancyParams{}, types.SyncDirOptions{})
+		require.NoError(t, err)
+		require.True(t, transferred)
+
+		// the parent directory is created by the file system supporting the directories
+		if recorder, ok := fileSystem.(*dirRecordingFileSystem); ok {
+			require.Equal(t, []string{filepath.Join("backup", "a")}, recorder.dirs)
+		}
+		file, err := fileSystem.Open(filePath)
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.Equal(t, "content", string(content))
+		_, err = fileSystem.Stat(filePath + types.TempFileSuffix)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+}
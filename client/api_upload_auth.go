@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	httplib "github.com/bnb-chain/greenfield-common/go/http"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// IUploadAuthClient - Client APIs for coordinating the uploads whose requests are signed outside the client,
+// e.g. by the wallet of a browser user, while the payload is sent by the server.
+type IUploadAuthClient interface {
+	PrepareObjectUpload(ctx context.Context, bucketName, objectName string, objectSize int64, opts types.PutObjectOptions) ([]*types.UnsignedUploadRequest, error)
+	UploadWithSignature(ctx context.Context, request *types.UnsignedUploadRequest, signature []byte, reader io.Reader) error
+}
+
+// PrepareObjectUpload - Construct the unsigned requests of uploading an object.
+//
+// The object is uploaded in a single PUT request if its size does not exceed opts.PartSize or opts.DisableResumable
+// is set, otherwise it is split into parts which are uploaded in order by the resumable upload requests. The
+// UnsignedMsg of each request should be signed by the uploader, then the requests are sent by UploadWithSignature.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object, it should have been created on chain.
+//
+// - objectSize: The size of the object.
+//
+// - opts: The options of uploading the object, the same as PutObject.
+//
+// - ret1: The unsigned requests in the uploading order.
+//
+// - ret2: Return error when the requests can not be constructed, otherwise return nil.
+func (c *Client) PrepareObjectUpload(ctx context.Context, bucketName, objectName string, objectSize int64,
+	opts types.PutObjectOptions,
+) ([]*types.UnsignedUploadRequest, error) {
+	if objectSize <= 0 {
+		return nil, errors.New("object size should be more than 0")
+	}
+	params, err := c.GetParams()
+	if err != nil {
+		return nil, err
+	}
+	if opts.PartSize, err = c.resolvePartSize(opts.PartSize, params); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
+	if err != nil {
+		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		return nil, err
+	}
+
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = types.ContentDefault
+	}
+	newUploadValues := func() url.Values {
+		urlValues := make(url.Values)
+		if opts.Delegated {
+			urlValues.Set("delegate", "")
+			urlValues.Set("is_update", strconv.FormatBool(opts.IsUpdate))
+			urlValues.Set("payload_size", strconv.FormatInt(objectSize, 10))
+			if !opts.IsUpdate {
+				urlValues.Set("visibility", strconv.FormatInt(int64(opts.Visibility), 10))
+			}
+		}
+		return urlValues
+	}
+
+	if objectSize <= int64(opts.PartSize) || opts.DisableResumable {
+		reqMeta := requestMeta{
+			bucketName:    bucketName,
+			objectName:    objectName,
+			contentSHA256: types.EmptyStringSHA256,
+			contentLength: objectSize,
+			contentType:   contentType,
+			urlValues:     newUploadValues(),
+		}
+		request, err := c.newUnsignedUploadRequest(ctx, http.MethodPut, reqMeta, opts.TxnHash, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		request.Complete = true
+		return []*types.UnsignedUploadRequest{request}, nil
+	}
+
+	totalPartsCount, partSize, lastPartSize, err := c.SplitPartInfo(objectSize, opts.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*types.UnsignedUploadRequest, 0, totalPartsCount)
+	for partNumber := 1; partNumber <= totalPartsCount; partNumber++ {
+		offset := int64(partNumber-1) * partSize
+		length := partSize
+		complete := partNumber == totalPartsCount
+		if complete {
+			length = lastPartSize
+		}
+		urlValues := newUploadValues()
+		urlValues.Set("offset", strconv.FormatInt(offset, 10))
+		urlValues.Set("complete", strconv.FormatBool(complete))
+		reqMeta := requestMeta{
+			bucketName:    bucketName,
+			objectName:    objectName,
+			contentLength: length,
+			contentType:   contentType,
+			urlValues:     urlValues,
+		}
+		request, err := c.newUnsignedUploadRequest(ctx, http.MethodPost, reqMeta, opts.TxnHash, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		request.Offset = offset
+		request.Complete = complete
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// UploadWithSignature - Send the upload request constructed by PrepareObjectUpload with the signature of the uploader.
+//
+// - ctx: Context variables for the current API call.
+//
+// - request: The request returned by PrepareObjectUpload.
+//
+// - signature: The 65 bytes ECDSA signature of request.UnsignedMsg signed by the uploader.
+//
+// - reader: The reader of the uploaded data, it should provide exactly request.ContentLength bytes.
+//
+// - ret: Return error when the upload failed, otherwise return nil.
+func (c *Client) UploadWithSignature(ctx context.Context, request *types.UnsignedUploadRequest, signature []byte,
+	reader io.Reader,
+) error {
+	if request == nil {
+		return errors.New("the upload request should not be nil")
+	}
+	if len(signature) != 65 {
+		return fmt.Errorf("invalid signature length %d, expect 65", len(signature))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, io.NopCloser(reader))
+	if err != nil {
+		return err
+	}
+	req.Header = request.Header.Clone()
+	req.Host = request.Host
+	req.ContentLength = request.ContentLength
+	req.Header.Set(types.HTTPHeaderAuthorization, strings.Join([]string{
+		httplib.Gnfd1Ecdsa,
+		"Signature=" + hex.EncodeToString(signature),
+	}, ", "))
+
+	_, err = c.doAPI(ctx, req, requestMeta{bucketName: request.BucketName, objectName: request.ObjectName}, true)
+	return err
+}
+
+// newUnsignedUploadRequest constructs the upload request and derives the message to be signed from it
+func (c *Client) newUnsignedUploadRequest(ctx context.Context, method string, meta requestMeta, txnHash string,
+	endpoint *url.URL,
+) (*types.UnsignedUploadRequest, error) {
+	req, err := c.buildRequest(ctx, method, meta, nil, txnHash, AdminAPIInfo{}, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UnsignedUploadRequest{
+		BucketName:       meta.bucketName,
+		ObjectName:       meta.objectName,
+		Method:           req.Method,
+		URL:              req.URL.String(),
+		Host:             req.Host,
+		Header:           req.Header.Clone(),
+		ContentLength:    req.ContentLength,
+		UnsignedMsg:      httplib.GetMsgToSignInGNFD1Auth(req),
+		CanonicalRequest: httplib.GetCanonicalRequest(req),
+	}, nil
+}
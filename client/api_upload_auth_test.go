@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
+	httplib "github.com/bnb-chain/greenfield-common/go/http"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// signedBy reports whether the request is signed by signer in the GNFD1-ECDSA way, as SP verifies it.
+func signedBy(r *http.Request, signer sdk.AccAddress) bool {
+	signature, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(types.HTTPHeaderAuthorization),
+		httplib.Gnfd1Ecdsa+", Signature="))
+	if err != nil {
+		return false
+	}
+	addr, _, err := hashlib.RecoverAddr(httplib.GetMsgToSignInGNFD1Auth(r), signature)
+	return err == nil && addr.Equals(signer)
+}
+
+func TestPrepareObjectUpload(t *testing.T) {
+	// the part size is 2048
+	testCases := []struct {
+		name       string
+		size       int64
+		opts       types.PutObjectOptions
+		method     string
+		partsSizes []int64
+	}{
+		{"one part", 2048, types.PutObjectOptions{}, http.MethodPut, []int64{2048}},
+		{"exact multiple of the part size", 4096, types.PutObjectOptions{}, http.MethodPost, []int64{2048, 2048}},
+		{"partial last part", 5000, types.PutObjectOptions{}, http.MethodPost, []int64{2048, 2048, 904}},
+		{"resumable upload disabled", 5000, types.PutObjectOptions{DisableResumable: true}, http.MethodPut, []int64{5000}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newUploadTestClient(t, 0, &uploadTestSP{uploadStatus: http.StatusOK})
+			requests, err := c.PrepareObjectUpload(context.Background(), "bucket", "object", tc.size, tc.opts)
+			require.NoError(t, err)
+			require.Len(t, requests, len(tc.partsSizes))
+			var offset int64
+			for i, request := range requests {
+				require.Equal(t, tc.method, request.Method)
+				require.Equal(t, tc.partsSizes[i], request.ContentLength)
+				require.Equal(t, offset, request.Offset)
+				require.Equal(t, i == len(requests)-1, request.Complete)
+				require.Len(t, request.UnsignedMsg, 32)
+				if tc.method == http.MethodPost {
+					reqURL, err := url.Parse(request.URL)
+					require.NoError(t, err)
+					require.Equal(t, strconv.FormatInt(offset, 10), reqURL.Query().Get("offset"))
+					require.Equal(t, strconv.FormatBool(request.Complete), reqURL.Query().Get("complete"))
+				}
+				offset += request.ContentLength
+			}
+			require.Equal(t, tc.size, offset)
+		})
+	}
+
+	// nothing to upload for the empty payload, the empty objects are sealed once created
+	c := newUploadTestClient(t, 0, &uploadTestSP{uploadStatus: http.StatusOK})
+	for _, size := range []int64{0, -1} {
+		_, err := c.PrepareObjectUpload(context.Background(), "bucket", "object", size, types.PutObjectOptions{})
+		require.Error(t, err)
+	}
+}
+
+func TestUploadWithSignature(t *testing.T) {
+	sp := &uploadTestSP{uploadStatus: http.StatusOK}
+	c := newUploadTestClient(t, 0, sp)
+	uploader := c.defaultAccount
+	sp.uploader = uploader.GetAddress()
+	payload := bytes.Repeat([]byte("a"), 5000)
+	requests, err := c.PrepareObjectUpload(context.Background(), "bucket", "object", int64(len(payload)), types.PutObjectOptions{})
+	require.NoError(t, err)
+
+	// the requests are refused before sending if they are incomplete
+	require.Error(t, c.UploadWithSignature(context.Background(), nil, make([]byte, 65), bytes.NewReader(nil)))
+	require.ErrorContains(t, c.UploadWithSignature(context.Background(), requests[0], make([]byte, 64), bytes.NewReader(nil)),
+		"invalid signature length 64")
+	require.Zero(t, sp.parts.Load())
+
+	// the signature of another account is rejected by SP
+	other, _, err := types.NewAccount("other")
+	require.NoError(t, err)
+	signature, err := other.Sign(requests[0].UnsignedMsg)
+	require.NoError(t, err)
+	err = c.UploadWithSignature(context.Background(), requests[0], signature,
+		bytes.NewReader(payload[:requests[0].ContentLength]))
+	require.ErrorContains(t, err, "SignatureDoesNotMatch")
+	require.Zero(t, sp.uploaded.Load())
+
+	// the parts signed by the uploader are uploaded in order
+	for _, request := range requests {
+		signature, err = uploader.Sign(request.UnsignedMsg)
+		require.NoError(t, err)
+		require.NoError(t, c.UploadWithSignature(context.Background(), request, signature,
+			bytes.NewReader(payload[request.Offset:request.Offset+request.ContentLength])))
+	}
+	require.Equal(t, int32(len(requests)+1), sp.parts.Load())
+	require.Equal(t, int64(len(payload)), sp.uploaded.Load())
+}
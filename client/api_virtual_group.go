@@ -3,7 +3,10 @@ package client
 import (
 	"context"
 
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	"github.com/bnb-chain/greenfield/types/common"
 	"github.com/bnb-chain/greenfield/x/virtualgroup/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // IVirtualGroupClient interface defines basic functions related to Virtual Group.
@@ -12,6 +15,11 @@ type IVirtualGroupClient interface {
 	QuerySpAvailableGlobalVirtualGroupFamilies(ctx context.Context, spID uint32) ([]uint32, error)
 	QuerySpOptimalGlobalVirtualGroupFamily(ctx context.Context, spID uint32, strategy types.PickVGFStrategy) (uint32, error)
 	QueryVirtualGroupParams(ctx context.Context) (*types.Params, error)
+	SPExit(ctx context.Context, spAddr string, txOption gnfdSdkTypes.TxOption) (string, error)
+	CompleteSPExit(ctx context.Context, spAddr string, txOption gnfdSdkTypes.TxOption) (string, error)
+	SwapOut(ctx context.Context, spAddr string, globalVirtualGroupFamilyID uint32, globalVirtualGroupIDs []uint32, successorSPID uint32, successorSPApproval *common.Approval, txOption gnfdSdkTypes.TxOption) (string, error)
+	CompleteSwapOut(ctx context.Context, spAddr string, globalVirtualGroupFamilyID uint32, globalVirtualGroupIDs []uint32, txOption gnfdSdkTypes.TxOption) (string, error)
+	ReserveSwapIn(ctx context.Context, spAddr string, targetSPID, globalVirtualGroupFamilyID, globalVirtualGroupID uint32, txOption gnfdSdkTypes.TxOption) (string, error)
 }
 
 // QueryVirtualGroupFamily - Query the virtual group family by ID.
@@ -26,7 +34,7 @@ type IVirtualGroupClient interface {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QueryVirtualGroupFamily(ctx context.Context, globalVirtualGroupFamilyID uint32) (*types.GlobalVirtualGroupFamily, error) {
-	queryResponse, err := c.chainClient.GlobalVirtualGroupFamily(ctx, &types.QueryGlobalVirtualGroupFamilyRequest{
+	queryResponse, err := c.chainClient.GlobalVirtualGroupFamily(c.queryContext(ctx), &types.QueryGlobalVirtualGroupFamilyRequest{
 		FamilyId: globalVirtualGroupFamilyID,
 	})
 	if err != nil {
@@ -47,7 +55,7 @@ func (c *Client) QueryVirtualGroupFamily(ctx context.Context, globalVirtualGroup
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QuerySpAvailableGlobalVirtualGroupFamilies(ctx context.Context, spID uint32) ([]uint32, error) {
-	queryResponse, err := c.chainClient.QuerySpAvailableGlobalVirtualGroupFamilies(ctx, &types.QuerySPAvailableGlobalVirtualGroupFamiliesRequest{
+	queryResponse, err := c.chainClient.QuerySpAvailableGlobalVirtualGroupFamilies(c.queryContext(ctx), &types.QuerySPAvailableGlobalVirtualGroupFamiliesRequest{
 		SpId: spID,
 	})
 	if err != nil {
@@ -68,7 +76,7 @@ func (c *Client) QuerySpAvailableGlobalVirtualGroupFamilies(ctx context.Context,
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QuerySpOptimalGlobalVirtualGroupFamily(ctx context.Context, spID uint32, strategy types.PickVGFStrategy) (uint32, error) {
-	queryResponse, err := c.chainClient.QuerySpOptimalGlobalVirtualGroupFamily(ctx, &types.QuerySpOptimalGlobalVirtualGroupFamilyRequest{
+	queryResponse, err := c.chainClient.QuerySpOptimalGlobalVirtualGroupFamily(c.queryContext(ctx), &types.QuerySpOptimalGlobalVirtualGroupFamilyRequest{
 		SpId:            spID,
 		PickVgfStrategy: strategy,
 	})
@@ -88,9 +96,180 @@ func (c *Client) QuerySpOptimalGlobalVirtualGroupFamily(ctx context.Context, spI
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QueryVirtualGroupParams(ctx context.Context) (*types.Params, error) {
-	queryResponse, err := c.chainClient.VirtualGroupQueryClient.Params(ctx, &types.QueryParamsRequest{})
+	queryResponse, err := c.chainClient.VirtualGroupQueryClient.Params(c.queryContext(ctx), &types.QueryParamsRequest{})
 	if err != nil {
 		return nil, err
 	}
 	return &queryResponse.Params, nil
 }
+
+// SPExit - Declare the storage provider exits from the network. The sender must be the storage provider's operator
+// address, and SP must be STATUS_IN_SERVICE. The exiting SP must swap out all its virtual groups to the successor SPs,
+// then complete the exit by CompleteSPExit.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddr: The HEX-encoded string of the storage provider operator address.
+//
+// - txOption: The options for customizing the transaction.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) SPExit(ctx context.Context, spAddr string, txOption gnfdSdkTypes.TxOption) (string, error) {
+	spAcc, err := sdk.AccAddressFromHexUnsafe(spAddr)
+	if err != nil {
+		return "", err
+	}
+	msgSPExit := &types.MsgStorageProviderExit{
+		StorageProvider: spAcc.String(),
+	}
+	return c.broadcastVirtualGroupMsg(ctx, msgSPExit, txOption)
+}
+
+// CompleteSPExit - Complete the exit of the storage provider after all its virtual groups are swapped out, the deposit
+// of the SP is returned to its funding address. The sender is the default account, which is not required to be the
+// exiting SP.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddr: The HEX-encoded string of the exiting storage provider operator address.
+//
+// - txOption: The options for customizing the transaction.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) CompleteSPExit(ctx context.Context, spAddr string, txOption gnfdSdkTypes.TxOption) (string, error) {
+	spAcc, err := sdk.AccAddressFromHexUnsafe(spAddr)
+	if err != nil {
+		return "", err
+	}
+	operator, err := c.GetDefaultAccount()
+	if err != nil {
+		return "", err
+	}
+	msgCompleteSPExit := &types.MsgCompleteStorageProviderExit{
+		StorageProvider: spAcc.String(),
+		Operator:        operator.GetAddress().String(),
+	}
+	return c.broadcastVirtualGroupMsg(ctx, msgCompleteSPExit, txOption)
+}
+
+// SwapOut - Swap out the virtual groups served by the storage provider to the successor SP, which takes over them
+// once it completes the data recovery and calls CompleteSwapOut. The sender must be the storage provider's operator
+// address.
+//
+// To swap out the SP as the primary SP of a virtual group family, set globalVirtualGroupFamilyID and leave
+// globalVirtualGroupIDs empty, otherwise set globalVirtualGroupIDs to swap out the SP as the secondary SP of them.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddr: The HEX-encoded string of the storage provider operator address.
+//
+// - globalVirtualGroupFamilyID: Identify the virtual group family to swap out, 0 if swapping out the global virtual groups.
+//
+// - globalVirtualGroupIDs: Identify the global virtual groups to swap out.
+//
+// - successorSPID: Identify the successor storage provider.
+//
+// - successorSPApproval: The approval of the swap out signed by the successor SP.
+//
+// - txOption: The options for customizing the transaction.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) SwapOut(ctx context.Context, spAddr string, globalVirtualGroupFamilyID uint32, globalVirtualGroupIDs []uint32,
+	successorSPID uint32, successorSPApproval *common.Approval, txOption gnfdSdkTypes.TxOption,
+) (string, error) {
+	spAcc, err := sdk.AccAddressFromHexUnsafe(spAddr)
+	if err != nil {
+		return "", err
+	}
+	msgSwapOut := &types.MsgSwapOut{
+		StorageProvider:            spAcc.String(),
+		GlobalVirtualGroupFamilyId: globalVirtualGroupFamilyID,
+		GlobalVirtualGroupIds:      globalVirtualGroupIDs,
+		SuccessorSpId:              successorSPID,
+		SuccessorSpApproval:        successorSPApproval,
+	}
+	return c.broadcastVirtualGroupMsg(ctx, msgSwapOut, txOption)
+}
+
+// CompleteSwapOut - Complete the swap out of the virtual groups, it is sent by the successor SP after it recovers the
+// data of the virtual groups. The sender must be the successor storage provider's operator address.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddr: The HEX-encoded string of the successor storage provider operator address.
+//
+// - globalVirtualGroupFamilyID: Identify the virtual group family swapped out, 0 if the global virtual groups are swapped out.
+//
+// - globalVirtualGroupIDs: Identify the global virtual groups swapped out.
+//
+// - txOption: The options for customizing the transaction.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) CompleteSwapOut(ctx context.Context, spAddr string, globalVirtualGroupFamilyID uint32, globalVirtualGroupIDs []uint32,
+	txOption gnfdSdkTypes.TxOption,
+) (string, error) {
+	spAcc, err := sdk.AccAddressFromHexUnsafe(spAddr)
+	if err != nil {
+		return "", err
+	}
+	msgCompleteSwapOut := &types.MsgCompleteSwapOut{
+		StorageProvider:            spAcc.String(),
+		GlobalVirtualGroupFamilyId: globalVirtualGroupFamilyID,
+		GlobalVirtualGroupIds:      globalVirtualGroupIDs,
+	}
+	return c.broadcastVirtualGroupMsg(ctx, msgCompleteSwapOut, txOption)
+}
+
+// ReserveSwapIn - Reserve the swap in of the virtual group family or the global virtual group served by the target
+// SP, which is usually exiting, so that the sender takes over it once it completes the data recovery. The sender must
+// be the storage provider's operator address.
+//
+// To take over the target SP as the primary SP of a virtual group family, set globalVirtualGroupFamilyID and leave
+// globalVirtualGroupID 0, otherwise set globalVirtualGroupID to take over the target SP as the secondary SP of it.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddr: The HEX-encoded string of the storage provider operator address swapping in.
+//
+// - targetSPID: Identify the storage provider to be swapped.
+//
+// - globalVirtualGroupFamilyID: Identify the virtual group family to swap in.
+//
+// - globalVirtualGroupID: Identify the global virtual group to swap in.
+//
+// - txOption: The options for customizing the transaction.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) ReserveSwapIn(ctx context.Context, spAddr string, targetSPID, globalVirtualGroupFamilyID, globalVirtualGroupID uint32,
+	txOption gnfdSdkTypes.TxOption,
+) (string, error) {
+	spAcc, err := sdk.AccAddressFromHexUnsafe(spAddr)
+	if err != nil {
+		return "", err
+	}
+	msgReserveSwapIn := &types.MsgReserveSwapIn{
+		StorageProvider:            spAcc.String(),
+		TargetSpId:                 targetSPID,
+		GlobalVirtualGroupFamilyId: globalVirtualGroupFamilyID,
+		GlobalVirtualGroupId:       globalVirtualGroupID,
+	}
+	return c.broadcastVirtualGroupMsg(ctx, msgReserveSwapIn, txOption)
+}
+
+func (c *Client) broadcastVirtualGroupMsg(ctx context.Context, msg sdk.Msg, txOption gnfdSdkTypes.TxOption) (string, error) {
+	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	if err != nil {
+		return "", err
+	}
+	return resp.TxResponse.TxHash, nil
+}
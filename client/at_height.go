@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// AtHeight - Get a read-only client whose chain queries are pinned to the block height, e.g. to audit the buckets and
+// the policies as of a past block or to run the reproducible analytics. The list and meta requests sent to SP carry
+// the height in the X-Gnfd-Query-Height header, the txs and the SP requests modifying data are refused with
+// types.ErrReadOnlyClient. The derived client shares the connections and the runtime state with the client.
+//
+// The chain queries of the past heights are served only by the nodes retaining the states of the heights, the pruned
+// heights are rejected by the nodes.
+//
+// - height: The block height which the queries are pinned to, it should be positive.
+//
+// - ret1: The read-only client pinned to the height.
+//
+// - ret2: Return error when the height is not positive, otherwise return nil.
+func (c *Client) AtHeight(height int64) (IClient, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("the pinned height %d should be positive", height)
+	}
+	clone := *c
+	clone.pinnedHeight = height
+	return &clone, nil
+}
+
+// PinnedHeight - Get the block height which the chain queries of the client are pinned to, it is 0 unless the client
+// is derived by AtHeight.
+func (c *Client) PinnedHeight() int64 {
+	return c.pinnedHeight
+}
+
+// queryContext returns the context of the chain queries, which carries the pinned height if the client is derived by
+// AtHeight, the chain queries of the other clients are served at the latest height.
+func (c *Client) queryContext(ctx context.Context) context.Context {
+	if c.pinnedHeight == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(c.pinnedHeight, 10))
+}
+
+// checkWritable refuses the txs and the SP requests modifying data if the client is pinned to a height.
+func (c *Client) checkWritable() error {
+	if c.pinnedHeight != 0 {
+		return types.ErrReadOnlyClient
+	}
+	return nil
+}
+
+// checkWritableMethod refuses the SP requests of the method if it may modify data and the client is pinned to a height.
+func (c *Client) checkWritableMethod(method string) error {
+	if method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+	return c.checkWritable()
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	govTypesV1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestAtHeight(t *testing.T) {
+	c := newTestClient(t)
+	_, err := c.AtHeight(0)
+	require.Error(t, err)
+
+	derived, err := c.AtHeight(100)
+	require.NoError(t, err)
+	pinned := derived.(*Client)
+	require.Equal(t, int64(100), pinned.PinnedHeight())
+	require.Equal(t, int64(0), c.PinnedHeight())
+
+	// the chain queries of the pinned client carry the height
+	md, ok := metadata.FromOutgoingContext(pinned.queryContext(context.Background()))
+	require.True(t, ok)
+	require.Equal(t, []string{"100"}, md.Get(grpctypes.GRPCBlockHeightHeader))
+	_, ok = metadata.FromOutgoingContext(c.queryContext(context.Background()))
+	require.False(t, ok)
+
+	// the pinned client is read-only
+	require.NoError(t, pinned.checkWritableMethod(http.MethodGet))
+	require.NoError(t, pinned.checkWritableMethod(http.MethodHead))
+	require.ErrorIs(t, pinned.checkWritableMethod(http.MethodPut), types.ErrReadOnlyClient)
+	require.ErrorIs(t, pinned.checkWritable(), types.ErrReadOnlyClient)
+	require.NoError(t, c.checkWritable())
+	_, err = pinned.BroadcastRawTx(context.Background(), []byte{1}, true)
+	require.ErrorIs(t, err, types.ErrReadOnlyClient)
+}
+
+// fakeGovQueryClient serves the proposals by proposal, it records the heights which the queries are pinned to.
+type fakeGovQueryClient struct {
+	govTypesV1.QueryClient
+	proposal func(id uint64) (*govTypesV1.Proposal, error)
+	heights  []string
+}
+
+func (f *fakeGovQueryClient) Proposal(ctx context.Context, req *govTypesV1.QueryProposalRequest, _ ...grpc.CallOption) (*govTypesV1.QueryProposalResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.heights = append(f.heights, md.Get(grpctypes.GRPCBlockHeightHeader)...)
+	proposal, err := f.proposal(req.ProposalId)
+	if err != nil {
+		return nil, err
+	}
+	return &govTypesV1.QueryProposalResponse{Proposal: proposal}, nil
+}
+
+func TestGetProposalAtHeight(t *testing.T) {
+	govQuery := &fakeGovQueryClient{proposal: func(id uint64) (*govTypesV1.Proposal, error) {
+		if id != 1 {
+			return nil, errors.New("proposal not found")
+		}
+		return &govTypesV1.Proposal{Id: id}, nil
+	}}
+	c := newTestClient(t, func(c *Client) { c.chainClient.GovQueryClientV1 = govQuery })
+	derived, err := c.AtHeight(100)
+	require.NoError(t, err)
+
+	proposal, err := derived.GetProposal(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), proposal.Id)
+	require.Equal(t, []string{"100"}, govQuery.heights)
+
+	// the failed query is reported rather than a nil proposal
+	_, err = derived.GetProposal(context.Background(), 2)
+	require.ErrorContains(t, err, "proposal not found")
+}
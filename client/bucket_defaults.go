@@ -0,0 +1,65 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// bucketDefaultsRegistry holds the default options of the buckets, it is shared by the clients of a ClientPool.
+type bucketDefaultsRegistry struct {
+	mu       sync.RWMutex
+	defaults map[string]types.BucketDefaults
+}
+
+func newBucketDefaultsRegistry(defaults map[string]types.BucketDefaults) *bucketDefaultsRegistry {
+	registry := &bucketDefaultsRegistry{defaults: make(map[string]types.BucketDefaults, len(defaults))}
+	for bucketName, bucketDefaults := range defaults {
+		registry.defaults[bucketName] = bucketDefaults
+	}
+	return registry
+}
+
+// SetBucketDefaults - Register the default options of the bucket, they are applied to the options of CreateBucket,
+// CreateObject and the uploads on the bucket which the caller does not set explicitly.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - defaults: The default options of the bucket, the registered ones are replaced.
+//
+// - ret: Return error when the defaults are invalid, otherwise return nil.
+func (c *Client) SetBucketDefaults(bucketName string, defaults types.BucketDefaults) error {
+	if err := defaults.Validate(); err != nil {
+		return err
+	}
+	c.bucketDefaults.mu.Lock()
+	defer c.bucketDefaults.mu.Unlock()
+	c.bucketDefaults.defaults[bucketName] = defaults
+	return nil
+}
+
+// RemoveBucketDefaults - Remove the default options of the bucket.
+//
+// - bucketName: The bucket name identifies the bucket.
+func (c *Client) RemoveBucketDefaults(bucketName string) {
+	c.bucketDefaults.mu.Lock()
+	defer c.bucketDefaults.mu.Unlock()
+	delete(c.bucketDefaults.defaults, bucketName)
+}
+
+// GetBucketDefaults - Get the default options registered for the bucket.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - ret1: The default options of the bucket.
+//
+// - ret2: Whether the defaults are registered for the bucket.
+func (c *Client) GetBucketDefaults(bucketName string) (types.BucketDefaults, bool) {
+	if c.bucketDefaults == nil {
+		return types.BucketDefaults{}, false
+	}
+	c.bucketDefaults.mu.RLock()
+	defer c.bucketDefaults.mu.RUnlock()
+	defaults, ok := c.bucketDefaults.defaults[bucketName]
+	return defaults, ok
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"testing"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestBucketDefaults(t *testing.T) {
+	c := newTestClient(t)
+	_, ok := c.GetBucketDefaults("site")
+	require.False(t, ok)
+
+	require.Error(t, c.SetBucketDefaults("site", types.BucketDefaults{
+		ContentTypeRules: []types.ContentTypeRule{{Pattern: "[", ContentType: "text/html"}},
+	}))
+	require.NoError(t, c.SetBucketDefaults("site", types.BucketDefaults{
+		Visibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ,
+		ContentTypeRules: []types.ContentTypeRule{
+			{Pattern: "assets/*.js", ContentType: "text/javascript"},
+			{Pattern: "*.html", ContentType: "text/html"},
+		},
+		PartSize: 32 * 1024 * 1024,
+	}))
+	defaults, ok := c.GetBucketDefaults("site")
+	require.True(t, ok)
+
+	// the patterns without "/" match the last element of the object names
+	require.Equal(t, "text/html", defaults.ContentType("docs/index.html"))
+	require.Equal(t, "text/javascript", defaults.ContentType("assets/app.js"))
+	require.Equal(t, "", defaults.ContentType("lib/app.js"))
+
+	// the explicit options take precedence
+	opts := types.PutObjectOptions{ContentType: "text/plain"}
+	defaults.ApplyToPut("index.html", &opts)
+	require.Equal(t, "text/plain", opts.ContentType)
+	require.Equal(t, storageTypes.VISIBILITY_TYPE_PUBLIC_READ, opts.Visibility)
+	require.Equal(t, uint64(32*1024*1024), opts.PartSize)
+
+	createOpts := types.CreateObjectOptions{Visibility: storageTypes.VISIBILITY_TYPE_PRIVATE}
+	defaults.ApplyToObject("index.html", &createOpts)
+	require.Equal(t, "text/html", createOpts.ContentType)
+	require.Equal(t, storageTypes.VISIBILITY_TYPE_PRIVATE, createOpts.Visibility)
+
+	c.RemoveBucketDefaults("site")
+	_, ok = c.GetBucketDefaults("site")
+	require.False(t, ok)
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// segmentChecksums computes the checksums of the segments of data, the last segment may be shorter.
+func segmentChecksums(data []byte, segmentSize int64) [][]byte {
+	checksums := make([][]byte, 0, (int64(len(data))+segmentSize-1)/segmentSize)
+	for start := int64(0); start < int64(len(data)); start += segmentSize {
+		end := start + segmentSize
+		if end > int64(len(data)) {
+			end = int64(len(data))
+		}
+		checksum := sha256.Sum256(data[start:end])
+		checksums = append(checksums, checksum[:])
+	}
+	return checksums
+}
+
+// segmentHasher computes the checksums of the segments of the content read through it.
+type segmentHasher struct {
+	reader      io.Reader
+	segmentSize int64
+	hash        hash.Hash
+	written     int64 // written indicates the bytes of the current segment hashed
+	checksums   [][]byte
+}
+
+// seekableSegmentHasher is the segmentHasher of a seekable reader, so that the requests uploading it can be retried.
+type seekableSegmentHasher struct {
+	*segmentHasher
+	seeker io.Seeker
+	start  int64
+}
+
+// newSegmentHasher wraps reader to compute the checksums of the segments, the returned reader can be rewound to its
+// current position if reader is an io.Seeker.
+func newSegmentHasher(reader io.Reader, segmentSize int64) (*segmentHasher, io.Reader) {
+	hasher := &segmentHasher{reader: reader, segmentSize: segmentSize, hash: sha256.New(), checksums: make([][]byte, 0)}
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return hasher, hasher
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return hasher, hasher
+	}
+	return hasher, &seekableSegmentHasher{segmentHasher: hasher, seeker: seeker, start: start}
+}
+
+func (h *segmentHasher) Read(p []byte) (int, error) {
+	n, err := h.reader.Read(p)
+	data := p[:n]
+	for len(data) > 0 {
+		chunk := data
+		if room := h.segmentSize - h.written; int64(len(chunk)) > room {
+			chunk = chunk[:room]
+		}
+		h.hash.Write(chunk)
+		h.written += int64(len(chunk))
+		data = data[len(chunk):]
+		if h.written == h.segmentSize {
+			h.checksums = append(h.checksums, h.hash.Sum(nil))
+			h.hash.Reset()
+			h.written = 0
+		}
+	}
+	return n, err
+}
+
+// Checksums returns the checksums of the segments read, including the last partial one.
+func (h *segmentHasher) Checksums() [][]byte {
+	if h.written == 0 {
+		return h.checksums
+	}
+	return append(h.checksums[:len(h.checksums):len(h.checksums)], h.hash.Sum(nil))
+}
+
+// Seek only supports rewinding to the start position, the checksums are computed again from it.
+func (h *seekableSegmentHasher) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekCurrent && offset == 0 {
+		return h.seeker.Seek(0, io.SeekCurrent)
+	}
+	if whence != io.SeekStart || offset != h.start {
+		return 0, errors.New("the hashed body can only be rewound to the start")
+	}
+	h.hash.Reset()
+	h.written = 0
+	h.checksums = h.checksums[:0]
+	return h.seeker.Seek(offset, io.SeekStart)
+}
+
+// verifyChecksumAck compares the checksums acknowledged by SP in the response headers with the local ones, local are
+// the checksums of the segments uploaded by the request starting from the segment firstSegment. The integrity hash is
+// compared if both SP and the caller provide it. Nothing is compared if SP does not acknowledge the checksums.
+func verifyChecksumAck(header http.Header, objectName string, firstSegment int, local [][]byte, integrityHash []byte) error {
+	if pieceHashes := header.Get(types.HTTPHeaderPieceHash); pieceHashes != "" {
+		acked := strings.Split(pieceHashes, ",")
+		if len(acked) != len(local) {
+			return &types.ChecksumAckError{ObjectName: objectName, Segment: firstSegment,
+				Detail: "SP acknowledges the checksums of a different number of segments"}
+		}
+		for i, ackedHash := range acked {
+			if expected := hex.EncodeToString(local[i]); !strings.EqualFold(strings.TrimSpace(ackedHash), expected) {
+				return &types.ChecksumAckError{ObjectName: objectName, Segment: firstSegment + i, Expected: expected,
+					Acknowledged: ackedHash}
+			}
+		}
+	}
+	if ackedIntegrity := header.Get(types.HTTPHeaderIntegrityHash); ackedIntegrity != "" && integrityHash != nil {
+		acked, err := hex.DecodeString(ackedIntegrity)
+		if err != nil || !bytes.Equal(acked, integrityHash) {
+			return &types.ChecksumAckError{ObjectName: objectName, Segment: types.IntegrityHashSegment,
+				Expected: hex.EncodeToString(integrityHash), Acknowledged: ackedIntegrity}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestSegmentHasher(t *testing.T) {
+	data := []byte("0123456789abcdefghij")
+	expected := segmentChecksums(data, 8)
+	require.Len(t, expected, 3)
+	last := sha256.Sum256(data[16:])
+	require.Equal(t, last[:], expected[2])
+
+	// the checksums do not depend on how the content is read
+	hasher, body := newSegmentHasher(iotest.OneByteReader(bytes.NewReader(data)), 8)
+	_, isSeeker := body.(io.Seeker)
+	require.False(t, isSeeker)
+	_, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, expected, hasher.Checksums())
+
+	// rewinding for a retry computes the checksums again
+	reader := bytes.NewReader(data)
+	_, err = reader.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	hasher, body = newSegmentHasher(reader, 8)
+	seeker, isSeeker := body.(io.Seeker)
+	require.True(t, isSeeker)
+	_, err = io.CopyN(io.Discard, body, 10)
+	require.NoError(t, err)
+	_, err = seeker.Seek(0, io.SeekStart)
+	require.Error(t, err)
+	_, err = seeker.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	_, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, segmentChecksums(data[4:], 8), hasher.Checksums())
+}
+
+func TestVerifyChecksumAck(t *testing.T) {
+	local := segmentChecksums([]byte("0123456789abcdefghij"), 8)
+	ack := func(checksums [][]byte) http.Header {
+		hexes := make([]string, 0, len(checksums))
+		for _, checksum := range checksums {
+			hexes = append(hexes, hex.EncodeToString(checksum))
+		}
+		header := make(http.Header)
+		header.Set(types.HTTPHeaderPieceHash, strings.Join(hexes, ","))
+		return header
+	}
+
+	// SP does not acknowledge the checksums
+	require.NoError(t, verifyChecksumAck(make(http.Header), "obj", 0, local, []byte("integrity")))
+	require.NoError(t, verifyChecksumAck(ack(local), "obj", 0, local, nil))
+
+	mismatched := [][]byte{local[0], local[2], local[1]}
+	err := verifyChecksumAck(ack(mismatched), "obj", 3, local, nil)
+	var ackErr *types.ChecksumAckError
+	require.True(t, errors.As(err, &ackErr))
+	require.Equal(t, 4, ackErr.Segment)
+	require.Equal(t, hex.EncodeToString(local[1]), ackErr.Expected)
+
+	err = verifyChecksumAck(ack(local[:2]), "obj", 0, local, nil)
+	require.True(t, errors.As(err, &ackErr))
+	require.NotEmpty(t, ackErr.Detail)
+
+	header := ack(local)
+	header.Set(types.HTTPHeaderIntegrityHash, hex.EncodeToString([]byte("other")))
+	err = verifyChecksumAck(header, "obj", 0, local, []byte("integrity"))
+	require.True(t, errors.As(err, &ackErr))
+	require.Equal(t, types.IntegrityHashSegment, ackErr.Segment)
+	header.Set(types.HTTPHeaderIntegrityHash, hex.EncodeToString([]byte("integrity")))
+	require.NoError(t, verifyChecksumAck(header, "obj", 0, local, []byte("integrity")))
+}
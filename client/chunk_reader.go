@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// chunkReader reads the chunks sent by a producer as a continuous stream of totalSize bytes. The chunks are received
+// ahead of the reading until limit bytes are buffered, then the producer is blocked until the buffered chunks are read,
+// so that the memory is bounded whatever the producer rate is.
+type chunkReader struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    [][]byte
+	buffered int64 // buffered is the bytes of the chunks received but not read yet.
+	limit    int64
+	read     int64
+	total    int64
+	eof      bool  // eof indicates the producer closed the channel.
+	err      error // err is returned by the following reads once set.
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newChunkReader(ctx context.Context, chunks <-chan []byte, totalSize, limit int64) *chunkReader {
+	r := &chunkReader{limit: limit, total: totalSize, stop: make(chan struct{})}
+	r.cond = sync.NewCond(&r.mu)
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.fail(ctx.Err())
+		case <-r.stop:
+		}
+	}()
+	go r.receive(ctx, chunks)
+	return r
+}
+
+// receive buffers the chunks from the producer until the channel is closed or the reading fails.
+func (r *chunkReader) receive(ctx context.Context, chunks <-chan []byte) {
+	for {
+		var (
+			chunk []byte
+			ok    bool
+		)
+		select {
+		case chunk, ok = <-chunks:
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		}
+
+		r.mu.Lock()
+		if !ok {
+			r.eof = true
+			r.cond.Broadcast()
+			r.mu.Unlock()
+			return
+		}
+		// a chunk larger than the limit is accepted when nothing is buffered, otherwise it could never be read
+		for r.err == nil && r.buffered > 0 && r.buffered+int64(len(chunk)) > r.limit {
+			r.cond.Wait()
+		}
+		if r.err != nil {
+			r.mu.Unlock()
+			return
+		}
+		if len(chunk) > 0 {
+			r.queue = append(r.queue, chunk)
+			r.buffered += int64(len(chunk))
+			r.cond.Broadcast()
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Read reads the buffered chunks in order, it blocks until a chunk is received.
+func (r *chunkReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for len(r.queue) == 0 && !r.eof && r.err == nil {
+		r.cond.Wait()
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	if len(r.queue) == 0 {
+		if r.read < r.total {
+			r.err = fmt.Errorf("%w: %d of %d bytes received", io.ErrUnexpectedEOF, r.read, r.total)
+			return 0, r.err
+		}
+		return 0, io.EOF
+	}
+
+	chunk := r.queue[0]
+	if r.read+int64(len(chunk)) > r.total {
+		r.err = fmt.Errorf("the chunks exceed the object size %d", r.total)
+		return 0, r.err
+	}
+	n := copy(p, chunk)
+	if n == len(chunk) {
+		r.queue[0] = nil
+		r.queue = r.queue[1:]
+	} else {
+		r.queue[0] = chunk[n:]
+	}
+	r.buffered -= int64(n)
+	r.read += int64(n)
+	r.cond.Broadcast()
+	return n, nil
+}
+
+// Close stops receiving the chunks, the producer blocked on sending is not released unless it watches the context.
+func (r *chunkReader) Close() error {
+	r.fail(errReaderClosed)
+	r.stopOnce.Do(func() { close(r.stop) })
+	return nil
+}
+
+func (r *chunkReader) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+	r.cond.Broadcast()
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkReader(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	chunks := make(chan []byte)
+	go func() {
+		defer close(chunks)
+		// the chunk boundaries do not align with the reads
+		for offset := 0; offset < len(content); offset += 37 {
+			end := offset + 37
+			if end > len(content) {
+				end = len(content)
+			}
+			chunks <- content[offset:end]
+		}
+	}()
+
+	r := newChunkReader(context.Background(), chunks, int64(len(content)), 100)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+}
+
+func TestChunkReaderSizeMismatch(t *testing.T) {
+	send := func(size int) <-chan []byte {
+		chunks := make(chan []byte, 1)
+		chunks <- make([]byte, size)
+		close(chunks)
+		return chunks
+	}
+
+	r := newChunkReader(context.Background(), send(10), 20, 100)
+	_, err := io.ReadAll(r)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	r.Close()
+
+	r = newChunkReader(context.Background(), send(30), 20, 100)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+	r.Close()
+}
+
+func TestChunkReaderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan []byte)
+	r := newChunkReader(ctx, chunks, 10, 100)
+	defer r.Close()
+	cancel()
+	_, err := io.ReadAll(r)
+	require.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// requestOutcome is the result of a request to SP as seen by the circuit breaker
+type requestOutcome int
+
+const (
+	outcomeSuccess  requestOutcome = iota
+	outcomeFailure                 // the connection failures and the server errors
+	outcomeCanceled                // the request canceled by the caller, which tells nothing about the SP
+)
+
+// circuitBreaker is the circuit breaker of an SP endpoint
+type circuitBreaker struct {
+	state    types.CircuitState
+	failures int
+	// retryAt is when the open circuit sends a probe request
+	retryAt time.Time
+	// probing indicates the probe request of the half-open circuit is in flight
+	probing bool
+}
+
+// circuitBreakers are the circuit breakers of the SP endpoints, they are shared by the clients of a pool
+type circuitBreakers struct {
+	mu        sync.Mutex
+	endpoints map[string]*circuitBreaker
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{endpoints: make(map[string]*circuitBreaker)}
+}
+
+// allow reports whether a request can be sent to host at now, the open circuit turns half-open once OpenDuration
+// elapses and lets a single probe request through. changed is set if the state of the circuit changes.
+func (b *circuitBreakers) allow(host string, now time.Time) (state types.CircuitState, changed bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker, ok := b.endpoints[host]
+	if !ok {
+		return types.CircuitClosed, false, nil
+	}
+	switch breaker.state {
+	case types.CircuitOpen:
+		if now.Before(breaker.retryAt) {
+			return breaker.state, false, fmt.Errorf("%w: %s until %s", types.ErrCircuitOpen, host, breaker.retryAt.Format(time.RFC3339))
+		}
+		breaker.state, breaker.probing = types.CircuitHalfOpen, true
+		return breaker.state, true, nil
+	case types.CircuitHalfOpen:
+		if breaker.probing {
+			return breaker.state, false, fmt.Errorf("%w: %s is being probed", types.ErrCircuitOpen, host)
+		}
+		breaker.probing = true
+	}
+	return breaker.state, false, nil
+}
+
+// record adds the outcome of a request to host, the circuit opens after FailureThreshold consecutive failures or a
+// failed probe, and closes after a successful request.
+func (b *circuitBreakers) record(policy types.CircuitBreakerPolicy, host string, outcome requestOutcome, now time.Time) (state types.CircuitState, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker, ok := b.endpoints[host]
+	if !ok {
+		if outcome != outcomeFailure {
+			return types.CircuitClosed, false
+		}
+		breaker = &circuitBreaker{state: types.CircuitClosed}
+		b.endpoints[host] = breaker
+	}
+	previous := breaker.state
+	switch outcome {
+	case outcomeCanceled:
+		// let another request probe the SP
+		breaker.probing = false
+	case outcomeSuccess:
+		breaker.state, breaker.failures, breaker.probing = types.CircuitClosed, 0, false
+	case outcomeFailure:
+		breaker.failures++
+		if previous == types.CircuitHalfOpen || (previous == types.CircuitClosed && breaker.failures >= policy.FailureThreshold) {
+			breaker.state, breaker.retryAt, breaker.probing = types.CircuitOpen, now.Add(policy.OpenDuration), false
+		}
+	}
+	return breaker.state, breaker.state != previous
+}
+
+// state returns the state of the circuit of host
+func (b *circuitBreakers) state(host string) types.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if breaker, ok := b.endpoints[host]; ok {
+		return breaker.state
+	}
+	return types.CircuitClosed
+}
+
+// circuitState returns the state of the circuit breaker of host, the circuit is closed if the circuit breakers are
+// not enabled.
+func (c *Client) circuitState(host string) types.CircuitState {
+	if c.circuitBreakerPolicy == nil {
+		return types.CircuitClosed
+	}
+	return c.state.circuitBreakers.state(host)
+}
+
+// allowSPRequest fails the request to host fast with types.ErrCircuitOpen if the circuit breaker is open, it always
+// allows the request if the circuit breaker is not enabled.
+func (c *Client) allowSPRequest(host string) error {
+	if c.circuitBreakerPolicy == nil {
+		return nil
+	}
+	state, changed, err := c.state.circuitBreakers.allow(host, c.clock.Now())
+	circuitMetrics, ok := c.metrics.(types.CircuitBreakerMetrics)
+	if !ok {
+		return err
+	}
+	if changed {
+		circuitMetrics.ObserveCircuitState(host, state)
+	}
+	if err != nil {
+		circuitMetrics.ObserveCircuitRejection(host)
+	}
+	return err
+}
+
+// recordCircuitOutcome records the outcome of the request to host to its circuit breaker.
+func (c *Client) recordCircuitOutcome(host string, outcome requestOutcome) {
+	if c.circuitBreakerPolicy == nil {
+		return
+	}
+	state, changed := c.state.circuitBreakers.record(*c.circuitBreakerPolicy, host, outcome, c.clock.Now())
+	if !changed {
+		return
+	}
+	if circuitMetrics, ok := c.metrics.(types.CircuitBreakerMetrics); ok {
+		circuitMetrics.ObserveCircuitState(host, state)
+	}
+}
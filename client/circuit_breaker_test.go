@@ -0,0 +1,58 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	policy := types.DefaultCircuitBreakerPolicy()
+	breakers := newCircuitBreakers()
+	now := time.Unix(1700000000, 0)
+
+	// the circuit opens after the consecutive failures
+	for i := 0; i < policy.FailureThreshold-1; i++ {
+		_, changed := breakers.record(policy, "sp1", outcomeFailure, now)
+		require.False(t, changed)
+	}
+	_, changed := breakers.record(policy, "sp1", outcomeSuccess, now)
+	require.False(t, changed)
+	for i := 0; i < policy.FailureThreshold; i++ {
+		breakers.record(policy, "sp1", outcomeFailure, now)
+	}
+	require.Equal(t, types.CircuitOpen, breakers.state("sp1"))
+	_, _, err := breakers.allow("sp1", now.Add(policy.OpenDuration-time.Second))
+	require.ErrorIs(t, err, types.ErrCircuitOpen)
+	_, _, err = breakers.allow("sp2", now)
+	require.NoError(t, err)
+
+	// a single probe is sent once the circuit has been open for OpenDuration, and its failure reopens the circuit
+	now = now.Add(policy.OpenDuration)
+	state, changed, err := breakers.allow("sp1", now)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, types.CircuitHalfOpen, state)
+	_, _, err = breakers.allow("sp1", now)
+	require.ErrorIs(t, err, types.ErrCircuitOpen)
+	state, changed = breakers.record(policy, "sp1", outcomeFailure, now)
+	require.True(t, changed)
+	require.Equal(t, types.CircuitOpen, state)
+
+	// the canceled probe lets another request probe, and the successful probe closes the circuit
+	now = now.Add(policy.OpenDuration)
+	_, _, err = breakers.allow("sp1", now)
+	require.NoError(t, err)
+	_, changed = breakers.record(policy, "sp1", outcomeCanceled, now)
+	require.False(t, changed)
+	_, _, err = breakers.allow("sp1", now)
+	require.NoError(t, err)
+	state, changed = breakers.record(policy, "sp1", outcomeSuccess, now)
+	require.True(t, changed)
+	require.Equal(t, types.CircuitClosed, state)
+	_, _, err = breakers.allow("sp1", now)
+	require.NoError(t, err)
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// ClientPool - Manage the clients of many accounts, e.g. for the backends sending requests on behalf of thousands of
+// users. The clients of the pool share the HTTP transport, the chain connection and the cached SP endpoints, so that
+// the sockets and the SP info queries do not grow with the accounts.
+type ClientPool struct {
+	base    *Client
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientPool - Create a pool of clients sharing the connections and the cached state.
+//
+// - chainID: The chain ID of the Greenfield blockchain.
+//
+// - endpoint: The RPC endpoint of the Greenfield blockchain.
+//
+// - option: The configurations of all the clients of the pool. The DefaultAccount, OffChainAuthOption and
+// OffChainAuthOptionV2 are ignored since they are bound to the accounts.
+//
+// - ret1: The pool of clients.
+//
+// - ret2: Return error when the shared connections can not be created, otherwise return nil.
+func NewClientPool(chainID, endpoint string, option Option) (*ClientPool, error) {
+	option.DefaultAccount = nil
+	option.OffChainAuthOption = nil
+	option.OffChainAuthOptionV2 = nil
+	client, err := New(chainID, endpoint, option)
+	if err != nil {
+		return nil, err
+	}
+	return newClientPool(client.(*Client)), nil
+}
+
+func newClientPool(base *Client) *ClientPool {
+	return &ClientPool{base: base, clients: make(map[string]*Client)}
+}
+
+// Get - Get the client sending the txs and the SP requests by the account, the same client is returned for the
+// accounts of the same address.
+//
+// - account: The account of the client.
+//
+// - ret1: The client of the account.
+//
+// - ret2: Return error when the account is nil, otherwise return nil.
+func (p *ClientPool) Get(account *types.Account) (IClient, error) {
+	if account == nil {
+		return nil, types.ErrorDefaultAccountNotExist
+	}
+	address := account.GetAddress().String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := p.clients[address]; ok {
+		return c, nil
+	}
+	c := p.base.withAccount(account)
+	p.clients[address] = c
+	return c, nil
+}
+
+// Remove - Remove the client of the account address from the pool, e.g. when the user signs out.
+//
+// - address: The HEX-encoded string of the account address.
+func (p *ClientPool) Remove(address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, address)
+}
+
+// Len - Get the number of the clients in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// withAccount returns a copy of the client using the account as the default account, the copy shares the
+// connections and the runtime state with the client.
+func (c *Client) withAccount(account *types.Account) *Client {
+	clone := *c
+	// the chain client is copied to sign the txs by the account, the underlying connections are shared
+	chainClient := *c.chainClient
+	chainClient.SetKeyManager(account.GetKeyManager())
+	clone.chainClient = &chainClient
+	clone.defaultAccount = account
+	return &clone
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestClientPool(t *testing.T) {
+	base := newTestClient(t)
+	baseAccount := base.defaultAccount
+	pool := newClientPool(base)
+
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := types.NewAccount("bob")
+	require.NoError(t, err)
+
+	aliceClient, err := pool.Get(alice)
+	require.NoError(t, err)
+	again, err := pool.Get(alice)
+	require.NoError(t, err)
+	require.Same(t, aliceClient, again)
+	bobClient, err := pool.Get(bob)
+	require.NoError(t, err)
+	require.Equal(t, 2, pool.Len())
+
+	a, b := aliceClient.(*Client), bobClient.(*Client)
+	require.Equal(t, alice, a.MustGetDefaultAccount())
+	require.Equal(t, bob, b.MustGetDefaultAccount())
+	require.Same(t, baseAccount, base.defaultAccount)
+	// the connections and the cached state are shared
+	require.Same(t, base.httpClient, a.httpClient)
+	require.Same(t, a.state, b.state)
+	require.NotSame(t, a.chainClient, b.chainClient)
+
+	pool.Remove(alice.GetAddress().String())
+	require.Equal(t, 1, pool.Len())
+	_, err = pool.Get(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// NewFromConfig - New Greenfield Go SDK Client by the declarative config, e.g. loaded by types.LoadClientConfig from
+// a YAML or JSON file and the environment variables.
+//
+// - config: The config of the client.
+//
+// - ret1: The new client that created, in IClient format.
+//
+// - ret2: Return error when the config is invalid or new Client failed, otherwise return nil.
+func NewFromConfig(config *types.ClientConfig) (IClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	fileSystem := config.FileSystem
+	if fileSystem == nil {
+		fileSystem = types.DefaultFileSystem()
+	}
+	account, err := configAccount(fileSystem, config.Key)
+	if err != nil {
+		return nil, err
+	}
+	option := Option{
+		GrpcAddress:         config.GrpcAddress,
+		DefaultAccount:      account,
+		Secure:              config.Secure,
+		Host:                config.Host,
+		ExpireSeconds:       config.ExpireSeconds,
+		MaxResponseBodySize: config.MaxResponseBodySize,
+		DefaultPartSize:     config.DefaultPartSize,
+		BroadcastEndpoints:  config.BroadcastEndpoints,
+		RetryPolicy:         config.RetryPolicy(),
+		FileSystem:          fileSystem,
+	}
+	if config.DialTimeout != 0 || config.ResponseHeaderTimeout != 0 {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if config.DialTimeout != 0 {
+			transport.DialContext = (&net.Dialer{Timeout: time.Duration(config.DialTimeout), KeepAlive: 30 * time.Second}).DialContext
+		}
+		transport.ResponseHeaderTimeout = time.Duration(config.ResponseHeaderTimeout)
+		option.Transport = transport
+	}
+
+	cli, err := New(config.ChainID, config.RPCAddress, option)
+	if err != nil {
+		return nil, err
+	}
+	if config.Trace.Enabled {
+		output, err := configTraceOutput(fileSystem, config.Trace.Output)
+		if err != nil {
+			return nil, err
+		}
+		cli.EnableTrace(output, config.Trace.OnlyErrors)
+	}
+	return cli, nil
+}
+
+// NewWithPreset - New Greenfield Go SDK Client for a well-known network, e.g. "mainnet", "testnet" or "localnet", so
+// that the applications do not hard-code its chain id and RPC endpoint.
+//
+// - preset: The name of the network preset, see types.LookupPreset.
+//
+// - account: The default account of the Client, it allows to be nil and overrides option.DefaultAccount otherwise.
+//
+// - option: The optional configurations for the Client.
+//
+// - ret1: The new client that created, in IClient format.
+//
+// - ret2: Return error when the preset is unknown or new Client failed, otherwise return nil.
+func NewWithPreset(preset string, account *types.Account, option Option) (IClient, error) {
+	network, err := types.LookupPreset(preset)
+	if err != nil {
+		return nil, err
+	}
+	if account != nil {
+		option.DefaultAccount = account
+	}
+	return New(network.ChainID, network.RPCAddress, option)
+}
+
+// configAccount loads the default account from the key source, it returns nil if no source is set. The private key
+// file is read from fileSystem.
+func configAccount(fileSystem types.FileSystem, key types.KeyConfig) (*types.Account, error) {
+	name := key.Name
+	if name == "" {
+		name = "default"
+	}
+	switch {
+	case key.PrivateKey != "":
+		return types.NewAccountFromPrivateKey(name, key.PrivateKey)
+	case key.Mnemonic != "":
+		return types.NewAccountFromMnemonic(name, key.Mnemonic)
+	case key.PrivateKeyFile != "":
+		content, err := readConfigFile(fileSystem, key.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read the private key file %s: %w", key.PrivateKeyFile, err)
+		}
+		return types.NewAccountFromPrivateKey(name, strings.TrimSpace(string(content)))
+	}
+	return nil, nil
+}
+
+func readConfigFile(fileSystem types.FileSystem, path string) ([]byte, error) {
+	file, err := fileSystem.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// configTraceOutput returns the writer of the traces, the trace file is opened in fileSystem while the standard
+// streams are not files of any FileSystem.
+func configTraceOutput(fileSystem types.FileSystem, output string) (io.Writer, error) {
+	switch output {
+	case "", "stderr":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	}
+	// the trace file is kept open for the lifetime of the client
+	return fileSystem.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestConfigFiles(t *testing.T) {
+	fileSystem := types.NewMemFileSystem()
+	account, privateKey, err := types.NewAccount("test")
+	require.NoError(t, err)
+	file, err := fileSystem.OpenFile("gnfd_key", os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte(privateKey + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// the private key file is read from the file system of the config
+	loaded, err := configAccount(fileSystem, types.KeyConfig{Name: "test", PrivateKeyFile: "gnfd_key"})
+	require.NoError(t, err)
+	require.Equal(t, account.GetAddress(), loaded.GetAddress())
+	_, err = configAccount(fileSystem, types.KeyConfig{PrivateKeyFile: "missing"})
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// the traces are appended to the file in the file system of the config
+	output, err := configTraceOutput(fileSystem, "trace.log")
+	require.NoError(t, err)
+	_, err = output.Write([]byte("trace"))
+	require.NoError(t, err)
+	file, err = fileSystem.Open("trace.log")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, "trace", string(content))
+
+	output, err = configTraceOutput(fileSystem, "")
+	require.NoError(t, err)
+	require.Equal(t, os.Stderr, output)
+}
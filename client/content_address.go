@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// PutIfAbsent - Store the payload as the object named by its content hash unless the identical content already
+// exists, which enables the dedup-style storage where the same payload is stored once.
+//
+// The object name is derived from the sha256 of the payload, see types.ContentAddressedName. If the object exists,
+// its checksums are compared with those of the payload to detect the collisions, and the payload of an existing
+// object which is created but not uploaded yet is uploaded. The payload is read from the current offset of the
+// reader up to three times, so the reader should be seekable.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - reader: The reader of the payload.
+//
+// - opts: The options to name and upload the object.
+//
+// - ret1: The result including the derived object name and whether the object existed.
+//
+// - ret2: Return types.ErrContentCollision when the object exists with different content, or the error of any
+// failed step, otherwise return nil.
+func (c *Client) PutIfAbsent(ctx context.Context, bucketName string, reader io.ReadSeeker, opts types.PutIfAbsentOptions) (*types.PutIfAbsentResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if reader == nil {
+		return nil, errors.New("fail to put object, reader is nil")
+	}
+	start, err := reader.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	objectName, err := types.ComputeContentAddressedName(contextReader{ctx: ctx, reader: reader}, opts.Prefix, opts.Naming)
+	if err != nil {
+		return nil, err
+	}
+	// the stream ends early once ctx is done, the name of the partial payload is discarded
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	if _, err = reader.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	result := &types.PutIfAbsentResult{ObjectName: objectName}
+
+	objectDetail, err := c.HeadObject(ctx, bucketName, objectName)
+	if err != nil && !strings.Contains(err.Error(), storageTypes.ErrNoSuchObject.Error()) {
+		return nil, err
+	}
+	if err == nil {
+		if err = c.checkSameContent(ctx, objectDetail.ObjectInfo, reader); err != nil {
+			return nil, err
+		}
+		result.Existed = true
+		if objectDetail.ObjectInfo.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED || objectDetail.ObjectInfo.PayloadSize == 0 {
+			return result, nil
+		}
+		if _, err = reader.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		putOpts := opts.UploadOptions.PutOptions
+		if putOpts.ContentType == "" {
+			putOpts.ContentType = objectDetail.ObjectInfo.ContentType
+		}
+		if err = c.PutObject(ctx, bucketName, objectName, int64(objectDetail.ObjectInfo.PayloadSize), reader, putOpts); err != nil {
+			return nil, fmt.Errorf("fail to upload the payload of the existing object %s: %w", objectName, err)
+		}
+		return result, nil
+	}
+
+	result.Upload, err = c.UploadObject(ctx, bucketName, objectName, reader, opts.UploadOptions)
+	return result, err
+}
+
+// checkSameContent compares the checksums of the object on chain with those of the payload from the current offset
+// of reader, it returns types.ErrContentCollision if they are different.
+func (c *Client) checkSameContent(ctx context.Context, objectInfo *storageTypes.ObjectInfo, reader io.Reader) error {
+	checksums, size, _, err := c.ComputeHashRootsWithContext(ctx, reader, false)
+	if err != nil {
+		return err
+	}
+	same := uint64(size) == objectInfo.PayloadSize && len(checksums) == len(objectInfo.Checksums)
+	for i := 0; same && i < len(checksums); i++ {
+		same = bytes.Equal(checksums[i], objectInfo.Checksums[i])
+	}
+	if !same {
+		return fmt.Errorf("object %s: %w", objectInfo.ObjectName, types.ErrContentCollision)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+
+	"github.com/bnb-chain/greenfield/types/s3util"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// CopyObject - Copy the sealed source object to the destination object, which may be in another bucket.
+//
+// The destination object is created by the copyObject tx on chain with the checksums of the source object, so the
+// payload does not need to be hashed again. If the SP of the destination bucket does not approve the copy, or
+// opts.DisableServerSideCopy is set, the destination object is created by CreateObject from the source payload
+// instead. The payload is then streamed from the source SP to the destination SP unless the destination object is
+// already sealed, nothing is buffered locally.
+//
+// - ctx: Context variables for the current API call.
+//
+// - srcBucketName: The bucket name of the source object.
+//
+// - srcObjectName: The object name of the source object.
+//
+// - dstBucketName: The bucket name of the destination object.
+//
+// - dstObjectName: The object name of the destination object.
+//
+// - opts: The options to create and upload the destination object.
+//
+// - ret1: Transaction hash which creates the destination object.
+//
+// - ret2: Return error when the source object is not sealed or the copy failed, otherwise return nil. The created
+// destination object is kept if the upload fails, so that it can be resumed by PutObject.
+func (c *Client) CopyObject(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string,
+	opts types.CopyObjectOptions,
+) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+	for _, name := range []string{srcBucketName, dstBucketName} {
+		if err := s3util.CheckValidBucketName(name); err != nil {
+			return "", err
+		}
+	}
+	for _, name := range []string{srcObjectName, dstObjectName} {
+		if err := s3util.CheckValidObjectName(name); err != nil {
+			return "", err
+		}
+	}
+	src, err := c.HeadObject(ctx, srcBucketName, srcObjectName)
+	if err != nil {
+		return "", err
+	}
+	srcInfo := src.ObjectInfo
+	if srcInfo.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED || srcInfo.IsUpdating {
+		return "", fmt.Errorf("the source object %s is not sealed", srcObjectName)
+	}
+
+	var txnHash string
+	if !opts.DisableServerSideCopy {
+		txnHash, err = c.copyObjectOnChain(ctx, srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts)
+		if err != nil && !errors.Is(err, errCopyNotApproved) {
+			return "", err
+		}
+		if err != nil {
+			log.Warn().Msg(fmt.Sprintf("re-upload object %s for the copy: %s", dstObjectName, err.Error()))
+		}
+	}
+	if txnHash == "" {
+		body, _, err := c.GetObject(ctx, srcBucketName, srcObjectName, opts.GetOptions)
+		if err != nil {
+			return "", err
+		}
+		createOpts := opts.CreateOptions
+		createOpts.IsAsyncMode = false
+		if createOpts.ContentType == "" {
+			createOpts.ContentType = srcInfo.ContentType
+		}
+		txnHash, err = c.CreateObject(ctx, dstBucketName, dstObjectName, body, createOpts)
+		body.Close()
+		if err != nil {
+			return txnHash, err
+		}
+	}
+
+	dst, err := c.HeadObject(ctx, dstBucketName, dstObjectName)
+	if err != nil {
+		return txnHash, err
+	}
+	if dst.ObjectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED {
+		return txnHash, nil
+	}
+	body, _, err := c.GetObject(ctx, srcBucketName, srcObjectName, opts.GetOptions)
+	if err != nil {
+		return txnHash, err
+	}
+	defer body.Close()
+	return txnHash, c.PutObject(ctx, dstBucketName, dstObjectName, int64(srcInfo.PayloadSize), body, opts.PutOptions)
+}
+
+// errCopyNotApproved indicates the SP of the destination bucket does not approve the copy, the object is re-uploaded.
+var errCopyNotApproved = errors.New("the copy is not approved by SP")
+
+// copyObjectOnChain sends the copyObject tx approved by the SP of the destination bucket and waits for it.
+func (c *Client) copyObjectOnChain(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string,
+	opts types.CopyObjectOptions,
+) (string, error) {
+	copyObjectMsg := storageTypes.NewMsgCopyObject(c.MustGetDefaultAccount().GetAddress(), srcBucketName, dstBucketName,
+		srcObjectName, dstObjectName, math.MaxUint, nil)
+	signedMsg, err := c.getCopyObjectApproval(ctx, copyObjectMsg)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errCopyNotApproved, err)
+	}
+	txnHash, err := c.sendTxn(ctx, signedMsg, opts.TxOpts)
+	if err != nil {
+		return "", err
+	}
+	txnResponse, err := c.WaitForTx(ctx, txnHash)
+	if err != nil {
+		return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+	}
+	if txnResponse.TxResult.Code != 0 {
+		return txnHash, fmt.Errorf("the copyObject txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+	}
+	return txnHash, nil
+}
+
+// getCopyObjectApproval returns the copyObject msg signed by the primary SP of the destination bucket.
+func (c *Client) getCopyObjectApproval(ctx context.Context, copyObjectMsg *storageTypes.MsgCopyObject) (*storageTypes.MsgCopyObject, error) {
+	unsignedBytes := copyObjectMsg.GetSignBytes()
+
+	reqMeta := requestMeta{
+		urlValues:     url.Values{"action": {types.CopyObjectAction}},
+		urlRelPath:    "get-approval",
+		contentSHA256: types.EmptyStringSHA256,
+		txnMsg:        hex.EncodeToString(unsignedBytes),
+	}
+
+	sendOpt := sendOptions{
+		method: http.MethodGet,
+		adminInfo: AdminAPIInfo{
+			isAdminAPI:   true,
+			adminVersion: types.AdminV1Version,
+		},
+	}
+
+	bucketName := copyObjectMsg.DstBucketName
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
+	if err != nil {
+		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		return nil, err
+	}
+
+	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch primary signed msg from sp response
+	signedRawMsg := resp.Header.Get(types.HTTPHeaderSignedMsg)
+	if signedRawMsg == "" {
+		return nil, errors.New("fail to fetch pre copyObject signature")
+	}
+
+	signedMsgBytes, err := hex.DecodeString(signedRawMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	var signedMsg storageTypes.MsgCopyObject
+	if err = storageTypes.ModuleCdc.UnmarshalJSON(signedMsgBytes, &signedMsg); err != nil {
+		return nil, err
+	}
+	return &signedMsg, nil
+}
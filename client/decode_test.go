@@ -0,0 +1,37 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// FuzzDecodeIDsResponse feeds arbitrary content to the xml types which implement their own UnmarshalXML.
+func FuzzDecodeIDsResponse(f *testing.F) {
+	f.Add([]byte("<GfSpListBucketsByIDsResponse><BucketEntry><Id>1</Id><Value><BucketInfo><BucketName>b</BucketName></BucketInfo></Value></BucketEntry></GfSpListBucketsByIDsResponse>"))
+	f.Add([]byte("<GfSpListObjectsByIDsResponse><ObjectEntry><Id>2</Id><Value></Value></ObjectEntry><ObjectEntry><Id>3</Id></ObjectEntry></GfSpListObjectsByIDsResponse>"))
+	f.Add([]byte("<GfSpListGroupsByIDsResponse><GroupEntry><Id>4</Id><Value><Group><GroupName>g</GroupName></Group></Value></GroupEntry></GfSpListGroupsByIDsResponse>"))
+	f.Add([]byte("<GfSpListGroupsByIDsResponse><GroupEntry><Id>x</Id>"))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		buckets := map[uint64]*types.BucketMeta{}
+		checkDecodeIDsResponse(t, body, types.DecodeXML(body, (*listBucketsByIDsResponse)(&buckets)))
+		objects := map[uint64]*types.ObjectMeta{}
+		checkDecodeIDsResponse(t, body, types.DecodeXML(body, (*listObjectsByIDsResponse)(&objects)))
+		groups := map[uint64]*types.GroupMeta{}
+		checkDecodeIDsResponse(t, body, types.DecodeXML(body, (*gfSpListGroupsByGroupIDsResponse)(&groups)))
+	})
+}
+
+func checkDecodeIDsResponse(t *testing.T, body []byte, err error) {
+	if err == nil {
+		return
+	}
+	var decodeErr *types.DecodeError
+	require.True(t, errors.As(err, &decodeErr), "unexpected error type: %T", err)
+	require.LessOrEqual(t, len(decodeErr.Snippet), types.DecodeErrSnippetSize)
+	require.True(t, bytes.HasPrefix(body, []byte(decodeErr.Snippet)))
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// auditedReadCloser counts the bytes read from the payload and reports the receipt once the payload is read to the
+// end or closed.
+type auditedReadCloser struct {
+	io.ReadCloser
+	receipt types.DownloadReceipt
+	hook    types.DownloadAuditHook
+	now     func() time.Time
+	once    sync.Once
+}
+
+func (r *auditedReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.receipt.Bytes += int64(n)
+	if errors.Is(err, io.EOF) {
+		r.receipt.Complete = true
+		r.report()
+	}
+	return n, err
+}
+
+func (r *auditedReadCloser) Close() error {
+	r.report()
+	return r.ReadCloser.Close()
+}
+
+func (r *auditedReadCloser) report() {
+	r.once.Do(func() {
+		r.receipt.CompletedAt = r.now()
+		r.hook(r.receipt)
+	})
+}
+
+// auditDownload wraps the payload of the response to report its receipt by the download audit hook of the client, the
+// payload is returned as is if the hook is not set.
+func (c *Client) auditDownload(ctx context.Context, resp *http.Response, bucketName, objectName, rangeInfo string,
+	endpoint, cdnEndpoint *url.URL,
+) io.ReadCloser {
+	if c.downloadAuditHook == nil {
+		return resp.Body
+	}
+	receipt := types.DownloadReceipt{
+		BucketName:    bucketName,
+		ObjectName:    objectName,
+		Range:         rangeInfo,
+		ContentLength: resp.ContentLength,
+		RequestID:     resp.Header.Get(types.HTTPHeaderRequestID),
+	}
+	if endpoint != nil {
+		receipt.Endpoint = endpoint.Host
+	}
+	if cdnEndpoint != nil {
+		receipt.CDNEndpoint = cdnEndpoint.Host
+	}
+	if c.defaultAccount != nil {
+		receipt.Requester = c.defaultAccount.GetAddress().String()
+	}
+	// the lookup is memoized by the head cache of the download
+	if detail, err := c.HeadObject(ctx, bucketName, objectName); err == nil {
+		receipt.ObjectID = detail.ObjectInfo.Id.String()
+	} else {
+		log.Warn().Msg(fmt.Sprintf("fail to query the id of object %s for the download receipt, err: %s", objectName, err))
+	}
+	return &auditedReadCloser{ReadCloser: resp.Body, receipt: receipt, hook: c.downloadAuditHook, now: c.now}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestAuditedReadCloser(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	newAudited := func(receipts *[]types.DownloadReceipt) *auditedReadCloser {
+		return &auditedReadCloser{
+			ReadCloser: io.NopCloser(strings.NewReader("0123456789")),
+			receipt:    types.DownloadReceipt{ObjectName: "object", ContentLength: 10},
+			hook:       func(receipt types.DownloadReceipt) { *receipts = append(*receipts, receipt) },
+			now:        func() time.Time { return now },
+		}
+	}
+
+	// the receipt is reported once when the payload is read to the end and then closed
+	receipts := make([]types.DownloadReceipt, 0)
+	audited := newAudited(&receipts)
+	data, err := io.ReadAll(audited)
+	require.NoError(t, err)
+	require.Equal(t, "0123456789", string(data))
+	require.NoError(t, audited.Close())
+	require.Len(t, receipts, 1)
+	require.Equal(t, int64(10), receipts[0].Bytes)
+	require.True(t, receipts[0].Complete)
+	require.Equal(t, now, receipts[0].CompletedAt)
+
+	// the payload closed early is reported as incomplete
+	receipts = receipts[:0]
+	audited = newAudited(&receipts)
+	_, err = io.ReadFull(audited, make([]byte, 4))
+	require.NoError(t, err)
+	require.NoError(t, audited.Close())
+	require.Len(t, receipts, 1)
+	require.Equal(t, int64(4), receipts[0].Bytes)
+	require.False(t, receipts[0].Complete)
+}
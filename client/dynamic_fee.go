@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	sdkmath "cosmossdk.io/math"
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+)
+
+// broadcastTxWithDynamicFee broadcasts the transaction with the gas limit and the fee estimated from the simulation,
+// the fee is the min gas price multiplied by the policy, and it is bumped when the tx is rejected for the insufficient
+// fee, e.g. the min gas price of the node rises after the simulation.
+func (c *Client) broadcastTxWithDynamicFee(ctx context.Context, msgs []sdk.Msg, txOpt *gnfdsdk.TxOption,
+	opts ...grpc.CallOption,
+) (*tx.BroadcastTxResponse, error) {
+	option := gnfdsdk.TxOption{}
+	if txOpt != nil {
+		option = *txOpt
+	}
+	gasLimit, gasPrice, err := c.estimateGas(ctx, msgs, option)
+	if err != nil {
+		return nil, fmt.Errorf("fail to estimate the fee of the tx: %w", err)
+	}
+	policy := c.dynamicFeePolicy
+	gasPrice.Amount = scaleAmount(gasPrice.Amount, policy.Multiplier)
+	// the gas limit and the fee of the tx are only used by the chain client when the simulation is skipped
+	option.NoSimulate = true
+	option.GasLimit = gasLimit
+	for bump := 0; ; bump++ {
+		option.FeeAmount = txFee(gasPrice, gasLimit)
+		resp, err := c.broadcastTx(ctx, msgs, &option, opts...)
+		if err == nil || bump >= policy.MaxBumps || !isInsufficientFee(resp) {
+			return resp, err
+		}
+		gasPrice.Amount = scaleAmount(gasPrice.Amount, policy.BumpMultiplier)
+		log.Warn().Msg(fmt.Sprintf("the tx is rejected for the insufficient fee %s, retry with the gas price %s, bump: %d",
+			option.FeeAmount, gasPrice, bump+1))
+	}
+}
+
+// useDynamicFee reports whether the tx is broadcast in the dynamic fee mode, the txs whose gas limits or fees are set
+// by the caller, or whose simulations are skipped, are broadcast as they are.
+func (c *Client) useDynamicFee(txOpt *gnfdsdk.TxOption) bool {
+	if c.dynamicFeePolicy == nil {
+		return false
+	}
+	return txOpt == nil || (!txOpt.NoSimulate && txOpt.GasLimit == 0 && txOpt.FeeAmount.IsZero())
+}
+
+// isInsufficientFee reports whether the tx is rejected for the insufficient fee.
+func isInsufficientFee(resp *tx.BroadcastTxResponse) bool {
+	if resp == nil || resp.TxResponse == nil {
+		return false
+	}
+	return resp.TxResponse.Codespace == sdkerrors.RootCodespace && resp.TxResponse.Code == sdkerrors.ErrInsufficientFee.ABCICode()
+}
+
+// scaleAmount multiplies the amount by the multiplier in the precision of 1/1000 and rounds it up, the amount is
+// increased by 1 at least if the multiplier is more than 1.
+func scaleAmount(amount sdkmath.Int, multiplier float64) sdkmath.Int {
+	if multiplier == 1 {
+		return amount
+	}
+	scaled := amount.MulRaw(int64(math.Ceil(multiplier * 1000))).AddRaw(999).QuoRaw(1000)
+	if multiplier > 1 && scaled.LTE(amount) {
+		return amount.AddRaw(1)
+	}
+	return scaled
+}
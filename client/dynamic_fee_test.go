@@ -0,0 +1,53 @@
+package client
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestScaleAmount(t *testing.T) {
+	require.Equal(t, sdkmath.NewInt(5000000000), scaleAmount(sdkmath.NewInt(5000000000), 1))
+	require.Equal(t, sdkmath.NewInt(7500000000), scaleAmount(sdkmath.NewInt(5000000000), 1.5))
+	// the scaled amount is rounded up
+	require.Equal(t, sdkmath.NewInt(12), scaleAmount(sdkmath.NewInt(11), 1.001))
+	// the small amount is increased at least by 1
+	require.Equal(t, sdkmath.NewInt(2), scaleAmount(sdkmath.NewInt(1), 1.2))
+	require.Equal(t, sdkmath.NewInt(1), scaleAmount(sdkmath.NewInt(0), 1.5))
+}
+
+func TestIsInsufficientFee(t *testing.T) {
+	newResp := func(codespace string, code uint32) *tx.BroadcastTxResponse {
+		return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{Codespace: codespace, Code: code}}
+	}
+	require.True(t, isInsufficientFee(newResp(sdkerrors.RootCodespace, sdkerrors.ErrInsufficientFee.ABCICode())))
+	require.False(t, isInsufficientFee(newResp(sdkerrors.RootCodespace, sdkerrors.ErrOutOfGas.ABCICode())))
+	require.False(t, isInsufficientFee(newResp("storage", sdkerrors.ErrInsufficientFee.ABCICode())))
+	require.False(t, isInsufficientFee(newResp(sdkerrors.RootCodespace, 0)))
+	require.False(t, isInsufficientFee(nil))
+}
+
+func TestTxFee(t *testing.T) {
+	fee := txFee(sdk.NewCoin("BNB", sdkmath.NewInt(5000000000)), 1200)
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin("BNB", sdkmath.NewInt(6000000000000))), fee)
+}
+
+func TestUseDynamicFee(t *testing.T) {
+	c := newTestClient(t)
+	require.False(t, c.useDynamicFee(nil))
+
+	c.dynamicFeePolicy = &types.DynamicFeePolicy{Multiplier: 1, BumpMultiplier: 1.5, MaxBumps: 3}
+	require.True(t, c.useDynamicFee(nil))
+	require.True(t, c.useDynamicFee(&gnfdsdk.TxOption{Memo: "memo"}))
+	// the gas limit, the fee or the skipped simulation of the caller are kept
+	require.False(t, c.useDynamicFee(&gnfdsdk.TxOption{GasLimit: 1000}))
+	require.False(t, c.useDynamicFee(&gnfdsdk.TxOption{FeeAmount: sdk.NewCoins(sdk.NewCoin("BNB", sdkmath.NewInt(1)))}))
+	require.False(t, c.useDynamicFee(&gnfdsdk.TxOption{NoSimulate: true}))
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"io"
+	"runtime"
+
+	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// contextReader ends the stream once ctx is done, so that the hashing over it stops at the next segment instead of
+// reading the whole payload. The stream is ended by io.EOF rather than an error, since the parallel hashing does not
+// stop its workers on the read errors, so the callers should check ctx.Err() after the hashing.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if r.ctx.Err() != nil {
+		return 0, io.EOF
+	}
+	return r.reader.Read(p)
+}
+
+// computeIntegrityHash computes the checksums of the payload like hashlib.ComputeIntegrityHash, it returns ctx.Err()
+// promptly once ctx is done. The payload is hashed serially on a single CPU, since the parallel hashing starts
+// NumCPU/2 workers and so none at all there.
+func computeIntegrityHash(ctx context.Context, reader io.Reader, segmentSize int64, dataShards, parityShards int,
+	isSerial bool,
+) ([][]byte, int64, storageTypes.RedundancyType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, storageTypes.REDUNDANCY_EC_TYPE, err
+	}
+	if runtime.NumCPU() < 2 {
+		isSerial = true
+	}
+	checksums, size, redundancyType, err := hashlib.ComputeIntegrityHash(contextReader{ctx: ctx, reader: reader},
+		segmentSize, dataShards, parityShards, isSerial)
+	// the checksums of the truncated payload are discarded
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, 0, storageTypes.REDUNDANCY_EC_TYPE, ctxErr
+	}
+	return checksums, size, redundancyType, err
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+)
+
+// cancelingReader cancels the context after the first read.
+type cancelingReader struct {
+	reader io.Reader
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (r *cancelingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads == 1 {
+		defer r.cancel()
+	}
+	return r.reader.Read(p)
+}
+
+func TestComputeIntegrityHashCanceled(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 64*1024)
+	for _, isSerial := range []bool{true, false} {
+		ctx, cancel := context.WithCancel(context.Background())
+		reader := &cancelingReader{reader: bytes.NewReader(payload), cancel: cancel}
+		_, _, _, err := computeIntegrityHash(ctx, reader, 1024, 4, 2, isSerial)
+		require.ErrorIs(t, err, context.Canceled)
+		// the hashing stops at the next segment rather than reading the whole payload
+		require.Equal(t, 1, reader.reads)
+	}
+
+	checksums, size, _, err := computeIntegrityHash(context.Background(), bytes.NewReader(payload), 1024, 4, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(payload)), size)
+	require.Len(t, checksums, 7)
+}
+
+func TestComputeHashRootsWithContext(t *testing.T) {
+	c := newStorageQueryTestClient(t, &fakeStorageQueryClient{
+		params: func() (*storageTypes.QueryParamsResponse, error) {
+			return &storageTypes.QueryParamsResponse{Params: storageTypes.Params{VersionedParams: storageTypes.VersionedParams{
+				MaxSegmentSize:          1024,
+				RedundantDataChunkNum:   4,
+				RedundantParityChunkNum: 2,
+			}}}, nil
+		},
+	})
+	payload := bytes.Repeat([]byte("a"), 64*1024)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := &cancelingReader{reader: bytes.NewReader(payload), cancel: cancel}
+	_, _, _, err := c.ComputeHashRootsWithContext(ctx, reader, false)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, reader.reads)
+
+	// ComputeHashRoots is not canceled
+	checksums, size, redundancyType, err := c.ComputeHashRoots(bytes.NewReader(payload), false)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(payload)), size)
+	require.Equal(t, storageTypes.REDUNDANCY_EC_TYPE, redundancyType)
+	require.Len(t, checksums, 7)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+type headCacheKey struct{}
+
+type headObjectKey struct {
+	bucketName string
+	objectName string
+}
+
+// headCache memoizes the HeadBucket and HeadObject results within a single operation, only the successful lookups are
+// cached so that the failed ones are retried.
+type headCache struct {
+	mu      sync.Mutex
+	buckets map[string]*storageTypes.BucketInfo
+	objects map[headObjectKey]*types.ObjectDetail
+}
+
+// WithHeadCache - Return a context which memoizes the HeadBucket and HeadObject lookups of the APIs called with it.
+//
+// The uploads and downloads enable the memoization by themselves, the callers can wrap the context of a sequence of
+// API calls on the same buckets and objects to share the lookups. The memoized results are not refreshed, so the
+// context should not outlive the operation, or the changes of the buckets and objects on chain are missed. The bucket
+// is looked up again once its SP rejects a request for it, so that the requests follow the bucket after it is
+// migrated to another SP.
+//
+// - ctx: The parent context, it is returned as is if it already memoizes the lookups.
+//
+// - ret: The context memoizing the lookups.
+func WithHeadCache(ctx context.Context) context.Context {
+	if headCacheFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, headCacheKey{}, &headCache{
+		buckets: make(map[string]*storageTypes.BucketInfo),
+		objects: make(map[headObjectKey]*types.ObjectDetail),
+	})
+}
+
+// withoutHeadCache returns a context whose lookups are not memoized, e.g. to poll the changes of an object.
+func withoutHeadCache(ctx context.Context) context.Context {
+	if headCacheFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, headCacheKey{}, (*headCache)(nil))
+}
+
+func headCacheFromContext(ctx context.Context) *headCache {
+	cache, _ := ctx.Value(headCacheKey{}).(*headCache)
+	return cache
+}
+
+func (h *headCache) getBucket(bucketName string) (*storageTypes.BucketInfo, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	bucketInfo, ok := h.buckets[bucketName]
+	return bucketInfo, ok
+}
+
+func (h *headCache) setBucket(bucketName string, bucketInfo *storageTypes.BucketInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buckets[bucketName] = bucketInfo
+}
+
+// deleteBucket drops the memoized bucket, e.g. when its primary SP has changed by the migration.
+func (h *headCache) deleteBucket(bucketName string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.buckets, bucketName)
+}
+
+func (h *headCache) getObject(bucketName, objectName string) (*types.ObjectDetail, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	objectDetail, ok := h.objects[headObjectKey{bucketName: bucketName, objectName: objectName}]
+	return objectDetail, ok
+}
+
+func (h *headCache) setObject(bucketName, objectName string, objectDetail *types.ObjectDetail) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.objects[headObjectKey{bucketName: bucketName, objectName: objectName}] = objectDetail
+}
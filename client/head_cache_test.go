@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestWithHeadCache(t *testing.T) {
+	require.Nil(t, headCacheFromContext(context.Background()))
+
+	ctx := WithHeadCache(context.Background())
+	cache := headCacheFromContext(ctx)
+	require.NotNil(t, cache)
+	// the nested operations share the cache of the outer one
+	require.Equal(t, ctx, WithHeadCache(ctx))
+
+	_, ok := cache.getBucket("bucket")
+	require.False(t, ok)
+	bucketInfo := &storageTypes.BucketInfo{BucketName: "bucket"}
+	cache.setBucket("bucket", bucketInfo)
+	cached, ok := cache.getBucket("bucket")
+	require.True(t, ok)
+	require.Same(t, bucketInfo, cached)
+	cache.deleteBucket("bucket")
+	_, ok = cache.getBucket("bucket")
+	require.False(t, ok)
+	cache.setBucket("bucket", bucketInfo)
+
+	objectDetail := &types.ObjectDetail{ObjectInfo: &storageTypes.ObjectInfo{BucketName: "bucket", ObjectName: "a/b"}}
+	cache.setObject("bucket", "a/b", objectDetail)
+	_, ok = cache.getObject("bucket/a", "b")
+	require.False(t, ok)
+	cachedObject, ok := cache.getObject("bucket", "a/b")
+	require.True(t, ok)
+	require.Same(t, objectDetail, cachedObject)
+}
+
+func TestIsRoutingError(t *testing.T) {
+	require.True(t, isRoutingError(types.ErrResponse{Code: types.SPErrCodeNoSuchBucket, StatusCode: http.StatusNotFound}))
+	require.True(t, isRoutingError(&types.SPError{Code: types.SPErrCodeAccessDenied, HTTPStatus: http.StatusForbidden}))
+	require.False(t, isRoutingError(types.ErrResponse{Code: types.SPErrCodeNoSuchObject, StatusCode: http.StatusNotFound}))
+	require.False(t, isRoutingError(types.ErrResponse{Code: types.SPErrCodeInternalError, StatusCode: http.StatusInternalServerError}))
+	require.False(t, isRoutingError(errors.New("connection reset")))
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// listShardFunc lists all the objects and the common prefixes under the prefix.
+type listShardFunc func(ctx context.Context, prefix string) ([]*types.ObjectMeta, []string, error)
+
+type listShard struct {
+	objects        []*types.ObjectMeta
+	commonPrefixes []string
+}
+
+// ListObjectsParallel - List the objects under the prefixes concurrently and merge them in the order of the object
+// names, it cuts the enumeration time of the large namespaces structured by the date or hash prefixes.
+//
+// Each prefix is listed page by page by ListObjects, and the objects of all the prefixes are held in memory until
+// merged. The prefixes covered by others are dropped, e.g. "logs/2024/" is covered by "logs/", so each object is
+// listed once. An empty prefix covers the whole bucket.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - prefixes: The prefixes to list.
+//
+// - opts: The options to list the objects.
+//
+// - ret1: The merged result, IsTruncated is always false.
+//
+// - ret2: Return error when listing any prefix failed, the other prefixes are canceled, otherwise return nil.
+func (c *Client) ListObjectsParallel(ctx context.Context, bucketName string, prefixes []string,
+	opts types.ListObjectsParallelOptions,
+) (types.ListObjectsResult, error) {
+	if err := opts.Validate(); err != nil {
+		return types.ListObjectsResult{}, err
+	}
+	listOpts := types.ListObjectsOptions{
+		ShowRemovedObject: opts.ShowRemovedObject,
+		Delimiter:         opts.Delimiter,
+		MaxKeys:           opts.MaxKeys,
+		Endpoint:          opts.Endpoint,
+		SPAddress:         opts.SPAddress,
+		Consistency:       opts.Consistency,
+	}
+	objects, commonPrefixes, err := listShardsInOrder(ctx, compactPrefixes(prefixes), opts.Concurrency,
+		func(ctx context.Context, prefix string) ([]*types.ObjectMeta, []string, error) {
+			shardOpts := listOpts
+			shardOpts.Prefix = prefix
+			objects := make([]*types.ObjectMeta, 0)
+			commonPrefixes := make([]string, 0)
+			for {
+				result, err := c.ListObjects(ctx, bucketName, shardOpts)
+				if err != nil {
+					return nil, nil, err
+				}
+				objects = append(objects, result.Objects...)
+				commonPrefixes = append(commonPrefixes, result.CommonPrefixes...)
+				if !result.IsTruncated {
+					return objects, commonPrefixes, nil
+				}
+				shardOpts.ContinuationToken = result.NextContinuationToken
+			}
+		})
+	if err != nil {
+		return types.ListObjectsResult{}, err
+	}
+	return types.ListObjectsResult{
+		Objects:        objects,
+		KeyCount:       strconv.Itoa(len(objects)),
+		Name:           bucketName,
+		Delimiter:      opts.Delimiter,
+		CommonPrefixes: commonPrefixes,
+	}, nil
+}
+
+// compactPrefixes sorts the prefixes and drops the duplicated ones and the ones covered by others, so the keys under
+// the remaining prefixes are disjoint and in the order of the prefixes.
+func compactPrefixes(prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return []string{""}
+	}
+	sorted := append([]string{}, prefixes...)
+	sort.Strings(sorted)
+	compacted := make([]string, 0, len(sorted))
+	for _, prefix := range sorted {
+		// a covering prefix sorts before the prefixes it covers
+		if n := len(compacted); n > 0 && strings.HasPrefix(prefix, compacted[n-1]) {
+			continue
+		}
+		compacted = append(compacted, prefix)
+	}
+	return compacted
+}
+
+// listShardsInOrder lists the disjoint sorted prefixes with bounded concurrency, and concatenates the results in the
+// order of the prefixes. The first error cancels the remaining prefixes.
+func listShardsInOrder(ctx context.Context, prefixes []string, concurrency int, list listShardFunc) ([]*types.ObjectMeta, []string, error) {
+	if concurrency <= 0 {
+		concurrency = types.DefaultListConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	shards := make([]listShard, len(prefixes))
+	tasks := make(chan int)
+	for i := 0; i < concurrency && i < len(prefixes); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range tasks {
+				objects, commonPrefixes, err := list(ctx, prefixes[index])
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				shards[index] = listShard{objects: objects, commonPrefixes: commonPrefixes}
+			}
+		}()
+	}
+
+feedTasks:
+	for index := range prefixes {
+		select {
+		case tasks <- index:
+		case <-ctx.Done():
+			break feedTasks
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	objects := make([]*types.ObjectMeta, 0)
+	commonPrefixes := make([]string, 0)
+	for _, shard := range shards {
+		objects = append(objects, shard.objects...)
+		commonPrefixes = append(commonPrefixes, shard.commonPrefixes...)
+	}
+	return objects, commonPrefixes, nil
+}
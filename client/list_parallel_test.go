@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestCompactPrefixes(t *testing.T) {
+	require.Equal(t, []string{""}, compactPrefixes(nil))
+	require.Equal(t, []string{""}, compactPrefixes([]string{"a/", ""}))
+	require.Equal(t, []string{"a/", "b/", "c"},
+		compactPrefixes([]string{"c", "b/", "a/2024/", "a/", "b/", "c/d"}))
+}
+
+func TestListShardsInOrder(t *testing.T) {
+	prefixes := []string{"a/", "b/", "c/", "d/"}
+	metas := make(map[string]*types.ObjectMeta)
+	for _, prefix := range prefixes {
+		metas[prefix] = &types.ObjectMeta{}
+	}
+	objects, commonPrefixes, err := listShardsInOrder(context.Background(), prefixes, 2,
+		func(ctx context.Context, prefix string) ([]*types.ObjectMeta, []string, error) {
+			return []*types.ObjectMeta{metas[prefix]}, []string{prefix + "x/"}, nil
+		})
+	require.NoError(t, err)
+	require.Len(t, objects, len(prefixes))
+	for i, prefix := range prefixes {
+		require.Same(t, metas[prefix], objects[i])
+		require.Equal(t, prefix+"x/", commonPrefixes[i])
+	}
+
+	// the first error cancels the remaining prefixes
+	var listed int32
+	errList := errors.New("list failed")
+	_, _, err = listShardsInOrder(context.Background(), prefixes, 1,
+		func(ctx context.Context, prefix string) ([]*types.ObjectMeta, []string, error) {
+			atomic.AddInt32(&listed, 1)
+			return nil, nil, errList
+		})
+	require.ErrorIs(t, err, errList)
+	require.Less(t, atomic.LoadInt32(&listed), int32(len(prefixes)))
+}
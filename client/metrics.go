@@ -0,0 +1,63 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// observeSPRequest reports the attempt of req to the metrics of the client, the response body is counted as the
+// downloaded bytes when it is read. It returns the response with the counted body.
+func (c *Client) observeSPRequest(req *http.Request, resp *http.Response, err error, start time.Time) *http.Response {
+	if c.metrics == nil {
+		return resp
+	}
+	metric := types.SPRequestMetric{Method: req.Method, Endpoint: req.URL.Host, Latency: time.Since(start)}
+	if resp != nil {
+		metric.StatusCode = resp.StatusCode
+		if req.ContentLength > 0 {
+			c.metrics.AddTransferredBytes(types.TransferUpload, req.ContentLength)
+		}
+	}
+	if spErr, ok := types.AsSPError(err); ok {
+		metric.ErrorCode = spErr.Code
+	}
+	c.metrics.ObserveSPRequest(metric)
+	if err == nil && resp != nil && resp.Body != nil {
+		resp.Body = &countingReadCloser{ReadCloser: resp.Body, metrics: c.metrics}
+	}
+	return resp
+}
+
+// countingReadCloser reports the bytes read from the response body as the downloaded bytes
+type countingReadCloser struct {
+	io.ReadCloser
+	metrics types.Metrics
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.metrics.AddTransferredBytes(types.TransferDownload, int64(n))
+	}
+	return n, err
+}
+
+// observeTxBroadcast reports the broadcast of a tx with msgs messages to the metrics of the client, resp is nil if the
+// broadcast fails before a response.
+func (c *Client) observeTxBroadcast(msgs int, resp *tx.BroadcastTxResponse, start time.Time) {
+	if c.metrics == nil {
+		return
+	}
+	metric := types.TxBroadcastMetric{Msgs: msgs, Latency: time.Since(start)}
+	if resp != nil && resp.TxResponse != nil {
+		metric.Success = resp.TxResponse.Code == 0
+		metric.Code = resp.TxResponse.Code
+		metric.Codespace = resp.TxResponse.Codespace
+	}
+	c.metrics.ObserveTxBroadcast(metric)
+}
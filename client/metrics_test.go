@@ -0,0 +1,55 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+type fakeMetrics struct {
+	mu       sync.Mutex
+	requests []types.SPRequestMetric
+	bytes    map[types.TransferDirection]int64
+}
+
+func (m *fakeMetrics) ObserveSPRequest(metric types.SPRequestMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, metric)
+}
+
+func (m *fakeMetrics) AddTransferredBytes(direction types.TransferDirection, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytes[direction] += bytes
+}
+
+func (m *fakeMetrics) ObserveTxBroadcast(types.TxBroadcastMetric) {}
+
+func TestObserveSPRequest(t *testing.T) {
+	metrics := &fakeMetrics{bytes: make(map[types.TransferDirection]int64)}
+	c := newTestClient(t, func(c *Client) { c.metrics = metrics })
+	req := &http.Request{Method: http.MethodPut, URL: &url.URL{Host: "sp.example"}, ContentLength: 7}
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("payload"))}
+
+	resp = c.observeSPRequest(req, resp, nil, time.Now())
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "payload", string(body))
+	require.Equal(t, int64(7), metrics.bytes[types.TransferUpload])
+	require.Equal(t, int64(7), metrics.bytes[types.TransferDownload])
+
+	spErr := &types.SPError{Code: "NoSuchObject", HTTPStatus: http.StatusNotFound}
+	c.observeSPRequest(req, &http.Response{StatusCode: http.StatusNotFound}, spErr, time.Now())
+	require.Len(t, metrics.requests, 2)
+	require.Equal(t, "sp.example", metrics.requests[0].Endpoint)
+	require.Equal(t, "NoSuchObject", metrics.requests[1].ErrorCode)
+}
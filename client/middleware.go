@@ -0,0 +1,15 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// doSPRequest sends the request to SP through the middlewares of the client.
+func (c *Client) doSPRequest(req *http.Request) (*http.Response, error) {
+	if len(c.middlewares) == 0 {
+		return c.httpClient.Do(req)
+	}
+	return types.ChainMiddlewares(c.httpClient.Do, c.middlewares...)(req)
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
+	"github.com/bnb-chain/greenfield-common/go/redundancy"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	virtualgroupTypes "github.com/bnb-chain/greenfield/x/virtualgroup/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// isSPUnavailable reports whether the SP request fails for the SP is unreachable or broken, i.e. the connection
+// failures, the 5xx responses and the open circuit breakers, rather than the request is rejected by the SP.
+func isSPUnavailable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, types.ErrCircuitOpen) {
+		return true
+	}
+	if spErr, ok := types.AsSPError(err); ok {
+		return spErr.HTTPStatus >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// getObjectFromSecondaries reconstructs the payload of the object from the pieces stored by the secondary SPs of its
+// global virtual group, it is the fallback of GetObject when the primary SP is unavailable.
+func (c *Client) getObjectFromSecondaries(ctx context.Context, bucketName, objectName string,
+	opts types.GetObjectOptions,
+) (io.ReadCloser, types.ObjectStat, error) {
+	detail, err := c.HeadObject(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	if detail.ObjectInfo.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return nil, types.ObjectStat{}, fmt.Errorf("the object %s is not sealed, its pieces are not stored by the secondary SPs", objectName)
+	}
+	if detail.GlobalVirtualGroup == nil {
+		return nil, types.ObjectStat{}, errors.New("the global virtual group of the object is not found")
+	}
+	plan, err := c.planParallelDownload(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	if plan.endOffset < plan.startOffset {
+		// nothing to reconstruct for the empty object
+		return io.NopCloser(bytes.NewReader(nil)), plan.objStat, nil
+	}
+	params, err := c.getRedundancyParams()
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+
+	source := &secondaryPieceSource{
+		c:           c,
+		objectInfo:  detail.ObjectInfo,
+		gvg:         detail.GlobalVirtualGroup,
+		params:      params,
+		pieceHashes: make(map[int][][]byte),
+		failed:      make(map[int]error),
+	}
+	reader := newSegmentReader(ctx, plan.startOffset, plan.endOffset, params.segmentSize, source.reconstructSegment)
+	body := readCloserWithRateLimit(ctx, reader, c.downloadLimiter, callRateLimiterFromContext(ctx))
+	return readCloserWithProgress(body, newProgressTracker(opts.Progress, 0, plan.objStat.Size)), plan.objStat, nil
+}
+
+// secondaryPieceSource fetches the pieces of an object from its secondary SPs by the challenge API, and verifies them
+// against the checksums on chain. The SPs failing to serve the valid pieces are skipped for the following segments.
+type secondaryPieceSource struct {
+	c          *Client
+	objectInfo *storageTypes.ObjectInfo
+	gvg        *virtualgroupTypes.GlobalVirtualGroup
+	params     redundancyParams
+	// pieceHashes are the piece hashes of the secondary SPs verified against the checksums, keyed by the redundancy index
+	pieceHashes map[int][][]byte
+	// failed are the errors of the secondary SPs skipped, keyed by the redundancy index
+	failed map[int]error
+}
+
+// reconstructSegment returns the content of the segment, which is decoded from the erasure coded pieces of the
+// secondary SPs, or copied from any secondary SP if the object is replicated.
+func (s *secondaryPieceSource) reconstructSegment(ctx context.Context, segment int64) ([]byte, error) {
+	segmentSize := s.params.segmentSize
+	if remaining := int64(s.objectInfo.PayloadSize) - segment*s.params.segmentSize; remaining < segmentSize {
+		segmentSize = remaining
+	}
+	required := s.params.dataBlocks
+	if s.objectInfo.RedundancyType == storageTypes.REDUNDANCY_REPLICA_TYPE {
+		required = 1
+	}
+
+	pieces := make([][]byte, len(s.gvg.SecondarySpIds))
+	fetched := 0
+	for redundancyIndex := range s.gvg.SecondarySpIds {
+		if fetched == required {
+			break
+		}
+		if _, ok := s.failed[redundancyIndex]; ok {
+			continue
+		}
+		piece, err := s.fetchPiece(ctx, segment, redundancyIndex)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Warn().Msg(fmt.Sprintf("skip the secondary SP %d of object %s, err: %s",
+				s.gvg.SecondarySpIds[redundancyIndex], s.objectInfo.ObjectName, err))
+			s.failed[redundancyIndex] = err
+			continue
+		}
+		if required == 1 {
+			return piece, nil
+		}
+		pieces[redundancyIndex] = piece
+		fetched++
+	}
+	if fetched < required {
+		return nil, fmt.Errorf("got %d valid pieces of segment %d from the secondary SPs, expect %d: %w",
+			fetched, segment, required, errors.Join(s.failedErrors()...))
+	}
+	return redundancy.DecodeRawSegment(pieces, segmentSize, s.params.dataBlocks, s.params.parityBlocks)
+}
+
+// fetchPiece downloads the piece of the segment from the secondary SP of the redundancy index and verifies it.
+func (s *secondaryPieceSource) fetchPiece(ctx context.Context, segment int64, redundancyIndex int) ([]byte, error) {
+	result, err := s.c.GetChallengeInfo(ctx, s.objectInfo.Id.String(), int(segment), redundancyIndex, types.GetChallengeInfoOptions{
+		SPAddress: s.c.spOperatorAddress(s.gvg.SecondarySpIds[redundancyIndex]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer result.PieceData.Close()
+	piece, err := io.ReadAll(result.PieceData)
+	if err != nil {
+		return nil, err
+	}
+
+	pieceHashes, ok := s.pieceHashes[redundancyIndex]
+	if !ok {
+		checksumIndex := redundancyIndex + 1
+		if checksumIndex >= len(s.objectInfo.Checksums) {
+			return nil, fmt.Errorf("the checksum of redundancy index %d is not found on chain", redundancyIndex)
+		}
+		pieceHashes = make([][]byte, len(result.PiecesHash))
+		for i, pieceHash := range result.PiecesHash {
+			if pieceHashes[i], err = hex.DecodeString(pieceHash); err != nil {
+				return nil, fmt.Errorf("the piece hash %d is not HEX-encoded", i)
+			}
+		}
+		if !bytes.Equal(hashlib.GenerateIntegrityHash(pieceHashes), s.objectInfo.Checksums[checksumIndex]) {
+			return nil, errors.New("the piece hashes do not match the checksum on chain")
+		}
+		s.pieceHashes[redundancyIndex] = pieceHashes
+	}
+	if segment >= int64(len(pieceHashes)) {
+		return nil, fmt.Errorf("got %d piece hashes, expect more than %d", len(pieceHashes), segment)
+	}
+	if !bytes.Equal(hashlib.GenerateChecksum(piece), pieceHashes[segment]) {
+		return nil, fmt.Errorf("piece %d does not match its hash", segment)
+	}
+	return piece, nil
+}
+
+func (s *secondaryPieceSource) failedErrors() []error {
+	errs := make([]error, 0, len(s.failed))
+	for redundancyIndex, err := range s.failed {
+		errs = append(errs, fmt.Errorf("secondary SP %d: %w", s.gvg.SecondarySpIds[redundancyIndex], err))
+	}
+	return errs
+}
+
+// segmentReader reads the range [start, end] of the payload, the segments covering the range are fetched in order
+// when they are read.
+type segmentReader struct {
+	ctx         context.Context
+	offset      int64
+	end         int64
+	segmentSize int64
+	fetch       func(ctx context.Context, segment int64) ([]byte, error)
+	buf         []byte
+	err         error
+}
+
+func newSegmentReader(ctx context.Context, start, end, segmentSize int64,
+	fetch func(ctx context.Context, segment int64) ([]byte, error),
+) *segmentReader {
+	return &segmentReader{ctx: ctx, offset: start, end: end, segmentSize: segmentSize, fetch: fetch}
+}
+
+func (r *segmentReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if len(r.buf) == 0 {
+		if r.offset > r.end {
+			return 0, io.EOF
+		}
+		segment := r.offset / r.segmentSize
+		data, err := r.fetch(r.ctx, segment)
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		segmentStart := segment * r.segmentSize
+		from, to := r.offset-segmentStart, r.end-segmentStart+1
+		if to > int64(len(data)) {
+			to = int64(len(data))
+		}
+		if from >= to {
+			r.err = fmt.Errorf("segment %d has %d bytes, expect more than %d", segment, len(data), from)
+			return 0, r.err
+		}
+		r.buf = data[from:to]
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.offset += int64(n)
+	return n, nil
+}
+
+func (r *segmentReader) Close() error {
+	r.buf = nil
+	r.err = errors.New("read on closed reader")
+	return nil
+}
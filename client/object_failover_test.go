@@ -0,0 +1,64 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestSegmentReader(t *testing.T) {
+	payload := make([]byte, 25)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	var fetched []int64
+	fetch := func(ctx context.Context, segment int64) ([]byte, error) {
+		fetched = append(fetched, segment)
+		end := (segment + 1) * 10
+		if end > int64(len(payload)) {
+			end = int64(len(payload))
+		}
+		return payload[segment*10 : end], nil
+	}
+
+	data, err := io.ReadAll(newSegmentReader(context.Background(), 0, 24, 10, fetch))
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+	require.Equal(t, []int64{0, 1, 2}, fetched)
+
+	// only the segments covering the range are fetched
+	fetched = nil
+	data, err = io.ReadAll(newSegmentReader(context.Background(), 12, 18, 10, fetch))
+	require.NoError(t, err)
+	require.Equal(t, payload[12:19], data)
+	require.Equal(t, []int64{1}, fetched)
+
+	// the failure of a segment is returned by the following reads
+	failure := errors.New("no enough pieces")
+	reader := newSegmentReader(context.Background(), 0, 24, 10, func(ctx context.Context, segment int64) ([]byte, error) {
+		if segment == 1 {
+			return nil, failure
+		}
+		return fetch(ctx, segment)
+	})
+	data, err = io.ReadAll(reader)
+	require.ErrorIs(t, err, failure)
+	require.True(t, bytes.Equal(payload[:10], data))
+	_, err = reader.Read(make([]byte, 1))
+	require.ErrorIs(t, err, failure)
+}
+
+func TestIsSPUnavailable(t *testing.T) {
+	require.True(t, isSPUnavailable(&url.Error{Op: "Get", URL: "http://sp", Err: errors.New("connection refused")}))
+	require.True(t, isSPUnavailable(&types.SPError{Code: "InternalError", HTTPStatus: 503}))
+	require.False(t, isSPUnavailable(&types.SPError{Code: types.SPErrCodeNoSuchObject, HTTPStatus: 404}))
+	require.False(t, isSPUnavailable(&url.Error{Op: "Get", URL: "http://sp", Err: context.Canceled}))
+	require.False(t, isSPUnavailable(errors.New("invalid argument")))
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// PageLister lists the page of token, and returns the items of the page and the token of the next page.
+type PageLister[T any] func(ctx context.Context, token types.PageToken) ([]T, types.PageToken, error)
+
+// ForEachPage - Walk the pages of a list API from the first page by the page tokens, e.g. the groups of an account:
+//
+//	ForEachPage(ctx, func(ctx context.Context, token types.PageToken) ([]*types.GroupMembers, types.PageToken, error) {
+//		result, err := c.ListGroupsByAccount(ctx, types.GroupsPaginationOptions{PageToken: token})
+//		if err != nil {
+//			return nil, "", err
+//		}
+//		return result.Groups, result.NextPageToken, nil
+//	}, handle)
+//
+// - ctx: Context variables for the current API call.
+//
+// - list: The function listing a page.
+//
+// - handle: The function handling the items of each page, the walk stops once it returns error.
+//
+// - ret: Return error when any page fails to list or handle, otherwise return nil.
+func ForEachPage[T any](ctx context.Context, list PageLister[T], handle func(items []T) error) error {
+	var token types.PageToken
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		items, next, err := list(ctx, token)
+		if err != nil {
+			return err
+		}
+		if err = handle(items); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+// ListAllPages - Collect the items of all the pages of a list API, see ForEachPage.
+//
+// - ctx: Context variables for the current API call.
+//
+// - list: The function listing a page.
+//
+// - ret1: The items of all the pages.
+//
+// - ret2: Return error when any page fails to list, otherwise return nil.
+func ListAllPages[T any](ctx context.Context, list PageLister[T]) ([]T, error) {
+	all := make([]T, 0)
+	err := ForEachPage(ctx, list, func(items []T) error {
+		all = append(all, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestListAllPages(t *testing.T) {
+	// three pages of two items, the token encodes the offset of the page
+	list := func(_ context.Context, token types.PageToken) ([]int, types.PageToken, error) {
+		position, err := token.Position(types.PageGroups)
+		if err != nil {
+			return nil, "", err
+		}
+		offset, _ := strconv.Atoi(position)
+		items := []int{offset, offset + 1}
+		if offset+2 >= 6 {
+			return items, "", nil
+		}
+		return items, types.NewPageToken(types.PageGroups, strconv.Itoa(offset+2)), nil
+	}
+	all, err := ListAllPages[int](context.Background(), list)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5}, all)
+
+	errStop := errors.New("stop")
+	pages := 0
+	err = ForEachPage[int](context.Background(), list, func(items []int) error {
+		pages++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 1, pages)
+}
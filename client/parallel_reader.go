@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+)
+
+var errReaderClosed = errors.New("read from closed reader")
+
+// fetchPartFunc downloads the range [partStart, partEnd] of the object.
+type fetchPartFunc func(ctx context.Context, partStart, partEnd int64) ([]byte, error)
+
+type partResult struct {
+	data []byte
+	err  error
+}
+
+// parallelReader reads the range [startOffset, endOffset] of the object in order while the following parts are
+// downloaded in parallel. At most concurrency parts are downloading or buffered at the same time, so the memory is
+// bounded by concurrency * partSize. If the limiter is set, the parts downloading are limited by it as well, and the
+// upper bound of the limiter takes the place of concurrency.
+type parallelReader struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	slots   chan struct{}        // slots limits the parts downloading or buffered.
+	parts   chan chan partResult // parts queues the results of the parts in the order of their offsets.
+	current *bytes.Reader        // current is the part being read.
+	err     error                // err is returned by the following reads once set.
+}
+
+func newParallelReader(ctx context.Context, startOffset, endOffset, partSize int64, concurrency int, limiter *aimdLimiter,
+	fetch fetchPartFunc,
+) *parallelReader {
+	ctx, cancel := context.WithCancel(ctx)
+	concurrency = limiter.workers(concurrency)
+	r := &parallelReader{
+		ctx:    ctx,
+		cancel: cancel,
+		slots:  make(chan struct{}, concurrency),
+		parts:  make(chan chan partResult, concurrency),
+	}
+	go r.schedule(ctx, startOffset, endOffset, partSize, limiter, fetch)
+	return r
+}
+
+// schedule starts downloading the parts in order whenever a slot is released by the reader.
+func (r *parallelReader) schedule(ctx context.Context, startOffset, endOffset, partSize int64, limiter *aimdLimiter,
+	fetch fetchPartFunc,
+) {
+	defer close(r.parts)
+	for partStart := startOffset; partStart <= endOffset; partStart += partSize {
+		select {
+		case r.slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		result := make(chan partResult, 1)
+		r.parts <- result
+		go func(partStart, partEnd int64) {
+			var data []byte
+			release, err := limiter.acquire(ctx)
+			if err == nil {
+				data, err = fetch(ctx, partStart, partEnd)
+				release(err)
+			}
+			result <- partResult{data: data, err: err}
+		}(partStart, getSegmentEnd(partStart, endOffset+1, partSize))
+	}
+}
+
+// Read reads the object content in order, it blocks until the part being read is downloaded.
+func (r *parallelReader) Read(p []byte) (int, error) {
+	for {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.current != nil {
+			if r.current.Len() > 0 {
+				return r.current.Read(p)
+			}
+			// the part is consumed, release its slot for the following part
+			r.current = nil
+			<-r.slots
+		}
+		result, ok := <-r.parts
+		if !ok {
+			// the scheduling stops early only if the download is canceled
+			r.err = r.ctx.Err()
+			if r.err == nil {
+				r.err = io.EOF
+			}
+			r.cancel()
+			continue
+		}
+		part := <-result
+		if part.err != nil {
+			r.err = part.err
+			r.cancel()
+			continue
+		}
+		r.current = bytes.NewReader(part.data)
+	}
+}
+
+// Close stops downloading the remaining parts.
+func (r *parallelReader) Close() error {
+	r.cancel()
+	if r.err == nil {
+		r.err = errReaderClosed
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestParallelReader(t *testing.T) {
+	content := make([]byte, 1000)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	var inFlight, maxInFlight atomic.Int64
+	fetch := func(ctx context.Context, partStart, partEnd int64) ([]byte, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		return append([]byte(nil), content[partStart:partEnd+1]...), nil
+	}
+
+	r := newParallelReader(context.Background(), 10, 989, 64, 3, nil, fetch)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, content[10:990], data)
+	require.LessOrEqual(t, maxInFlight.Load(), int64(3))
+	require.NoError(t, r.Close())
+}
+
+func TestParallelReaderError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	fetch := func(ctx context.Context, partStart, partEnd int64) ([]byte, error) {
+		if partStart >= 20 {
+			return nil, errFetch
+		}
+		return make([]byte, partEnd-partStart+1), nil
+	}
+
+	r := newParallelReader(context.Background(), 0, 99, 10, 4, nil, fetch)
+	data, err := io.ReadAll(r)
+	require.ErrorIs(t, err, errFetch)
+	require.Len(t, data, 20)
+	require.NoError(t, r.Close())
+}
+
+func TestParallelReaderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fetch := func(ctx context.Context, partStart, partEnd int64) ([]byte, error) {
+		if partStart >= 10 {
+			cancel()
+		}
+		return make([]byte, partEnd-partStart+1), nil
+	}
+
+	r := newParallelReader(ctx, 0, 99, 10, 1, nil, fetch)
+	_, err := io.ReadAll(r)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParallelReaderAdaptiveConcurrency(t *testing.T) {
+	content := make([]byte, 100)
+	var inFlight, maxInFlight atomic.Int64
+	errFetch := errors.New("fetch failed")
+	fetch := func(ctx context.Context, partStart, partEnd int64) ([]byte, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if partStart >= 50 {
+			return nil, errFetch
+		}
+		return content[partStart : partEnd+1], nil
+	}
+
+	// the successful parts raise the limit by about one, and the failures cut it under the initial one
+	limiter := newAIMDLimiter(&types.AdaptiveConcurrency{MaxConcurrency: 8, DecreaseFactor: 0.5}, 4)
+	r := newParallelReader(context.Background(), 0, 99, 10, 4, limiter, fetch)
+	data, err := io.ReadAll(r)
+	require.ErrorIs(t, err, errFetch)
+	require.Equal(t, content[:50], data)
+	require.NoError(t, r.Close())
+	require.LessOrEqual(t, maxInFlight.Load(), int64(8))
+	require.Less(t, limiter.concurrency(), 4)
+}
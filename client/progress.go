@@ -0,0 +1,95 @@
+package client
+
+import (
+	"io"
+	"sync"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// progressTracker accumulates the transferred bytes of a transfer and reports them to the listener, it is shared by
+// the parts transferred in parallel.
+type progressTracker struct {
+	mu          sync.Mutex
+	listener    types.ProgressListener
+	transferred int64
+	total       int64
+}
+
+// newProgressTracker returns nil if listener is nil, the methods of the nil tracker do nothing.
+func newProgressTracker(listener types.ProgressListener, transferred, total int64) *progressTracker {
+	if listener == nil {
+		return nil
+	}
+	return &progressTracker{listener: listener, transferred: transferred, total: total}
+}
+
+// add records n more transferred bytes and reports the progress, n is negative when the transfer is rewound.
+func (t *progressTracker) add(n int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.transferred += n
+	t.listener.OnProgress(t.transferred, t.total)
+}
+
+// progressReader reports the bytes read from the reader.
+type progressReader struct {
+	reader  io.Reader
+	tracker *progressTracker
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.tracker.add(int64(n))
+	}
+	return n, err
+}
+
+// progressReadSeeker keeps the reader seekable, so that the request bodies can be rewound for the retries.
+type progressReadSeeker struct {
+	progressReader
+	seeker io.Seeker
+}
+
+func (r *progressReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	current, err := r.seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	next, err := r.seeker.Seek(offset, whence)
+	if err != nil {
+		return next, err
+	}
+	if next != current {
+		r.tracker.add(next - current)
+	}
+	return next, nil
+}
+
+// withProgress returns the reader reporting the bytes read to the tracker, reader is returned as it is if tracker is
+// nil.
+func withProgress(reader io.Reader, tracker *progressTracker) io.Reader {
+	if tracker == nil {
+		return reader
+	}
+	progress := progressReader{reader: reader, tracker: tracker}
+	if seeker, ok := reader.(io.Seeker); ok {
+		return &progressReadSeeker{progressReader: progress, seeker: seeker}
+	}
+	return &progress
+}
+
+// readCloserWithProgress returns the body reporting the bytes read to the tracker.
+func readCloserWithProgress(body io.ReadCloser, tracker *progressTracker) io.ReadCloser {
+	if tracker == nil {
+		return body
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{withProgress(body, tracker), body}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestWithProgress(t *testing.T) {
+	require.Nil(t, newProgressTracker(nil, 0, 10))
+	reader := bytes.NewReader([]byte("0123456789"))
+	require.Same(t, reader, withProgress(reader, nil))
+
+	var reports [][2]int64
+	tracker := newProgressTracker(types.ProgressListenerFunc(func(transferred, total int64) {
+		reports = append(reports, [2]int64{transferred, total})
+	}), 0, 10)
+	wrapped := withProgress(reader, tracker)
+	seeker, ok := wrapped.(io.ReadSeeker)
+	require.True(t, ok)
+
+	buf := make([]byte, 4)
+	_, err := io.ReadFull(seeker, buf)
+	require.NoError(t, err)
+	// rewinding for a retry reports the decreased progress
+	_, err = seeker.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	content, err := io.ReadAll(seeker)
+	require.NoError(t, err)
+	require.Equal(t, "0123456789", string(content))
+	require.Equal(t, [2]int64{4, 10}, reports[0])
+	require.Equal(t, [2]int64{0, 10}, reports[1])
+	require.Equal(t, [2]int64{10, 10}, reports[len(reports)-1])
+
+	// the readers without Seek are not made seekable
+	_, ok = withProgress(io.LimitReader(reader, 1), tracker).(io.Seeker)
+	require.False(t, ok)
+}
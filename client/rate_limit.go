@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimitChunkSize is the max number of bytes read at once by a rate limited reader, so that the transfer is paced
+// smoothly rather than by the large buffers of the callers.
+const rateLimitChunkSize = 32 * 1024
+
+// rateLimiter is a token bucket limiting the bytes transferred per second, it is shared by the transfers of a client
+// or the parts of a call. The bucket holds the tokens of one second at most, and the transfers exceeding the tokens
+// reserve them in advance and wait until they are refilled. A nil limiter does not limit the transfers.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // rate is the number of tokens refilled per second.
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newRateLimiter returns the limiter of bytesPerSecond, it returns nil if bytesPerSecond is not positive.
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	rate := float64(bytesPerSecond)
+	return &rateLimiter{rate: rate, burst: rate, tokens: rate, last: time.Now(), now: time.Now}
+}
+
+// wait takes n tokens from the bucket, and waits until the bucket is refilled if the tokens are insufficient.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+	delay := l.reserve(n)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes n tokens from the bucket and returns how long to wait until the debt is refilled.
+func (l *rateLimiter) reserve(n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitedReader paces the bytes read from the reader by all the limiters.
+type rateLimitedReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	limiters []*rateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rateLimitChunkSize {
+		p = p[:rateLimitChunkSize]
+	}
+	n, err := r.reader.Read(p)
+	for _, limiter := range r.limiters {
+		if waitErr := limiter.wait(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// rateLimitedReadSeeker keeps the reader seekable, so that the request bodies can be rewound for the retries.
+type rateLimitedReadSeeker struct {
+	rateLimitedReader
+	io.Seeker
+}
+
+// withRateLimit returns the reader paced by the limiters, the nil limiters are skipped and reader is returned as it
+// is if there is no limiter.
+func withRateLimit(ctx context.Context, reader io.Reader, limiters ...*rateLimiter) io.Reader {
+	active := activeRateLimiters(limiters)
+	if len(active) == 0 {
+		return reader
+	}
+	limited := rateLimitedReader{ctx: ctx, reader: reader, limiters: active}
+	if seeker, ok := reader.(io.Seeker); ok {
+		return &rateLimitedReadSeeker{rateLimitedReader: limited, Seeker: seeker}
+	}
+	return &limited
+}
+
+// readCloserWithRateLimit returns the body paced by the limiters.
+func readCloserWithRateLimit(ctx context.Context, body io.ReadCloser, limiters ...*rateLimiter) io.ReadCloser {
+	active := activeRateLimiters(limiters)
+	if len(active) == 0 {
+		return body
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{&rateLimitedReader{ctx: ctx, reader: body, limiters: active}, body}
+}
+
+func activeRateLimiters(limiters []*rateLimiter) []*rateLimiter {
+	active := make([]*rateLimiter, 0, len(limiters))
+	for _, limiter := range limiters {
+		if limiter != nil {
+			active = append(active, limiter)
+		}
+	}
+	return active
+}
+
+type callRateLimiterKey struct{}
+
+// withCallRateLimiter returns the context carrying the limiter of bytesPerSecond shared by the parts of a download
+// call, e.g. the parts downloaded in parallel. ctx is returned as it is if bytesPerSecond is not positive or it
+// already carries the limiter of the outer call.
+func withCallRateLimiter(ctx context.Context, bytesPerSecond int64) context.Context {
+	if bytesPerSecond <= 0 || callRateLimiterFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, callRateLimiterKey{}, newRateLimiter(bytesPerSecond))
+}
+
+func callRateLimiterFromContext(ctx context.Context) *rateLimiter {
+	limiter, _ := ctx.Value(callRateLimiterKey{}).(*rateLimiter)
+	return limiter
+}
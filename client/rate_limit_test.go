@@ -0,0 +1,66 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	limiter := newRateLimiter(1000)
+	now := time.Unix(0, 0)
+	limiter.last, limiter.now = now, func() time.Time { return now }
+
+	// the tokens of one second are available at once
+	require.Zero(t, limiter.reserve(1000))
+	// the debt is refilled at the rate
+	require.Equal(t, 500*time.Millisecond, limiter.reserve(500))
+	require.Equal(t, time.Second, limiter.reserve(500))
+
+	// the idle time refills the bucket up to one second of tokens
+	now = now.Add(time.Hour)
+	require.Zero(t, limiter.reserve(1000))
+	require.Equal(t, time.Millisecond, limiter.reserve(1))
+
+	var nilLimiter *rateLimiter
+	require.NoError(t, nilLimiter.wait(context.Background(), 1<<20))
+	require.Nil(t, newRateLimiter(0))
+}
+
+func TestRateLimitedReader(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 100)
+	reader := withRateLimit(context.Background(), bytes.NewReader(data), nil)
+	_, ok := reader.(*bytes.Reader)
+	require.True(t, ok)
+
+	limiter := newRateLimiter(100)
+	reader = withRateLimit(context.Background(), bytes.NewReader(data), limiter)
+	_, ok = reader.(io.Seeker)
+	require.True(t, ok)
+	read, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, data, read)
+
+	// the bucket is drained, the next read waits and is canceled by ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	body := readCloserWithRateLimit(ctx, io.NopCloser(bytes.NewReader(data)), limiter)
+	_, err = io.ReadAll(body)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NoError(t, body.Close())
+}
+
+func TestCallRateLimiter(t *testing.T) {
+	ctx := withCallRateLimiter(context.Background(), 0)
+	require.Nil(t, callRateLimiterFromContext(ctx))
+
+	ctx = withCallRateLimiter(ctx, 100)
+	limiter := callRateLimiterFromContext(ctx)
+	require.NotNil(t, limiter)
+	// the limiter of the outer call is shared by the inner ones
+	require.Same(t, limiter, callRateLimiterFromContext(withCallRateLimiter(ctx, 200)))
+}
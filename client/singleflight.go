@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// flightGroup deduplicates the concurrent identical queries, the callers of the same key share the result of the
+// query in flight rather than issuing their own, e.g. hundreds of downloads of the same object started together
+// share one HeadObject query.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do calls fn once for the concurrent callers of the key, shared reports whether the result is of the call started by
+// another caller. The callers waiting for a call stop waiting when their contexts are done, and they call fn by
+// themselves if the shared call fails for the canceled context of its caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (val interface{}, shared bool, err error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+		if !errors.Is(call.err, context.Canceled) && !errors.Is(call.err, context.DeadlineExceeded) {
+			return call.val, true, call.err
+		}
+		val, err = fn()
+		return val, false, err
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+	call.val, call.err = fn()
+	return call.val, false, call.err
+}
+
+// dedupQuery runs the query through the flight group shared by the clients, and reports whether it is deduplicated
+// to the metrics implementing types.DedupMetrics. The key is scoped by the pinned height of the client.
+func (c *Client) dedupQuery(ctx context.Context, query, key string, fn func() (interface{}, error)) (interface{}, error) {
+	if c.pinnedHeight != 0 {
+		key = fmt.Sprintf("%s@%d", key, c.pinnedHeight)
+	}
+	val, shared, err := c.state.queries.do(ctx, query+"/"+key, fn)
+	if dedupMetrics, ok := c.metrics.(types.DedupMetrics); ok {
+		dedupMetrics.ObserveDedup(query, shared)
+	}
+	return val, err
+}
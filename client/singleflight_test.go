@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightGroupShare(t *testing.T) {
+	g := newFlightGroup()
+	release := make(chan struct{})
+	var calls int32
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "info", nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	var shared int32
+	results := make([]interface{}, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, isShared, err := g.do(context.Background(), "bucket", fn)
+			assert.NoError(t, err)
+			if isShared {
+				atomic.AddInt32(&shared, 1)
+			}
+			results[i] = val
+		}(i)
+	}
+	// wait for the callers to join the call in flight
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["bucket"] != nil
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(callers-1), atomic.LoadInt32(&shared))
+	for _, val := range results {
+		assert.Equal(t, "info", val)
+	}
+	assert.Empty(t, g.calls)
+
+	// the completed call is not reused
+	_, isShared, err := g.do(context.Background(), "bucket", func() (interface{}, error) { return "new", nil })
+	require.NoError(t, err)
+	assert.False(t, isShared)
+}
+
+func TestFlightGroupErrors(t *testing.T) {
+	g := newFlightGroup()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _, _ = g.do(leaderCtx, "object", func() (interface{}, error) {
+			close(started)
+			<-release
+			return nil, leaderCtx.Err()
+		})
+	}()
+	<-started
+
+	// the waiting caller stops waiting once its context is done
+	ctx, cancelWait := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelWait()
+	_, _, err := g.do(ctx, "object", func() (interface{}, error) { return "unexpected", nil })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the caller retries by itself when the shared call is canceled by its caller
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		val, isShared, err := g.do(context.Background(), "object", func() (interface{}, error) { return "retried", nil })
+		assert.NoError(t, err)
+		assert.False(t, isShared)
+		assert.Equal(t, "retried", val)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	close(release)
+	<-done
+
+	// the other errors are shared
+	errNotFound := errors.New("not found")
+	_, _, err = g.do(context.Background(), "object", func() (interface{}, error) { return nil, errNotFound })
+	assert.ErrorIs(t, err, errNotFound)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// spHealthWeight is the weight of the latest request in the recent error rate and latency of an SP
+const spHealthWeight = 0.2
+
+// spHealthTracker records the health of the SPs measured from the requests, it is shared by the clients of a pool
+type spHealthTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*types.SPHealthScore
+}
+
+func newSPHealthTracker() *spHealthTracker {
+	return &spHealthTracker{endpoints: make(map[string]*types.SPHealthScore)}
+}
+
+// record adds a request to the SP of host, the SP is ejected until now+EjectDuration if it fails too often
+func (t *spHealthTracker) record(policy types.SPHealthPolicy, host string, latency time.Duration, failed bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	score, ok := t.endpoints[host]
+	if !ok {
+		score = &types.SPHealthScore{Endpoint: host, Latency: latency}
+		t.endpoints[host] = score
+	}
+	score.Requests++
+	score.Latency = time.Duration((1-spHealthWeight)*float64(score.Latency) + spHealthWeight*float64(latency))
+	sample := 0.0
+	if failed {
+		sample = 1
+		score.Failures++
+		score.ConsecutiveFailures++
+	} else {
+		score.ConsecutiveFailures = 0
+	}
+	score.ErrorRate = (1-spHealthWeight)*score.ErrorRate + spHealthWeight*sample
+	if !failed || policy.Disabled {
+		return
+	}
+	if (policy.ConsecutiveFailures > 0 && score.ConsecutiveFailures >= policy.ConsecutiveFailures) ||
+		(score.Requests >= policy.MinSamples && score.ErrorRate >= policy.ErrorRateThreshold) {
+		score.EjectedUntil = now.Add(policy.EjectDuration)
+	}
+}
+
+// isEjected reports whether the SP of host is skipped by the routing at now
+func (t *spHealthTracker) isEjected(host string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	score, ok := t.endpoints[host]
+	return ok && now.Before(score.EjectedUntil)
+}
+
+// snapshot returns the health of the SPs sorted by the endpoints
+func (t *spHealthTracker) snapshot(now time.Time) []types.SPHealthScore {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	scores := make([]types.SPHealthScore, 0, len(t.endpoints))
+	for _, score := range t.endpoints {
+		s := *score
+		s.Ejected = now.Before(s.EjectedUntil)
+		scores = append(scores, s)
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Endpoint < scores[j].Endpoint })
+	return scores
+}
+
+// recordSPHealth records the attempt of req to the health of its SP, the server errors and the connection failures
+// are failures while the client errors are the problems of the requests rather than the SP.
+func (c *Client) recordSPHealth(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	outcome := outcomeSuccess
+	switch {
+	case err != nil && resp == nil && req.Context().Err() != nil:
+		// the request is canceled by the caller
+		outcome = outcomeCanceled
+	case (err != nil && resp == nil) || (resp != nil && resp.StatusCode >= http.StatusInternalServerError):
+		outcome = outcomeFailure
+	}
+	c.recordCircuitOutcome(req.URL.Host, outcome)
+	if outcome == outcomeCanceled {
+		return
+	}
+	c.state.spHealth.record(c.spHealthPolicy, req.URL.Host, latency, outcome == outcomeFailure, c.clock.Now())
+}
+
+// GetSPHealth - Get the health of the SPs measured from the requests sent by the client, including whether they are
+// ejected from the routing of the requests which can be served by any SP, and the states of their circuit breakers.
+//
+// - ret1: The health of the SPs which have been requested, sorted by the endpoints.
+func (c *Client) GetSPHealth() []types.SPHealthScore {
+	scores := c.state.spHealth.snapshot(c.clock.Now())
+	if c.circuitBreakerPolicy != nil {
+		for i := range scores {
+			scores[i].Circuit = c.state.circuitBreakers.state(scores[i].Endpoint)
+		}
+	}
+	return scores
+}
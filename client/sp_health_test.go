@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestSPHealthTracker(t *testing.T) {
+	policy := types.DefaultSPHealthPolicy()
+	tracker := newSPHealthTracker()
+	now := time.Unix(1700000000, 0)
+
+	// the consecutive failures eject the SP until the eject duration elapses
+	for i := 0; i < policy.ConsecutiveFailures-1; i++ {
+		tracker.record(policy, "sp1", time.Second, true, now)
+	}
+	require.False(t, tracker.isEjected("sp1", now))
+	tracker.record(policy, "sp1", time.Second, true, now)
+	require.True(t, tracker.isEjected("sp1", now))
+	require.True(t, tracker.isEjected("sp1", now.Add(policy.EjectDuration-time.Second)))
+	require.False(t, tracker.isEjected("sp1", now.Add(policy.EjectDuration)))
+
+	// a success resets the consecutive failures
+	tracker.record(policy, "sp2", time.Millisecond, true, now)
+	tracker.record(policy, "sp2", time.Millisecond, false, now)
+	tracker.record(policy, "sp2", time.Millisecond, true, now)
+	require.False(t, tracker.isEjected("sp2", now))
+	require.False(t, tracker.isEjected("unknown", now))
+
+	scores := tracker.snapshot(now)
+	require.Len(t, scores, 2)
+	require.Equal(t, "sp1", scores[0].Endpoint)
+	require.True(t, scores[0].Ejected)
+	require.Equal(t, int64(3), scores[0].Failures)
+	require.Equal(t, 3, scores[0].ConsecutiveFailures)
+	require.Equal(t, "sp2", scores[1].Endpoint)
+	require.False(t, scores[1].Ejected)
+	require.Equal(t, int64(3), scores[1].Requests)
+	require.Equal(t, 1, scores[1].ConsecutiveFailures)
+	require.Equal(t, time.Millisecond, scores[1].Latency)
+
+	// the error rate ejects the SP failing intermittently once there are enough samples
+	policy.ConsecutiveFailures = 0
+	for i := int64(1); i <= policy.MinSamples; i++ {
+		tracker.record(policy, "sp3", time.Millisecond, i%3 != 0, now)
+	}
+	require.True(t, tracker.isEjected("sp3", now))
+
+	// nothing is ejected if the policy is disabled
+	policy.Disabled = true
+	for i := 0; i < 10; i++ {
+		tracker.record(policy, "sp4", time.Millisecond, true, now)
+	}
+	require.False(t, tracker.isEjected("sp4", now))
+}
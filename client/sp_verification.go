@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
+	"github.com/bnb-chain/greenfield/types/common"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// VerifyCreateBucketApproval - Verify the approval of the createBucket msg is signed by the approval key of its
+// primary SP on chain.
+//
+// - ctx: Context variables for the current API call.
+//
+// - createBucketMsg: The msg of create bucket signed by GetCreateBucketApproval.
+//
+// - ret: Return types.ErrInvalidSPSignature when the signature is missing or invalid, types.ErrUnverifiableSPResponse
+// when the primary SP is unknown, otherwise return nil.
+func (c *Client) VerifyCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket) error {
+	sp, err := c.storageProviderByAddr(createBucketMsg.GetPrimarySpAddress())
+	if err != nil {
+		return err
+	}
+	return verifyApprovalSignature(createBucketMsg.GetApprovalBytes(), createBucketMsg.GetPrimarySpApproval(), sp)
+}
+
+// VerifyCreateObjectApproval - Verify the approval of the createObject msg is signed by the approval key of the
+// primary SP of the bucket on chain.
+//
+// - ctx: Context variables for the current API call.
+//
+// - createObjectMsg: The msg of create object signed by GetCreateObjectApproval.
+//
+// - ret: Return types.ErrInvalidSPSignature when the signature is missing or invalid, types.ErrUnverifiableSPResponse
+// when the primary SP can not be resolved, otherwise return nil.
+func (c *Client) VerifyCreateObjectApproval(ctx context.Context, createObjectMsg *storageTypes.MsgCreateObject) error {
+	sp, err := c.pickStorageProviderByBucket(ctx, createObjectMsg.GetBucketName())
+	if err != nil {
+		return fmt.Errorf("%w: %s", types.ErrUnverifiableSPResponse, err)
+	}
+	return verifyApprovalSignature(createObjectMsg.GetApprovalBytes(), createObjectMsg.GetPrimarySpApproval(), sp)
+}
+
+// storageProviderByAddr returns the SP of the operator address, types.ErrUnverifiableSPResponse is returned if the SP
+// is unknown.
+func (c *Client) storageProviderByAddr(address string) (*types.StorageProvider, error) {
+	acc, err := sdk.AccAddressFromHexUnsafe(address)
+	if err != nil {
+		return nil, err
+	}
+	for _, sp := range c.getStorageProviders() {
+		if sp.OperatorAddress.Equals(acc) {
+			return sp, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the SP %s is unknown", types.ErrUnverifiableSPResponse, address)
+}
+
+// verifyApprovalSignature checks the approval is signed over the approval bytes by the approval address of the SP,
+// the signature is verified against the keccak256 hash of the bytes in the same way as the chain does.
+func verifyApprovalSignature(approvalBytes []byte, approval *common.Approval, sp *types.StorageProvider) error {
+	if approval == nil || len(approval.Sig) == 0 {
+		return fmt.Errorf("%w: no approval signature", types.ErrInvalidSPSignature)
+	}
+	if sp.ApprovalAddress.Empty() {
+		return fmt.Errorf("%w: the approval address of SP %d is unknown", types.ErrUnverifiableSPResponse, sp.Id)
+	}
+	signer, _, err := hashlib.RecoverAddr(ethcrypto.Keccak256(approvalBytes), approval.Sig)
+	if err != nil {
+		return fmt.Errorf("%w: %s", types.ErrInvalidSPSignature, err)
+	}
+	if !signer.Equals(sp.ApprovalAddress) {
+		return fmt.Errorf("%w: the approval is signed by %s rather than the approval address %s of SP %d",
+			types.ErrInvalidSPSignature, signer, sp.ApprovalAddress, sp.Id)
+	}
+	return nil
+}
+
+// verifyChallengeResult checks the piece data and the piece hashes served by the challenge API against the checksum
+// of the SP sealed on chain. The piece data is read into memory to be hashed, the result carries the buffered data.
+func (c *Client) verifyChallengeResult(ctx context.Context, objectID string, pieceIndex, redundancyIndex int,
+	result *types.ChallengeResult,
+) error {
+	detail, err := c.HeadObjectByID(ctx, objectID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", types.ErrUnverifiableSPResponse, err)
+	}
+	checksumIndex := redundancyIndex + 1
+	if checksumIndex >= len(detail.ObjectInfo.Checksums) {
+		return fmt.Errorf("%w: the checksum of redundancy index %d is not found on chain", types.ErrUnverifiableSPResponse, redundancyIndex)
+	}
+	checksum := detail.ObjectInfo.Checksums[checksumIndex]
+
+	pieceData, err := io.ReadAll(result.PieceData)
+	result.PieceData.Close()
+	if err != nil {
+		return err
+	}
+	result.PieceData = io.NopCloser(bytes.NewReader(pieceData))
+
+	if result.IntegrityHash != hex.EncodeToString(checksum) {
+		return fmt.Errorf("%w: the integrity hash %s mismatches the checksum on chain", types.ErrInvalidSPSignature, result.IntegrityHash)
+	}
+	pieceHashes := make([][]byte, len(result.PiecesHash))
+	for i, pieceHash := range result.PiecesHash {
+		if pieceHashes[i], err = hex.DecodeString(pieceHash); err != nil {
+			return fmt.Errorf("%w: the piece hash %d is not HEX-encoded", types.ErrInvalidSPSignature, i)
+		}
+	}
+	if !bytes.Equal(hashlib.GenerateIntegrityHash(pieceHashes), checksum) {
+		return fmt.Errorf("%w: the piece hashes mismatch the checksum on chain", types.ErrInvalidSPSignature)
+	}
+	if pieceIndex >= len(pieceHashes) || !bytes.Equal(hashlib.GenerateChecksum(pieceData), pieceHashes[pieceIndex]) {
+		return fmt.Errorf("%w: piece %d mismatches its hash", types.ErrInvalidSPSignature, pieceIndex)
+	}
+	return nil
+}
+
+// checkSPVerification applies the SPResponseVerification of the client to the result of a verification, the
+// unverifiable responses are accepted with a warning unless the verification is strict.
+func (c *Client) checkSPVerification(what string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, types.ErrUnverifiableSPResponse) && c.spResponseVerification != types.SPResponseVerificationStrict {
+		log.Warn().Msg(fmt.Sprintf("accept the unverifiable %s, err: %s", what, err))
+		return nil
+	}
+	return err
+}
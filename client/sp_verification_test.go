@@ -0,0 +1,39 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bnb-chain/greenfield/types/common"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestVerifyApprovalSignature(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	sp := &types.StorageProvider{Id: 1, ApprovalAddress: sdk.AccAddress(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes())}
+	approvalBytes := []byte("approval bytes")
+	sig, err := ethcrypto.Sign(ethcrypto.Keccak256(approvalBytes), key)
+	require.NoError(t, err)
+
+	require.NoError(t, verifyApprovalSignature(approvalBytes, &common.Approval{Sig: sig}, sp))
+	require.ErrorIs(t, verifyApprovalSignature([]byte("tampered"), &common.Approval{Sig: sig}, sp), types.ErrInvalidSPSignature)
+	require.ErrorIs(t, verifyApprovalSignature(approvalBytes, &common.Approval{}, sp), types.ErrInvalidSPSignature)
+	require.ErrorIs(t, verifyApprovalSignature(approvalBytes, &common.Approval{Sig: sig}, &types.StorageProvider{Id: 2}),
+		types.ErrUnverifiableSPResponse)
+}
+
+func TestCheckSPVerification(t *testing.T) {
+	unverifiable := errors.Join(types.ErrUnverifiableSPResponse)
+	c := newTestClient(t, func(c *Client) { c.spResponseVerification = types.SPResponseVerificationBestEffort })
+	require.NoError(t, c.checkSPVerification("approval", nil))
+	require.NoError(t, c.checkSPVerification("approval", unverifiable))
+	require.ErrorIs(t, c.checkSPVerification("approval", types.ErrInvalidSPSignature), types.ErrInvalidSPSignature)
+
+	c.spResponseVerification = types.SPResponseVerificationStrict
+	require.ErrorIs(t, c.checkSPVerification("approval", unverifiable), types.ErrUnverifiableSPResponse)
+}
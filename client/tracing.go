@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// noopTracer starts the non-recording spans of the clients without the TracerProvider.
+var noopTracer = trace.NewNoopTracerProvider().Tracer(types.TracerName)
+
+// newTracer returns the tracer of the client from the TracerProvider of the option, the client is not traced if it is
+// nil.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		return nil
+	}
+	return provider.Tracer(types.TracerName, trace.WithInstrumentationVersion(types.Version))
+}
+
+// startSpan starts a client span by the tracer of the client, a non-recording span is returned if the client has no
+// tracer.
+func (c *Client) startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := c.tracer
+	if tracer == nil {
+		tracer = noopTracer
+	}
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// injectTraceContext writes the trace context carried by ctx into the headers of the SP request, so that the traces are
+// continued by SP. The W3C trace context is used if the client has no propagator.
+func (c *Client) injectTraceContext(ctx context.Context, header http.Header) {
+	if c.tracer == nil {
+		return
+	}
+	var propagator propagation.TextMapPropagator = propagation.TraceContext{}
+	if c.propagator != nil {
+		propagator = c.propagator
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// endSpan ends the span, err is recorded as the status of the span if it is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+)
+
+// fakeSpan records the attributes, the error and the end of the span, the other calls panic.
+type fakeSpan struct {
+	trace.Span
+	name        string
+	spanContext trace.SpanContext
+	attributes  map[attribute.Key]attribute.Value
+	err         error
+	status      codes.Code
+	ended       bool
+}
+
+func (s *fakeSpan) SpanContext() trace.SpanContext { return s.spanContext }
+
+func (s *fakeSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, kv := range attributes {
+		s.attributes[kv.Key] = kv.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error, _ ...trace.EventOption) { s.err = err }
+
+func (s *fakeSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *fakeSpan) End(...trace.SpanEndOption) { s.ended = true }
+
+// fakeTracerProvider is the TracerProvider recording the spans started by its tracers.
+type fakeTracerProvider struct {
+	embedded.TracerProvider
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (p *fakeTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &fakeTracer{provider: p}
+}
+
+// fakeTracer starts the spans recorded by its provider.
+type fakeTracer struct {
+	embedded.Tracer
+	provider *fakeTracerProvider
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.provider.start(ctx, name, opts...)
+}
+
+func (p *fakeTracerProvider) start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	span := &fakeSpan{
+		name: name,
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x0a, 0xf7, 0x65, 0x19},
+			SpanID:     trace.SpanID{byte(len(p.spans) + 1)},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		attributes: make(map[attribute.Key]attribute.Value),
+	}
+	config := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(config.Attributes()...)
+	p.spans = append(p.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func (p *fakeTracerProvider) span(name string) *fakeSpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, span := range p.spans {
+		if span.name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestStartSpan(t *testing.T) {
+	ctx := context.Background()
+	_, span := (&Client{}).startSpan(ctx, "noop")
+	require.False(t, span.IsRecording())
+	endSpan(span, errors.New("timeout"))
+
+	provider := &fakeTracerProvider{}
+	c := newTestClient(t, func(c *Client) { c.tracer = newTracer(provider) })
+	_, span = c.startSpan(ctx, "greenfield.chain/WaitForTx", attribute.String("greenfield.tx.hash", "0x1"))
+	endSpan(span, errors.New("timeout"))
+	recorded := provider.span("greenfield.chain/WaitForTx")
+	require.True(t, recorded.ended)
+	require.EqualError(t, recorded.err, "timeout")
+	require.Equal(t, codes.Error, recorded.status)
+	require.Equal(t, "0x1", recorded.attributes["greenfield.tx.hash"].AsString())
+}
+
+func TestSendReqTracing(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	provider := &fakeTracerProvider{}
+	c := newTestClient(t, withTestServer(server), func(c *Client) { c.tracer = newTracer(provider) })
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	meta := requestMeta{bucketName: "bucket", objectName: "object", contentSHA256: types.EmptyStringSHA256}
+	_, err = c.sendReq(context.Background(), meta, &sendOptions{method: http.MethodGet}, endpoint)
+	require.NoError(t, err)
+
+	// the SP request is traced and the trace context is propagated to SP
+	span := provider.span("greenfield.sp/GET")
+	require.NotNil(t, span)
+	require.True(t, span.ended)
+	require.NoError(t, span.err)
+	require.Equal(t, "bucket", span.attributes["greenfield.bucket"].AsString())
+	require.Equal(t, endpoint.Host, span.attributes["server.address"].AsString())
+	require.Equal(t, "00-"+span.spanContext.TraceID().String()+"-"+span.spanContext.SpanID().String()+"-01", traceparent)
+
+	// the client without the tracer does not propagate the trace context
+	c.tracer = nil
+	_, err = c.sendReq(context.Background(), meta, &sendOptions{method: http.MethodGet}, endpoint)
+	require.NoError(t, err)
+	require.Empty(t, traceparent)
+}
+
+func TestBroadcastTxTracing(t *testing.T) {
+	provider := &fakeTracerProvider{}
+	c := newTxTestClient(t, &fakeTxClient{broadcastTx: func(*tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error) {
+		return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "0xabc", Code: 5, Codespace: "sdk"}}, nil
+	}})
+	c.tracer = newTracer(provider)
+
+	msg := banktypes.NewMsgSend(c.defaultAccount.GetAddress(), c.defaultAccount.GetAddress(), sdk.NewCoins(sdk.NewInt64Coin(gnfdsdk.Denom, 1)))
+	_, err := c.BroadcastTx(context.Background(), []sdk.Msg{msg}, &gnfdsdk.TxOption{
+		NoSimulate: true,
+		GasLimit:   1000,
+		FeeAmount:  sdk.NewCoins(sdk.NewInt64Coin(gnfdsdk.Denom, 5000000000000)),
+	})
+	require.Error(t, err)
+
+	span := provider.span("greenfield.chain/BroadcastTx")
+	require.NotNil(t, span)
+	require.True(t, span.ended)
+	require.Equal(t, err, span.err)
+	require.Equal(t, codes.Error, span.status)
+	require.Equal(t, int64(1), span.attributes["greenfield.tx.msgs"].AsInt64())
+	require.Equal(t, "0xabc", span.attributes["greenfield.tx.hash"].AsString())
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// TxBuilder collects the msgs of the storage, payment, permission and other modules and broadcasts them in one
+// transaction, so that they are executed atomically in the same block, e.g. to create a bucket and put its policy.
+//
+//	txBuilder := cli.NewTxBuilder().
+//		AddCreateBucket(ctx, bucketName, primarySP, types.CreateBucketOptions{}).
+//		AddPutBucketPolicy(bucketName, principal, statements, nil)
+//	txHash, err := txBuilder.Broadcast(ctx, types.TxBuilderBroadcastOptions{})
+//
+// The errors building the msgs are deferred to Broadcast, a TxBuilder is not safe for concurrent use.
+type TxBuilder struct {
+	client *Client
+	msgs   []sdk.Msg
+	err    error
+}
+
+// NewTxBuilder - Create a transaction builder signing by the default account of the client.
+func (c *Client) NewTxBuilder() *TxBuilder {
+	return &TxBuilder{client: c, msgs: make([]sdk.Msg, 0)}
+}
+
+// AddMsgs appends the msgs to the transaction.
+func (b *TxBuilder) AddMsgs(msgs ...sdk.Msg) *TxBuilder {
+	b.msgs = append(b.msgs, msgs...)
+	return b
+}
+
+// AddCreateBucket appends the msgs to create the bucket in the same way as CreateBucket, the TxOpts and IsAsyncMode of
+// opts are ignored.
+func (b *TxBuilder) AddCreateBucket(ctx context.Context, bucketName, primaryAddr string, opts types.CreateBucketOptions) *TxBuilder {
+	if b.err != nil {
+		return b
+	}
+	msgs, err := b.client.createBucketMsgs(ctx, bucketName, primaryAddr, opts)
+	if err != nil {
+		b.err = fmt.Errorf("fail to build the msgs to create bucket %s: %w", bucketName, err)
+		return b
+	}
+	return b.AddMsgs(msgs...)
+}
+
+// AddPutBucketPolicy appends the msg to put the bucket policy of the principal, the policy never expires if
+// expireTime is nil.
+func (b *TxBuilder) AddPutBucketPolicy(bucketName string, principal types.Principal, statements []*permTypes.Statement,
+	expireTime *time.Time,
+) *TxBuilder {
+	return b.addPutPolicy(gnfdTypes.NewBucketGRN(bucketName).String(), principal, statements, expireTime)
+}
+
+// AddPutObjectPolicy appends the msg to put the object policy of the principal, the policy never expires if
+// expireTime is nil.
+func (b *TxBuilder) AddPutObjectPolicy(bucketName, objectName string, principal types.Principal, statements []*permTypes.Statement,
+	expireTime *time.Time,
+) *TxBuilder {
+	return b.addPutPolicy(gnfdTypes.NewObjectGRN(bucketName, objectName).String(), principal, statements, expireTime)
+}
+
+func (b *TxBuilder) addPutPolicy(resource string, principalStr types.Principal, statements []*permTypes.Statement,
+	expireTime *time.Time,
+) *TxBuilder {
+	if b.err != nil {
+		return b
+	}
+	principal := &permTypes.Principal{}
+	if err := principal.Unmarshal([]byte(principalStr)); err != nil {
+		b.err = fmt.Errorf("fail to build the msg to put the policy of %s: %w", resource, err)
+		return b
+	}
+	return b.AddMsgs(storageTypes.NewMsgPutPolicy(b.client.MustGetDefaultAccount().GetAddress(), resource, principal,
+		statements, expireTime))
+}
+
+// Msgs returns the msgs appended so far.
+func (b *TxBuilder) Msgs() []sdk.Msg {
+	return b.msgs
+}
+
+// Err returns the first error building the msgs.
+func (b *TxBuilder) Err() error {
+	return b.err
+}
+
+// Simulate - Simulate the transaction and return the gas it uses, without broadcasting it.
+//
+// - ctx: Context variables for the current API call.
+//
+// - ret1: The gas used by the transaction.
+//
+// - ret2: Return error when the msgs are invalid or the transaction fails in the simulation, otherwise return nil.
+func (b *TxBuilder) Simulate(ctx context.Context) (uint64, error) {
+	if err := b.validate(); err != nil {
+		return 0, err
+	}
+	resp, err := b.client.SimulateTx(ctx, b.msgs, gnfdsdk.TxOption{})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GasInfo.GetGasUsed(), nil
+}
+
+// EstimateFee - Estimate the gas limit and the fee of the transaction in the same way as Client.EstimateFee, without
+// broadcasting it.
+//
+// - ctx: Context variables for the current API call.
+//
+// - txOpt: TxOpt contains options for customizing the transaction.
+//
+// - ret1: The estimated gas limit of the transaction.
+//
+// - ret2: The estimated fee of the transaction.
+//
+// - ret3: Return error when the msgs are invalid or the transaction fails in the simulation, otherwise return nil.
+func (b *TxBuilder) EstimateFee(ctx context.Context, txOpt gnfdsdk.TxOption) (uint64, sdk.Coins, error) {
+	if err := b.validate(); err != nil {
+		return 0, nil, err
+	}
+	return b.client.EstimateFee(ctx, b.msgs, txOpt)
+}
+
+// Broadcast - Broadcast the msgs in one transaction, the gas is simulated unless the gas limit is set in opts.TxOpts.
+//
+// - ctx: Context variables for the current API call.
+//
+// - opts: The options to customize the transaction and to wait for it.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the msgs are invalid or the transaction fails, otherwise return nil.
+func (b *TxBuilder) Broadcast(ctx context.Context, opts types.TxBuilderBroadcastOptions) (string, error) {
+	if err := b.validate(); err != nil {
+		return "", err
+	}
+	if opts.TxOpts == nil {
+		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
+		opts.TxOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
+	}
+	resp, err := b.client.BroadcastTx(ctx, b.msgs, opts.TxOpts)
+	if err != nil {
+		return "", err
+	}
+	txnHash := resp.TxResponse.TxHash
+	if !opts.IsAsyncMode {
+		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
+		defer cancel()
+		txnResponse, err := b.client.WaitForTx(ctxTimeout, txnHash)
+		if err != nil {
+			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+		}
+		if txnResponse.TxResult.Code != 0 {
+			return txnHash, fmt.Errorf("the txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+		}
+	}
+	return txnHash, nil
+}
+
+func (b *TxBuilder) validate() error {
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.msgs) == 0 {
+		return errors.New("no msg is added to the transaction")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// decodeTxBody decodes the body of the signed tx broadcast.
+func decodeTxBody(t *testing.T, txBytes []byte) *tx.TxBody {
+	var txRaw tx.TxRaw
+	require.NoError(t, txRaw.Unmarshal(txBytes))
+	var body tx.TxBody
+	require.NoError(t, body.Unmarshal(txRaw.BodyBytes))
+	return &body
+}
+
+// decodeTxAuthInfo decodes the auth info, i.e. the gas and the fee, of the signed tx broadcast.
+func decodeTxAuthInfo(t *testing.T, txBytes []byte) *tx.AuthInfo {
+	var txRaw tx.TxRaw
+	require.NoError(t, txRaw.Unmarshal(txBytes))
+	var authInfo tx.AuthInfo
+	require.NoError(t, authInfo.Unmarshal(txRaw.AuthInfoBytes))
+	return &authInfo
+}
+
+func TestTxBuilderValidate(t *testing.T) {
+	// the tx client is never called since the builders are invalid
+	c := newTxTestClient(t, &fakeTxClient{})
+	principal, err := utils.NewPrincipalWithAccount(c.defaultAccount.GetAddress())
+	require.NoError(t, err)
+	statement := utils.NewStatement([]permTypes.ActionType{permTypes.ACTION_GET_OBJECT}, permTypes.EFFECT_ALLOW, nil, types.NewStatementOptions{})
+	statements := []*permTypes.Statement{&statement}
+
+	testCases := []struct {
+		name        string
+		build       func(b *TxBuilder) *TxBuilder
+		msgs        int
+		deferred    bool
+		errContains string
+	}{
+		{
+			name:        "no msg",
+			build:       func(b *TxBuilder) *TxBuilder { return b },
+			errContains: "no msg is added to the transaction",
+		},
+		{
+			name:        "invalid principal",
+			build:       func(b *TxBuilder) *TxBuilder { return b.AddPutBucketPolicy("bucket", "invalid", statements, nil) },
+			deferred:    true,
+			errContains: "fail to build the msg to put the policy of grn:b::bucket",
+		},
+		{
+			name: "invalid create bucket",
+			build: func(b *TxBuilder) *TxBuilder {
+				return b.AddCreateBucket(context.Background(), "bucket", "invalid", types.CreateBucketOptions{})
+			},
+			deferred:    true,
+			errContains: "fail to build the msgs to create bucket bucket",
+		},
+		{
+			name: "msgs added after the error are dropped",
+			build: func(b *TxBuilder) *TxBuilder {
+				return b.AddPutBucketPolicy("bucket", principal, statements, nil).
+					AddPutObjectPolicy("bucket", "object", "invalid", statements, nil).
+					AddPutBucketPolicy("bucket", principal, statements, nil).
+					AddCreateBucket(context.Background(), "bucket", "invalid", types.CreateBucketOptions{})
+			},
+			msgs:        1,
+			deferred:    true,
+			errContains: "fail to build the msg to put the policy of grn:o::bucket/object",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tc.build(c.NewTxBuilder())
+			require.Len(t, b.Msgs(), tc.msgs)
+			if tc.deferred {
+				// the first error is kept until the broadcast
+				require.ErrorContains(t, b.Err(), tc.errContains)
+			} else {
+				require.NoError(t, b.Err())
+			}
+
+			_, err := b.Simulate(context.Background())
+			require.ErrorContains(t, err, tc.errContains)
+			_, _, err = b.EstimateFee(context.Background(), gnfdsdk.TxOption{})
+			require.ErrorContains(t, err, tc.errContains)
+			_, err = b.Broadcast(context.Background(), types.TxBuilderBroadcastOptions{IsAsyncMode: true})
+			require.ErrorContains(t, err, tc.errContains)
+		})
+	}
+}
+
+func TestTxBuilderBroadcast(t *testing.T) {
+	asyncMode := tx.BroadcastMode_BROADCAST_MODE_ASYNC
+	feeAmount := sdk.NewCoins(sdk.NewInt64Coin(gnfdsdk.Denom, 5000000000000))
+
+	testCases := []struct {
+		name        string
+		opts        types.TxBuilderBroadcastOptions
+		code        uint32
+		mode        tx.BroadcastMode
+		gasLimit    uint64
+		memo        string
+		simulations int
+		errContains string
+	}{
+		{
+			name:        "default options",
+			opts:        types.TxBuilderBroadcastOptions{IsAsyncMode: true},
+			mode:        tx.BroadcastMode_BROADCAST_MODE_SYNC,
+			gasLimit:    1200,
+			simulations: 1,
+		},
+		{
+			name: "custom options",
+			opts: types.TxBuilderBroadcastOptions{
+				TxOpts:      &gnfdsdk.TxOption{Mode: &asyncMode, NoSimulate: true, GasLimit: 1000, FeeAmount: feeAmount, Memo: "memo"},
+				IsAsyncMode: true,
+			},
+			mode:     asyncMode,
+			gasLimit: 1000,
+			memo:     "memo",
+		},
+		{
+			name: "failed tx",
+			opts: types.TxBuilderBroadcastOptions{
+				TxOpts:      &gnfdsdk.TxOption{NoSimulate: true, GasLimit: 1000, FeeAmount: feeAmount},
+				IsAsyncMode: true,
+			},
+			code:        5,
+			mode:        tx.BroadcastMode_BROADCAST_MODE_SYNC,
+			gasLimit:    1000,
+			errContains: "the tx has failed with response code: 5",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				broadcasts  []*tx.BroadcastTxRequest
+				simulations int
+			)
+			c := newTxTestClient(t, &fakeTxClient{
+				broadcastTx: func(req *tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error) {
+					broadcasts = append(broadcasts, req)
+					return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "0xabc", Code: tc.code, Codespace: "sdk"}}, nil
+				},
+				simulate: func(*tx.SimulateRequest) (*tx.SimulateResponse, error) {
+					simulations++
+					return &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 1200, MinGasPrice: "5000000000" + gnfdsdk.Denom}}, nil
+				},
+			})
+			operator := c.defaultAccount.GetAddress()
+			b := c.NewTxBuilder().AddMsgs(
+				banktypes.NewMsgSend(operator, operator, sdk.NewCoins(sdk.NewInt64Coin(gnfdsdk.Denom, 1))),
+				storageTypes.NewMsgSetTag(operator, "grn:b::bucket", &storageTypes.ResourceTags{}),
+			)
+			require.NoError(t, b.Err())
+
+			txHash, err := b.Broadcast(context.Background(), tc.opts)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				require.Empty(t, txHash)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "0xabc", txHash)
+			}
+			require.Equal(t, tc.simulations, simulations)
+			// all the msgs are broadcast in one tx with the options
+			require.Len(t, broadcasts, 1)
+			require.Equal(t, tc.mode, broadcasts[0].Mode)
+			body := decodeTxBody(t, broadcasts[0].TxBytes)
+			require.Len(t, body.Messages, 2)
+			require.Equal(t, tc.memo, body.Memo)
+			require.Equal(t, tc.gasLimit, decodeTxAuthInfo(t, broadcasts[0].TxBytes).Fee.GasLimit)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// UploadObject - Create the object on chain, upload its payload to SP and optionally wait until it is sealed, which
+// collapses CreateObject, WaitForTx, PutObject and WaitForObjectSeal into a single call.
+//
+// The payload is read twice from the current offset of the reader: once to compute the checksums of the object and
+// once to upload it, so the reader should be seekable.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - objectName: The object name identifies the object.
+//
+// - reader: The reader of the payload.
+//
+// - opts: The options to create, upload and wait for the object.
+//
+// - ret1: The result including the hash of the create tx, it is returned once the object is created even if the
+// upload or the seal fails, so that the object can be resumed or canceled.
+//
+// - ret2: Return error when any step failed, otherwise return nil.
+func (c *Client) UploadObject(ctx context.Context, bucketName, objectName string, reader io.ReadSeeker,
+	opts types.UploadObjectOptions,
+) (*types.UploadObjectResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if reader == nil {
+		return nil, errors.New("fail to upload object, reader is nil")
+	}
+	start, err := reader.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	end, err := reader.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = reader.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	objectSize := end - start
+
+	createOpts, putOpts := opts.CreateOptions, opts.PutOptions
+	if createOpts.ContentType == "" {
+		createOpts.ContentType = putOpts.ContentType
+	}
+	if putOpts.ContentType == "" {
+		putOpts.ContentType = createOpts.ContentType
+	}
+	// the object should be on chain before the upload
+	createOpts.IsAsyncMode = false
+	txnHash, err := c.CreateObject(ctx, bucketName, objectName, reader, createOpts)
+	if err != nil {
+		return nil, err
+	}
+	result := &types.UploadObjectResult{CreateTxHash: txnHash}
+
+	// the empty object is sealed once created
+	if objectSize > 0 {
+		if _, err = reader.Seek(start, io.SeekStart); err != nil {
+			return result, err
+		}
+		if err = c.PutObject(ctx, bucketName, objectName, objectSize, reader, putOpts); err != nil {
+			return result, fmt.Errorf("object %s is created by tx %s but fails to upload: %w", objectName, txnHash, err)
+		}
+	}
+
+	if !opts.WaitForSeal {
+		return result, nil
+	}
+	detail, err := c.WaitForObjectSeal(ctx, bucketName, objectName, opts.SealOptions)
+	if err != nil {
+		return result, err
+	}
+	result.Sealed, result.ObjectDetail = true, detail
+	return result, nil
+}
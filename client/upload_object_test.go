@@ -0,0 +1,216 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	vgTypes "github.com/bnb-chain/greenfield/x/virtualgroup/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// fakeVirtualGroupQueryClient serves the family queries by the primary SP 1, the other queries panic.
+type fakeVirtualGroupQueryClient struct {
+	vgTypes.QueryClient
+}
+
+func (f *fakeVirtualGroupQueryClient) GlobalVirtualGroupFamily(_ context.Context, req *vgTypes.QueryGlobalVirtualGroupFamilyRequest,
+	_ ...grpc.CallOption,
+) (*vgTypes.QueryGlobalVirtualGroupFamilyResponse, error) {
+	return &vgTypes.QueryGlobalVirtualGroupFamilyResponse{GlobalVirtualGroupFamily: &vgTypes.GlobalVirtualGroupFamily{
+		Id:          req.FamilyId,
+		PrimarySpId: 1,
+	}}, nil
+}
+
+// newTxResultServer returns the tendermint RPC server serving the tx queries by the tx result of code.
+func newTxResultServer(t *testing.T, code uint32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "tx", req.Method)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"hash":"0ABC","height":"10","index":0,"tx_result":{"code":%d},"tx":""}}`,
+			req.ID, code)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// uploadTestSP counts the uploads served by the SP, the uploads are refused with uploadStatus if it is not 200, or if
+// they are not signed by uploader when it is set.
+type uploadTestSP struct {
+	uploadStatus int
+	uploader     sdk.AccAddress
+	singleParts  atomic.Int32 // the PUT requests uploading the whole payload
+	parts        atomic.Int32 // the POST requests uploading a part of the resumable upload
+	uploaded     atomic.Int64
+}
+
+func (sp *uploadTestSP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && query.Has("upload-progress"):
+		w.Write([]byte("<QueryUploadProgress><ProgressDescription>object created</ProgressDescription></QueryUploadProgress>"))
+		return
+	case r.Method == http.MethodGet && query.Has("upload-context"):
+		w.Write([]byte("<QueryResumeOffset><Offset>0</Offset></QueryResumeOffset>"))
+		return
+	case r.Method == http.MethodPut:
+		sp.singleParts.Add(1)
+	case r.Method == http.MethodPost && query.Has("offset"):
+		sp.parts.Add(1)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+	if sp.uploader != nil && !signedBy(r, sp.uploader) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<Error><Code>SignatureDoesNotMatch</Code><Message>the signer is not the uploader</Message></Error>"))
+		return
+	}
+	if sp.uploadStatus != http.StatusOK {
+		w.WriteHeader(sp.uploadStatus)
+		w.Write([]byte("<Error><Code>AccessDenied</Code><Message>upload refused</Message></Error>"))
+		return
+	}
+	n, _ := io.Copy(io.Discard, r.Body)
+	sp.uploaded.Add(n)
+}
+
+// newUploadTestClient returns the client creating the objects by the tx of txCode and uploading them to sp, the
+// segment size is 1024 and the default part size is 2048.
+func newUploadTestClient(t *testing.T, txCode uint32, sp *uploadTestSP) *Client {
+	server := httptest.NewServer(sp)
+	t.Cleanup(server.Close)
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c := newTxTestClientWithRPC(t, newTxResultServer(t, txCode).URL, &fakeTxClient{
+		broadcastTx: func(*tx.BroadcastTxRequest) (*tx.BroadcastTxResponse, error) {
+			return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "0ABC"}}, nil
+		},
+	})
+	c.chainClient.StorageQueryClient = &fakeStorageQueryClient{
+		params: func() (*storageTypes.QueryParamsResponse, error) {
+			return &storageTypes.QueryParamsResponse{Params: storageTypes.Params{VersionedParams: storageTypes.VersionedParams{
+				MaxSegmentSize:          1024,
+				RedundantDataChunkNum:   4,
+				RedundantParityChunkNum: 2,
+			}}}, nil
+		},
+		headBucket: func(req *storageTypes.QueryHeadBucketRequest) (*storageTypes.QueryHeadBucketResponse, error) {
+			return &storageTypes.QueryHeadBucketResponse{BucketInfo: &storageTypes.BucketInfo{
+				BucketName:                 req.BucketName,
+				GlobalVirtualGroupFamilyId: 7,
+			}}, nil
+		},
+		headObject: func(req *storageTypes.QueryHeadObjectRequest) (*storageTypes.QueryHeadObjectResponse, error) {
+			return &storageTypes.QueryHeadObjectResponse{ObjectInfo: &storageTypes.ObjectInfo{
+				BucketName:   req.BucketName,
+				ObjectName:   req.ObjectName,
+				ObjectStatus: storageTypes.OBJECT_STATUS_CREATED,
+			}}, nil
+		},
+	}
+	c.chainClient.VirtualGroupQueryClient = &fakeVirtualGroupQueryClient{}
+	c.httpClient = server.Client()
+	c.defaultPartSize = 2048
+	c.setStorageProviders(map[uint32]*types.StorageProvider{1: {Id: 1, EndPoint: endpoint}})
+	return c
+}
+
+// uploadTestOptions returns the options creating the object without the simulation.
+func uploadTestOptions(putOpts types.PutObjectOptions) types.UploadObjectOptions {
+	return types.UploadObjectOptions{
+		CreateOptions: types.CreateObjectOptions{TxOpts: &gnfdsdk.TxOption{
+			NoSimulate: true,
+			GasLimit:   1000,
+			FeeAmount:  sdk.NewCoins(sdk.NewInt64Coin(gnfdsdk.Denom, 5000000000000)),
+		}},
+		PutOptions: putOpts,
+	}
+}
+
+func TestUploadObject(t *testing.T) {
+	testCases := []struct {
+		name        string
+		size        int
+		putOpts     types.PutObjectOptions
+		singleParts int32
+		parts       int32
+	}{
+		{name: "object within a part", size: 1500, singleParts: 1},
+		{name: "object of exactly a part", size: 2048, singleParts: 1},
+		{name: "object beyond a part", size: 5000, parts: 3},
+		{name: "resumable upload disabled", size: 5000, putOpts: types.PutObjectOptions{DisableResumable: true}, singleParts: 1},
+		{name: "part size option", size: 1500, putOpts: types.PutObjectOptions{PartSize: 1024}, parts: 2},
+		{name: "empty object", size: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := &uploadTestSP{uploadStatus: http.StatusOK}
+			c := newUploadTestClient(t, 0, sp)
+
+			result, err := c.UploadObject(context.Background(), "bucket", "object",
+				bytes.NewReader(bytes.Repeat([]byte("a"), tc.size)), uploadTestOptions(tc.putOpts))
+			require.NoError(t, err)
+			require.Equal(t, "0ABC", result.CreateTxHash)
+			require.False(t, result.Sealed)
+			require.Equal(t, tc.singleParts, sp.singleParts.Load())
+			require.Equal(t, tc.parts, sp.parts.Load())
+			require.Equal(t, int64(tc.size), sp.uploaded.Load())
+		})
+	}
+}
+
+func TestUploadObjectErrors(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 5000)
+
+	// the invalid options are refused before any call
+	sp := &uploadTestSP{uploadStatus: http.StatusOK}
+	c := newUploadTestClient(t, 0, sp)
+	result, err := c.UploadObject(context.Background(), "bucket", "object", bytes.NewReader(payload),
+		uploadTestOptions(types.PutObjectOptions{Delegated: true}))
+	var optionsErr *types.OptionsError
+	require.True(t, errors.As(err, &optionsErr), "unexpected error: %v", err)
+	require.Nil(t, result)
+	_, err = c.UploadObject(context.Background(), "bucket", "object", nil, uploadTestOptions(types.PutObjectOptions{}))
+	require.EqualError(t, err, "fail to upload object, reader is nil")
+
+	// nothing is uploaded if the object fails to create
+	c = newUploadTestClient(t, 5, sp)
+	result, err = c.UploadObject(context.Background(), "bucket", "object", bytes.NewReader(payload), uploadTestOptions(types.PutObjectOptions{}))
+	require.ErrorContains(t, err, "the createObject txn has failed with response code: 5")
+	require.Nil(t, result)
+	require.Zero(t, sp.singleParts.Load()+sp.parts.Load())
+
+	// the created object is returned with the upload errors of both the single part and the resumable uploads
+	for _, putOpts := range []types.PutObjectOptions{{DisableResumable: true}, {}} {
+		sp = &uploadTestSP{uploadStatus: http.StatusForbidden}
+		c = newUploadTestClient(t, 0, sp)
+		result, err = c.UploadObject(context.Background(), "bucket", "object", bytes.NewReader(payload), uploadTestOptions(putOpts))
+		require.ErrorContains(t, err, "object object is created by tx 0ABC but fails to upload")
+		require.ErrorContains(t, err, "upload refused")
+		require.Equal(t, "0ABC", result.CreateTxHash)
+		require.False(t, result.Sealed)
+		require.Equal(t, int32(1), sp.singleParts.Load()+sp.parts.Load())
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// uploadPlanLimits are the chain params and the client settings which the upload plan depends on.
+type uploadPlanLimits struct {
+	segmentSize     uint64
+	maxPayloadSize  uint64
+	defaultPartSize uint64
+	blockTime       time.Duration
+}
+
+// PlanUpload - Estimate the cost of uploading an object of objectSize by the chain params, without sending any tx.
+//
+// The estimations assume the hash computing and the connection throughputs of types.UploadPlanHashThroughput and
+// types.UploadPlanConnectionThroughput, and the SP approvals valid for types.ApprovalValidBlocks, so they are meant
+// for budgeting rather than guarantees.
+//
+// - ctx: Context variables for the current API call.
+//
+// - objectSize: The size of the object to upload.
+//
+// - partSize: The part size of the resumable upload, it is recommended by bandwidthHint if it is 0.
+//
+// - bandwidthHint: The bytes per second available for the upload, the transfer time is not estimated if it is 0.
+//
+// - ret1: The plan of the upload.
+//
+// - ret2: Return error when the object can not be uploaded with the part size, otherwise return nil.
+func (c *Client) PlanUpload(ctx context.Context, objectSize int64, partSize uint64, bandwidthHint int64) (*types.UploadPlan, error) {
+	if bandwidthHint < 0 {
+		return nil, fmt.Errorf("the bandwidth hint %d should not be negative", bandwidthHint)
+	}
+	params, err := c.GetParams()
+	if err != nil {
+		return nil, err
+	}
+	if partSize != 0 {
+		if partSize, err = c.resolvePartSize(partSize, params); err != nil {
+			return nil, err
+		}
+	}
+	return planUpload(objectSize, partSize, bandwidthHint, uploadPlanLimits{
+		segmentSize:     params.GetMaxSegmentSize(),
+		maxPayloadSize:  params.GetMaxPayloadSize(),
+		defaultPartSize: c.defaultPartSize,
+		blockTime:       c.averageBlockTime(ctx),
+	})
+}
+
+func planUpload(objectSize int64, partSize uint64, bandwidthHint int64, limits uploadPlanLimits) (*types.UploadPlan, error) {
+	if objectSize <= 0 {
+		return nil, errors.New("object size should be more than 0")
+	}
+	if limits.segmentSize == 0 {
+		return nil, errors.New("the max segment size on chain is unknown")
+	}
+	size := uint64(objectSize)
+	if limits.maxPayloadSize != 0 && size > limits.maxPayloadSize {
+		return nil, fmt.Errorf("object size %d exceeds the max payload size %d", objectSize, limits.maxPayloadSize)
+	}
+
+	plan := &types.UploadPlan{
+		ObjectSize:   objectSize,
+		SegmentCount: ceilDiv(size, limits.segmentSize),
+		Concurrency:  types.DefaultSyncConcurrency,
+		TxCount:      1,
+		HashDuration: transferDuration(objectSize, types.UploadPlanHashThroughput),
+	}
+	if bandwidthHint > 0 {
+		plan.Concurrency = types.MaxUploadPlanConcurrency
+		if connections := ceilDiv(uint64(bandwidthHint), types.UploadPlanConnectionThroughput); connections < types.MaxUploadPlanConcurrency {
+			plan.Concurrency = int(connections)
+		}
+		plan.TransferDuration = transferDuration(objectSize, bandwidthHint)
+	}
+
+	plan.PartSize = partSize
+	if plan.PartSize == 0 {
+		plan.PartSize = limits.defaultPartSize
+		if bandwidthHint > 0 {
+			plan.PartSize = recommendPartSize(bandwidthHint/int64(plan.Concurrency), limits)
+		}
+	}
+	plan.PartCount = 1
+	if size > plan.PartSize {
+		plan.PartCount = ceilDiv(size, plan.PartSize)
+	}
+
+	// the approval is requested before computing the checksums, and it should stay valid until the tx is sent
+	approvalWindow := time.Duration(types.ApprovalValidBlocks-types.ApprovalExpiryMargin) * limits.blockTime
+	plan.ApprovalExpiryRisk = plan.HashDuration >= approvalWindow
+	return plan, nil
+}
+
+// recommendPartSize returns the part size uploaded in about types.UploadPlanPartDuration by a connection, it is an
+// integer multiple of the segment size within the max payload size.
+func recommendPartSize(connectionBandwidth int64, limits uploadPlanLimits) uint64 {
+	partSize := uint64(connectionBandwidth) * uint64(types.UploadPlanPartDuration/time.Second)
+	if limits.maxPayloadSize != 0 && partSize > limits.maxPayloadSize {
+		partSize = limits.maxPayloadSize
+	}
+	if partSize < limits.segmentSize {
+		return limits.segmentSize
+	}
+	return partSize / limits.segmentSize * limits.segmentSize
+}
+
+func transferDuration(size, bytesPerSecond int64) time.Duration {
+	return time.Duration(float64(size) / float64(bytesPerSecond) * float64(time.Second))
+}
+
+func ceilDiv(a, b uint64) uint64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestPlanUpload(t *testing.T) {
+	const mib = 1024 * 1024
+	limits := uploadPlanLimits{segmentSize: 16 * mib, maxPayloadSize: 64 * 1024 * mib, defaultPartSize: 32 * mib, blockTime: time.Second}
+
+	// the default part size is used without the bandwidth hint
+	plan, err := planUpload(100*mib, 0, 0, limits)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), plan.SegmentCount)
+	require.Equal(t, uint64(32*mib), plan.PartSize)
+	require.Equal(t, uint64(4), plan.PartCount)
+	require.Equal(t, types.DefaultSyncConcurrency, plan.Concurrency)
+	require.Equal(t, 1, plan.TxCount)
+	require.Zero(t, plan.TransferDuration)
+	require.False(t, plan.ApprovalExpiryRisk)
+
+	// 20MiB/s takes 3 connections, each of them uploads about 30s of 6.67MiB/s in a part
+	plan, err = planUpload(10*1024*mib, 0, 20*mib, limits)
+	require.NoError(t, err)
+	require.Equal(t, 3, plan.Concurrency)
+	require.Equal(t, uint64(192*mib), plan.PartSize)
+	require.Equal(t, uint64(54), plan.PartCount)
+	require.Equal(t, 512*time.Second, plan.TransferDuration)
+	require.Equal(t, 160*time.Second, plan.HashDuration)
+	require.True(t, plan.ApprovalExpiryRisk)
+
+	// the specified part size is kept, the fast links are capped
+	plan, err = planUpload(16*mib, 48*mib, 1024*mib, limits)
+	require.NoError(t, err)
+	require.Equal(t, types.MaxUploadPlanConcurrency, plan.Concurrency)
+	require.Equal(t, uint64(48*mib), plan.PartSize)
+	require.Equal(t, uint64(1), plan.PartCount)
+
+	_, err = planUpload(0, 0, 0, limits)
+	require.Error(t, err)
+	_, err = planUpload(65*1024*mib, 0, 0, limits)
+	require.Error(t, err)
+}
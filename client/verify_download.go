@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// getObjectWithVerification downloads the object by the options and verifies the payload against the checksum of the
+// primary SP on chain while it is read.
+func (c *Client) getObjectWithVerification(ctx context.Context, bucketName, objectName string,
+	opts types.GetObjectOptions,
+) (io.ReadCloser, types.ObjectStat, error) {
+	expected, segmentSize, err := c.getIntegrityChecksum(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	opts.VerifyIntegrity = false
+	body, stat, err := c.GetObject(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	return newVerifyingReader(body, objectName, segmentSize, expected), stat, nil
+}
+
+// getIntegrityChecksum returns the checksum of the primary SP of the object on chain and the segment size which the
+// payload is hashed by.
+func (c *Client) getIntegrityChecksum(ctx context.Context, bucketName, objectName string) ([]byte, int64, error) {
+	detail, err := c.HeadObject(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(detail.ObjectInfo.Checksums) == 0 {
+		return nil, 0, fmt.Errorf("the checksums of object %s are not found on chain", objectName)
+	}
+	params, err := c.getRedundancyParams()
+	if err != nil {
+		return nil, 0, err
+	}
+	return detail.ObjectInfo.Checksums[0], params.segmentSize, nil
+}
+
+// verifyDownloadedFile verifies the payload of the object downloaded into the file against the checksum of the
+// primary SP on chain.
+func (c *Client) verifyDownloadedFile(ctx context.Context, bucketName, objectName, filePath string) error {
+	expected, segmentSize, err := c.getIntegrityChecksum(ctx, bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	file, err := c.fileSystem.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	hasher, reader := newSegmentHasher(file, segmentSize)
+	size, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return err
+	}
+	return checkIntegrity(objectName, hasher.Checksums(), size, expected)
+}
+
+// checkIntegrity returns a *types.IntegrityError if the integrity hash of the segment checksums mismatches the
+// expected checksum
+func checkIntegrity(objectName string, checksums [][]byte, size int64, expected []byte) error {
+	computed := hashlib.GenerateIntegrityHash(checksums)
+	if bytes.Equal(computed, expected) {
+		return nil
+	}
+	return &types.IntegrityError{
+		ObjectName: objectName,
+		Segments:   len(checksums),
+		Size:       size,
+		Expected:   hex.EncodeToString(expected),
+		Computed:   hex.EncodeToString(computed),
+	}
+}
+
+// verifyingReader hashes the segments of the payload as they are read, and checks the integrity hash of the segment
+// hashes against the expected checksum when the payload is read to the end.
+type verifyingReader struct {
+	body       io.ReadCloser
+	reader     io.Reader // reader reads body through hasher
+	hasher     *segmentHasher
+	objectName string
+	expected   []byte
+	size       int64
+	err        error
+}
+
+func newVerifyingReader(body io.ReadCloser, objectName string, segmentSize int64, expected []byte) *verifyingReader {
+	hasher, reader := newSegmentHasher(body, segmentSize)
+	return &verifyingReader{
+		body:       body,
+		reader:     reader,
+		hasher:     hasher,
+		objectName: objectName,
+		expected:   expected,
+	}
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	if err == io.EOF {
+		if verifyErr := checkIntegrity(r.objectName, r.hasher.Checksums(), r.size, r.expected); verifyErr != nil {
+			err = verifyErr
+		}
+	}
+	if err != nil {
+		r.err = err
+	}
+	return n, err
+}
+
+func (r *verifyingReader) Close() error {
+	return r.body.Close()
+}
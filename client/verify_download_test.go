@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func integrityHashOf(payload []byte, segmentSize int) []byte {
+	var checksums []byte
+	for start := 0; start < len(payload); start += segmentSize {
+		end := start + segmentSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		checksum := sha256.Sum256(payload[start:end])
+		checksums = append(checksums, checksum[:]...)
+	}
+	integrityHash := sha256.Sum256(checksums)
+	return integrityHash[:]
+}
+
+func TestVerifyingReader(t *testing.T) {
+	payload := bytes.Repeat([]byte("greenfield"), 7)
+	expected := integrityHashOf(payload, 16)
+
+	// the payload read in chunks unaligned with the segments passes
+	reader := newVerifyingReader(io.NopCloser(iotest.OneByteReader(bytes.NewReader(payload))), "obj", 16, expected)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	// the tampered payload is reported in place of io.EOF
+	tampered := append([]byte{}, payload...)
+	tampered[20] ^= 0xff
+	reader = newVerifyingReader(io.NopCloser(bytes.NewReader(tampered)), "obj", 16, expected)
+	data, err = io.ReadAll(reader)
+	var integrityErr *types.IntegrityError
+	require.True(t, errors.As(err, &integrityErr))
+	require.Equal(t, len(tampered), len(data))
+	require.Equal(t, 5, integrityErr.Segments)
+	require.Equal(t, int64(len(tampered)), integrityErr.Size)
+	_, err = reader.Read(make([]byte, 1))
+	require.True(t, errors.As(err, &integrityErr))
+
+	// the truncated payload is reported as well
+	reader = newVerifyingReader(io.NopCloser(bytes.NewReader(payload[:32])), "obj", 16, expected)
+	_, err = io.ReadAll(reader)
+	require.True(t, errors.As(err, &integrityErr))
+
+	// the empty payload
+	reader = newVerifyingReader(io.NopCloser(bytes.NewReader(nil)), "obj", 16, integrityHashOf(nil, 16))
+	data, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Empty(t, data)
+}
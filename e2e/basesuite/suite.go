@@ -82,21 +82,8 @@ func (s *BaseSuite) SetupSuite() {
 
 func (s *BaseSuite) WaitSealObject(bucketName string, objectName string) {
 	startCheckTime := time.Now()
-	var (
-		objectDetail *types.ObjectDetail
-		err          error
-	)
-
-	// wait 300s
-	for i := 0; i < 100; i++ {
-		objectDetail, err = s.Client.HeadObject(s.ClientContext, bucketName, objectName)
-		s.Require().NoError(err)
-		if objectDetail.ObjectInfo.GetObjectStatus() == storageTypes.OBJECT_STATUS_SEALED && !objectDetail.ObjectInfo.GetIsUpdating() {
-			break
-		}
-		time.Sleep(3 * time.Second)
-	}
-
-	s.Require().Equal(objectDetail.ObjectInfo.GetObjectStatus().String(), "OBJECT_STATUS_SEALED")
+	objectDetail, err := s.Client.WaitForObjectSeal(s.ClientContext, bucketName, objectName, types.WaitForObjectSealOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(storageTypes.OBJECT_STATUS_SEALED, objectDetail.ObjectInfo.GetObjectStatus())
 	s.T().Logf("---> Wait Seal Object cost %d ms, <---", time.Since(startCheckTime).Milliseconds())
 }
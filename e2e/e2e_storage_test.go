@@ -452,6 +452,16 @@ func (s *StorageTestSuite) createBigObjectWithoutPutObject() (bucket string, obj
 	return bucketName, objectName, buffer
 }
 
+// bytesWriterAt writes into a pre-allocated buffer, the concurrent writes must not overlap.
+type bytesWriterAt []byte
+
+func (b bytesWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 || off+int64(len(p)) > int64(len(b)) {
+		return 0, io.ErrShortWrite
+	}
+	return copy(b[off:], p), nil
+}
+
 func getTmpFilesInDirectory(directory string) ([]string, error) {
 	var tmpFiles []string
 
@@ -537,6 +547,14 @@ func (s *StorageTestSuite) Test_Resumable_Upload_And_Download() {
 	s.Require().True(isSame)
 	s.Require().NoError(err)
 
+	// download the parts in parallel into a pre-allocated buffer
+	sink := make(bytesWriterAt, buffer.Len())
+	objStat, err := s.Client.GetObjectToWriterAt(s.ClientContext, bucketName, objectName, sink,
+		types.GetObjectOptions{PartSize: 16 * 1024 * 1024, Concurrency: 3})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(buffer.Len()), objStat.Size)
+	s.Require().Equal(buffer.Bytes(), []byte(sink))
+
 	// 4) Resumabledownload, download a file with default checkpoint
 	client.DownloadSegmentHooker = DownloadErrorHooker
 	resumableDownloadFile := storageTestUtil.GenRandomObjectName()
@@ -18,6 +18,13 @@ func main() {
 		log.Fatalf("unable to new greenfield client, %v", err)
 	}
 	ctx := context.Background()
+	if faucetURL != "" {
+		balance, err := cli.RequestFaucetFunds(ctx, "", types.RequestFaucetOptions{FaucetURL: faucetURL})
+		if err != nil {
+			log.Fatalf("unable to request faucet funds, %v", err)
+		}
+		log.Printf("balance after funded: %s", balance.String())
+	}
 	nodeInfo, versionInfo, err := cli.GetNodeInfo(ctx)
 	if err != nil {
 		log.Fatalf("unable to get node info, %v", err)
@@ -2,12 +2,12 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
 )
 
 // The config information is consistent with the testnet of greenfield
@@ -28,6 +28,7 @@ const (
 	paymentAddr             = ""
 	bscRpcAddr              = "https://data-seed-prebsc-1-s1.binance.org:8545/"
 	bscPrivateKey           = "a6f2041aeca9a09159c937b77316c9c7e2c0f1c5b7241832f84bf1d37eb49661"
+	faucetURL               = "" // used to fund the account of privateKey on testnet, skipped if it is empty
 )
 
 func handleErr(err error, funcName string) {
@@ -37,24 +38,8 @@ func handleErr(err error, funcName string) {
 }
 
 func waitObjectSeal(cli client.IClient, bucketName, objectName string) {
-	ctx := context.Background()
 	// wait for the object to be sealed
-	timeout := time.After(15 * time.Second)
-	ticker := time.NewTicker(2 * time.Second)
-
-	for {
-		select {
-		case <-timeout:
-			err := errors.New("object not sealed after 15 seconds")
-			handleErr(err, "HeadObject")
-		case <-ticker.C:
-			objectDetail, err := cli.HeadObject(ctx, bucketName, objectName)
-			handleErr(err, "HeadObject")
-			if objectDetail.ObjectInfo.GetObjectStatus().String() == "OBJECT_STATUS_SEALED" {
-				ticker.Stop()
-				fmt.Printf("put object %s successfully \n", objectName)
-				return
-			}
-		}
-	}
+	_, err := cli.WaitForObjectSeal(context.Background(), bucketName, objectName, types.WaitForObjectSealOptions{Timeout: 15 * time.Second})
+	handleErr(err, "WaitForObjectSeal")
+	fmt.Printf("put object %s successfully \n", objectName)
 }
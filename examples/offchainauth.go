@@ -28,7 +28,7 @@ func main() {
 	ctx := context.Background()
 	// list object
 	objects, err := cli.ListObjects(ctx, bucketName, types.ListObjectsOptions{
-		true, "", "", "/", "", 10, "", "",
+		ShowRemovedObject: true, Delimiter: "/", MaxKeys: 10,
 	})
 	log.Println("list objects result:")
 	for _, obj := range objects.Objects {
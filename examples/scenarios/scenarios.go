@@ -0,0 +1,326 @@
+// Package scenarios provides the common multi-step usages of the SDK as parameterized functions, they can be run
+// programmatically to smoke-test a Greenfield deployment.
+package scenarios
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+)
+
+// Config contains the parameters of the scenarios.
+type Config struct {
+	BucketName   string        // BucketName indicates the bucket created by the scenario, a random name is used if it is empty.
+	ObjectName   string        // ObjectName indicates the object created by the scenario, a random name is used if it is empty.
+	GroupName    string        // GroupName indicates the group created by the scenario, a random name is used if it is empty.
+	ObjectSize   int64         // ObjectSize indicates the size of the uploaded object, the default value is 1000 bytes.
+	PrimarySP    string        // PrimarySP indicates the operator address of the primary SP, the first in-service SP is used if it is empty.
+	Principal    string        // Principal indicates the HEX-encoded address which the object is shared with or added to the group, the step is skipped if it is empty.
+	SealTimeout  time.Duration // SealTimeout indicates the max time to wait for the object to be sealed, the default value is 1 minute.
+	KeepResource bool          // KeepResource indicates whether to keep the created resources instead of cleaning them up.
+}
+
+// StepResult records the outcome of a step of the scenario.
+type StepResult struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// Report records the steps run by the scenario.
+type Report struct {
+	Scenario string
+	Steps    []StepResult
+}
+
+// Failed returns true if any step of the scenario failed.
+func (r *Report) Failed() bool {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the readable summary of the report.
+func (r *Report) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "scenario %s:\n", r.Scenario)
+	for _, step := range r.Steps {
+		status := "ok"
+		if step.Err != nil {
+			status = "failed: " + step.Err.Error()
+		}
+		fmt.Fprintf(&buf, "  %-16s %-10s %s\n", step.Name, step.Duration.Round(time.Millisecond), status)
+	}
+	return buf.String()
+}
+
+// Scenario is the signature of the scenarios, the returned error is the error of the first failed step.
+type Scenario func(ctx context.Context, cli client.IClient, cfg Config) (*Report, error)
+
+// All returns the scenarios keyed by name.
+func All() map[string]Scenario {
+	return map[string]Scenario{
+		"storage": StorageLifecycle,
+		"group":   GroupLifecycle,
+	}
+}
+
+// run runs the step and records its result, the step is skipped if a previous step failed
+func (r *Report) run(name string, step func() error) {
+	if r.Failed() {
+		return
+	}
+	start := time.Now()
+	err := step()
+	r.Steps = append(r.Steps, StepResult{Name: name, Duration: time.Since(start), Err: err})
+}
+
+// cleanup runs the cleanup step and records its result even if a previous step failed
+func (r *Report) cleanup(name string, step func() error) {
+	start := time.Now()
+	err := step()
+	r.Steps = append(r.Steps, StepResult{Name: name, Duration: time.Since(start), Err: err})
+}
+
+// firstErr returns the error of the first failed step
+func (r *Report) firstErr() error {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return fmt.Errorf("step %s failed: %w", step.Name, step.Err)
+		}
+	}
+	return nil
+}
+
+// StorageLifecycle - Run the scenario of create bucket, upload object, share object, download object and clean up.
+//
+// - ctx: Context variables for the current API call.
+//
+// - cli: The client with the default account which pays for the scenario.
+//
+// - cfg: The parameters of the scenario.
+//
+// - ret1: The report of the steps.
+//
+// - ret2: Return the error of the first failed step, otherwise return nil.
+func StorageLifecycle(ctx context.Context, cli client.IClient, cfg Config) (*Report, error) {
+	cfg = withDefaults(cfg)
+	report := &Report{Scenario: "storage"}
+	payload := randomPayload(cfg.ObjectSize)
+
+	bucketCreated, objectCreated := false, false
+	report.run("CreateBucket", func() error {
+		primarySP := cfg.PrimarySP
+		if primarySP == "" {
+			spList, err := cli.ListStorageProviders(ctx, true)
+			if err != nil {
+				return err
+			}
+			if len(spList) == 0 {
+				return errors.New("no in-service SP")
+			}
+			primarySP = spList[0].GetOperatorAddress()
+		}
+		_, err := cli.CreateBucket(ctx, cfg.BucketName, primarySP, types.CreateBucketOptions{})
+		bucketCreated = err == nil
+		return err
+	})
+	report.run("CreateObject", func() error {
+		_, err := cli.CreateObject(ctx, cfg.BucketName, cfg.ObjectName, bytes.NewReader(payload), types.CreateObjectOptions{})
+		objectCreated = err == nil
+		return err
+	})
+	report.run("PutObject", func() error {
+		return cli.PutObject(ctx, cfg.BucketName, cfg.ObjectName, int64(len(payload)), bytes.NewReader(payload),
+			types.PutObjectOptions{})
+	})
+	report.run("WaitObjectSeal", func() error {
+		_, err := cli.WaitForObjectSeal(ctx, cfg.BucketName, cfg.ObjectName, types.WaitForObjectSealOptions{Timeout: cfg.SealTimeout})
+		return err
+	})
+	if cfg.Principal != "" {
+		report.run("PutObjectPolicy", func() error {
+			principalAddr, err := sdk.AccAddressFromHexUnsafe(cfg.Principal)
+			if err != nil {
+				return err
+			}
+			principal, err := utils.NewPrincipalWithAccount(principalAddr)
+			if err != nil {
+				return err
+			}
+			statement := utils.NewStatement([]permTypes.ActionType{permTypes.ACTION_GET_OBJECT}, permTypes.EFFECT_ALLOW,
+				nil, types.NewStatementOptions{})
+			txnHash, err := cli.PutObjectPolicy(ctx, cfg.BucketName, cfg.ObjectName, principal,
+				[]*permTypes.Statement{&statement}, types.PutPolicyOption{})
+			if err != nil {
+				return err
+			}
+			return waitTx(ctx, cli, txnHash)
+		})
+	}
+	report.run("GetObject", func() error {
+		reader, _, err := cli.GetObject(ctx, cfg.BucketName, cfg.ObjectName, types.GetObjectOptions{})
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(content, payload) {
+			return errors.New("the downloaded content is not the same as the uploaded one")
+		}
+		return nil
+	})
+
+	if !cfg.KeepResource {
+		if objectCreated {
+			report.cleanup("DeleteObject", func() error {
+				txnHash, err := cli.DeleteObject(ctx, cfg.BucketName, cfg.ObjectName, types.DeleteObjectOption{})
+				if err != nil {
+					return err
+				}
+				return waitTx(ctx, cli, txnHash)
+			})
+		}
+		if bucketCreated {
+			report.cleanup("DeleteBucket", func() error {
+				txnHash, err := cli.DeleteBucket(ctx, cfg.BucketName, types.DeleteBucketOption{})
+				if err != nil {
+					return err
+				}
+				return waitTx(ctx, cli, txnHash)
+			})
+		}
+	}
+	return report, report.firstErr()
+}
+
+// GroupLifecycle - Run the scenario of create group, add member, check member and clean up.
+//
+// - ctx: Context variables for the current API call.
+//
+// - cli: The client with the default account which owns the group.
+//
+// - cfg: The parameters of the scenario, the member steps are skipped if cfg.Principal is empty.
+//
+// - ret1: The report of the steps.
+//
+// - ret2: Return the error of the first failed step, otherwise return nil.
+func GroupLifecycle(ctx context.Context, cli client.IClient, cfg Config) (*Report, error) {
+	cfg = withDefaults(cfg)
+	report := &Report{Scenario: "group"}
+
+	owner, err := cli.GetDefaultAccount()
+	if err != nil {
+		return report, err
+	}
+	ownerAddr := owner.GetAddress().String()
+
+	groupCreated := false
+	report.run("CreateGroup", func() error {
+		txnHash, err := cli.CreateGroup(ctx, cfg.GroupName, types.CreateGroupOptions{})
+		if err != nil {
+			return err
+		}
+		groupCreated = true
+		return waitTx(ctx, cli, txnHash)
+	})
+	report.run("HeadGroup", func() error {
+		_, err := cli.HeadGroup(ctx, cfg.GroupName, ownerAddr)
+		return err
+	})
+	if cfg.Principal != "" {
+		report.run("UpdateGroupMember", func() error {
+			txnHash, err := cli.UpdateGroupMember(ctx, cfg.GroupName, ownerAddr, []string{cfg.Principal}, nil,
+				types.UpdateGroupMemberOption{})
+			if err != nil {
+				return err
+			}
+			return waitTx(ctx, cli, txnHash)
+		})
+		report.run("HeadGroupMember", func() error {
+			if !cli.HeadGroupMember(ctx, cfg.GroupName, ownerAddr, cfg.Principal) {
+				return fmt.Errorf("member %s is not found in group %s", cfg.Principal, cfg.GroupName)
+			}
+			return nil
+		})
+	}
+
+	if !cfg.KeepResource && groupCreated {
+		report.cleanup("DeleteGroup", func() error {
+			txnHash, err := cli.DeleteGroup(ctx, cfg.GroupName, types.DeleteGroupOption{})
+			if err != nil {
+				return err
+			}
+			return waitTx(ctx, cli, txnHash)
+		})
+	}
+	return report, report.firstErr()
+}
+
+func withDefaults(cfg Config) Config {
+	suffix := randomName()
+	if cfg.BucketName == "" {
+		cfg.BucketName = "scenario-bucket-" + suffix
+	}
+	if cfg.ObjectName == "" {
+		cfg.ObjectName = "scenario-object-" + suffix
+	}
+	if cfg.GroupName == "" {
+		cfg.GroupName = "scenario-group-" + suffix
+	}
+	if cfg.ObjectSize == 0 {
+		cfg.ObjectSize = 1000
+	}
+	if cfg.SealTimeout == 0 {
+		cfg.SealTimeout = time.Minute
+	}
+	return cfg
+}
+
+func randomName() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	name := make([]byte, 8)
+	for i := range name {
+		name[i] = letters[rand.Intn(len(letters))]
+	}
+	return string(name)
+}
+
+func randomPayload(size int64) []byte {
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = byte('a' + rand.Intn(26))
+	}
+	return payload
+}
+
+func waitTx(ctx context.Context, cli client.IClient, txnHash string) error {
+	ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
+	defer cancel()
+	resp, err := cli.WaitForTx(ctxTimeout, txnHash)
+	if err != nil {
+		return err
+	}
+	if resp.TxResult.Code != 0 {
+		return fmt.Errorf("the txn %s has failed with response code: %d, codespace: %s", txnHash,
+			resp.TxResult.Code, resp.TxResult.Codespace)
+	}
+	return nil
+}
@@ -82,7 +82,7 @@ func main() {
 
 	// list object
 	objects, err := cli.ListObjects(ctx, bucketName, types.ListObjectsOptions{
-		true, "", "", "/", "", 10, httpsAddr, "",
+		ShowRemovedObject: true, Delimiter: "/", MaxKeys: 10, Endpoint: httpsAddr,
 	})
 	log.Println("list objects result:")
 	for _, obj := range objects.Objects {
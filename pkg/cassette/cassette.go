@@ -0,0 +1,187 @@
+/*
+Package cassette provides recorded-response support for the greenfield-go-sdk.
+
+A Cassette keeps the interactions between the SDK and the outside world (SP HTTP requests and chain gRPC calls)
+in a single JSON file. In ModeRecord the interactions are forwarded to the real services and saved, in ModeReplay
+the recorded responses are served back without any network access, so downstream projects can run deterministic
+tests in CI without a devnet.
+
+The SP interactions are captured by setting a Transport as client.Option.Transport. The chain interactions are
+captured by connecting to the chain gRPC endpoint with client.Option.GrpcAddress and setting
+grpc.WithUnaryInterceptor(UnaryClientInterceptor(cassette)) as client.Option.GrpcDialOption. The queries which
+are only served by the chain RPC endpoint, e.g. the node status and the blocks, are not captured.
+*/
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Mode indicates how a Cassette handles the interactions.
+type Mode int
+
+const (
+	// ModeRecord forwards the interactions to the real services and records them.
+	ModeRecord Mode = iota
+	// ModeReplay serves the interactions from the recorded file, no request will be sent to the real services.
+	ModeReplay
+)
+
+const (
+	// KindHTTP indicates the interaction is a http request sent to SP.
+	KindHTTP = "http"
+	// KindGRPC indicates the interaction is a gRPC call sent to the chain node.
+	KindGRPC = "grpc"
+
+	cassetteVersion = 1
+)
+
+// ErrInteractionNotFound is returned in ModeReplay when no recorded interaction matches the request.
+var ErrInteractionNotFound = errors.New("cassette: no recorded interaction matches the request")
+
+// Interaction defines a recorded request and its response.
+type Interaction struct {
+	Kind     string   `json:"kind"`
+	Key      string   `json:"key"`
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request defines the recorded request info.
+type Request struct {
+	// Method is the http method of the SP request or the full method name of the gRPC call.
+	Method string `json:"method"`
+	// URL is the url of the SP request, it is empty for the gRPC call.
+	URL string `json:"url,omitempty"`
+	// Body is the marshaled request of the gRPC call, the body of the SP request is not recorded.
+	Body []byte `json:"body,omitempty"`
+}
+
+// Response defines the recorded response info.
+type Response struct {
+	StatusCode int         `json:"status_code,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+	// Error is the error of the SP request, or the status message of the failed gRPC call.
+	Error string `json:"error,omitempty"`
+	// GRPCCode is the status code of the failed gRPC call, so that the replayed error has the same code.
+	GRPCCode codes.Code `json:"grpc_code,omitempty"`
+}
+
+type cassetteFile struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Cassette stores the recorded interactions of a test run.
+type Cassette struct {
+	mu   sync.Mutex
+	path string
+	mode Mode
+	// interactions keeps all the interactions in recorded order, it is what will be saved to file.
+	interactions []*Interaction
+	// replayQueue indexes the interactions which have not been replayed yet by kind and key.
+	replayQueue map[string][]*Interaction
+}
+
+// New - Create a Cassette bound to the file specified by path.
+//
+// - path: The file used to store the recorded interactions.
+//
+// - mode: ModeRecord to record the interactions, ModeReplay to load and replay them.
+//
+// - ret1: The created cassette.
+//
+// - ret2: Return error when the cassette file can not be loaded in ModeReplay, otherwise return nil.
+func New(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{
+		path:        path,
+		mode:        mode,
+		replayQueue: make(map[string][]*Interaction),
+	}
+	if mode == ModeReplay {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Mode returns the mode of the cassette.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Interactions returns a copy of the recorded interactions.
+func (c *Cassette) Interactions() []*Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	interactions := make([]*Interaction, len(c.interactions))
+	copy(interactions, c.interactions)
+	return interactions
+}
+
+// Save writes the recorded interactions to the cassette file, it does nothing in ModeReplay.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+	c.mu.Lock()
+	content, err := json.MarshalIndent(cassetteFile{Version: cassetteVersion, Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, content, 0o644)
+}
+
+func (c *Cassette) load() error {
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	file := cassetteFile{}
+	if err = json.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("cassette: fail to decode %s: %v", c.path, err)
+	}
+	if file.Version != cassetteVersion {
+		return fmt.Errorf("cassette: unsupported version %d of %s", file.Version, c.path)
+	}
+	c.interactions = file.Interactions
+	for _, interaction := range file.Interactions {
+		queueKey := interaction.Kind + " " + interaction.Key
+		c.replayQueue[queueKey] = append(c.replayQueue[queueKey], interaction)
+	}
+	return nil
+}
+
+func (c *Cassette) record(interaction *Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+}
+
+// next pops the first unplayed interaction matching the kind and the key.
+// The same request can be sent several times in a test, the recorded responses are replayed in order.
+func (c *Cassette) next(kind, key string) (*Interaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	queueKey := kind + " " + key
+	queue := c.replayQueue[queueKey]
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInteractionNotFound, queueKey)
+	}
+	c.replayQueue[queueKey] = queue[1:]
+	return queue[0], nil
+}
@@ -0,0 +1,185 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type response struct {
+	statusCode int
+	header     string
+	body       string
+}
+
+func doGet(t *testing.T, client *http.Client, url string, header http.Header) response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for key := range header {
+		req.Header.Set(key, header.Get(key))
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return response{statusCode: resp.StatusCode, header: resp.Header.Get("X-Gnfd-Request-Id"), body: string(body)}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var counter int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&counter, 1)
+		w.Header().Set("X-Gnfd-Request-Id", fmt.Sprintf("req-%d", n))
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		fmt.Fprintf(w, "%s %s %s %d", r.Method, r.URL.RequestURI(), r.Header.Get("Range"), n)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	recorder, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	recordClient := &http.Client{Transport: NewTransport(recorder, nil)}
+
+	urls := []string{
+		server.URL + "/object?read-quota",
+		server.URL + "/object?read-quota",
+		server.URL + "/missing",
+		server.URL + "/object?read-quota",
+	}
+	var recorded []response
+	for _, url := range urls {
+		recorded = append(recorded, doGet(t, recordClient, url, nil))
+	}
+	recordedRange := doGet(t, recordClient, server.URL+"/object", http.Header{"Range": []string{"bytes=0-9"}})
+	require.NoError(t, recorder.Save())
+	require.Len(t, recorder.Interactions(), len(urls)+1)
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	require.Equal(t, ModeReplay, replayer.Mode())
+	replayClient := &http.Client{Transport: NewTransport(replayer, nil)}
+	server.Close()
+
+	// the repeated requests are replayed in the recorded order
+	require.Equal(t, recordedRange, doGet(t, replayClient, server.URL+"/object", http.Header{"Range": []string{"bytes=0-9"}}))
+	for i, url := range urls {
+		require.Equal(t, recorded[i], doGet(t, replayClient, url, nil))
+	}
+	require.Equal(t, http.StatusNotFound, recorded[2].statusCode)
+	require.NotEqual(t, recorded[0].body, recorded[1].body)
+
+	// all the recorded interactions have been replayed
+	_, err = replayClient.Get(server.URL + "/object?read-quota")
+	require.True(t, errors.Is(err, ErrInteractionNotFound), "unexpected error: %v", err)
+	_, err = replayClient.Get(server.URL + "/another")
+	require.True(t, errors.Is(err, ErrInteractionNotFound), "unexpected error: %v", err)
+}
+
+func TestReplayTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL + "/object"
+	server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	_, recordErr := (&http.Client{Transport: NewTransport(recorder, nil)}).Get(url)
+	require.Error(t, recordErr)
+	require.NoError(t, recorder.Save())
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	_, replayErr := (&http.Client{Transport: NewTransport(replayer, nil)}).Get(url)
+	require.Error(t, replayErr)
+	require.False(t, errors.Is(replayErr, ErrInteractionNotFound))
+	require.Contains(t, replayErr.Error(), "connection refused")
+}
+
+func TestReplayGRPCError(t *testing.T) {
+	const method = "/cosmos.bank.v1beta1.Query/Balance"
+	invoker := func(_ context.Context, _ string, req, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		switch req.(*gogotypes.StringValue).Value {
+		case "missing":
+			return status.Error(codes.NotFound, "account not found")
+		case "plain":
+			return errors.New("connection reset")
+		}
+		reply.(*gogotypes.StringValue).Value = "found"
+		return nil
+	}
+	call := func(interceptor grpc.UnaryClientInterceptor, value string) (string, error) {
+		reply := &gogotypes.StringValue{}
+		err := interceptor(context.Background(), method, &gogotypes.StringValue{Value: value}, reply, nil, invoker)
+		return reply.Value, err
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	values := []string{"missing", "plain", "account"}
+	recordErrs := make([]error, len(values))
+	for i, value := range values {
+		_, recordErrs[i] = call(UnaryClientInterceptor(recorder), value)
+	}
+	require.NoError(t, recorder.Save())
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	interceptor := UnaryClientInterceptor(replayer)
+
+	// the replayed error has the code and the message of the recorded one
+	_, err = call(interceptor, "missing")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, "account not found", status.Convert(err).Message())
+	require.Equal(t, recordErrs[0].Error(), err.Error())
+
+	// the errors without a status are replayed as unknown, as gRPC reports them
+	_, err = call(interceptor, "plain")
+	require.Equal(t, codes.Unknown, status.Code(err))
+	require.Equal(t, "connection reset", status.Convert(err).Message())
+
+	value, err := call(interceptor, "account")
+	require.NoError(t, err)
+	require.Equal(t, "found", value)
+}
+
+func TestHTTPKey(t *testing.T) {
+	req1, err := http.NewRequest(http.MethodGet, "https://bucket1.sp.example.com/object?read-quota", nil)
+	require.NoError(t, err)
+	req2, err := http.NewRequest(http.MethodGet, "https://bucket2.sp.example.com/object?read-quota", nil)
+	require.NoError(t, err)
+	require.NotEqual(t, httpKey(req1), httpKey(req2))
+
+	req3, err := http.NewRequest(http.MethodGet, "https://bucket1.sp.example.com/object?read-quota", nil)
+	require.NoError(t, err)
+	req3.Header.Set("Range", "bytes=0-9")
+	require.NotEqual(t, httpKey(req1), httpKey(req3))
+}
+
+func TestLoadVersionMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "interactions": []}`), 0o644))
+	_, err := New(path, ModeReplay)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported version 2")
+
+	_, err = New(filepath.Join(t.TempDir(), "not-exist.json"), ModeReplay)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,80 @@
+package cassette
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor - Create a gRPC interceptor which records or replays the unary calls sent to the chain node.
+//
+// The interceptor can be installed with grpc.WithUnaryInterceptor when dialing the chain gRPC endpoint, so that the
+// chain interactions can be recorded into the same cassette as the SP interactions.
+//
+// - cassette: The cassette which keeps the interactions.
+func UnaryClientInterceptor(cassette *Cassette) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		reqMsg, ok := req.(proto.Message)
+		if !ok {
+			return fmt.Errorf("cassette: request of %s is not a proto message", method)
+		}
+		replyMsg, ok := reply.(proto.Message)
+		if !ok {
+			return fmt.Errorf("cassette: reply of %s is not a proto message", method)
+		}
+		reqBytes, err := proto.Marshal(reqMsg)
+		if err != nil {
+			return err
+		}
+		key := grpcKey(method, reqBytes)
+
+		if cassette.mode == ModeReplay {
+			interaction, err := cassette.next(KindGRPC, key)
+			if err != nil {
+				return err
+			}
+			if interaction.Response.Error != "" {
+				code := interaction.Response.GRPCCode
+				if code == codes.OK {
+					// the cassettes recorded without the code
+					code = codes.Unknown
+				}
+				return status.Error(code, interaction.Response.Error)
+			}
+			return proto.Unmarshal(interaction.Response.Body, replyMsg)
+		}
+
+		interaction := &Interaction{
+			Kind:    KindGRPC,
+			Key:     key,
+			Request: Request{Method: method, Body: reqBytes},
+		}
+		invokeErr := invoker(ctx, method, req, reply, cc, opts...)
+		if invokeErr != nil {
+			st := status.Convert(invokeErr)
+			interaction.Response.Error = st.Message()
+			interaction.Response.GRPCCode = st.Code()
+		} else {
+			interaction.Response.Body, err = proto.Marshal(replyMsg)
+			if err != nil {
+				return err
+			}
+		}
+		cassette.record(interaction)
+		return invokeErr
+	}
+}
+
+// grpcKey returns the key to match the recorded gRPC interaction by the method and the request content.
+func grpcKey(method string, reqBytes []byte) string {
+	sum := sha256.Sum256(reqBytes)
+	return method + " " + hex.EncodeToString(sum[:])
+}
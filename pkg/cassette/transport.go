@@ -0,0 +1,99 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Transport is a http.RoundTripper which records or replays the http requests sent to SP.
+// It can be set as the Transport of client.Option to make the SP interactions of the client deterministic.
+type Transport struct {
+	cassette *Cassette
+	// next is the underlying RoundTripper used in ModeRecord, http.DefaultTransport is used if it is nil.
+	next http.RoundTripper
+}
+
+// NewTransport - Create a Transport bound to the cassette.
+//
+// - cassette: The cassette which keeps the interactions.
+//
+// - next: The RoundTripper used to send the real requests in ModeRecord, http.DefaultTransport is used if it is nil.
+func NewTransport(cassette *Cassette, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{cassette: cassette, next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := httpKey(req)
+	if t.cassette.mode == ModeReplay {
+		// the body of the request is not needed in replay mode, drain it like a real transport does
+		if req.Body != nil {
+			_, _ = io.Copy(io.Discard, req.Body)
+			req.Body.Close()
+		}
+		interaction, err := t.cassette.next(KindHTTP, key)
+		if err != nil {
+			return nil, err
+		}
+		if interaction.Response.Error != "" {
+			return nil, errors.New(interaction.Response.Error)
+		}
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	// the auth and date headers change in every run, they are not kept in the cassette
+	interaction := &Interaction{
+		Kind: KindHTTP,
+		Key:  key,
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+		},
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		interaction.Response.Error = err.Error()
+		t.cassette.record(interaction)
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		interaction.Response.Error = err.Error()
+		t.cassette.record(interaction)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction.Response.StatusCode = resp.StatusCode
+	interaction.Response.Header = resp.Header.Clone()
+	interaction.Response.Body = body
+	t.cassette.record(interaction)
+	return resp, nil
+}
+
+// httpKey returns the key to match the recorded http interaction.
+// The host is kept since the bucket name is carried by it in virtual-hosted style requests, the Range header is kept
+// so that the segments of a concurrent download are matched to their own responses.
+func httpKey(req *http.Request) string {
+	key := req.Method + " " + req.URL.Host + req.URL.RequestURI()
+	if rangeInfo := req.Header.Get("Range"); rangeInfo != "" {
+		key += " " + rangeInfo
+	}
+	return key
+}
@@ -0,0 +1,144 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// ExpireTagPrefix is the prefix of the bucket tags storing the expiry rules, the rest of the key is the prefix of
+// the objects and the value is the age after which they expire, e.g. "lifecycle/logs/" => "720h0m0s".
+const ExpireTagPrefix = "lifecycle/"
+
+// ApplyLifecycleRule - Store an expiry rule in the tags of the bucket, so the objects under the prefix are deleted
+// by RunLifecycleOnce once they are older than expireAfter. The rule replaces the existing one of the same prefix,
+// and a non-positive expireAfter removes it. The other tags of the bucket are kept.
+//
+// - ctx: Context variables for the current API call.
+//
+// - cli: The client whose default account is the owner of the bucket or has the permission to set its tags.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - prefix: The prefix of the expiring objects, an empty prefix applies to all the objects.
+//
+// - expireAfter: The age after which the objects expire.
+//
+// - opts: The options to send the setTag tx.
+//
+// - ret1: Transaction hash return from blockchain.
+//
+// - ret2: Return error when the tx failed, otherwise return nil.
+func ApplyLifecycleRule(ctx context.Context, cli client.IClient, bucketName, prefix string, expireAfter time.Duration, opts types.SetTagsOptions) (string, error) {
+	bucketInfo, err := cli.HeadBucket(ctx, bucketName)
+	if err != nil {
+		return "", err
+	}
+	tags := types.TagsToMap(bucketInfo.Tags)
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	key := ExpireTagPrefix + prefix
+	if expireAfter > 0 {
+		tags[key] = expireAfter.String()
+	} else {
+		delete(tags, key)
+	}
+	grn := gnfdTypes.NewBucketGRN(bucketName)
+	return cli.SetTag(ctx, grn.String(), *types.NewResourceTags(tags), opts)
+}
+
+// LoadLifecycleRules - Load the expiry rules stored in the tags of the bucket by ApplyLifecycleRule.
+//
+// - ctx: Context variables for the current API call.
+//
+// - cli: The client to query the bucket.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - ret1: The delete rules of the bucket, sorted by the prefixes.
+//
+// - ret2: Return error when the bucket cannot be queried or a rule is malformed, otherwise return nil.
+func LoadLifecycleRules(ctx context.Context, cli client.IClient, bucketName string) ([]Rule, error) {
+	bucketInfo, err := cli.HeadBucket(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	return rulesFromTags(bucketName, bucketInfo.Tags)
+}
+
+// rulesFromTags parses the expiry rules from the tags of the bucket
+func rulesFromTags(bucketName string, tags *storageTypes.ResourceTags) ([]Rule, error) {
+	var rules []Rule
+	for key, value := range types.TagsToMap(tags) {
+		if !strings.HasPrefix(key, ExpireTagPrefix) {
+			continue
+		}
+		expireAfter, err := time.ParseDuration(value)
+		if err != nil || expireAfter <= 0 {
+			return nil, fmt.Errorf("invalid lifecycle tag %s=%s of bucket %s", key, value, bucketName)
+		}
+		rules = append(rules, Rule{
+			ID:         bucketName + ":" + key,
+			BucketName: bucketName,
+			Prefix:     strings.TrimPrefix(key, ExpireTagPrefix),
+			OlderThan:  expireAfter,
+			Action:     ActionDelete,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Prefix < rules[j].Prefix })
+	return rules, nil
+}
+
+// RunLifecycleOnce - Load the expiry rules stored in the tags of the buckets and delete the expired objects in
+// batches, e.g. by a cron job cleaning the log and temp-data buckets. A bucket whose rules cannot be loaded is
+// reported as a failed rule and the other buckets continue.
+//
+// - ctx: Context variables for the current API call.
+//
+// - cli: The client whose default account has the permission to delete the objects.
+//
+// - bucketNames: The buckets to scan.
+//
+// - opts: The options of the round, Interval and OnRound are not used.
+//
+// - ret1: The report of the round.
+//
+// - ret2: Return error when the round is interrupted, otherwise return nil.
+func RunLifecycleOnce(ctx context.Context, cli client.IClient, bucketNames []string, opts Options) (*Report, error) {
+	if cli == nil {
+		return nil, errors.New("the client of lifecycle scheduler should not be nil")
+	}
+	var (
+		rules  []Rule
+		failed []RuleReport
+	)
+	for _, bucketName := range bucketNames {
+		bucketRules, err := LoadLifecycleRules(ctx, cli, bucketName)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			failed = append(failed, RuleReport{RuleID: bucketName + ":" + ExpireTagPrefix, Err: err})
+			continue
+		}
+		rules = append(rules, bucketRules...)
+	}
+	scheduler, err := NewScheduler(cli, rules, opts)
+	if err != nil {
+		return nil, err
+	}
+	report, err := scheduler.RunOnce(ctx)
+	if report != nil {
+		report.Rules = append(failed, report.Rules...)
+	}
+	return report, err
+}
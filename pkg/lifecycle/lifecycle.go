@@ -0,0 +1,302 @@
+// Package lifecycle applies the client-side lifecycle rules to the objects of buckets, e.g. deleting the objects
+// older than N days under a prefix, or tagging them as archive hints. Greenfield has no server-side lifecycle
+// policies, the Scheduler scans the buckets on a schedule and executes the actions in batched transactions.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	gnfdsdk "github.com/bnb-chain/greenfield/sdk/types"
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// Action indicates what to do with the objects matched by a rule.
+type Action int
+
+const (
+	// ActionDelete deletes the matched objects.
+	ActionDelete Action = iota
+	// ActionSetTags sets the tags of the rule to the matched objects, e.g. as the archive hints.
+	ActionSetTags
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case ActionDelete:
+		return "delete"
+	case ActionSetTags:
+		return "set-tags"
+	default:
+		return "unknown"
+	}
+}
+
+// Rule defines which objects are matched and the action applied to them.
+type Rule struct {
+	ID          string                     // ID identifies the rule in the reports.
+	BucketName  string                     // BucketName indicates the bucket the rule applies to.
+	Prefix      string                     // Prefix limits the rule to the objects whose names begin with it.
+	OlderThan   time.Duration              // OlderThan matches the objects created more than the duration ago.
+	Action      Action                     // Action indicates what to do with the matched objects.
+	Tags        *storageTypes.ResourceTags // Tags indicates the tags set by ActionSetTags.
+	SkipTagged  bool                       // SkipTagged skips the objects which already have all the Tags, it only works with ActionSetTags.
+	MaxPerRound int                        // MaxPerRound limits the number of objects handled by the rule in a round, 0 means no limit.
+}
+
+// Validate checks the rule is complete.
+func (r Rule) Validate() error {
+	if r.BucketName == "" {
+		return errors.New("the bucket name of lifecycle rule should not be empty")
+	}
+	if r.OlderThan < 0 {
+		return errors.New("the age of lifecycle rule should not be negative")
+	}
+	switch r.Action {
+	case ActionDelete:
+	case ActionSetTags:
+		if r.Tags == nil || len(r.Tags.Tags) == 0 {
+			return errors.New("the tags of set-tags lifecycle rule should not be empty")
+		}
+	default:
+		return fmt.Errorf("invalid lifecycle action: %d", r.Action)
+	}
+	return nil
+}
+
+// matches returns true if the object is matched by the rule at the time now
+func (r Rule) matches(object *storageTypes.ObjectInfo, now time.Time) bool {
+	if object == nil || !strings.HasPrefix(object.ObjectName, r.Prefix) {
+		return false
+	}
+	if object.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return false
+	}
+	if now.Sub(time.Unix(object.CreateAt, 0)) < r.OlderThan {
+		return false
+	}
+	if r.Action == ActionSetTags && r.SkipTagged && hasTags(object.Tags, r.Tags) {
+		return false
+	}
+	return true
+}
+
+func hasTags(current, expected *storageTypes.ResourceTags) bool {
+	if expected == nil {
+		return true
+	}
+	if current == nil {
+		return len(expected.Tags) == 0
+	}
+	for _, tag := range expected.Tags {
+		found := false
+		for _, t := range current.Tags {
+			if t.Key == tag.Key && t.Value == tag.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Options contains the options of the Scheduler.
+type Options struct {
+	Interval  time.Duration         // Interval indicates the interval between the rounds of Start, the default value is 1 hour.
+	BatchSize int                   // BatchSize indicates the number of actions sent in a transaction, the default value is 50.
+	DryRun    bool                  // DryRun only reports the matched objects without executing the actions.
+	TxOpts    *gnfdsdk.TxOption     // TxOpts defines the options to customize the transactions.
+	OnRound   func(report *Report)  // OnRound is called with the report after each round of Start, it is optional.
+	Now       func() time.Time      // Now returns the current time, time.Now is used if it is nil.
+	ListOpts  types.EndPointOptions // ListOpts indicates the SP endpoint used to list the objects.
+}
+
+// RuleReport records the outcome of a rule in a round.
+type RuleReport struct {
+	RuleID   string
+	Matched  int      // Matched indicates the number of the matched objects.
+	Applied  int      // Applied indicates the number of the objects whose actions are committed.
+	TxHashes []string // TxHashes contains the hashes of the committed transactions.
+	Err      error
+}
+
+// Report records the outcome of a round.
+type Report struct {
+	StartedAt time.Time
+	Duration  time.Duration
+	Rules     []RuleReport
+}
+
+// Scheduler applies the lifecycle rules by scanning the buckets.
+type Scheduler struct {
+	client client.IClient
+	rules  []Rule
+	opts   Options
+
+	mu      sync.Mutex
+	running bool
+}
+
+// NewScheduler - Create a Scheduler applying the rules by the client, the default account of the client should be
+// the owner or have the permissions of the actions.
+func NewScheduler(cli client.IClient, rules []Rule, opts Options) (*Scheduler, error) {
+	if cli == nil {
+		return nil, errors.New("the client of lifecycle scheduler should not be nil")
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", rule.ID, err)
+		}
+	}
+	if opts.Interval == 0 {
+		opts.Interval = time.Hour
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.TxOpts == nil {
+		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
+		opts.TxOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
+	}
+	return &Scheduler{client: cli, rules: rules, opts: opts}, nil
+}
+
+// Start runs the rounds on the schedule until ctx is done, the first round runs immediately.
+func (s *Scheduler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		report, err := s.RunOnce(ctx)
+		if err != nil {
+			log.Error().Msg(fmt.Sprintf("lifecycle round failed: %s", err.Error()))
+		}
+		if report != nil && s.opts.OnRound != nil {
+			s.opts.OnRound(report)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce runs a round which scans the buckets and applies all the rules. The rules are applied one by one, the
+// failure of a rule is recorded in its report and the other rules continue.
+func (s *Scheduler) RunOnce(ctx context.Context) (*Report, error) {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return nil, errors.New("the lifecycle round is already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	report := &Report{StartedAt: s.opts.Now()}
+	for _, rule := range s.rules {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Rules = append(report.Rules, s.applyRule(ctx, rule, report.StartedAt))
+	}
+	report.Duration = time.Since(report.StartedAt)
+	return report, nil
+}
+
+func (s *Scheduler) applyRule(ctx context.Context, rule Rule, now time.Time) RuleReport {
+	ruleReport := RuleReport{RuleID: rule.ID}
+	var matched []*storageTypes.ObjectInfo
+	limitReached := errors.New("limit reached")
+	_, err := s.client.ListObjectsWithCursor(ctx, rule.BucketName, types.ListObjectsWithCursorOptions{
+		Prefix:    rule.Prefix,
+		Endpoint:  s.opts.ListOpts.Endpoint,
+		SPAddress: s.opts.ListOpts.SPAddress,
+	}, func(result types.ListObjectsResult) error {
+		for _, object := range result.Objects {
+			if object.Removed || !rule.matches(object.ObjectInfo, now) {
+				continue
+			}
+			matched = append(matched, object.ObjectInfo)
+			if rule.MaxPerRound > 0 && len(matched) >= rule.MaxPerRound {
+				return limitReached
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, limitReached) {
+		ruleReport.Err = err
+		return ruleReport
+	}
+	ruleReport.Matched = len(matched)
+	if s.opts.DryRun {
+		return ruleReport
+	}
+
+	operator := s.client.MustGetDefaultAccount().GetAddress()
+	for start := 0; start < len(matched); start += s.opts.BatchSize {
+		end := start + s.opts.BatchSize
+		if end > len(matched) {
+			end = len(matched)
+		}
+		msgs := make([]sdk.Msg, 0, end-start)
+		for _, object := range matched[start:end] {
+			switch rule.Action {
+			case ActionDelete:
+				msgs = append(msgs, storageTypes.NewMsgDeleteObject(operator, object.BucketName, object.ObjectName))
+			case ActionSetTags:
+				grn := gnfdTypes.NewObjectGRN(object.BucketName, object.ObjectName)
+				msgs = append(msgs, storageTypes.NewMsgSetTag(operator, grn.String(), rule.Tags))
+			}
+		}
+		txHash, err := s.broadcast(ctx, msgs)
+		if err != nil {
+			ruleReport.Err = fmt.Errorf("fail to %s objects: %w", rule.Action, err)
+			return ruleReport
+		}
+		ruleReport.TxHashes = append(ruleReport.TxHashes, txHash)
+		ruleReport.Applied += len(msgs)
+	}
+	return ruleReport
+}
+
+// broadcast sends the msgs in a transaction and waits for it to be committed
+func (s *Scheduler) broadcast(ctx context.Context, msgs []sdk.Msg) (string, error) {
+	resp, err := s.client.BroadcastTx(ctx, msgs, s.opts.TxOpts)
+	if err != nil {
+		return "", err
+	}
+	txnHash := resp.TxResponse.TxHash
+	ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
+	defer cancel()
+	txnResponse, err := s.client.WaitForTx(ctxTimeout, txnHash)
+	if err != nil {
+		return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+	}
+	if txnResponse.TxResult.Code != 0 {
+		return txnHash, fmt.Errorf("the txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+	}
+	return txnHash, nil
+}
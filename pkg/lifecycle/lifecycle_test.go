@@ -0,0 +1,65 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+func TestRuleMatches(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	archiveTags := &storageTypes.ResourceTags{Tags: []storageTypes.ResourceTags_Tag{{Key: "tier", Value: "archive"}}}
+	newObject := func(name string, age time.Duration, tags *storageTypes.ResourceTags) *storageTypes.ObjectInfo {
+		return &storageTypes.ObjectInfo{
+			BucketName:   "bucket",
+			ObjectName:   name,
+			ObjectStatus: storageTypes.OBJECT_STATUS_SEALED,
+			CreateAt:     now.Add(-age).Unix(),
+			Tags:         tags,
+		}
+	}
+
+	deleteRule := Rule{ID: "expire-logs", BucketName: "bucket", Prefix: "logs/", OlderThan: 24 * time.Hour, Action: ActionDelete}
+	require.NoError(t, deleteRule.Validate())
+	require.True(t, deleteRule.matches(newObject("logs/a", 48*time.Hour, nil), now))
+	require.False(t, deleteRule.matches(newObject("logs/b", time.Hour, nil), now))
+	require.False(t, deleteRule.matches(newObject("data/a", 48*time.Hour, nil), now))
+	created := newObject("logs/c", 48*time.Hour, nil)
+	created.ObjectStatus = storageTypes.OBJECT_STATUS_CREATED
+	require.False(t, deleteRule.matches(created, now))
+
+	tagRule := Rule{ID: "archive", BucketName: "bucket", OlderThan: time.Hour, Action: ActionSetTags, Tags: archiveTags, SkipTagged: true}
+	require.NoError(t, tagRule.Validate())
+	require.True(t, tagRule.matches(newObject("a", 2*time.Hour, nil), now))
+	require.False(t, tagRule.matches(newObject("b", 2*time.Hour, archiveTags), now))
+
+	require.Error(t, Rule{BucketName: "bucket", Action: ActionSetTags}.Validate())
+	require.Error(t, Rule{Action: ActionDelete}.Validate())
+	require.Error(t, Rule{BucketName: "bucket", Action: Action(9)}.Validate())
+}
+
+func TestRulesFromTags(t *testing.T) {
+	tags := &storageTypes.ResourceTags{Tags: []storageTypes.ResourceTags_Tag{
+		{Key: "team", Value: "data"},
+		{Key: ExpireTagPrefix + "tmp/", Value: "1h"},
+		{Key: ExpireTagPrefix + "logs/", Value: "720h0m0s"},
+	}}
+	rules, err := rulesFromTags("bucket", tags)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, "logs/", rules[0].Prefix)
+	require.Equal(t, 720*time.Hour, rules[0].OlderThan)
+	require.Equal(t, ActionDelete, rules[0].Action)
+	require.NoError(t, rules[0].Validate())
+	require.Equal(t, "tmp/", rules[1].Prefix)
+
+	rules, err = rulesFromTags("bucket", nil)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	_, err = rulesFromTags("bucket", &storageTypes.ResourceTags{Tags: []storageTypes.ResourceTags_Tag{{Key: ExpireTagPrefix, Value: "soon"}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,144 @@
+/*
+Package metrics provides the Prometheus implementation of types.Metrics, so that the operators can monitor the
+workloads driven by the SDK.
+
+The client is instrumented by setting the Prometheus metrics as client.Option.Metrics:
+
+	m, err := metrics.NewPrometheus("greenfield_sdk", prometheus.DefaultRegisterer)
+	cli, err := client.New(chainID, rpcAddr, client.Option{Metrics: m})
+*/
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// Prometheus exports the measurements of the client as the Prometheus metrics.
+type Prometheus struct {
+	spRequests       *prometheus.CounterVec
+	spRequestLatency *prometheus.HistogramVec
+	spErrors         *prometheus.CounterVec
+	transferredBytes *prometheus.CounterVec
+	txBroadcasts     *prometheus.CounterVec
+	txLatency        prometheus.Histogram
+	dedupQueries     *prometheus.CounterVec
+	circuitState     *prometheus.GaugeVec
+	circuitRejects   *prometheus.CounterVec
+}
+
+var (
+	_ types.Metrics               = (*Prometheus)(nil)
+	_ types.DedupMetrics          = (*Prometheus)(nil)
+	_ types.CircuitBreakerMetrics = (*Prometheus)(nil)
+)
+
+// NewPrometheus creates the Prometheus metrics with the namespace and registers them to the registerer, e.g.
+// prometheus.DefaultRegisterer.
+func NewPrometheus(namespace string, registerer prometheus.Registerer) (*Prometheus, error) {
+	m := &Prometheus{
+		spRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sp_requests_total",
+			Help:      "The number of the HTTP requests sent to SP by the method and the status code, 0 means no response.",
+		}, []string{"method", "status_code"}),
+		spRequestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sp_request_duration_seconds",
+			Help:      "The time until the response headers of the SP requests are received.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		spErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sp_errors_total",
+			Help:      "The number of the error responses of SP by the error code.",
+		}, []string{"code"}),
+		transferredBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sp_transferred_bytes_total",
+			Help:      "The number of the bytes sent to or read from SP.",
+		}, []string{"direction"}),
+		txBroadcasts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tx_broadcasts_total",
+			Help:      "The number of the broadcast transactions by the result and the codespace.",
+		}, []string{"result", "codespace"}),
+		txLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tx_broadcast_duration_seconds",
+			Help:      "The time of broadcasting the transactions.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		dedupQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dedup_queries_total",
+			Help:      "The number of the chain queries by the query and whether the result is shared with an identical query in flight.",
+		}, []string{"query", "shared"}),
+		circuitState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "sp_circuit_state",
+			Help:      "The state of the circuit breaker of the SP endpoint, it is 1 for the current state and 0 for the others.",
+		}, []string{"endpoint", "state"}),
+		circuitRejects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sp_circuit_rejections_total",
+			Help:      "The number of the SP requests failed fast by the open circuit breaker of the endpoint.",
+		}, []string{"endpoint"}),
+	}
+	for _, collector := range []prometheus.Collector{
+		m.spRequests, m.spRequestLatency, m.spErrors, m.transferredBytes, m.txBroadcasts, m.txLatency, m.dedupQueries, m.circuitState, m.circuitRejects,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveSPRequest counts the request and observes its latency.
+func (m *Prometheus) ObserveSPRequest(metric types.SPRequestMetric) {
+	m.spRequests.WithLabelValues(metric.Method, strconv.Itoa(metric.StatusCode)).Inc()
+	m.spRequestLatency.WithLabelValues(metric.Method).Observe(metric.Latency.Seconds())
+	if metric.ErrorCode != "" {
+		m.spErrors.WithLabelValues(metric.ErrorCode).Inc()
+	}
+}
+
+// AddTransferredBytes adds the bytes to the counter of the direction.
+func (m *Prometheus) AddTransferredBytes(direction types.TransferDirection, bytes int64) {
+	m.transferredBytes.WithLabelValues(string(direction)).Add(float64(bytes))
+}
+
+// ObserveTxBroadcast counts the transaction by its result and observes the latency.
+func (m *Prometheus) ObserveTxBroadcast(metric types.TxBroadcastMetric) {
+	result := "success"
+	if !metric.Success {
+		result = "failure"
+	}
+	m.txBroadcasts.WithLabelValues(result, metric.Codespace).Inc()
+	m.txLatency.Observe(metric.Latency.Seconds())
+}
+
+// ObserveDedup counts the query by whether its result is shared, the dedup hit rate is the ratio of the shared ones.
+func (m *Prometheus) ObserveDedup(query string, shared bool) {
+	m.dedupQueries.WithLabelValues(query, strconv.FormatBool(shared)).Inc()
+}
+
+// ObserveCircuitState sets the gauge of the current state of the endpoint to 1 and those of the other states to 0.
+func (m *Prometheus) ObserveCircuitState(endpoint string, state types.CircuitState) {
+	for _, s := range []types.CircuitState{types.CircuitClosed, types.CircuitOpen, types.CircuitHalfOpen} {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		m.circuitState.WithLabelValues(endpoint, string(s)).Set(value)
+	}
+}
+
+// ObserveCircuitRejection counts the request failed fast by the open circuit breaker.
+func (m *Prometheus) ObserveCircuitRejection(endpoint string) {
+	m.circuitRejects.WithLabelValues(endpoint).Inc()
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	require.NoError(t, counter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
+
+func TestPrometheus(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m, err := NewPrometheus("test", registry)
+	require.NoError(t, err)
+
+	m.ObserveSPRequest(types.SPRequestMetric{Method: "GET", StatusCode: 200, Latency: time.Millisecond})
+	m.ObserveSPRequest(types.SPRequestMetric{Method: "GET", StatusCode: 404, ErrorCode: "NoSuchObject", Latency: time.Millisecond})
+	m.AddTransferredBytes(types.TransferDownload, 10)
+	m.AddTransferredBytes(types.TransferDownload, 5)
+	m.ObserveTxBroadcast(types.TxBroadcastMetric{Success: false, Code: 5, Codespace: "sdk"})
+
+	require.Equal(t, 1.0, counterValue(t, m.spRequests.WithLabelValues("GET", "404")))
+	require.Equal(t, 1.0, counterValue(t, m.spErrors.WithLabelValues("NoSuchObject")))
+	require.Equal(t, 15.0, counterValue(t, m.transferredBytes.WithLabelValues("download")))
+	require.Equal(t, 1.0, counterValue(t, m.txBroadcasts.WithLabelValues("failure", "sdk")))
+
+	m.ObserveDedup("HeadObject", true)
+	m.ObserveCircuitState("sp0.example.com", types.CircuitOpen)
+	m.ObserveCircuitRejection("sp0.example.com")
+	require.Equal(t, 1.0, counterValue(t, m.dedupQueries.WithLabelValues("HeadObject", "true")))
+	require.Equal(t, 1.0, counterValue(t, m.circuitRejects.WithLabelValues("sp0.example.com")))
+	gauge := &dto.Metric{}
+	require.NoError(t, m.circuitState.WithLabelValues("sp0.example.com", string(types.CircuitOpen)).Write(gauge))
+	require.Equal(t, 1.0, gauge.GetGauge().GetValue())
+
+	_, err = NewPrometheus("test", registry)
+	require.Error(t, err)
+}
@@ -0,0 +1,673 @@
+// Package provision manages the buckets, the groups and the bucket policies as code: a declarative Spec is compared
+// with the resources on chain by Plan, which returns the changes as a diff, and the changes are executed by Apply.
+// Planning and applying again are idempotent, so a spec can be applied repeatedly, e.g. by a CI job.
+//
+//	p := provision.New(cli, provision.Options{})
+//	plan, err := p.Plan(ctx, spec)
+//	fmt.Print(plan)
+//	err = p.Apply(ctx, plan)
+package provision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gnfdsdktypes "github.com/bnb-chain/greenfield/sdk/types"
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// memberPageSize is the number of the group members listed per page
+const memberPageSize = 1000
+
+// Action indicates how a resource is changed.
+type Action string
+
+const (
+	ActionCreate Action = "create" // ActionCreate creates the resource.
+	ActionUpdate Action = "update" // ActionUpdate updates the resource in place.
+	ActionDelete Action = "delete" // ActionDelete deletes the resource.
+)
+
+// symbol returns the prefix of the change in the diff output.
+func (a Action) symbol() string {
+	switch a {
+	case ActionCreate:
+		return "+"
+	case ActionDelete:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Kind indicates the kind of a resource.
+type Kind string
+
+const (
+	KindBucket Kind = "bucket" // KindBucket is a bucket.
+	KindGroup  Kind = "group"  // KindGroup is a group.
+	KindPolicy Kind = "policy" // KindPolicy is the bucket policy of a principal.
+)
+
+// Change is a change of a resource in the plan.
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string   // Name identifies the resource, the policies are named by the bucket and the principal.
+	Diffs  []string // Diffs describe the changed fields, e.g. "visibility: VISIBILITY_TYPE_PRIVATE -> VISIBILITY_TYPE_PUBLIC_READ".
+
+	apply func(ctx context.Context) error
+}
+
+// String returns the change as a diff, e.g. "~ bucket logs" followed by the indented changed fields.
+func (c Change) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s\n", c.Action.symbol(), c.Kind, c.Name)
+	for _, diff := range c.Diffs {
+		fmt.Fprintf(&b, "    %s\n", diff)
+	}
+	return b.String()
+}
+
+// Plan contains the changes reconciling the resources on chain to a spec, in the order they are applied.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the resources on chain already match the spec.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the changes as a diff followed by a summary.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes, the resources match the spec.\n"
+	}
+	var b strings.Builder
+	counts := make(map[Action]int)
+	for _, change := range p.Changes {
+		b.WriteString(change.String())
+		counts[change.Action]++
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.\n", counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+	return b.String()
+}
+
+// ApplyError is returned when a change of the plan fails to apply, the changes before it have been applied.
+type ApplyError struct {
+	Change  Change // Change is the failed change.
+	Applied int    // Applied is the number of the changes applied before it.
+	Err     error  // Err is the error of the change.
+}
+
+// Error returns the error msg
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("fail to %s %s %s after %d changes applied: %s", e.Change.Action, e.Change.Kind, e.Change.Name, e.Applied, e.Err)
+}
+
+// Unwrap returns the error of the change.
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// Options contains the options of a Provisioner.
+type Options struct {
+	TxOpts *gnfdsdktypes.TxOption // TxOpts defines the options to customize the transactions.
+}
+
+// Provisioner plans and applies the specs by the default account of the client, which owns the resources.
+type Provisioner struct {
+	client client.IClient
+	opts   Options
+}
+
+// New - Create a Provisioner managing the resources owned by the default account of cli.
+func New(cli client.IClient, opts Options) *Provisioner {
+	return &Provisioner{client: cli, opts: opts}
+}
+
+// planner collects the changes of a spec
+type planner struct {
+	*Provisioner
+	spec  Spec
+	owner sdk.AccAddress
+	// missingBuckets and missingGroups are the declared resources which do not exist yet
+	missingBuckets map[string]bool
+	missingGroups  map[string]bool
+	changes        []Change
+}
+
+// Plan - Compare the spec with the resources on chain and return the changes to reconcile them. The groups are
+// changed first, then the buckets and the policies, and the pruned resources are deleted last.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spec: The declared resources.
+//
+// - ret1: The changes, the plan is empty if the resources match the spec.
+//
+// - ret2: Return error when the spec is invalid or the resources fail to be queried, otherwise return nil.
+func (p *Provisioner) Plan(ctx context.Context, spec Spec) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	account, err := p.client.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+	pl := &planner{
+		Provisioner:    p,
+		spec:           spec,
+		owner:          account.GetAddress(),
+		missingBuckets: make(map[string]bool),
+		missingGroups:  make(map[string]bool),
+	}
+	for _, group := range spec.Groups {
+		if err = pl.planGroup(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+	for _, bucket := range spec.Buckets {
+		if err = pl.planBucket(ctx, bucket); err != nil {
+			return nil, err
+		}
+	}
+	for _, policy := range spec.Policies {
+		if err = pl.planPolicy(ctx, policy); err != nil {
+			return nil, err
+		}
+	}
+	if spec.Prune {
+		if err = pl.planPrune(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &Plan{Changes: pl.changes}, nil
+}
+
+// Apply - Apply the changes of the plan in order, and wait for each transaction to be confirmed. The plan should be
+// applied soon after it is made, since the changes are based on the resources on chain when planning.
+//
+// - ctx: Context variables for the current API call.
+//
+// - plan: The plan returned by Plan.
+//
+// - ret: Return *ApplyError when a change fails, the changes before it are kept, and the spec can be planned and
+// applied again after the cause is fixed. Otherwise return nil.
+func (p *Provisioner) Apply(ctx context.Context, plan *Plan) error {
+	for i, change := range plan.Changes {
+		if err := change.apply(ctx); err != nil {
+			return &ApplyError{Change: change, Applied: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// wait waits for the transaction to be confirmed if it is sent.
+func (p *Provisioner) wait(ctx context.Context, txHash string, err error) error {
+	if err != nil {
+		return err
+	}
+	_, err = p.client.WaitForTx(ctx, txHash)
+	return err
+}
+
+func (pl *planner) planGroup(ctx context.Context, group GroupSpec) error {
+	owner := pl.owner.String()
+	groupInfo, err := pl.client.HeadGroup(ctx, group.Name, owner)
+	if err != nil {
+		if !isNotFound(err, storageTypes.ErrNoSuchGroup.Error()) {
+			return err
+		}
+		pl.missingGroups[group.Name] = true
+		tags := pl.desiredTags(group.Tags, nil)
+		change := Change{Action: ActionCreate, Kind: KindGroup, Name: group.Name, Diffs: diffTags(nil, tags)}
+		if len(group.Members) > 0 {
+			change.Diffs = append(change.Diffs, "members: + "+strings.Join(group.Members, ", "))
+		}
+		change.apply = func(ctx context.Context) error {
+			txHash, err := pl.client.CreateGroup(ctx, group.Name, types.CreateGroupOptions{TxOpts: pl.opts.TxOpts, Tags: creationTags(tags)})
+			if err = pl.wait(ctx, txHash, err); err != nil {
+				return err
+			}
+			if len(group.Members) == 0 {
+				return nil
+			}
+			txHash, err = pl.client.UpdateGroupMember(ctx, group.Name, owner, group.Members, nil, types.UpdateGroupMemberOption{TxOpts: pl.opts.TxOpts})
+			return pl.wait(ctx, txHash, err)
+		}
+		pl.changes = append(pl.changes, change)
+		return nil
+	}
+
+	members, err := pl.listGroupMembers(ctx, int64(groupInfo.Id.Uint64()))
+	if err != nil {
+		return err
+	}
+	added, removed := diffMembers(members, group.Members)
+	existingTags := tagsOf(groupInfo.Tags)
+	tags := pl.desiredTags(group.Tags, existingTags)
+	change := Change{Action: ActionUpdate, Kind: KindGroup, Name: group.Name, Diffs: diffTags(existingTags, tags)}
+	tagsChanged := len(change.Diffs) > 0
+	if len(added) > 0 {
+		change.Diffs = append(change.Diffs, "members: + "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		change.Diffs = append(change.Diffs, "members: - "+strings.Join(removed, ", "))
+	}
+	if len(change.Diffs) == 0 {
+		return nil
+	}
+	change.apply = func(ctx context.Context) error {
+		if tagsChanged {
+			grn := gnfdTypes.NewGroupGRN(pl.owner, group.Name).String()
+			txHash, err := pl.client.SetTag(ctx, grn, resourceTags(tags), types.SetTagsOptions{TxOpts: pl.opts.TxOpts})
+			if err = pl.wait(ctx, txHash, err); err != nil {
+				return err
+			}
+		}
+		if len(added) == 0 && len(removed) == 0 {
+			return nil
+		}
+		txHash, err := pl.client.UpdateGroupMember(ctx, group.Name, owner, added, removed, types.UpdateGroupMemberOption{TxOpts: pl.opts.TxOpts})
+		return pl.wait(ctx, txHash, err)
+	}
+	pl.changes = append(pl.changes, change)
+	return nil
+}
+
+func (pl *planner) listGroupMembers(ctx context.Context, groupID int64) ([]string, error) {
+	var (
+		members []string
+		token   types.PageToken
+	)
+	for {
+		result, err := pl.client.ListGroupMembers(ctx, groupID, types.GroupMembersPaginationOptions{Limit: memberPageSize, PageToken: token})
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range result.Groups {
+			if !member.Removed {
+				members = append(members, member.AccountID)
+			}
+		}
+		if result.NextPageToken == "" {
+			return members, nil
+		}
+		token = result.NextPageToken
+	}
+}
+
+func (pl *planner) planBucket(ctx context.Context, bucket BucketSpec) error {
+	bucketInfo, err := pl.client.HeadBucket(ctx, bucket.Name)
+	if err != nil {
+		if !isNotFound(err, storageTypes.ErrNoSuchBucket.Error()) {
+			return err
+		}
+		pl.missingBuckets[bucket.Name] = true
+		tags := pl.desiredTags(bucket.Tags, nil)
+		change := Change{Action: ActionCreate, Kind: KindBucket, Name: bucket.Name}
+		if bucket.Visibility != storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
+			change.Diffs = append(change.Diffs, "visibility: "+bucket.Visibility.String())
+		}
+		if bucket.ChargedQuota != nil {
+			change.Diffs = append(change.Diffs, fmt.Sprintf("charged_quota: %d", *bucket.ChargedQuota))
+		}
+		change.Diffs = append(change.Diffs, diffTags(nil, tags)...)
+		change.apply = func(ctx context.Context) error {
+			primarySPAddress := bucket.PrimarySPAddress
+			if primarySPAddress == "" {
+				sps, err := pl.client.ListStorageProviders(ctx, true)
+				if err != nil {
+					return err
+				}
+				if len(sps) == 0 {
+					return errors.New("no in-service SP to create the bucket")
+				}
+				primarySPAddress = sps[0].OperatorAddress
+			}
+			opts := types.CreateBucketOptions{Visibility: bucket.Visibility, TxOpts: pl.opts.TxOpts, Tags: creationTags(tags)}
+			if bucket.ChargedQuota != nil {
+				opts.ChargedQuota = *bucket.ChargedQuota
+			}
+			_, err := pl.client.CreateBucket(ctx, bucket.Name, primarySPAddress, opts)
+			return err
+		}
+		pl.changes = append(pl.changes, change)
+		return nil
+	}
+	if !strings.EqualFold(bucketInfo.Owner, pl.owner.String()) {
+		return fmt.Errorf("bucket %s is owned by %s rather than %s", bucket.Name, bucketInfo.Owner, pl.owner.String())
+	}
+
+	change := Change{Action: ActionUpdate, Kind: KindBucket, Name: bucket.Name}
+	updateOpts := types.UpdateBucketOptions{Visibility: bucketInfo.Visibility, TxOpts: pl.opts.TxOpts}
+	if bucket.Visibility != storageTypes.VISIBILITY_TYPE_UNSPECIFIED && bucket.Visibility != bucketInfo.Visibility {
+		change.Diffs = append(change.Diffs, fmt.Sprintf("visibility: %s -> %s", bucketInfo.Visibility, bucket.Visibility))
+		updateOpts.Visibility = bucket.Visibility
+	}
+	if bucket.ChargedQuota != nil && *bucket.ChargedQuota != bucketInfo.ChargedReadQuota {
+		change.Diffs = append(change.Diffs, fmt.Sprintf("charged_quota: %d -> %d", bucketInfo.ChargedReadQuota, *bucket.ChargedQuota))
+		updateOpts.ChargedQuota = bucket.ChargedQuota
+	}
+	infoChanged := len(change.Diffs) > 0
+	existingTags := tagsOf(bucketInfo.Tags)
+	tags := pl.desiredTags(bucket.Tags, existingTags)
+	tagDiffs := diffTags(existingTags, tags)
+	change.Diffs = append(change.Diffs, tagDiffs...)
+	if len(change.Diffs) == 0 {
+		return nil
+	}
+	change.apply = func(ctx context.Context) error {
+		if infoChanged {
+			txHash, err := pl.client.UpdateBucketInfo(ctx, bucket.Name, updateOpts)
+			if err = pl.wait(ctx, txHash, err); err != nil {
+				return err
+			}
+		}
+		if len(tagDiffs) == 0 {
+			return nil
+		}
+		grn := gnfdTypes.NewBucketGRN(bucket.Name).String()
+		txHash, err := pl.client.SetTag(ctx, grn, resourceTags(tags), types.SetTagsOptions{TxOpts: pl.opts.TxOpts})
+		return pl.wait(ctx, txHash, err)
+	}
+	pl.changes = append(pl.changes, change)
+	return nil
+}
+
+func (pl *planner) planPolicy(ctx context.Context, policy PolicySpec) error {
+	statement := utils.NewStatement(policy.Actions, policy.effect(), policy.Resources, types.NewStatementOptions{})
+	change := Change{Action: ActionCreate, Kind: KindPolicy, Name: policy.key()}
+	change.apply = func(ctx context.Context) error {
+		principal, err := pl.principal(ctx, policy)
+		if err != nil {
+			return err
+		}
+		txHash, err := pl.client.PutBucketPolicy(ctx, policy.BucketName, principal, []*permTypes.Statement{&statement},
+			types.PutPolicyOption{TxOpts: pl.opts.TxOpts})
+		return pl.wait(ctx, txHash, err)
+	}
+
+	var existing *permTypes.Policy
+	if !pl.missingBuckets[policy.BucketName] && !pl.missingGroups[policy.GroupName] {
+		var err error
+		if policy.GroupName != "" {
+			groupInfo, headErr := pl.client.HeadGroup(ctx, policy.GroupName, pl.owner.String())
+			if headErr != nil {
+				return headErr
+			}
+			existing, err = pl.client.GetBucketPolicyOfGroup(ctx, policy.BucketName, groupInfo.Id.Uint64())
+		} else {
+			existing, err = pl.client.GetBucketPolicy(ctx, policy.BucketName, policy.Account)
+		}
+		if err != nil && !isNotFound(err, storageTypes.ErrNoSuchPolicy.Error()) {
+			return err
+		}
+	}
+	if existing == nil {
+		change.Diffs = describeStatement(nil, &statement)
+		pl.changes = append(pl.changes, change)
+		return nil
+	}
+	var current *permTypes.Statement
+	if len(existing.Statements) == 1 {
+		current = existing.Statements[0]
+	}
+	if current != nil && sameStatement(current, &statement) {
+		return nil
+	}
+	change.Action = ActionUpdate
+	change.Diffs = describeStatement(current, &statement)
+	if current == nil {
+		change.Diffs = append([]string{fmt.Sprintf("statements: %d -> 1", len(existing.Statements))}, change.Diffs...)
+	}
+	pl.changes = append(pl.changes, change)
+	return nil
+}
+
+// principal returns the principal of the policy, the group is resolved when applying since it may be created by the
+// plan.
+func (pl *planner) principal(ctx context.Context, policy PolicySpec) (types.Principal, error) {
+	if policy.GroupName == "" {
+		account, err := sdk.AccAddressFromHexUnsafe(policy.Account)
+		if err != nil {
+			return "", err
+		}
+		return utils.NewPrincipalWithAccount(account)
+	}
+	groupInfo, err := pl.client.HeadGroup(ctx, policy.GroupName, pl.owner.String())
+	if err != nil {
+		return "", err
+	}
+	return utils.NewPrincipalWithGroupId(groupInfo.Id.Uint64())
+}
+
+// planPrune deletes the buckets and the groups tagged by the spec which are no longer declared.
+func (pl *planner) planPrune(ctx context.Context) error {
+	declaredBuckets := make(map[string]bool, len(pl.spec.Buckets))
+	for _, bucket := range pl.spec.Buckets {
+		declaredBuckets[bucket.Name] = true
+	}
+	buckets, err := pl.client.ListBuckets(ctx, types.ListBucketsOptions{Account: pl.owner.String()})
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets.Buckets {
+		if bucket.Removed || bucket.BucketInfo == nil || declaredBuckets[bucket.BucketInfo.BucketName] ||
+			tagsOf(bucket.BucketInfo.Tags)[ManagedByTagKey] != pl.spec.Name {
+			continue
+		}
+		bucketName := bucket.BucketInfo.BucketName
+		pl.changes = append(pl.changes, Change{
+			Action: ActionDelete,
+			Kind:   KindBucket,
+			Name:   bucketName,
+			apply: func(ctx context.Context) error {
+				txHash, err := pl.client.DeleteBucket(ctx, bucketName, types.DeleteBucketOption{TxOpts: pl.opts.TxOpts})
+				return pl.wait(ctx, txHash, err)
+			},
+		})
+	}
+
+	declaredGroups := make(map[string]bool, len(pl.spec.Groups))
+	for _, group := range pl.spec.Groups {
+		declaredGroups[group.Name] = true
+	}
+	var token types.PageToken
+	for {
+		groups, err := pl.client.ListGroupsByOwner(ctx, types.GroupsOwnerPaginationOptions{
+			Limit: memberPageSize, PageToken: token, Owner: pl.owner.String(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, group := range groups.Groups {
+			if group.Removed || group.Group == nil || declaredGroups[group.Group.GroupName] ||
+				tagsOf(group.Group.Tags)[ManagedByTagKey] != pl.spec.Name {
+				continue
+			}
+			groupName := group.Group.GroupName
+			pl.changes = append(pl.changes, Change{
+				Action: ActionDelete,
+				Kind:   KindGroup,
+				Name:   groupName,
+				apply: func(ctx context.Context) error {
+					txHash, err := pl.client.DeleteGroup(ctx, groupName, types.DeleteGroupOption{TxOpts: pl.opts.TxOpts})
+					return pl.wait(ctx, txHash, err)
+				},
+			})
+		}
+		if groups.NextPageToken == "" {
+			return nil
+		}
+		token = groups.NextPageToken
+	}
+}
+
+// desiredTags returns the tags of the declared resource, the existing ones are kept if the tags are not declared. The
+// resources declared by a named spec are tagged with ManagedByTagKey, so that they are pruned once removed from it.
+func (pl *planner) desiredTags(declared, existing map[string]string) map[string]string {
+	source := declared
+	if source == nil {
+		source = existing
+	}
+	tags := make(map[string]string, len(source)+1)
+	for key, value := range source {
+		tags[key] = value
+	}
+	if pl.spec.Name != "" {
+		tags[ManagedByTagKey] = pl.spec.Name
+	}
+	return tags
+}
+
+// isNotFound reports whether the query fails for the resource does not exist, notFound is the message of its error.
+func isNotFound(err error, notFound string) bool {
+	return strings.Contains(err.Error(), notFound)
+}
+
+func tagsOf(tags *storageTypes.ResourceTags) map[string]string {
+	result := make(map[string]string)
+	if tags == nil {
+		return result
+	}
+	for _, tag := range tags.Tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+// resourceTags returns the tags sorted by the keys, so that the same tags are always set in the same order.
+func resourceTags(tags map[string]string) storageTypes.ResourceTags {
+	resource := storageTypes.ResourceTags{}
+	for _, key := range sortedKeys(tags) {
+		resource.Tags = append(resource.Tags, storageTypes.ResourceTags_Tag{Key: key, Value: tags[key]})
+	}
+	return resource
+}
+
+// creationTags returns the tags set when creating the resource, it is nil if there is no tag.
+func creationTags(tags map[string]string) *storageTypes.ResourceTags {
+	if len(tags) == 0 {
+		return nil
+	}
+	resource := resourceTags(tags)
+	return &resource
+}
+
+// diffTags describes the changes from the existing tags to the desired ones.
+func diffTags(existing, desired map[string]string) []string {
+	var diffs []string
+	for _, key := range sortedKeys(desired) {
+		value, ok := existing[key]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("tags.%s: %q", key, desired[key]))
+		case value != desired[key]:
+			diffs = append(diffs, fmt.Sprintf("tags.%s: %q -> %q", key, value, desired[key]))
+		}
+	}
+	for _, key := range sortedKeys(existing) {
+		if _, ok := desired[key]; !ok {
+			diffs = append(diffs, fmt.Sprintf("tags.%s: %q -> (removed)", key, existing[key]))
+		}
+	}
+	return diffs
+}
+
+// diffMembers returns the declared members to add and the existing members to remove, the addresses are compared
+// case-insensitively.
+func diffMembers(existing, declared []string) (added, removed []string) {
+	existingSet := make(map[string]bool, len(existing))
+	for _, member := range existing {
+		existingSet[strings.ToLower(member)] = true
+	}
+	declaredSet := make(map[string]bool, len(declared))
+	for _, member := range declared {
+		declaredSet[strings.ToLower(member)] = true
+		if !existingSet[strings.ToLower(member)] {
+			added = append(added, member)
+		}
+	}
+	for _, member := range existing {
+		if !declaredSet[strings.ToLower(member)] {
+			removed = append(removed, member)
+		}
+	}
+	return added, removed
+}
+
+// sameStatement reports whether the statements have the same effect, actions and resources regardless of the order.
+func sameStatement(a, b *permTypes.Statement) bool {
+	return a.Effect == b.Effect && sameSet(actionNames(a.Actions), actionNames(b.Actions)) && sameSet(a.Resources, b.Resources)
+}
+
+// describeStatement describes the changes from the current statement to the desired one, current is nil if the
+// policy does not exist or has another number of statements.
+func describeStatement(current, desired *permTypes.Statement) []string {
+	if current == nil {
+		current = &permTypes.Statement{}
+	}
+	var diffs []string
+	if current.Effect != desired.Effect {
+		diffs = append(diffs, fmt.Sprintf("effect: %s -> %s", current.Effect, desired.Effect))
+	}
+	currentActions, desiredActions := actionNames(current.Actions), actionNames(desired.Actions)
+	if !sameSet(currentActions, desiredActions) {
+		diffs = append(diffs, fmt.Sprintf("actions: %v -> %v", sorted(currentActions), sorted(desiredActions)))
+	}
+	if !sameSet(current.Resources, desired.Resources) {
+		diffs = append(diffs, fmt.Sprintf("resources: %v -> %v", sorted(current.Resources), sorted(desired.Resources)))
+	}
+	return diffs
+}
+
+func actionNames(actions []permTypes.ActionType) []string {
+	names := make([]string, 0, len(actions))
+	for _, action := range actions {
+		names = append(names, action.String())
+	}
+	return names
+}
+
+func sameSet(a, b []string) bool {
+	setA := make(map[string]bool, len(a))
+	for _, value := range a {
+		setA[value] = true
+	}
+	setB := make(map[string]bool, len(b))
+	for _, value := range b {
+		if !setA[value] {
+			return false
+		}
+		setB[value] = true
+	}
+	return len(setA) == len(setB)
+}
+
+func sorted(values []string) []string {
+	result := append([]string(nil), values...)
+	sort.Strings(result)
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package provision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+)
+
+func TestSpecValidate(t *testing.T) {
+	policy := PolicySpec{BucketName: "bucket", GroupName: "team", Actions: []permTypes.ActionType{permTypes.ACTION_GET_OBJECT}}
+	spec := Spec{
+		Name:     "prod",
+		Buckets:  []BucketSpec{{Name: "bucket"}},
+		Groups:   []GroupSpec{{Name: "team"}},
+		Policies: []PolicySpec{policy},
+		Prune:    true,
+	}
+	require.NoError(t, spec.Validate())
+	require.Equal(t, permTypes.EFFECT_ALLOW, policy.effect())
+
+	require.Error(t, Spec{Prune: true}.Validate())
+	require.Error(t, Spec{Buckets: []BucketSpec{{Name: "bucket"}, {Name: "bucket"}}}.Validate())
+	require.Error(t, Spec{Groups: []GroupSpec{{Name: "team", Tags: map[string]string{ManagedByTagKey: "x"}}}}.Validate())
+	require.Error(t, Spec{Policies: []PolicySpec{{BucketName: "bucket", Actions: policy.Actions}}}.Validate())
+	require.Error(t, Spec{Policies: []PolicySpec{{BucketName: "bucket", GroupName: "team", Account: "0x01", Actions: policy.Actions}}}.Validate())
+	require.Error(t, Spec{Policies: []PolicySpec{{BucketName: "bucket", GroupName: "team"}}}.Validate())
+	require.Error(t, Spec{Policies: []PolicySpec{policy, policy}}.Validate())
+}
+
+func TestDiffs(t *testing.T) {
+	pl := &planner{spec: Spec{Name: "prod"}}
+	existing := map[string]string{"team": "data", "env": "dev"}
+	desired := pl.desiredTags(map[string]string{"team": "data", "env": "prod"}, existing)
+	require.Equal(t, []string{
+		`tags.env: "dev" -> "prod"`,
+		`tags.provision/managed-by: "prod"`,
+	}, diffTags(existing, desired))
+	// the existing tags are kept if the tags are not declared
+	require.Empty(t, diffTags(existing, (&planner{}).desiredTags(nil, existing)))
+	require.Equal(t, []string{`tags.env: "dev" -> (removed)`, `tags.team: "data" -> (removed)`},
+		diffTags(existing, (&planner{}).desiredTags(map[string]string{}, existing)))
+
+	added, removed := diffMembers([]string{"0xAB", "0xcd"}, []string{"0xab", "0xef"})
+	require.Equal(t, []string{"0xef"}, added)
+	require.Equal(t, []string{"0xcd"}, removed)
+
+	current := &permTypes.Statement{
+		Effect:  permTypes.EFFECT_ALLOW,
+		Actions: []permTypes.ActionType{permTypes.ACTION_GET_OBJECT, permTypes.ACTION_LIST_OBJECT},
+	}
+	desiredStatement := &permTypes.Statement{
+		Effect:  permTypes.EFFECT_ALLOW,
+		Actions: []permTypes.ActionType{permTypes.ACTION_LIST_OBJECT, permTypes.ACTION_GET_OBJECT},
+	}
+	require.True(t, sameStatement(current, desiredStatement))
+	desiredStatement.Actions = []permTypes.ActionType{permTypes.ACTION_GET_OBJECT}
+	require.False(t, sameStatement(current, desiredStatement))
+	require.Equal(t, []string{"actions: [ACTION_GET_OBJECT ACTION_LIST_OBJECT] -> [ACTION_GET_OBJECT]"}, describeStatement(current, desiredStatement))
+}
+
+func TestPlanString(t *testing.T) {
+	require.Equal(t, "No changes, the resources match the spec.\n", (&Plan{}).String())
+	plan := &Plan{Changes: []Change{
+		{Action: ActionCreate, Kind: KindGroup, Name: "team", Diffs: []string{"members: + 0x01"}},
+		{Action: ActionUpdate, Kind: KindBucket, Name: "bucket", Diffs: []string{"charged_quota: 0 -> 100"}},
+		{Action: ActionDelete, Kind: KindBucket, Name: "old"},
+	}}
+	require.Equal(t, "+ group team\n    members: + 0x01\n~ bucket bucket\n    charged_quota: 0 -> 100\n- bucket old\n"+
+		"Plan: 1 to create, 1 to update, 1 to delete.\n", plan.String())
+}
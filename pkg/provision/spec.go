@@ -0,0 +1,126 @@
+package provision
+
+import (
+	"errors"
+	"fmt"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// ManagedByTagKey is the tag set to the buckets and the groups created by a named Spec, its value is the name of the
+// spec. The resources tagged by a spec are deleted by its Prune once they are removed from the spec.
+const ManagedByTagKey = "provision/managed-by"
+
+// Spec declares the buckets, the groups and the policies owned by the default account of the client. The resources
+// on chain are reconciled to match it by the Provisioner, the resources not declared are left as they are unless
+// they are managed by the spec and Prune is set.
+type Spec struct {
+	// Name identifies the spec, the buckets and the groups created by it are tagged with ManagedByTagKey. It is
+	// required by Prune.
+	Name     string       `json:"name,omitempty"`
+	Buckets  []BucketSpec `json:"buckets,omitempty"`
+	Groups   []GroupSpec  `json:"groups,omitempty"`
+	Policies []PolicySpec `json:"policies,omitempty"`
+	// Prune deletes the buckets and the groups tagged by the spec which are no longer declared in it. The buckets
+	// should be emptied before they are pruned.
+	Prune bool `json:"prune,omitempty"`
+}
+
+// BucketSpec declares a bucket, the zero fields are not managed, e.g. the visibility of the bucket is kept as it is
+// if Visibility is VISIBILITY_TYPE_UNSPECIFIED.
+type BucketSpec struct {
+	Name             string                      `json:"name"`
+	PrimarySPAddress string                      `json:"primary_sp_address,omitempty"` // PrimarySPAddress is the operator address of the primary SP of the created bucket, the in-service SP is picked by the client if it is empty.
+	Visibility       storageTypes.VisibilityType `json:"visibility,omitempty"`
+	ChargedQuota     *uint64                     `json:"charged_quota,omitempty"` // ChargedQuota is the read quota charged in bytes per month.
+	Tags             map[string]string           `json:"tags,omitempty"`          // Tags are all the tags of the bucket except ManagedByTagKey if it is not nil.
+}
+
+// GroupSpec declares a group, the members not declared are removed from the group.
+type GroupSpec struct {
+	Name    string            `json:"name"`
+	Members []string          `json:"members,omitempty"` // Members are the HEX-encoded addresses of the members.
+	Tags    map[string]string `json:"tags,omitempty"`    // Tags are all the tags of the group except ManagedByTagKey if it is not nil.
+}
+
+// PolicySpec declares the bucket policy of a principal, which is either a group owned by the default account or an
+// account. The policy is replaced by a single statement of Effect, Actions and Resources.
+type PolicySpec struct {
+	BucketName string                 `json:"bucket_name"`
+	GroupName  string                 `json:"group_name,omitempty"`
+	Account    string                 `json:"account,omitempty"` // Account is the HEX-encoded address of the principal.
+	Effect     permTypes.Effect       `json:"effect,omitempty"`  // Effect is EFFECT_ALLOW if it is EFFECT_UNSPECIFIED.
+	Actions    []permTypes.ActionType `json:"actions"`
+	Resources  []string               `json:"resources,omitempty"` // Resources limit the statement to the objects matching the GRN patterns, it applies to the bucket if it is empty.
+}
+
+// principal returns the name of the principal in the plan.
+func (p PolicySpec) principal() string {
+	if p.GroupName != "" {
+		return "group:" + p.GroupName
+	}
+	return "account:" + p.Account
+}
+
+// key identifies the policy in the plan.
+func (p PolicySpec) key() string {
+	return p.BucketName + "/" + p.principal()
+}
+
+func (p PolicySpec) effect() permTypes.Effect {
+	if p.Effect == permTypes.EFFECT_UNSPECIFIED {
+		return permTypes.EFFECT_ALLOW
+	}
+	return p.Effect
+}
+
+// Validate checks the spec is complete and the resources are declared once.
+func (s Spec) Validate() error {
+	if s.Prune && s.Name == "" {
+		return errors.New("the name of the spec is required to prune")
+	}
+	buckets := make(map[string]bool, len(s.Buckets))
+	for _, bucket := range s.Buckets {
+		if bucket.Name == "" {
+			return errors.New("the bucket name should not be empty")
+		}
+		if buckets[bucket.Name] {
+			return fmt.Errorf("bucket %s is declared more than once", bucket.Name)
+		}
+		buckets[bucket.Name] = true
+		if _, ok := bucket.Tags[ManagedByTagKey]; ok {
+			return fmt.Errorf("the tag %s of bucket %s is reserved", ManagedByTagKey, bucket.Name)
+		}
+	}
+	groups := make(map[string]bool, len(s.Groups))
+	for _, group := range s.Groups {
+		if group.Name == "" {
+			return errors.New("the group name should not be empty")
+		}
+		if groups[group.Name] {
+			return fmt.Errorf("group %s is declared more than once", group.Name)
+		}
+		groups[group.Name] = true
+		if _, ok := group.Tags[ManagedByTagKey]; ok {
+			return fmt.Errorf("the tag %s of group %s is reserved", ManagedByTagKey, group.Name)
+		}
+	}
+	policies := make(map[string]bool, len(s.Policies))
+	for _, policy := range s.Policies {
+		if policy.BucketName == "" {
+			return errors.New("the bucket name of the policy should not be empty")
+		}
+		if (policy.GroupName == "") == (policy.Account == "") {
+			return fmt.Errorf("the policy of bucket %s should have either a group or an account as the principal", policy.BucketName)
+		}
+		if len(policy.Actions) == 0 {
+			return fmt.Errorf("the actions of policy %s should not be empty", policy.key())
+		}
+		if policies[policy.key()] {
+			return fmt.Errorf("policy %s is declared more than once", policy.key())
+		}
+		policies[policy.key()] = true
+	}
+	return nil
+}
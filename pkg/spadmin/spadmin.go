@@ -0,0 +1,164 @@
+// Package spadmin wraps the operational endpoints of SP for the SP operators, so that the maintenance, e.g. checking
+// the status of an SP, handing over the GVG migration tasks and recovering the lost pieces, can be scripted with the
+// SDK instead of curl.
+//
+// The requests are sent by the low-level sprpc client, so they are signed by the default account of the client. SP
+// authorizes the admin requests by the signer, the client should be created with the operator key or the approval key
+// of the SP accordingly.
+//
+//	cli, _ := client.New(chainID, rpcAddr, client.Option{DefaultAccount: operatorAccount})
+//	admin := spadmin.New(cli)
+//	status, err := admin.Status(ctx, spOperatorAddress)
+package spadmin
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/sprpc"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const (
+	// StatusPath is the path of the endpoint reporting the status of SP.
+	StatusPath = "status"
+	// MigrateGVGPath is the path of the admin endpoint receiving the GVG migration tasks.
+	MigrateGVGPath = "greenfield/admin/v1/notify-migrate-swap-out-task"
+	// RecoverPiecePath is the path of the admin endpoint serving the pieces for the recovery of the other SPs.
+	RecoverPiecePath = "greenfield/admin/v1/recovery-piece"
+
+	// maxPieceSize is the max size of a piece served by RecoverPiece, it is the max segment size of the replicated
+	// objects.
+	maxPieceSize = 16 * 1024 * 1024
+)
+
+// Client sends the admin requests to the SPs.
+type Client struct {
+	rpc *sprpc.Client
+}
+
+// New - Create an admin client sending the requests by sender, client.IClient implements sprpc.Sender.
+func New(sender sprpc.Sender) *Client {
+	return &Client{rpc: sprpc.New(sender)}
+}
+
+// Status is the status reported by SP, e.g. its version and the states of its services, the fields depend on the
+// version of SP.
+type Status map[string]string
+
+// statusXML collects the leaf elements of the status response
+type statusXML struct {
+	Elements []struct {
+		XMLName xml.Name
+		Value   string `xml:",chardata"`
+	} `xml:",any"`
+}
+
+// Status - Query the status of the SP.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddress: The HEX-encoded operator address of the SP.
+//
+// - ret1: The status fields of the SP keyed by their names.
+//
+// - ret2: Return error when the request fails, the error responses of SP are returned as *types.SPError, otherwise
+// return nil.
+func (c *Client) Status(ctx context.Context, spAddress string) (Status, error) {
+	var result statusXML
+	if _, err := c.rpc.DoXML(ctx, sprpc.NewRequest(http.MethodGet, StatusPath).SPAddress(spAddress), &result); err != nil {
+		return nil, err
+	}
+	status := make(Status, len(result.Elements))
+	for _, element := range result.Elements {
+		status[element.XMLName.Local] = element.Value
+	}
+	return status, nil
+}
+
+// MigrateGVGTask is a GVG migration task handed over to the destination SP, which pulls the pieces of the GVG from
+// the source SP.
+type MigrateGVGTask struct {
+	SrcGVGID        uint32 `json:"src_gvg_id"`       // SrcGVGID is the id of the global virtual group being migrated.
+	DstGVGID        uint32 `json:"dst_gvg_id"`       // DstGVGID is the id of the global virtual group migrated to.
+	BucketID        uint64 `json:"bucket_id"`        // BucketID is the id of the bucket being migrated, it is 0 for the SP exits.
+	RedundancyIndex int32  `json:"redundancy_index"` // RedundancyIndex is the redundancy index of the SP in the GVG, -1 for the primary SP.
+	SrcSPAddress    string `json:"src_sp_address"`   // SrcSPAddress is the HEX-encoded operator address of the source SP.
+}
+
+// MigrateGVG - Hand over a GVG migration task to the destination SP.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddress: The HEX-encoded operator address of the destination SP.
+//
+// - task: The migration task.
+//
+// - ret: Return error when the task is rejected, the error responses of SP are returned as *types.SPError, otherwise
+// return nil.
+func (c *Client) MigrateGVG(ctx context.Context, spAddress string, task MigrateGVGTask) error {
+	if task.SrcGVGID == 0 || task.DstGVGID == 0 {
+		return errors.New("the source and the destination GVG of the migration task should be set")
+	}
+	if task.SrcSPAddress == "" {
+		return errors.New("the source SP of the migration task should be set")
+	}
+	body, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	req := sprpc.NewRequest(http.MethodPost, MigrateGVGPath).SPAddress(spAddress).Body(body, "application/json")
+	_, err = c.rpc.DoXML(ctx, req, nil)
+	return err
+}
+
+// RecoverPiece - Get a piece of an object from an SP of its GVG, e.g. to restore the piece lost by another SP of the
+// GVG. The piece is not verified, the callers should check it against the piece hashes of the SP.
+//
+// - ctx: Context variables for the current API call.
+//
+// - spAddress: The HEX-encoded operator address of the SP serving the piece.
+//
+// - objectID: The id of the object.
+//
+// - segmentIndex: The index of the segment of the object.
+//
+// - redundancyIndex: The redundancy index of the piece, types.PrimaryRedundancyIndex for the segment stored by the
+// primary SP.
+//
+// - ret1: The piece data.
+//
+// - ret2: Return error when the request fails, the error responses of SP are returned as *types.SPError, otherwise
+// return nil.
+func (c *Client) RecoverPiece(ctx context.Context, spAddress, objectID string, segmentIndex, redundancyIndex int) ([]byte, error) {
+	if objectID == "" {
+		return nil, errors.New("the object id should be set")
+	}
+	if segmentIndex < 0 || redundancyIndex < types.PrimaryRedundancyIndex {
+		return nil, fmt.Errorf("the segment index %d or the redundancy index %d is invalid", segmentIndex, redundancyIndex)
+	}
+	req := sprpc.NewRequest(http.MethodGet, RecoverPiecePath).SPAddress(spAddress).
+		Header(types.HTTPHeaderObjectID, objectID).
+		Header(types.HTTPHeaderPieceIndex, strconv.Itoa(segmentIndex)).
+		Header(types.HTTPHeaderRedundancyIndex, strconv.Itoa(redundancyIndex))
+	resp, err := c.rpc.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseResponse(resp)
+	piece, err := io.ReadAll(io.LimitReader(resp.Body, maxPieceSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(piece) > maxPieceSize {
+		return nil, types.ErrResponseBodyTooLarge
+	}
+	return piece, nil
+}
@@ -0,0 +1,71 @@
+package spadmin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+type fakeSender struct {
+	sent     types.SPRequest
+	response string
+}
+
+func (s *fakeSender) NewSPRequest(_ context.Context, req types.SPRequest) (*http.Request, error) {
+	s.sent = req
+	return http.NewRequest(req.Method, "http://sp/"+req.Path, req.Body)
+}
+
+func (s *fakeSender) SendSPRequest(_ context.Context, req types.SPRequest) (*http.Response, error) {
+	s.sent = req
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(s.response))}, nil
+}
+
+func TestStatus(t *testing.T) {
+	sender := &fakeSender{response: "<StatusInfo><BinaryVersion>v1.8.0</BinaryVersion><GoVersion>go1.20</GoVersion></StatusInfo>"}
+	status, err := New(sender).Status(context.Background(), "0x1")
+	require.NoError(t, err)
+	require.Equal(t, Status{"BinaryVersion": "v1.8.0", "GoVersion": "go1.20"}, status)
+	require.Equal(t, StatusPath, sender.sent.Path)
+	require.Equal(t, "0x1", sender.sent.EndPoint.SPAddress)
+}
+
+func TestMigrateGVG(t *testing.T) {
+	sender := &fakeSender{}
+	admin := New(sender)
+	require.Error(t, admin.MigrateGVG(context.Background(), "0x2", MigrateGVGTask{SrcSPAddress: "0x1"}))
+
+	task := MigrateGVGTask{SrcGVGID: 1, DstGVGID: 2, BucketID: 3, RedundancyIndex: -1, SrcSPAddress: "0x1"}
+	require.NoError(t, admin.MigrateGVG(context.Background(), "0x2", task))
+	require.Equal(t, http.MethodPost, sender.sent.Method)
+	require.Equal(t, MigrateGVGPath, sender.sent.Path)
+	body, err := io.ReadAll(sender.sent.Body)
+	require.NoError(t, err)
+	var sent MigrateGVGTask
+	require.NoError(t, json.Unmarshal(body, &sent))
+	require.Equal(t, task, sent)
+}
+
+func TestRecoverPiece(t *testing.T) {
+	sender := &fakeSender{response: "piece"}
+	admin := New(sender)
+	_, err := admin.RecoverPiece(context.Background(), "0x1", "", 0, 0)
+	require.Error(t, err)
+	_, err = admin.RecoverPiece(context.Background(), "0x1", "1", 0, -2)
+	require.Error(t, err)
+
+	piece, err := admin.RecoverPiece(context.Background(), "0x1", "100", 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("piece"), piece)
+	require.Equal(t, RecoverPiecePath, sender.sent.Path)
+	require.Equal(t, "100", sender.sent.Header.Get(types.HTTPHeaderObjectID))
+	require.Equal(t, "2", sender.sent.Header.Get(types.HTTPHeaderPieceIndex))
+	require.Equal(t, "3", sender.sent.Header.Get(types.HTTPHeaderRedundancyIndex))
+}
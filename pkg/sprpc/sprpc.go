@@ -0,0 +1,156 @@
+// Package sprpc is the low-level client of the REST API of SP. It allows to call the SP endpoints which the SDK has
+// not wrapped yet without forking it, the requests are routed, signed, retried and their errors are parsed in the same
+// way as the wrapped APIs of client.Client.
+//
+//	rpc := sprpc.New(cli)
+//	var nonce struct {
+//		NextNonce int64 `xml:"NextNonce"`
+//	}
+//	_, err := rpc.DoXML(ctx, sprpc.NewRequest(http.MethodGet, "auth/request_nonce"), &nonce)
+package sprpc
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// Sender builds, signs and sends the requests to SP, client.IClient implements it.
+type Sender interface {
+	NewSPRequest(ctx context.Context, req types.SPRequest) (*http.Request, error)
+	SendSPRequest(ctx context.Context, req types.SPRequest) (*http.Response, error)
+}
+
+// Client sends the raw requests to SP and decodes the responses.
+type Client struct {
+	sender      Sender
+	maxBodySize int64
+}
+
+// New - Create a low-level SP client sending the requests by sender.
+func New(sender Sender) *Client {
+	return &Client{sender: sender, maxBodySize: types.MaxResponseBodySize}
+}
+
+// WithMaxBodySize returns a copy of the client which decodes the responses up to limit bytes,
+// types.MaxResponseBodySize is used by default.
+func (c *Client) WithMaxBodySize(limit int64) *Client {
+	copied := *c
+	copied.maxBodySize = limit
+	return &copied
+}
+
+// Request is the builder of types.SPRequest.
+type Request struct {
+	req types.SPRequest
+}
+
+// NewRequest - Create the request of method to the relative path of the url.
+func NewRequest(method, path string) *Request {
+	return &Request{req: types.SPRequest{Method: method, Path: path}}
+}
+
+// SPRequest returns the built request.
+func (r *Request) SPRequest() types.SPRequest {
+	return r.req
+}
+
+// Object sets the bucket and the object in the url, the object is omitted if it is empty. The request is routed to
+// the primary SP of the bucket unless the endpoint is set.
+func (r *Request) Object(bucketName, objectName string) *Request {
+	r.req.BucketName = bucketName
+	r.req.ObjectName = objectName
+	return r
+}
+
+// Endpoint sets the endpoint of the SP to send the request to.
+func (r *Request) Endpoint(endpoint string) *Request {
+	r.req.EndPoint = &types.EndPointOptions{Endpoint: endpoint}
+	return r
+}
+
+// SPAddress sets the operator address of the SP to send the request to.
+func (r *Request) SPAddress(address string) *Request {
+	r.req.EndPoint = &types.EndPointOptions{SPAddress: address}
+	return r
+}
+
+// Query adds the url value.
+func (r *Request) Query(key, value string) *Request {
+	if r.req.Query == nil {
+		r.req.Query = make(url.Values)
+	}
+	r.req.Query.Add(key, value)
+	return r
+}
+
+// Header sets the request header, it is signed together with the request.
+func (r *Request) Header(key, value string) *Request {
+	if r.req.Header == nil {
+		r.req.Header = make(http.Header)
+	}
+	r.req.Header.Set(key, value)
+	return r
+}
+
+// Body sets the request body and its size and sha256, the request is retried if the body implements io.Seeker.
+func (r *Request) Body(body []byte, contentType string) *Request {
+	r.req.Body = bytes.NewReader(body)
+	r.req.ContentLength = int64(len(body))
+	r.req.ContentType = contentType
+	r.req.ContentSHA256 = utils.CalcSHA256Hex(body)
+	return r
+}
+
+// Sign - Build and sign the http request without sending it.
+func (c *Client) Sign(ctx context.Context, req *Request) (*http.Request, error) {
+	return c.sender.NewSPRequest(ctx, req.req)
+}
+
+// Do - Send the request and return the response, the caller should close the response body. The error responses of
+// SP are returned as *types.SPError.
+func (c *Client) Do(ctx context.Context, req *Request) (*http.Response, error) {
+	return c.sender.SendSPRequest(ctx, req.req)
+}
+
+// DoXML - Send the request and decode the xml response into v, v is not decoded if it is nil.
+func (c *Client) DoXML(ctx context.Context, req *Request, v interface{}) (types.ResponseInfo, error) {
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return types.ResponseInfo{}, err
+	}
+	defer utils.CloseResponse(resp)
+	info := types.NewResponseInfo(resp)
+	if v == nil {
+		return info, nil
+	}
+	return info, types.DecodeXMLBody(resp.Body, v, c.maxBodySize)
+}
+
+// DoJSON - Send the request and decode the json response into v, v is not decoded if it is nil.
+func (c *Client) DoJSON(ctx context.Context, req *Request, v interface{}) (types.ResponseInfo, error) {
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return types.ResponseInfo{}, err
+	}
+	defer utils.CloseResponse(resp)
+	info := types.NewResponseInfo(resp)
+	if v == nil {
+		return info, nil
+	}
+	return info, types.DecodeJSONBody(resp.Body, v, c.maxBodySize)
+}
+
+// ErrorCode - Return the code and the http status code of the SP error response, ok is false if err is not an error
+// response of SP.
+func ErrorCode(err error) (code string, statusCode int, ok bool) {
+	spErr, ok := types.AsSPError(err)
+	if !ok {
+		return "", 0, false
+	}
+	return spErr.Code, spErr.HTTPStatus, true
+}
@@ -0,0 +1,68 @@
+package sprpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+type fakeSender struct {
+	sent     types.SPRequest
+	response *http.Response
+	err      error
+}
+
+func (s *fakeSender) NewSPRequest(_ context.Context, req types.SPRequest) (*http.Request, error) {
+	s.sent = req
+	return http.NewRequest(req.Method, "http://sp/"+req.Path, req.Body)
+}
+
+func (s *fakeSender) SendSPRequest(_ context.Context, req types.SPRequest) (*http.Response, error) {
+	s.sent = req
+	return s.response, s.err
+}
+
+func TestRequestBuilder(t *testing.T) {
+	req := NewRequest(http.MethodPut, "custom").Object("bucket", "object").SPAddress("0x1").
+		Query("a", "1").Query("a", "2").Header("X-Custom", "v").Body([]byte("content"), "text/plain").SPRequest()
+	require.Equal(t, "bucket", req.BucketName)
+	require.Equal(t, "object", req.ObjectName)
+	require.Equal(t, "0x1", req.EndPoint.SPAddress)
+	require.Equal(t, []string{"1", "2"}, req.Query["a"])
+	require.Equal(t, "v", req.Header.Get("X-Custom"))
+	require.Equal(t, int64(7), req.ContentLength)
+	require.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", req.ContentSHA256)
+	_, ok := req.Body.(io.Seeker)
+	require.True(t, ok)
+}
+
+func TestDoXML(t *testing.T) {
+	sender := &fakeSender{response: &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{types.HTTPHeaderRequestID: []string{"req-1"}},
+		Body:       io.NopCloser(strings.NewReader("<Result><Value>3</Value></Result>")),
+	}}
+	var result struct {
+		Value int `xml:"Value"`
+	}
+	info, err := New(sender).DoXML(context.Background(), NewRequest(http.MethodGet, "custom"), &result)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Value)
+	require.Equal(t, "req-1", info.RequestID)
+	require.Equal(t, "custom", sender.sent.Path)
+
+	sender = &fakeSender{err: types.ErrResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound}}
+	_, err = New(sender).DoXML(context.Background(), NewRequest(http.MethodGet, "custom"), &result)
+	code, status, ok := ErrorCode(err)
+	require.True(t, ok)
+	require.Equal(t, "NoSuchBucket", code)
+	require.Equal(t, http.StatusNotFound, status)
+	_, _, ok = ErrorCode(context.Canceled)
+	require.False(t, ok)
+}
@@ -0,0 +1,313 @@
+// Package staticsite publishes the public objects of a bucket as a static site, e.g. to host a website on Greenfield.
+// The objects are either exported into a local directory with the generated index pages to be served by any static
+// hosting, or listed in a manifest linking to the universal endpoint of SP.
+//
+//	publisher := staticsite.New(cli, staticsite.Options{Prefix: "site/"})
+//	manifest, err := publisher.Export(ctx, bucketName, "./public")
+package staticsite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// DefaultIndexFile is the name of the index pages by default.
+const DefaultIndexFile = "index.html"
+
+// Options defines the objects to publish and the layout of the site.
+type Options struct {
+	Prefix string // Prefix limits the site to the objects whose names begin with it, the paths in the site are relative to it.
+	// Filter selects the objects in addition to the prefix, all the public objects are published if it is nil.
+	Filter       func(objectInfo *storageTypes.ObjectInfo) bool
+	IndexFile    string // IndexFile indicates the name of the index pages, DefaultIndexFile is used if it is empty.
+	NoIndexPages bool   // NoIndexPages disables generating the index pages listing the directories without one.
+}
+
+// Entry is a file of the site.
+type Entry struct {
+	Path        string `json:"path"`                  // Path is the slash separated path of the file in the site.
+	ObjectName  string `json:"object_name,omitempty"` // ObjectName is empty for the generated index pages.
+	ContentType string `json:"content_type"`
+	Size        uint64 `json:"size"`
+	URL         string `json:"url,omitempty"` // URL is the universal endpoint url of the object.
+}
+
+// Manifest lists the files of the site.
+type Manifest struct {
+	BucketName string  `json:"bucket_name"`
+	Entries    []Entry `json:"entries"` // Entries are sorted by the paths.
+}
+
+// Publisher publishes the public objects of the buckets.
+type Publisher struct {
+	client client.IClient
+	opts   Options
+}
+
+// New - Create a publisher reading the objects by cli.
+func New(cli client.IClient, opts Options) *Publisher {
+	if opts.IndexFile == "" {
+		opts.IndexFile = DefaultIndexFile
+	}
+	return &Publisher{client: cli, opts: opts}
+}
+
+// Manifest - List the public objects of the bucket with their universal endpoint urls, no index page is generated.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - ret1: The manifest of the site.
+//
+// - ret2: Return error when the objects can not be listed, otherwise return nil.
+func (p *Publisher) Manifest(ctx context.Context, bucketName string) (*Manifest, error) {
+	manifest, err := p.listEntries(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range manifest.Entries {
+		entry := &manifest.Entries[i]
+		if entry.URL, err = p.client.GetObjectUniversalURL(ctx, bucketName, entry.ObjectName, types.UniversalURLOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Export - Download the public objects of the bucket into the local directory in the layout of the site, and generate
+// the index pages for the directories without one unless disabled.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The bucket name identifies the bucket.
+//
+// - outDir: The local directory of the site, it is created if it does not exist.
+//
+// - ret1: The manifest of the site including the generated index pages.
+//
+// - ret2: Return error when the objects can not be listed or downloaded, otherwise return nil.
+func (p *Publisher) Export(ctx context.Context, bucketName, outDir string) (*Manifest, error) {
+	manifest, err := p.listEntries(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range manifest.Entries {
+		if err = p.downloadEntry(ctx, bucketName, outDir, entry); err != nil {
+			return nil, fmt.Errorf("fail to export object %s: %w", entry.ObjectName, err)
+		}
+	}
+	if p.opts.NoIndexPages {
+		return manifest, nil
+	}
+
+	pages, err := indexPages(manifest.Entries, p.opts.IndexFile)
+	if err != nil {
+		return nil, err
+	}
+	for pagePath, content := range pages {
+		localPath, err := sitePath(outDir, pagePath)
+		if err != nil {
+			return nil, err
+		}
+		if err = os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(localPath, content, types.FilePermMode); err != nil {
+			return nil, err
+		}
+		manifest.Entries = append(manifest.Entries, Entry{Path: pagePath, ContentType: "text/html; charset=utf-8", Size: uint64(len(content))})
+	}
+	sort.Slice(manifest.Entries, func(i, j int) bool { return manifest.Entries[i].Path < manifest.Entries[j].Path })
+	return manifest, nil
+}
+
+// listEntries lists the public sealed objects selected by the options.
+func (p *Publisher) listEntries(ctx context.Context, bucketName string) (*Manifest, error) {
+	bucketInfo, err := p.client.HeadBucket(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	bucketPublic := bucketInfo.Visibility == storageTypes.VISIBILITY_TYPE_PUBLIC_READ
+
+	manifest := &Manifest{BucketName: bucketName, Entries: make([]Entry, 0)}
+	_, err = p.client.ListObjectsWithCursor(ctx, bucketName, types.ListObjectsWithCursorOptions{Prefix: p.opts.Prefix},
+		func(result types.ListObjectsResult) error {
+			for _, object := range result.Objects {
+				if object.Removed || object.ObjectInfo == nil {
+					continue
+				}
+				objectInfo := object.ObjectInfo
+				sitePath := strings.TrimPrefix(objectInfo.ObjectName, p.opts.Prefix)
+				if objectInfo.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED || sitePath == "" || strings.HasSuffix(sitePath, "/") ||
+					!isPublic(objectInfo.Visibility, bucketPublic) || (p.opts.Filter != nil && !p.opts.Filter(objectInfo)) {
+					continue
+				}
+				manifest.Entries = append(manifest.Entries, Entry{
+					Path:        sitePath,
+					ObjectName:  objectInfo.ObjectName,
+					ContentType: contentType(sitePath, objectInfo.ContentType),
+					Size:        objectInfo.PayloadSize,
+				})
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (p *Publisher) downloadEntry(ctx context.Context, bucketName, outDir string, entry Entry) error {
+	localPath, err := sitePath(outDir, entry.Path)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return err
+	}
+	body, _, err := p.client.GetObject(ctx, bucketName, entry.ObjectName, types.GetObjectOptions{})
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	file, err := os.OpenFile(localPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, types.FilePermMode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// isPublic reports whether the object can be read by anyone.
+func isPublic(visibility storageTypes.VisibilityType, bucketPublic bool) bool {
+	switch visibility {
+	case storageTypes.VISIBILITY_TYPE_PUBLIC_READ:
+		return true
+	case storageTypes.VISIBILITY_TYPE_INHERIT:
+		return bucketPublic
+	default:
+		return false
+	}
+}
+
+// contentType returns the content type of the object, it is inferred from the extension if the object is uploaded
+// without one.
+func contentType(sitePath, objectContentType string) string {
+	if objectContentType != "" && objectContentType != types.ContentDefault {
+		return objectContentType
+	}
+	if inferred := mime.TypeByExtension(path.Ext(sitePath)); inferred != "" {
+		return inferred
+	}
+	return types.ContentDefault
+}
+
+// sitePath locates the file of the site in the local directory, it rejects the paths escaping the directory.
+func sitePath(outDir, relPath string) (string, error) {
+	localPath := filepath.Join(outDir, filepath.FromSlash(relPath))
+	rel, err := filepath.Rel(outDir, localPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the site path %s escapes the directory %s", relPath, outDir)
+	}
+	return localPath, nil
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Index of /{{.Dir}}</title></head>
+<body>
+<h1>Index of /{{.Dir}}</h1>
+<ul>
+{{- range .Links}}
+<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+type indexLink struct {
+	Name string
+	Href string
+}
+
+// indexPages renders the index pages of the directories without an index file, the pages are keyed by their paths.
+func indexPages(entries []Entry, indexFile string) (map[string][]byte, error) {
+	// the children of each directory, the subdirectories end with "/"
+	children := map[string]map[string]bool{"": {}}
+	files := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		files[entry.Path] = true
+		child := entry.Path
+		for {
+			dir := path.Dir(strings.TrimSuffix(child, "/"))
+			if dir == "." {
+				dir = ""
+			}
+			if children[dir] == nil {
+				children[dir] = make(map[string]bool)
+			}
+			name := strings.TrimPrefix(child, dir)
+			name = strings.TrimPrefix(name, "/")
+			children[dir][name] = true
+			if dir == "" {
+				break
+			}
+			child = dir + "/"
+		}
+	}
+
+	pages := make(map[string][]byte)
+	for dir, names := range children {
+		pagePath := path.Join(dir, indexFile)
+		if files[pagePath] {
+			continue
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		links := make([]indexLink, 0, len(sorted))
+		for _, name := range sorted {
+			links = append(links, indexLink{Name: name, Href: "./" + escapeHref(name)})
+		}
+		var buf bytes.Buffer
+		if err := indexTemplate.Execute(&buf, struct {
+			Dir   string
+			Links []indexLink
+		}{Dir: dir, Links: links}); err != nil {
+			return nil, err
+		}
+		pages[pagePath] = buf.Bytes()
+	}
+	return pages, nil
+}
+
+// escapeHref escapes the name of the child as a relative url, the trailing slash of the subdirectories is kept.
+func escapeHref(name string) string {
+	trimmed := strings.TrimSuffix(name, "/")
+	escaped := (&url.URL{Path: trimmed}).EscapedPath()
+	if trimmed != name {
+		escaped += "/"
+	}
+	return escaped
+}
@@ -0,0 +1,45 @@
+package staticsite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+func TestIndexPages(t *testing.T) {
+	pages, err := indexPages([]Entry{
+		{Path: "index.html"},
+		{Path: "css/site.css"},
+		{Path: "docs/a b.html"},
+		{Path: "docs/guide/intro.html"},
+	}, DefaultIndexFile)
+	require.NoError(t, err)
+	require.Len(t, pages, 3)
+	require.NotContains(t, pages, "index.html")
+	require.Contains(t, string(pages["css/index.html"]), `<a href="./site.css">site.css</a>`)
+	docs := string(pages["docs/index.html"])
+	require.Contains(t, docs, `<a href="./a%20b.html">a b.html</a>`)
+	require.Contains(t, docs, `<a href="./guide/">guide/</a>`)
+	require.Contains(t, string(pages["docs/guide/index.html"]), "Index of /docs/guide")
+}
+
+func TestContentType(t *testing.T) {
+	require.Equal(t, "text/markdown", contentType("README.md", "text/markdown"))
+	require.Equal(t, "text/css; charset=utf-8", contentType("site.css", "application/octet-stream"))
+	require.Equal(t, "application/octet-stream", contentType("blob", ""))
+}
+
+func TestSelection(t *testing.T) {
+	require.True(t, isPublic(storageTypes.VISIBILITY_TYPE_PUBLIC_READ, false))
+	require.True(t, isPublic(storageTypes.VISIBILITY_TYPE_INHERIT, true))
+	require.False(t, isPublic(storageTypes.VISIBILITY_TYPE_INHERIT, false))
+	require.False(t, isPublic(storageTypes.VISIBILITY_TYPE_PRIVATE, true))
+
+	_, err := sitePath("/tmp/site", "../etc/passwd")
+	require.Error(t, err)
+	localPath, err := sitePath("/tmp/site", "docs/a.html")
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/site/docs/a.html", localPath)
+}
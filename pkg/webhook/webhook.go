@@ -0,0 +1,346 @@
+// Package webhook dispatches the storage events on chain to the webhooks of the off-chain systems, e.g. to index the
+// objects or to notify the users, without writing the indexer code. Each webhook receives the events matching its
+// filter as a json payload signed by HMAC-SHA256 with its secret, and the failed deliveries are retried.
+//
+//	dispatcher, err := webhook.New([]webhook.Endpoint{{
+//		URL:    "https://example.com/hooks/greenfield",
+//		Secret: []byte("secret"),
+//		Filter: webhook.Filter{BucketNames: []string{"photos"}},
+//	}}, webhook.Options{})
+//	err = dispatcher.Run(ctx, cli, types.NewFileEventOffsetStore(types.OSFileSystem{}, "webhook.offset"), types.WatchEventsOptions{})
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const (
+	// SignatureHeader is the header carrying the HEX-encoded HMAC-SHA256 signature of the payload, see Sign.
+	SignatureHeader = "X-Gnfd-Webhook-Signature"
+	// TimestampHeader is the header carrying the unix time in seconds when the payload is signed, the receivers can
+	// reject the stale payloads by it to prevent the replays.
+	TimestampHeader = "X-Gnfd-Webhook-Timestamp"
+)
+
+// DefaultEventTypes are the storage events dispatched by default, they are the proto names of the typed events
+// emitted by the storage module.
+var DefaultEventTypes = []string{
+	"greenfield.storage.EventCreateBucket",
+	"greenfield.storage.EventDeleteBucket",
+	"greenfield.storage.EventUpdateBucketInfo",
+	"greenfield.storage.EventCreateObject",
+	"greenfield.storage.EventSealObject",
+	"greenfield.storage.EventRejectSealObject",
+	"greenfield.storage.EventDeleteObject",
+	"greenfield.storage.EventUpdateObjectInfo",
+	"greenfield.storage.EventCreateGroup",
+	"greenfield.storage.EventDeleteGroup",
+	"greenfield.storage.EventUpdateGroupMember",
+	"greenfield.storage.EventLeaveGroup",
+}
+
+// Filter selects the events dispatched to a webhook, the empty fields match any event. An event is matched if it
+// matches all the non-empty fields.
+type Filter struct {
+	EventTypes  []string // EventTypes are the proto names of the matched events.
+	BucketNames []string // BucketNames match the events whose bucket_name attribute is any of them, e.g. the bucket and the object events.
+	Owners      []string // Owners match the events whose owner attribute is any of the HEX-encoded addresses, e.g. the bucket and the group events.
+}
+
+// Match reports whether the event is selected by the filter.
+func (f Filter) Match(event types.ChainEvent) bool {
+	if len(f.EventTypes) > 0 && !contains(f.EventTypes, event.Type) {
+		return false
+	}
+	if len(f.BucketNames) > 0 && !matchAttribute(event, "bucket_name", f.BucketNames, false) {
+		return false
+	}
+	if len(f.Owners) > 0 && !matchAttribute(event, "owner", f.Owners, true) {
+		return false
+	}
+	return true
+}
+
+// matchAttribute reports whether the json encoded string attribute of the event is any of the values.
+func matchAttribute(event types.ChainEvent, key string, values []string, ignoreCase bool) bool {
+	encoded, ok := event.Attributes[key]
+	if !ok {
+		return false
+	}
+	var value string
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		return false
+	}
+	for _, v := range values {
+		if v == value || (ignoreCase && strings.EqualFold(v, value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoint is a webhook receiving the events matching its filter.
+type Endpoint struct {
+	URL    string
+	Secret []byte // Secret is the key signing the payloads, the payloads are not signed if it is empty.
+	Filter Filter
+}
+
+// Payload is the json body posted to the webhooks, it contains the matched events of a block. The Height, TxIndex and
+// EventIndex of the events identify them, the receivers can deduplicate the events redelivered by them.
+type Payload struct {
+	Height int64              `json:"height"`
+	Events []types.ChainEvent `json:"events"`
+}
+
+// Options contains the options of the Dispatcher.
+type Options struct {
+	HTTPClient  *http.Client       // HTTPClient sends the requests, http.DefaultClient is used if it is nil.
+	RetryPolicy *types.RetryPolicy // RetryPolicy indicates how the failed deliveries are retried, types.DefaultRetryPolicy is used if it is nil.
+	Clock       types.Clock        // Clock provides the timestamps of the signatures, types.SystemClock is used if it is nil.
+}
+
+// Dispatcher posts the events to the webhooks of the endpoints, it implements types.EventSink so that the events are
+// exported with at-least-once delivery by the ExportEvents API of the client.
+type Dispatcher struct {
+	endpoints   []Endpoint
+	httpClient  *http.Client
+	retryPolicy *types.RetryPolicy
+	clock       types.Clock
+
+	mu sync.Mutex
+	// delivered are the heights of the last blocks delivered to the endpoints, the blocks redelivered after the other
+	// endpoints fail are skipped by the endpoints which have received them.
+	delivered []int64
+}
+
+// New - Create a dispatcher posting the events to the webhooks of the endpoints.
+//
+// - endpoints: The webhooks and the filters of the events they receive.
+//
+// - opts: The options to customize the delivery.
+//
+// - ret1: The dispatcher.
+//
+// - ret2: Return error when no endpoint is provided or an endpoint has no URL, otherwise return nil.
+func New(endpoints []Endpoint, opts Options) (*Dispatcher, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no webhook endpoint is provided")
+	}
+	for i, endpoint := range endpoints {
+		if endpoint.URL == "" {
+			return nil, fmt.Errorf("the url of webhook endpoint %d should not be empty", i)
+		}
+	}
+	d := &Dispatcher{
+		endpoints:   endpoints,
+		httpClient:  opts.HTTPClient,
+		retryPolicy: opts.RetryPolicy,
+		clock:       opts.Clock,
+		delivered:   make([]int64, len(endpoints)),
+	}
+	if d.httpClient == nil {
+		d.httpClient = http.DefaultClient
+	}
+	if d.retryPolicy == nil {
+		d.retryPolicy = types.DefaultRetryPolicy()
+	}
+	if d.clock == nil {
+		d.clock = types.SystemClock{}
+	}
+	return d, nil
+}
+
+// Run - Export the events of the new blocks to the webhooks until ctx is done or the delivery fails.
+//
+// The height of the last delivered block is saved to offsets, calling it again resumes from the block after it. The
+// event types matched by the filters of all the endpoints are watched, DefaultEventTypes are watched if any filter
+// matches all the types and opts.EventTypes is empty.
+//
+// - ctx: Context variables for the current API call, the dispatching stops when it is done.
+//
+// - cli: The client watching the events.
+//
+// - offsets: The store persisting the height of the last delivered block.
+//
+// - opts: The options to set the first block when no offset is stored and the types of the events to watch.
+//
+// - ret: Return error when the events can not be queried, delivered or the offset can not be saved, or ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, cli client.IEventClient, offsets types.EventOffsetStore, opts types.WatchEventsOptions) error {
+	if len(opts.EventTypes) == 0 {
+		opts.EventTypes = d.eventTypes()
+	}
+	return cli.ExportEvents(ctx, d, offsets, opts)
+}
+
+// eventTypes returns the event types matched by the filters of the endpoints.
+func (d *Dispatcher) eventTypes() []string {
+	eventTypes := make([]string, 0)
+	for _, endpoint := range d.endpoints {
+		if len(endpoint.Filter.EventTypes) == 0 {
+			return DefaultEventTypes
+		}
+		for _, eventType := range endpoint.Filter.EventTypes {
+			if !contains(eventTypes, eventType) {
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+	return eventTypes
+}
+
+// Publish posts the events of a block matched by the filters to the webhooks concurrently, the events are treated as
+// delivered only if all the webhooks respond with 2xx, after the failed ones are retried by the retry policy.
+func (d *Dispatcher) Publish(ctx context.Context, events []types.ChainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	height := events[0].Height
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(d.endpoints))
+	for i := range d.endpoints {
+		endpoint := d.endpoints[i]
+		matched := make([]types.ChainEvent, 0)
+		for _, event := range events {
+			if endpoint.Filter.Match(event) {
+				matched = append(matched, event)
+			}
+		}
+		if len(matched) == 0 || d.isDelivered(i, height) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := d.deliver(ctx, endpoint, Payload{Height: height, Events: matched}); err != nil {
+				errs[i] = fmt.Errorf("fail to deliver the events of block %d to webhook %s: %w", height, endpoint.URL, err)
+				return
+			}
+			d.setDelivered(i, height)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) isDelivered(endpoint int, height int64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.delivered[endpoint] >= height
+}
+
+func (d *Dispatcher) setDelivered(endpoint int, height int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delivered[endpoint] = height
+}
+
+// deliver posts the payload to the webhook, the transport errors and the retryable status codes are retried.
+func (d *Dispatcher) deliver(ctx context.Context, endpoint Endpoint, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		retryable, err := d.post(ctx, endpoint, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= d.retryPolicy.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		delay := d.retryPolicy.Delay(attempt)
+		log.Warn().Msg(fmt.Sprintf("retry webhook %s after %s, attempt: %d, err: %s", endpoint.URL, delay, attempt, err))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// post sends the signed body to the webhook once, it returns whether the failure is retryable.
+func (d *Dispatcher) post(ctx context.Context, endpoint Endpoint, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set(types.HTTPHeaderContentType, "application/json")
+	req.Header.Set(types.HTTPHeaderUserAgent, types.UserAgent)
+	if len(endpoint.Secret) > 0 {
+		timestamp := strconv.FormatInt(d.clock.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, body))
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, types.DecodeErrSnippetSize))
+		return d.retryPolicy.IsRetryableStatus(resp.StatusCode),
+			fmt.Errorf("webhook responds with status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return false, nil
+}
+
+// Sign returns the HEX-encoded HMAC-SHA256 of the timestamp, a dot and the body by the secret, which is carried by
+// SignatureHeader.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature of the payload received by a webhook is signed by the secret, the receivers
+// should check the timestamp is recent as well.
+//
+// - secret: The secret of the webhook endpoint.
+//
+// - timestamp: The value of TimestampHeader.
+//
+// - body: The request body.
+//
+// - signature: The value of SignatureHeader.
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const owner = "0x1111111111111111111111111111111111111111"
+
+func newEvent(height int64, eventType, bucketName string) types.ChainEvent {
+	return types.ChainEvent{
+		Height: height,
+		Type:   eventType,
+		Attributes: map[string]string{
+			"bucket_name": `"` + bucketName + `"`,
+			"owner":       `"` + owner + `"`,
+		},
+	}
+}
+
+type fixedClock struct{ now time.Time }
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func TestFilterMatch(t *testing.T) {
+	event := newEvent(10, "greenfield.storage.EventCreateObject", "photos")
+	require.True(t, Filter{}.Match(event))
+	require.True(t, Filter{BucketNames: []string{"docs", "photos"}}.Match(event))
+	require.False(t, Filter{BucketNames: []string{"docs"}}.Match(event))
+	// the addresses are matched case-insensitively
+	require.True(t, Filter{Owners: []string{"0x1111111111111111111111111111111111111111"}}.Match(event))
+	require.False(t, Filter{Owners: []string{"0x2222222222222222222222222222222222222222"}}.Match(event))
+	require.False(t, Filter{EventTypes: []string{"greenfield.storage.EventDeleteObject"}}.Match(event))
+	require.True(t, Filter{
+		EventTypes:  []string{"greenfield.storage.EventCreateObject"},
+		BucketNames: []string{"photos"},
+		Owners:      []string{owner},
+	}.Match(event))
+
+	// the events without the attribute are not matched by the filter on it
+	groupEvent := types.ChainEvent{Type: "greenfield.storage.EventCreateGroup", Attributes: map[string]string{"owner": `"` + owner + `"`}}
+	require.False(t, Filter{BucketNames: []string{"photos"}}.Match(groupEvent))
+	require.True(t, Filter{Owners: []string{owner}}.Match(groupEvent))
+}
+
+func TestSignAndVerify(t *testing.T) {
+	secret, body := []byte("secret"), []byte(`{"height":1}`)
+	signature := Sign(secret, "1700000000", body)
+	require.True(t, Verify(secret, "1700000000", body, signature))
+	require.False(t, Verify(secret, "1700000001", body, signature))
+	require.False(t, Verify([]byte("other"), "1700000000", body, signature))
+	require.False(t, Verify(secret, "1700000000", []byte(`{"height":2}`), signature))
+	require.False(t, Verify(secret, "1700000000", body, "not-hex"))
+}
+
+func TestDispatcherPublish(t *testing.T) {
+	secret := []byte("secret")
+	var photoPayloads []Payload
+	var failures int32 = 1
+	photos := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "1700000000", r.Header.Get(TimestampHeader))
+		require.True(t, Verify(secret, r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader)))
+		// the first delivery fails and is retried
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload Payload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		photoPayloads = append(photoPayloads, payload)
+	}))
+	defer photos.Close()
+	var docsStatus int32 = http.StatusOK
+	var docsDeliveries int32
+	docs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get(SignatureHeader))
+		atomic.AddInt32(&docsDeliveries, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&docsStatus)))
+	}))
+	defer docs.Close()
+
+	dispatcher, err := New([]Endpoint{
+		{URL: photos.URL, Secret: secret, Filter: Filter{BucketNames: []string{"photos"}}},
+		{URL: docs.URL, Filter: Filter{BucketNames: []string{"docs"}}},
+	}, Options{
+		RetryPolicy: &types.RetryPolicy{MaxAttempts: 2, Backoff: func(int) time.Duration { return 0 }},
+		Clock:       fixedClock{now: time.Unix(1700000000, 0)},
+	})
+	require.NoError(t, err)
+
+	events := []types.ChainEvent{
+		newEvent(10, "greenfield.storage.EventCreateObject", "photos"),
+		newEvent(10, "greenfield.storage.EventCreateObject", "docs"),
+		newEvent(10, "greenfield.storage.EventSealObject", "photos"),
+	}
+	require.NoError(t, dispatcher.Publish(context.Background(), events))
+	require.Len(t, photoPayloads, 1)
+	require.Equal(t, int64(10), photoPayloads[0].Height)
+	require.Equal(t, []types.ChainEvent{events[0], events[2]}, photoPayloads[0].Events)
+	require.Equal(t, int32(1), atomic.LoadInt32(&docsDeliveries))
+
+	// the non-retryable failure is not retried, and the block redelivered is skipped by the webhook received it
+	atomic.StoreInt32(&docsStatus, http.StatusBadRequest)
+	events = []types.ChainEvent{
+		newEvent(11, "greenfield.storage.EventDeleteObject", "photos"),
+		newEvent(11, "greenfield.storage.EventDeleteObject", "docs"),
+	}
+	require.Error(t, dispatcher.Publish(context.Background(), events))
+	require.Equal(t, int32(2), atomic.LoadInt32(&docsDeliveries))
+	require.Len(t, photoPayloads, 2)
+	atomic.StoreInt32(&docsStatus, http.StatusOK)
+	require.NoError(t, dispatcher.Publish(context.Background(), events))
+	require.Equal(t, int32(3), atomic.LoadInt32(&docsDeliveries))
+	require.Len(t, photoPayloads, 2)
+}
+
+func TestDispatcherEventTypes(t *testing.T) {
+	dispatcher, err := New([]Endpoint{
+		{URL: "http://a", Filter: Filter{EventTypes: []string{"greenfield.storage.EventCreateObject"}}},
+		{URL: "http://b", Filter: Filter{EventTypes: []string{"greenfield.storage.EventCreateObject", "greenfield.storage.EventDeleteObject"}}},
+	}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"greenfield.storage.EventCreateObject", "greenfield.storage.EventDeleteObject"}, dispatcher.eventTypes())
+
+	dispatcher, err = New([]Endpoint{{URL: "http://a"}}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultEventTypes, dispatcher.eventTypes())
+
+	_, err = New(nil, Options{})
+	require.Error(t, err)
+	_, err = New([]Endpoint{{}}, Options{})
+	require.Error(t, err)
+}
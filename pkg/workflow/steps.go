@@ -0,0 +1,93 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+const (
+	// creating is the state of a resource whose creation is sent but not confirmed yet
+	creating = "creating"
+	// created is the state of a resource created by the workflow
+	created = "created"
+	// existed is the state of a resource which existed before the workflow
+	existed = "existed"
+)
+
+// CreateBucketStep returns the step creating the bucket, the bucket which already exists is kept as it is. The
+// compensation deletes the bucket only if it is created by the workflow, and it should be empty then.
+func CreateBucketStep(cli client.IClient, bucketName, primaryAddr string, opts types.CreateBucketOptions) Step {
+	key := "bucket/" + bucketName
+	return Step{
+		Name: "create-bucket/" + bucketName,
+		Do: func(ctx context.Context, state *State) error {
+			_, err := cli.HeadBucket(ctx, bucketName)
+			return createOnce(state, key, err, storageTypes.ErrNoSuchBucket.Error(), func() error {
+				opts.IsAsyncMode = false
+				_, err := cli.CreateBucket(ctx, bucketName, primaryAddr, opts)
+				return err
+			})
+		},
+		Compensate: func(ctx context.Context, state *State) error {
+			if value, _ := state.Get(key); value != created {
+				return nil
+			}
+			_, err := cli.DeleteBucket(ctx, bucketName, types.DeleteBucketOption{})
+			return err
+		},
+	}
+}
+
+// CreateGroupStep returns the step creating the group owned by the default account of the client, the group which
+// already exists is kept as it is. The compensation deletes the group only if it is created by the workflow.
+func CreateGroupStep(cli client.IClient, groupName string, opts types.CreateGroupOptions) Step {
+	key := "group/" + groupName
+	return Step{
+		Name: "create-group/" + groupName,
+		Do: func(ctx context.Context, state *State) error {
+			owner := cli.MustGetDefaultAccount().GetAddress().String()
+			_, err := cli.HeadGroup(ctx, groupName, owner)
+			return createOnce(state, key, err, storageTypes.ErrNoSuchGroup.Error(), func() error {
+				txHash, err := cli.CreateGroup(ctx, groupName, opts)
+				if err != nil {
+					return err
+				}
+				_, err = cli.WaitForTx(ctx, txHash)
+				return err
+			})
+		},
+		Compensate: func(ctx context.Context, state *State) error {
+			if value, _ := state.Get(key); value != created {
+				return nil
+			}
+			_, err := cli.DeleteGroup(ctx, groupName, types.DeleteGroupOption{})
+			return err
+		},
+	}
+}
+
+// createOnce records whether the resource of key is created by the workflow. headErr is the error of querying the
+// resource and notFound is the message of its error when the resource does not exist. The intent to create is saved
+// before create, so that a resource found after a crash during create is still known to be created by the workflow.
+func createOnce(state *State, key string, headErr error, notFound string, create func() error) error {
+	if headErr == nil {
+		if value, _ := state.Get(key); value == creating || value == created {
+			return state.Set(key, created)
+		}
+		return state.Set(key, existed)
+	}
+	if !strings.Contains(headErr.Error(), notFound) {
+		return headErr
+	}
+	if err := state.Set(key, creating); err != nil {
+		return err
+	}
+	if err := create(); err != nil {
+		return err
+	}
+	return state.Set(key, created)
+}
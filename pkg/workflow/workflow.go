@@ -0,0 +1,281 @@
+// Package workflow runs the multi-step operations, e.g. creating a bucket, a group and the policies between them,
+// as a Workflow whose steps are undone by their compensations when a later step fails. The progress is recorded in a
+// CheckpointStore after each step, so that a workflow interrupted by a crash is resumed or rolled back by another
+// process, which makes the higher-level provisioning idempotent.
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// recordVersion is the version of the workflow record format
+const recordVersion = 1
+
+// Status indicates the state of a workflow.
+type Status string
+
+const (
+	StatusRunning        Status = "running"         // StatusRunning indicates the workflow is running or has been interrupted.
+	StatusCompleted      Status = "completed"       // StatusCompleted indicates all the steps are done.
+	StatusFailed         Status = "failed"          // StatusFailed indicates a step failed and the done steps are kept for a resume or a rollback.
+	StatusRolledBack     Status = "rolled_back"     // StatusRolledBack indicates the done steps are compensated.
+	StatusRollbackFailed Status = "rollback_failed" // StatusRollbackFailed indicates a compensation failed, the rollback can be retried.
+)
+
+// Step is a step of a workflow.
+type Step struct {
+	// Name identifies the step in the record, it should be unique in the workflow and stable across the versions of
+	// the program which may resume the workflow.
+	Name string
+	// Do executes the step. It is executed again when the workflow is resumed after being interrupted during the
+	// step, so it should be idempotent, e.g. skip the creation of a resource which already exists.
+	Do func(ctx context.Context, state *State) error
+	// Compensate undoes the step when a later step fails or the workflow is rolled back, it is optional. It may be
+	// called in another process after a crash, so it should rely on the state rather than the variables of Do.
+	Compensate func(ctx context.Context, state *State) error
+}
+
+// Record is the progress of a workflow saved in the CheckpointStore.
+type Record struct {
+	Version int               `json:"version"`
+	ID      string            `json:"id"`
+	Status  Status            `json:"status"`
+	Done    []string          `json:"done"`            // Done contains the names of the done steps in order, the compensated steps are removed.
+	Values  map[string]string `json:"values"`          // Values contains the state set by the steps.
+	Error   string            `json:"error,omitempty"` // Error is the error of the failed step or compensation.
+}
+
+// State is the key-value state shared by the steps of a workflow, e.g. the ids of the created resources which are
+// needed by the compensations. The values are saved with the record once they are set.
+type State struct {
+	mu       sync.Mutex
+	workflow *Workflow
+}
+
+// Get returns the value of key, false is returned if it is not set.
+func (s *State) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.workflow.record.Values[key]
+	return value, ok
+}
+
+// Set sets the value of key and saves the record, so that the value survives a crash in the middle of the step.
+func (s *State) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workflow.record.Values[key] = value
+	return s.workflow.save()
+}
+
+// Options contains the options of a Workflow.
+type Options struct {
+	// Store persists the record of the workflow, the record is kept in memory if it is nil so the workflow can not be
+	// resumed by another process.
+	Store types.CheckpointStore
+	// ManualRollback keeps the done steps when a step fails instead of compensating them, so that the workflow can
+	// be resumed after the cause is fixed or rolled back by Rollback.
+	ManualRollback bool
+}
+
+// StepError is returned when a step of the workflow fails.
+type StepError struct {
+	Step        string // Step is the name of the failed step.
+	Err         error  // Err is the error of the step.
+	RollbackErr error  // RollbackErr is the error of the compensations after the failure, it is nil if they succeed or are not run.
+}
+
+// Error returns the error msg
+func (e *StepError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("workflow step %s failed: %s, and the rollback failed: %s", e.Step, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("workflow step %s failed: %s", e.Step, e.Err)
+}
+
+// Unwrap returns the error of the step.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Workflow executes the steps in order and compensates the done steps in the reverse order on failures.
+type Workflow struct {
+	id    string
+	steps []Step
+	opts  Options
+
+	mu     sync.Mutex
+	record *Record
+	state  *State
+}
+
+// New - Create a Workflow identified by id, the progress recorded by an earlier run of the same id is loaded from
+// opts.Store, so that Run resumes it.
+func New(id string, steps []Step, opts Options) (*Workflow, error) {
+	if id == "" {
+		return nil, errors.New("the id of workflow should not be empty")
+	}
+	names := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		if step.Name == "" || step.Do == nil {
+			return nil, errors.New("the name and the action of workflow step should not be empty")
+		}
+		if names[step.Name] {
+			return nil, fmt.Errorf("duplicate workflow step %s", step.Name)
+		}
+		names[step.Name] = true
+	}
+	w := &Workflow{id: id, steps: steps, opts: opts}
+	w.state = &State{workflow: w}
+	record, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.record = record
+	return w, nil
+}
+
+// Key returns the key of the workflow record in the CheckpointStore.
+func Key(id string) string {
+	return "workflow/" + id
+}
+
+// Record returns a copy of the progress of the workflow, it waits for the running Run or Rollback.
+func (w *Workflow) Record() Record {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	record := *w.record
+	record.Done = append([]string(nil), w.record.Done...)
+	record.Values = make(map[string]string, len(w.record.Values))
+	for key, value := range w.record.Values {
+		record.Values[key] = value
+	}
+	return record
+}
+
+// Run executes the steps which are not done yet, it returns nil at once if the workflow is completed. If a step
+// fails, the done steps are compensated in the reverse order unless ManualRollback is set, and a *StepError is
+// returned. A rolled back workflow runs from the first step again.
+func (w *Workflow) Run(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch w.record.Status {
+	case StatusCompleted:
+		return nil
+	case StatusRollbackFailed:
+		return fmt.Errorf("the rollback of workflow %s failed, it should be rolled back before running again: %s", w.id, w.record.Error)
+	case StatusRolledBack:
+		w.record.Done, w.record.Values = nil, make(map[string]string)
+	}
+	w.record.Status, w.record.Error = StatusRunning, ""
+	if err := w.save(); err != nil {
+		return err
+	}
+
+	for _, step := range w.steps[len(w.record.Done):] {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := step.Do(ctx, w.state); err != nil {
+			stepErr := &StepError{Step: step.Name, Err: err}
+			w.record.Status, w.record.Error = StatusFailed, stepErr.Error()
+			if !w.opts.ManualRollback {
+				stepErr.RollbackErr = w.rollback(ctx)
+			}
+			if saveErr := w.save(); saveErr != nil && stepErr.RollbackErr == nil {
+				stepErr.RollbackErr = saveErr
+			}
+			return stepErr
+		}
+		w.record.Done = append(w.record.Done, step.Name)
+		if err := w.save(); err != nil {
+			return err
+		}
+	}
+	w.record.Status = StatusCompleted
+	return w.save()
+}
+
+// Rollback compensates the done steps in the reverse order, e.g. after the workflow failed with ManualRollback or
+// was interrupted by a crash. The compensated steps are removed from the record one by one, so a failed rollback
+// is retried from the step whose compensation failed.
+func (w *Workflow) Rollback(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.rollback(ctx)
+	if saveErr := w.save(); err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+// rollback compensates the done steps, the record is saved after each compensation
+func (w *Workflow) rollback(ctx context.Context) error {
+	for len(w.record.Done) > 0 {
+		last := len(w.record.Done) - 1
+		step := w.steps[last]
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, w.state); err != nil {
+				w.record.Status = StatusRollbackFailed
+				w.record.Error = fmt.Sprintf("compensate workflow step %s: %s", step.Name, err)
+				return fmt.Errorf("compensate workflow step %s: %w", step.Name, err)
+			}
+		}
+		w.record.Done = w.record.Done[:last]
+		if err := w.save(); err != nil {
+			return err
+		}
+	}
+	w.record.Status, w.record.Error = StatusRolledBack, ""
+	return nil
+}
+
+// load returns the record saved in the store, a new record is returned if there is none
+func (w *Workflow) load() (*Record, error) {
+	record := &Record{Version: recordVersion, ID: w.id, Status: StatusRunning, Values: make(map[string]string)}
+	if w.opts.Store == nil {
+		return record, nil
+	}
+	content, err := w.opts.Store.Load(Key(w.id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return record, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, record); err != nil {
+		return nil, fmt.Errorf("invalid record of workflow %s: %w", w.id, err)
+	}
+	if record.Version != recordVersion || record.ID != w.id {
+		return nil, fmt.Errorf("the record of workflow %s has version %d and id %s", w.id, record.Version, record.ID)
+	}
+	if record.Values == nil {
+		record.Values = make(map[string]string)
+	}
+	// the steps are done in order, so the done steps should be a prefix of the steps
+	for i, name := range record.Done {
+		if i >= len(w.steps) || w.steps[i].Name != name {
+			return nil, fmt.Errorf("the done step %s of workflow %s does not match the steps", name, w.id)
+		}
+	}
+	return record, nil
+}
+
+// save persists the record if the workflow has a store
+func (w *Workflow) save() error {
+	if w.opts.Store == nil {
+		return nil
+	}
+	content, err := json.Marshal(w.record)
+	if err != nil {
+		return err
+	}
+	return w.opts.Store.Save(Key(w.id), content)
+}
@@ -0,0 +1,114 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string][]byte
+}
+
+func (s *memoryStore) Load(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.records[key]
+	if !ok {
+		return nil, fmt.Errorf("load %s: %w", key, fs.ErrNotExist)
+	}
+	return content, nil
+}
+
+func (s *memoryStore) Save(key string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = content
+	return nil
+}
+
+func (s *memoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryStore) List() ([]string, error) {
+	return nil, nil
+}
+
+func TestWorkflow(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{records: make(map[string][]byte)}
+	var log []string
+	failPolicy := true
+	steps := func() []Step {
+		step := func(name string, fail *bool) Step {
+			return Step{
+				Name: name,
+				Do: func(ctx context.Context, state *State) error {
+					if fail != nil && *fail {
+						return errors.New("boom")
+					}
+					log = append(log, "do "+name)
+					return state.Set(name, "done")
+				},
+				Compensate: func(ctx context.Context, state *State) error {
+					value, _ := state.Get(name)
+					log = append(log, "undo "+name+" "+value)
+					return nil
+				},
+			}
+		}
+		return []Step{step("bucket", nil), step("group", nil), step("policy", &failPolicy)}
+	}
+
+	// the failure is compensated in the reverse order
+	w, err := New("provision", steps(), Options{Store: store})
+	require.NoError(t, err)
+	err = w.Run(ctx)
+	var stepErr *StepError
+	require.ErrorAs(t, err, &stepErr)
+	require.Equal(t, "policy", stepErr.Step)
+	require.NoError(t, stepErr.RollbackErr)
+	require.Equal(t, []string{"do bucket", "do group", "undo group done", "undo bucket done"}, log)
+	require.Equal(t, StatusRolledBack, w.Record().Status)
+
+	// the progress is kept with ManualRollback and resumed by another workflow of the same id
+	log = nil
+	w, err = New("provision", steps(), Options{Store: store, ManualRollback: true})
+	require.NoError(t, err)
+	require.Error(t, w.Run(ctx))
+	require.Equal(t, StatusFailed, w.Record().Status)
+	require.Equal(t, []string{"bucket", "group"}, w.Record().Done)
+
+	failPolicy = false
+	log = nil
+	w, err = New("provision", steps(), Options{Store: store})
+	require.NoError(t, err)
+	require.NoError(t, w.Run(ctx))
+	require.Equal(t, []string{"do policy"}, log)
+	require.Equal(t, StatusCompleted, w.Record().Status)
+	require.NoError(t, w.Run(ctx))
+	require.Equal(t, []string{"do policy"}, log)
+
+	// the completed workflow is rolled back explicitly
+	log = nil
+	require.NoError(t, w.Rollback(ctx))
+	require.Equal(t, []string{"undo policy done", "undo group done", "undo bucket done"}, log)
+
+	_, err = New("provision", []Step{{Name: "other", Do: steps()[0].Do}}, Options{Store: store})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(Key("provision"), []byte(`{"version":1,"id":"provision","done":["other"]}`)))
+	_, err = New("provision", steps(), Options{Store: store})
+	require.Error(t, err)
+	_, err = New("provision", []Step{{Name: "a", Do: steps()[0].Do}, {Name: "a", Do: steps()[0].Do}}, Options{})
+	require.Error(t, err)
+}
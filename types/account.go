@@ -10,6 +10,7 @@ import (
 	"github.com/bnb-chain/greenfield/sdk/keys"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 )
 
 // Account indicates the user's identity information used for interaction with Greenfield.
@@ -24,6 +25,21 @@ type TransferDetail struct {
 	Amount    math.Int
 }
 
+// PendingTx is an unconfirmed tx of an account in the mempool of the connected node.
+type PendingTx struct {
+	Hash     string
+	Sequence uint64 // Sequence is the sequence the account signed the tx with.
+	Tx       *tx.Tx
+}
+
+// AccountSequence compares the sequence of an account committed on chain with the sequence implied by its pending txs.
+type AccountSequence struct {
+	ChainSequence   uint64       // ChainSequence is the sequence expected by the chain for the next tx of the account.
+	MempoolSequence uint64       // MempoolSequence is the sequence following the contiguous pending txs, the next tx should be signed with it.
+	PendingTxs      []*PendingTx // PendingTxs are the unconfirmed txs of the account, sorted by sequence.
+	Stuck           bool         // Stuck indicates some pending txs can not be executed because of a gap before their sequences.
+}
+
 // NewAccountFromPrivateKey - Create account instance according to private key.
 //
 // -name: Account name.
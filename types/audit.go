@@ -0,0 +1,57 @@
+package types
+
+// AuditMode is the way the sampled objects are verified by AuditBucket.
+type AuditMode string
+
+const (
+	// AuditFullRead downloads the whole objects from the primary SP and verifies the checksums computed from the
+	// contents against the chain.
+	AuditFullRead AuditMode = "full_read"
+	// AuditChallenge downloads a random piece of the objects from each SP by the challenge API and verifies the piece
+	// hashes against the chain, the client should be constructed with an account authorized to challenge.
+	AuditChallenge AuditMode = "challenge"
+)
+
+// AuditFailureKind is the kind of the failure found when auditing an object.
+type AuditFailureKind string
+
+const (
+	// AuditUnavailable indicates the SP fails to serve the object or the piece.
+	AuditUnavailable AuditFailureKind = "unavailable"
+	// AuditCorrupted indicates the data served by the SP does not match the checksums on chain.
+	AuditCorrupted AuditFailureKind = "corrupted"
+)
+
+// AuditFailure is a failure of an SP found when auditing an object.
+type AuditFailure struct {
+	ObjectName      string           `json:"object_name"`
+	SPID            uint32           `json:"sp_id"`
+	SPAddress       string           `json:"sp_address,omitempty"`
+	RedundancyIndex int              `json:"redundancy_index"` // RedundancyIndex is the index of the replica, PrimaryRedundancyIndex stands for the primary SP.
+	Kind            AuditFailureKind `json:"kind"`
+	Detail          string           `json:"detail,omitempty"`
+}
+
+// AuditSPStats summarizes the checks of an SP in the audit.
+type AuditSPStats struct {
+	SPID      uint32 `json:"sp_id"`
+	SPAddress string `json:"sp_address,omitempty"`
+	Checked   int    `json:"checked"` // Checked is the number of the objects or pieces checked against the SP.
+	Failed    int    `json:"failed"`  // Failed is the number of the checks failed.
+}
+
+// AuditReport is the machine-readable result of auditing the integrity of the objects in a bucket.
+type AuditReport struct {
+	BucketName string         `json:"bucket_name"`
+	Mode       AuditMode      `json:"mode"`
+	Objects    int            `json:"objects"` // Objects is the number of the sealed objects to sample from.
+	Sampled    int            `json:"sampled"` // Sampled is the number of the audited objects.
+	Passed     int            `json:"passed"`  // Passed is the number of the audited objects without any failure.
+	Failures   []AuditFailure `json:"failures"`
+	SPs        []AuditSPStats `json:"sps"` // SPs attributes the checks to the SPs, sorted by the SP ids.
+}
+
+// Healthy reports whether no failure is found.
+func (r *AuditReport) Healthy() bool {
+	return len(r.Failures) == 0
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"net/http"
+	"time"
+)
+
+// Capability identifies an API feature which the SPs of different versions may or may not support.
+type Capability string
+
+const (
+	CapabilityResumableUpload Capability = "resumable_upload"  // CapabilityResumableUpload is the resumable upload of the objects by parts.
+	CapabilityDelegatedUpload Capability = "delegated_upload"  // CapabilityDelegatedUpload is the upload which SP creates or updates the objects on behalf of the uploader.
+	CapabilityOffChainAuthV1  Capability = "off_chain_auth_v1" // CapabilityOffChainAuthV1 is the off-chain-auth signing the requests by the EdDSA keys registered with the nonces.
+	CapabilityOffChainAuthV2  Capability = "off_chain_auth_v2" // CapabilityOffChainAuthV2 is the off-chain-auth signing the requests by the ed25519 keys.
+	CapabilityBundle          Capability = "bundle"            // CapabilityBundle is the upload of many small objects in a bundle.
+)
+
+// SDKCapabilities are the capabilities which this SDK implements, the SPs are probed for them by Capabilities.
+var SDKCapabilities = []Capability{
+	CapabilityResumableUpload,
+	CapabilityDelegatedUpload,
+	CapabilityOffChainAuthV1,
+	CapabilityOffChainAuthV2,
+}
+
+// KnownCapabilities are all the capabilities reported by Capabilities, including the ones the SDK does not implement.
+var KnownCapabilities = append(append([]Capability{}, SDKCapabilities...), CapabilityBundle)
+
+// CapabilityStatus indicates whether an SP supports a capability.
+type CapabilityStatus int
+
+const (
+	CapabilityUnknown     CapabilityStatus = iota // CapabilityUnknown indicates the support can not be determined, e.g. the probe fails or the SDK does not implement the capability.
+	CapabilitySupported                           // CapabilitySupported indicates the SP serves the API of the capability.
+	CapabilityUnsupported                         // CapabilityUnsupported indicates the SP does not know the API of the capability.
+)
+
+// String returns the name of the status.
+func (s CapabilityStatus) String() string {
+	switch s {
+	case CapabilitySupported:
+		return "supported"
+	case CapabilityUnsupported:
+		return "unsupported"
+	default:
+		return "unknown"
+	}
+}
+
+// SPCapabilities is the capability set of an SP discovered by Capabilities.
+type SPCapabilities struct {
+	OperatorAddress string
+	Endpoint        string
+	ProbedAt        time.Time                       // ProbedAt indicates the time when the SP is probed, the capabilities may be cached for a while.
+	Statuses        map[Capability]CapabilityStatus // Statuses contains the status of each of KnownCapabilities.
+	Errors          map[Capability]string           // Errors describes why the statuses are unknown.
+	SDKSupported    map[Capability]bool             // SDKSupported indicates which capabilities are implemented by the SDK.
+}
+
+// Supports reports whether both the SP and the SDK support the capability.
+func (s *SPCapabilities) Supports(capability Capability) bool {
+	return s.Statuses[capability] == CapabilitySupported && s.SDKSupported[capability]
+}
+
+// CapabilityStatusFromProbe - Determine the support of a capability by the response of SP to the probe request of the
+// capability API.
+//
+// The probes are sent with the fake resources, so the SPs which know the API reply the success or the xml error
+// response of the API, e.g. NoSuchBucket. The SPs which do not know the API fail to route the request and reply 404,
+// 405 or 501 without the xml error.
+//
+// - statusCode: The status code of the response.
+//
+// - body: The response body, it may be truncated.
+//
+// - ret: The status of the capability.
+func CapabilityStatusFromProbe(statusCode int, body []byte) CapabilityStatus {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		errResp := ErrResponse{}
+		if err := DecodeXML(body, &errResp); err != nil || errResp.Code == "" {
+			return CapabilityUnsupported
+		}
+		return CapabilitySupported
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return CapabilityUnknown
+	}
+	return CapabilitySupported
+}
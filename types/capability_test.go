@@ -0,0 +1,28 @@
+package types
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilityStatusFromProbe(t *testing.T) {
+	noSuchBucket := []byte(`<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist.</Message></Error>`)
+	// the API is routed and rejects the fake resources
+	require.Equal(t, CapabilitySupported, CapabilityStatusFromProbe(http.StatusNotFound, noSuchBucket))
+	require.Equal(t, CapabilitySupported, CapabilityStatusFromProbe(http.StatusUnauthorized, nil))
+	require.Equal(t, CapabilitySupported, CapabilityStatusFromProbe(http.StatusOK, nil))
+	// the API is unknown to the router
+	require.Equal(t, CapabilityUnsupported, CapabilityStatusFromProbe(http.StatusNotFound, []byte("404 page not found")))
+	require.Equal(t, CapabilityUnsupported, CapabilityStatusFromProbe(http.StatusMethodNotAllowed, nil))
+	require.Equal(t, CapabilityUnknown, CapabilityStatusFromProbe(http.StatusBadGateway, nil))
+
+	capabilities := &SPCapabilities{
+		Statuses:     map[Capability]CapabilityStatus{CapabilityResumableUpload: CapabilitySupported, CapabilityBundle: CapabilitySupported},
+		SDKSupported: map[Capability]bool{CapabilityResumableUpload: true},
+	}
+	require.True(t, capabilities.Supports(CapabilityResumableUpload))
+	require.False(t, capabilities.Supports(CapabilityBundle))
+	require.False(t, capabilities.Supports(CapabilityOffChainAuthV2))
+}
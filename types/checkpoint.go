@@ -0,0 +1,156 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// DownloadCheckpointVersion is the version of the download checkpoint format, the checkpoints of the other versions
+// are treated as corrupted.
+const DownloadCheckpointVersion = 1
+
+// ErrCheckpointCorrupted indicates the checkpoint file is truncated, modified or written by an incompatible version.
+var ErrCheckpointCorrupted = errors.New("checkpoint is corrupted")
+
+// CheckpointPart records a part which has been written to the temp file of the resumable download.
+type CheckpointPart struct {
+	Size  int64  `json:"size"`
+	CRC32 uint32 `json:"crc32"` // CRC32 is the IEEE checksum of the part content.
+}
+
+// DownloadCheckpoint records the progress of a resumable download, it is persisted next to the temp file so that the
+// download can be resumed after the process crashes.
+//
+// The parts are written to the temp file in order, the temp file content beyond the recorded parts or failing their
+// checksums is discarded when resuming.
+type DownloadCheckpoint struct {
+	Version     int              `json:"version"`
+	BucketName  string           `json:"bucket_name"`
+	ObjectName  string           `json:"object_name"`
+	ObjectID    string           `json:"object_id"` // ObjectID identifies the object, the checkpoint is stale if the object is recreated.
+	Range       string           `json:"range"`
+	PartSize    int64            `json:"part_size"`
+	StartOffset int64            `json:"start_offset"` // StartOffset is the offset in the object of the first byte of the temp file.
+	Parts       []CheckpointPart `json:"parts"`
+	Total       int64            `json:"total,omitempty"` // Total is the size of the whole download.
+	Checksum    uint32           `json:"checksum"`        // Checksum is the IEEE CRC32 of the checkpoint content with Checksum set to 0.
+}
+
+// LoadDownloadCheckpoint - Load the checkpoint persisted at path by fileSystem.
+//
+// - fileSystem: The file system where the checkpoint is persisted.
+//
+// - path: The path of the checkpoint file.
+//
+// - ret1: The loaded checkpoint.
+//
+// - ret2: Return error wrapping os.ErrNotExist if the checkpoint file does not exist, or ErrCheckpointCorrupted if the
+// content is broken, otherwise return nil if loaded.
+func LoadDownloadCheckpoint(fileSystem FileSystem, path string) (*DownloadCheckpoint, error) {
+	file, err := fileSystem.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := DecodeDownloadCheckpoint(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w of %s", err, path)
+	}
+	return checkpoint, nil
+}
+
+// DecodeDownloadCheckpoint decodes the checkpoint encoded by DownloadCheckpoint.Encode, the error wraps
+// ErrCheckpointCorrupted if the content is broken.
+func DecodeDownloadCheckpoint(content []byte) (*DownloadCheckpoint, error) {
+	checkpoint := &DownloadCheckpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("%w: fail to decode: %v", ErrCheckpointCorrupted, err)
+	}
+	if checkpoint.Version != DownloadCheckpointVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrCheckpointCorrupted, checkpoint.Version)
+	}
+	checksum, err := checkpoint.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum != checkpoint.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCheckpointCorrupted)
+	}
+	return checkpoint, nil
+}
+
+// Save persists the checkpoint at path by fileSystem. The checkpoint is written to a temp file and renamed to path, so
+// that the persisted checkpoint is not corrupted if the process crashes when saving.
+func (c *DownloadCheckpoint) Save(fileSystem FileSystem, path string) error {
+	content, err := c.Encode()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(fileSystem, path, content)
+}
+
+// Encode encodes the checkpoint with its checksum.
+func (c *DownloadCheckpoint) Encode() ([]byte, error) {
+	c.Version = DownloadCheckpointVersion
+	checksum, err := c.checksum()
+	if err != nil {
+		return nil, err
+	}
+	c.Checksum = checksum
+	return json.Marshal(c)
+}
+
+// Size returns the total size of the recorded parts.
+func (c *DownloadCheckpoint) Size() int64 {
+	var size int64
+	for _, part := range c.Parts {
+		size += part.Size
+	}
+	return size
+}
+
+// AddPart records a part which has been written to the temp file, checksum is the IEEE CRC32 of the part content.
+func (c *DownloadCheckpoint) AddPart(size int64, checksum uint32) {
+	c.Parts = append(c.Parts, CheckpointPart{Size: size, CRC32: checksum})
+}
+
+// SameDownload reports whether the checkpoint records the same download as other, the parts are not compared.
+func (c *DownloadCheckpoint) SameDownload(other *DownloadCheckpoint) bool {
+	return c.BucketName == other.BucketName && c.ObjectName == other.ObjectName && c.ObjectID == other.ObjectID &&
+		c.Range == other.Range && c.PartSize == other.PartSize && c.StartOffset == other.StartOffset
+}
+
+// Verify reads the temp file content from r and drops the recorded parts from the first one which is missing or
+// fails its checksum, the parts before it are preserved. It returns the number of the preserved parts.
+func (c *DownloadCheckpoint) Verify(r io.Reader) (int, error) {
+	for i, part := range c.Parts {
+		hash := crc32.NewIEEE()
+		n, err := io.Copy(hash, io.LimitReader(r, part.Size))
+		if err != nil {
+			return 0, err
+		}
+		if n != part.Size || hash.Sum32() != part.CRC32 {
+			c.Parts = c.Parts[:i]
+			return i, nil
+		}
+	}
+	return len(c.Parts), nil
+}
+
+func (c *DownloadCheckpoint) checksum() (uint32, error) {
+	content := *c
+	content.Checksum = 0
+	encoded, err := json.Marshal(&content)
+	if err != nil {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(encoded), nil
+}
@@ -0,0 +1,368 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// UploadCheckpointVersion is the version of the upload checkpoint format.
+const UploadCheckpointVersion = 1
+
+// ErrCheckpointListUnsupported indicates the checkpoint store can not enumerate its checkpoints.
+var ErrCheckpointListUnsupported = errors.New("the checkpoint store does not support listing")
+
+// ErrCheckpointLocked indicates the checkpoint is locked by another worker, e.g. which is resuming the same transfer.
+var ErrCheckpointLocked = errors.New("the checkpoint is locked by another worker")
+
+// CheckpointKind indicates the kind of transfer recorded by a checkpoint.
+type CheckpointKind string
+
+const (
+	CheckpointDownload CheckpointKind = "download" // CheckpointDownload is the checkpoint of a resumable download.
+	CheckpointUpload   CheckpointKind = "upload"   // CheckpointUpload is the checkpoint of a resumable upload.
+)
+
+// CheckpointStore persists the checkpoints of the resumable transfers by keys, e.g. in a local directory, a shared
+// volume or a database, so that the transfers can be inspected and resumed by other processes.
+//
+// The keys of the download checkpoints are the paths of the temp files, and the keys of the upload checkpoints are
+// returned by UploadCheckpointKey.
+type CheckpointStore interface {
+	// Load returns the content saved by key, the error wraps fs.ErrNotExist if there is no such checkpoint.
+	Load(key string) ([]byte, error)
+	// Save replaces the content of key atomically, a crash when saving keeps either the old or the new content.
+	Save(key string, content []byte) error
+	// Delete removes the checkpoint of key, the error wraps fs.ErrNotExist if there is no such checkpoint.
+	Delete(key string) error
+	// List returns the keys of all the checkpoints, the error is ErrCheckpointListUnsupported if the store can not
+	// enumerate them.
+	List() ([]string, error)
+}
+
+// CheckpointLocker is optionally implemented by the CheckpointStore shared by the workers, so that a transfer is
+// resumed by one worker at a time, e.g. after the worker started it is rescheduled to another machine.
+//
+// The locks are advisory and expire, a worker crashing when holding a lock blocks the others until the lock expires.
+type CheckpointLocker interface {
+	// TryLock acquires or renews the lock of key for holder until ttl elapses, the error wraps ErrCheckpointLocked if
+	// the lock is held by another holder and has not expired.
+	TryLock(key, holder string, ttl time.Duration) error
+	// Unlock releases the lock of key if it is held by holder.
+	Unlock(key, holder string) error
+}
+
+// DirLister is implemented by the file systems which can list the names of the files in a directory.
+type DirLister interface {
+	ReadDirNames(dir string) ([]string, error)
+}
+
+// FileCheckpointStore is the CheckpointStore keeping each checkpoint in a file, it is used by default. It implements
+// CheckpointLocker by the lock files, so it can be shared by the workers on a shared volume.
+type FileCheckpointStore struct {
+	// FileSystem is where the checkpoint files are kept, DefaultFileSystem() is used if it is nil.
+	FileSystem FileSystem
+	// Dir indicates the directory of the checkpoint files. If it is empty, each checkpoint is kept next to its key
+	// path with CheckpointFileSuffix, e.g. next to the temp file of the download, and the checkpoints can not be listed.
+	Dir string
+}
+
+var _ CheckpointLocker = FileCheckpointStore{}
+
+// Load returns the content saved by key.
+func (s FileCheckpointStore) Load(key string) ([]byte, error) {
+	file, err := s.fileSystem().Open(s.path(key))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// Save writes the content to a temp file and renames it to the checkpoint file.
+func (s FileCheckpointStore) Save(key string, content []byte) error {
+	return writeFileAtomic(s.fileSystem(), s.path(key), content)
+}
+
+// Delete removes the checkpoint file of key.
+func (s FileCheckpointStore) Delete(key string) error {
+	return s.fileSystem().Remove(s.path(key))
+}
+
+// List returns the keys of the checkpoint files in Dir, the file system should implement DirLister.
+func (s FileCheckpointStore) List() ([]string, error) {
+	lister, ok := s.fileSystem().(DirLister)
+	if s.Dir == "" || !ok {
+		return nil, ErrCheckpointListUnsupported
+	}
+	names, err := lister.ReadDirNames(s.Dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		if !strings.HasSuffix(name, CheckpointFileSuffix) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSuffix(name, CheckpointFileSuffix))
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// checkpointLock is the content of the lock file of a checkpoint.
+type checkpointLock struct {
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TryLock creates the lock file next to the checkpoint file, the lock file of another holder is replaced once it
+// expires. Two holders taking over an expired lock at the same moment may both succeed on the file systems without
+// the atomic rename, it is rare and only leads to the transfer being resumed twice.
+func (s FileCheckpointStore) TryLock(key, holder string, ttl time.Duration) error {
+	lockPath := s.path(key) + CheckpointLockSuffix
+	content, err := json.Marshal(checkpointLock{Holder: holder, ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return err
+	}
+	file, err := s.fileSystem().OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, FilePermMode)
+	if err == nil {
+		if _, err = file.Write(content); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+	if !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+
+	// renew the lock of the holder, or take over the expired or the corrupted lock
+	if current, err := s.readLock(lockPath); err == nil && current.Holder != holder && time.Now().Before(current.ExpiresAt) {
+		return fmt.Errorf("%w: %s until %s", ErrCheckpointLocked, current.Holder, current.ExpiresAt.Format(time.RFC3339))
+	}
+	if err = writeFileAtomic(s.fileSystem(), lockPath, content); err != nil {
+		return err
+	}
+	// another holder taking over the lock at the same time wins if its lock file replaces this one
+	current, err := s.readLock(lockPath)
+	if err != nil {
+		return err
+	}
+	if current.Holder != holder {
+		return fmt.Errorf("%w: %s until %s", ErrCheckpointLocked, current.Holder, current.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Unlock removes the lock file if it is held by holder.
+func (s FileCheckpointStore) Unlock(key, holder string) error {
+	lockPath := s.path(key) + CheckpointLockSuffix
+	current, err := s.readLock(lockPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if current.Holder != holder {
+		return nil
+	}
+	return s.fileSystem().Remove(lockPath)
+}
+
+func (s FileCheckpointStore) readLock(lockPath string) (*checkpointLock, error) {
+	file, err := s.fileSystem().Open(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	lock := &checkpointLock{}
+	if err = json.Unmarshal(content, lock); err != nil {
+		return nil, fmt.Errorf("%w: the lock file %s is broken: %v", ErrCheckpointCorrupted, lockPath, err)
+	}
+	return lock, nil
+}
+
+func (s FileCheckpointStore) fileSystem() FileSystem {
+	if s.FileSystem == nil {
+		return DefaultFileSystem()
+	}
+	return s.FileSystem
+}
+
+// path maps the key to the checkpoint file, the keys are escaped into the flat file names under Dir.
+func (s FileCheckpointStore) path(key string) string {
+	if s.Dir == "" {
+		return key + CheckpointFileSuffix
+	}
+	return path.Join(filepath.ToSlash(s.Dir), url.PathEscape(key)+CheckpointFileSuffix)
+}
+
+// UploadCheckpoint records the progress of a resumable upload, the upload started by another process resumes from
+// Offset if SP has not persisted it yet, see PutObjectOptions.MinResumeOffset.
+//
+// The checkpoint is bound to the object created on chain by ObjectID and IntegrityHash, so that a worker resuming the
+// upload on another machine does not resume the upload of a recreated object, or of another content.
+type UploadCheckpoint struct {
+	Version       int            `json:"version"`
+	Kind          CheckpointKind `json:"kind"`
+	BucketName    string         `json:"bucket_name"`
+	ObjectName    string         `json:"object_name"`
+	ObjectID      string         `json:"object_id,omitempty"`      // ObjectID identifies the object, it is empty in the checkpoints recorded by the older versions.
+	IntegrityHash string         `json:"integrity_hash,omitempty"` // IntegrityHash is the HEX-encoded integrity hash of the object content on chain.
+	ObjectSize    int64          `json:"object_size"`
+	PartSize      uint64         `json:"part_size"`
+	Offset        uint64         `json:"offset"` // Offset is the size of the parts which SP has acknowledged.
+	Checksum      uint32         `json:"checksum"`
+}
+
+// SameUpload reports whether the checkpoint records the upload of the same object content as other, the bindings
+// absent in the older checkpoints are not compared.
+func (c *UploadCheckpoint) SameUpload(other *UploadCheckpoint) bool {
+	if c.BucketName != other.BucketName || c.ObjectName != other.ObjectName || c.ObjectSize != other.ObjectSize ||
+		c.PartSize != other.PartSize {
+		return false
+	}
+	if c.ObjectID != "" && other.ObjectID != "" && c.ObjectID != other.ObjectID {
+		return false
+	}
+	return c.IntegrityHash == "" || other.IntegrityHash == "" || strings.EqualFold(c.IntegrityHash, other.IntegrityHash)
+}
+
+// UploadCheckpointKey returns the key of the upload checkpoint of the object.
+func UploadCheckpointKey(bucketName, objectName string) string {
+	return string(CheckpointUpload) + "/" + bucketName + "/" + objectName
+}
+
+// Encode encodes the checkpoint with its checksum.
+func (c *UploadCheckpoint) Encode() ([]byte, error) {
+	c.Version, c.Kind = UploadCheckpointVersion, CheckpointUpload
+	checksum, err := c.checksum()
+	if err != nil {
+		return nil, err
+	}
+	c.Checksum = checksum
+	return json.Marshal(c)
+}
+
+// DecodeUploadCheckpoint decodes the checkpoint encoded by UploadCheckpoint.Encode, the error wraps
+// ErrCheckpointCorrupted if the content is broken.
+func DecodeUploadCheckpoint(content []byte) (*UploadCheckpoint, error) {
+	checkpoint := &UploadCheckpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCheckpointCorrupted, err)
+	}
+	if checkpoint.Version != UploadCheckpointVersion || checkpoint.Kind != CheckpointUpload {
+		return nil, fmt.Errorf("%w: unsupported upload checkpoint version %d", ErrCheckpointCorrupted, checkpoint.Version)
+	}
+	checksum, err := checkpoint.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum != checkpoint.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch of the upload checkpoint", ErrCheckpointCorrupted)
+	}
+	return checkpoint, nil
+}
+
+func (c *UploadCheckpoint) checksum() (uint32, error) {
+	content := *c
+	content.Checksum = 0
+	encoded, err := json.Marshal(&content)
+	if err != nil {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(encoded), nil
+}
+
+// CheckpointInfo describes a pending resumable transfer recorded by a checkpoint.
+type CheckpointInfo struct {
+	Key         string
+	Kind        CheckpointKind
+	BucketName  string
+	ObjectName  string
+	Transferred int64 // Transferred indicates the bytes which have been transferred.
+	Total       int64 // Total indicates the bytes to transfer in total, it is -1 if unknown, e.g. recorded by the older versions.
+	Corrupted   bool  // Corrupted indicates the checkpoint is broken and the transfer restarts from the beginning.
+}
+
+// DecodeCheckpointInfo - Describe the checkpoint saved by key.
+//
+// - key: The key of the checkpoint.
+//
+// - content: The content of the checkpoint.
+//
+// - ret: The description of the transfer, Corrupted is set if the content can not be decoded.
+func DecodeCheckpointInfo(key string, content []byte) *CheckpointInfo {
+	info := &CheckpointInfo{Key: key, Total: -1}
+	header := struct {
+		Kind CheckpointKind `json:"kind"`
+	}{}
+	if err := json.Unmarshal(content, &header); err != nil {
+		info.Corrupted = true
+		return info
+	}
+	if header.Kind == CheckpointUpload {
+		info.Kind = CheckpointUpload
+		checkpoint, err := DecodeUploadCheckpoint(content)
+		if err != nil {
+			info.Corrupted = true
+			return info
+		}
+		info.BucketName, info.ObjectName = checkpoint.BucketName, checkpoint.ObjectName
+		info.Transferred, info.Total = int64(checkpoint.Offset), checkpoint.ObjectSize
+		return info
+	}
+
+	// the download checkpoints are written without the kind
+	info.Kind = CheckpointDownload
+	checkpoint, err := DecodeDownloadCheckpoint(content)
+	if err != nil {
+		info.Corrupted = true
+		return info
+	}
+	info.BucketName, info.ObjectName = checkpoint.BucketName, checkpoint.ObjectName
+	info.Transferred = checkpoint.Size()
+	if checkpoint.Total > 0 {
+		info.Total = checkpoint.Total
+	}
+	return info
+}
+
+// writeFileAtomic writes the content to a temp file and renames it to path, so that path is not corrupted if the
+// process crashes when writing.
+func writeFileAtomic(fileSystem FileSystem, path string, content []byte) error {
+	tempPath := path + TempFileSuffix
+	file, err := fileSystem.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermMode)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return fileSystem.Rename(tempPath, filepath.Clean(path))
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	fileSystem := NewMemFileSystem()
+
+	// the checkpoints are kept next to the keys if Dir is empty
+	store := FileCheckpointStore{FileSystem: fileSystem}
+	require.NoError(t, store.Save("dir/object.temp", []byte("content")))
+	_, err := fileSystem.Stat("dir/object.temp" + CheckpointFileSuffix)
+	require.NoError(t, err)
+	_, err = store.List()
+	require.ErrorIs(t, err, ErrCheckpointListUnsupported)
+
+	store = FileCheckpointStore{FileSystem: fileSystem, Dir: "checkpoints"}
+	keys, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	uploadKey := UploadCheckpointKey("bucket", "dir/object")
+	require.NoError(t, store.Save(uploadKey, []byte("upload")))
+	require.NoError(t, store.Save("dir/object.temp", []byte("download")))
+	keys, err = store.List()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{uploadKey, "dir/object.temp"}, keys)
+	content, err := store.Load(uploadKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("upload"), content)
+
+	require.NoError(t, store.Delete(uploadKey))
+	_, err = store.Load(uploadKey)
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+	require.True(t, errors.Is(store.Delete(uploadKey), fs.ErrNotExist))
+}
+
+func TestDecodeCheckpointInfo(t *testing.T) {
+	upload := &UploadCheckpoint{BucketName: "bucket", ObjectName: "object", ObjectSize: 100, PartSize: 16, Offset: 32}
+	content, err := upload.Encode()
+	require.NoError(t, err)
+	decoded, err := DecodeUploadCheckpoint(content)
+	require.NoError(t, err)
+	require.Equal(t, upload, decoded)
+	info := DecodeCheckpointInfo("key", content)
+	require.Equal(t, &CheckpointInfo{Key: "key", Kind: CheckpointUpload, BucketName: "bucket", ObjectName: "object", Transferred: 32, Total: 100}, info)
+
+	content = bytes.Replace(content, []byte(`"offset":32`), []byte(`"offset":48`), 1)
+	_, err = DecodeUploadCheckpoint(content)
+	require.ErrorIs(t, err, ErrCheckpointCorrupted)
+	require.True(t, DecodeCheckpointInfo("key", content).Corrupted)
+
+	download := &DownloadCheckpoint{BucketName: "bucket", ObjectName: "object", PartSize: 6, Total: 20}
+	download.AddPart(6, 1)
+	content, err = download.Encode()
+	require.NoError(t, err)
+	info = DecodeCheckpointInfo("object.temp", content)
+	require.Equal(t, &CheckpointInfo{Key: "object.temp", Kind: CheckpointDownload, BucketName: "bucket", ObjectName: "object", Transferred: 6, Total: 20}, info)
+	require.True(t, DecodeCheckpointInfo("broken", []byte("{")).Corrupted)
+}
+
+func TestFileCheckpointStoreLock(t *testing.T) {
+	store := FileCheckpointStore{FileSystem: NewMemFileSystem(), Dir: "checkpoints"}
+	key := UploadCheckpointKey("bucket", "object")
+
+	require.NoError(t, store.TryLock(key, "worker1", time.Minute))
+	// the holder renews its lock, and the others fail until it is released
+	require.NoError(t, store.TryLock(key, "worker1", time.Minute))
+	require.ErrorIs(t, store.TryLock(key, "worker2", time.Minute), ErrCheckpointLocked)
+	require.NoError(t, store.Unlock(key, "worker2"))
+	require.ErrorIs(t, store.TryLock(key, "worker2", time.Minute), ErrCheckpointLocked)
+	require.NoError(t, store.Unlock(key, "worker1"))
+	require.NoError(t, store.TryLock(key, "worker2", 0))
+
+	// the expired lock is taken over
+	require.NoError(t, store.TryLock(key, "worker3", time.Minute))
+	require.ErrorIs(t, store.TryLock(key, "worker2", time.Minute), ErrCheckpointLocked)
+
+	// the lock files are not listed as the checkpoints
+	keys, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestUploadCheckpointSameUpload(t *testing.T) {
+	checkpoint := &UploadCheckpoint{BucketName: "bucket", ObjectName: "object", ObjectID: "1", IntegrityHash: "ab", ObjectSize: 100, PartSize: 16}
+	other := *checkpoint
+	require.True(t, checkpoint.SameUpload(&other))
+	other.IntegrityHash = "AB"
+	require.True(t, checkpoint.SameUpload(&other))
+
+	// the object recreated with another content is not resumed
+	other.ObjectID = "2"
+	require.False(t, checkpoint.SameUpload(&other))
+	other.ObjectID, other.IntegrityHash = "1", "cd"
+	require.False(t, checkpoint.SameUpload(&other))
+
+	// the checkpoints of the older versions are not bound to the object
+	other.ObjectID, other.IntegrityHash = "", ""
+	require.True(t, checkpoint.SameUpload(&other))
+}
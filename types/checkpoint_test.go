@@ -0,0 +1,85 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadCheckpoint(t *testing.T) {
+	for name, fileSystem := range map[string]FileSystem{
+		"os":     OSFileSystem{},
+		"memory": NewMemFileSystem(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "object.temp.cp")
+			_, err := LoadDownloadCheckpoint(fileSystem, path)
+			require.True(t, errors.Is(err, os.ErrNotExist))
+
+			parts := [][]byte{[]byte("part-0"), []byte("part-1"), []byte("part-2")}
+			checkpoint := &DownloadCheckpoint{BucketName: "bucket", ObjectName: "object", ObjectID: "1", PartSize: 6}
+			for _, part := range parts {
+				checkpoint.AddPart(int64(len(part)), crc32.ChecksumIEEE(part))
+			}
+			require.NoError(t, checkpoint.Save(fileSystem, path))
+
+			loaded, err := LoadDownloadCheckpoint(fileSystem, path)
+			require.NoError(t, err)
+			require.True(t, loaded.SameDownload(checkpoint))
+			require.Equal(t, int64(18), loaded.Size())
+
+			// the parts are preserved until the first broken one
+			content := bytes.Join(parts, nil)
+			content[8] = 'X'
+			preserved, err := loaded.Verify(bytes.NewReader(content))
+			require.NoError(t, err)
+			require.Equal(t, 1, preserved)
+			require.Equal(t, int64(6), loaded.Size())
+
+			// the truncated temp file drops the incomplete part
+			loaded, err = LoadDownloadCheckpoint(fileSystem, path)
+			require.NoError(t, err)
+			preserved, err = loaded.Verify(bytes.NewReader(bytes.Join(parts, nil)[:15]))
+			require.NoError(t, err)
+			require.Equal(t, 2, preserved)
+		})
+	}
+}
+
+func TestDownloadCheckpointCorrupted(t *testing.T) {
+	fileSystem := NewMemFileSystem()
+	checkpoint := &DownloadCheckpoint{BucketName: "bucket", ObjectName: "object", PartSize: 6}
+	checkpoint.AddPart(6, 1)
+	require.NoError(t, checkpoint.Save(fileSystem, "object.cp"))
+	file, err := fileSystem.Open("object.cp")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	valid := buf.Bytes()
+
+	testCases := map[string][]byte{
+		"truncated": valid[:len(valid)/2],
+		"modified":  bytes.Replace(valid, []byte(`"part_size":6`), []byte(`"part_size":7`), 1),
+		"version":   bytes.Replace(valid, []byte(`"version":1`), []byte(`"version":2`), 1),
+		"empty":     {},
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			file, err := fileSystem.OpenFile("broken.cp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermMode)
+			require.NoError(t, err)
+			_, err = file.Write(content)
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+
+			_, err = LoadDownloadCheckpoint(fileSystem, "broken.cp")
+			require.True(t, errors.Is(err, ErrCheckpointCorrupted), "unexpected error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCircuitOpen indicates the request is not sent since the circuit breaker of the SP endpoint is open, i.e. the SP
+// has failed recently and is not probed yet.
+var ErrCircuitOpen = errors.New("the circuit breaker of the SP endpoint is open")
+
+// CircuitState is the state of the circuit breaker of an SP endpoint.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // CircuitClosed sends the requests to the SP as usual.
+	CircuitOpen     CircuitState = "open"      // CircuitOpen fails the requests to the SP fast with ErrCircuitOpen.
+	CircuitHalfOpen CircuitState = "half-open" // CircuitHalfOpen sends a probe request to the SP and fails the others fast until the probe completes.
+)
+
+// CircuitBreakerPolicy decides when the requests to an SP endpoint stop being sent, so that an SP outage fails the
+// requests fast rather than each of them waiting for the timeout. Unlike SPHealthPolicy, it applies to all the
+// requests to the SP, including those routed to it as the primary SP of a bucket.
+type CircuitBreakerPolicy struct {
+	FailureThreshold int           // FailureThreshold opens the circuit after the number of the consecutive failed requests.
+	OpenDuration     time.Duration // OpenDuration is how long the circuit stays open before a probe request is sent.
+}
+
+// DefaultCircuitBreakerPolicy returns the CircuitBreakerPolicy with the default thresholds.
+func DefaultCircuitBreakerPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	}
+}
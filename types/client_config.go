@@ -0,0 +1,281 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigEnvPrefix is the prefix of the environment variables overriding the client config, e.g. GREENFIELD_CHAIN_ID.
+const ConfigEnvPrefix = "GREENFIELD_"
+
+// Duration is the time.Duration decoded from the strings like "30s" in the config files, the integers are taken as
+// nanoseconds.
+type Duration time.Duration
+
+// UnmarshalJSON decodes the duration from a string parsed by time.ParseDuration or an integer.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(v)
+	default:
+		return fmt.Errorf("invalid duration %s", string(data))
+	}
+	return nil
+}
+
+// MarshalJSON encodes the duration as a string like "30s".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// KeyConfig indicates where the default account of the client is loaded from, at most one source should be set.
+type KeyConfig struct {
+	Name           string `json:"name"`             // Name indicates the name of the account, "default" is used if it is empty.
+	PrivateKey     string `json:"private_key"`      // PrivateKey indicates the HEX-encoded private key.
+	Mnemonic       string `json:"mnemonic"`         // Mnemonic indicates the mnemonic of the account.
+	PrivateKeyFile string `json:"private_key_file"` // PrivateKeyFile indicates the file containing the HEX-encoded private key, so that the key is not kept in the config.
+}
+
+// RetryConfig indicates how the failed SP requests are retried, see RetryPolicy.
+type RetryConfig struct {
+	MaxAttempts          int      `json:"max_attempts"`           // MaxAttempts indicates the max number of attempts including the first one, the requests are not retried if it is less than 2.
+	BaseDelay            Duration `json:"base_delay"`             // BaseDelay indicates the delay before the first retry, DefaultRetryBaseDelay is used if it is 0.
+	MaxDelay             Duration `json:"max_delay"`              // MaxDelay indicates the max delay between the retries, DefaultRetryMaxDelay is used if it is 0.
+	RetryableStatusCodes []int    `json:"retryable_status_codes"` // RetryableStatusCodes indicates the response status codes to retry, DefaultRetryableStatusCodes is used if it is empty.
+}
+
+// TraceConfig indicates how the requests to SP and their responses are traced.
+type TraceConfig struct {
+	Enabled    bool   `json:"enabled"`     // Enabled indicates whether to trace the requests.
+	OnlyErrors bool   `json:"only_errors"` // OnlyErrors indicates whether to trace the failed requests only.
+	Output     string `json:"output"`      // Output indicates where the traces are written, "stdout", "stderr" or a file path. "stderr" is used if it is empty.
+}
+
+// ClientConfig is the declarative configuration of the client, it is decoded from the YAML or JSON files and
+// overridden by the environment variables, so that the deployments can tune the client without code changes.
+type ClientConfig struct {
+	ChainID               string      `json:"chain_id"`                // ChainID indicates the chain id of Greenfield.
+	RPCAddress            string      `json:"rpc_address"`             // RPCAddress indicates the RPC URL of the blockchain node.
+	GrpcAddress           string      `json:"grpc_address"`            // GrpcAddress indicates the gRPC address of the blockchain node, the chain is accessed via gRPC if it is set.
+	BroadcastEndpoints    []string    `json:"broadcast_endpoints"`     // BroadcastEndpoints indicates the RPC URLs of the additional nodes to broadcast the txs to.
+	Secure                bool        `json:"secure"`                  // Secure indicates whether to use HTTPS to access SP.
+	Host                  string      `json:"host"`                    // Host indicates the fixed SP host to send the requests to.
+	Key                   KeyConfig   `json:"key"`                     // Key indicates the source of the default account, the client has no default account if no source is set.
+	ExpireSeconds         uint64      `json:"expire_seconds"`          // ExpireSeconds indicates the seconds after which the signatures of the SP requests expire.
+	DialTimeout           Duration    `json:"dial_timeout"`            // DialTimeout indicates the timeout of connecting to SP, it is not limited if it is 0.
+	ResponseHeaderTimeout Duration    `json:"response_header_timeout"` // ResponseHeaderTimeout indicates the timeout of waiting for the response headers of SP, it is not limited if it is 0.
+	DefaultPartSize       uint64      `json:"default_part_size"`       // DefaultPartSize indicates the part size of the resumable uploads and downloads.
+	MaxResponseBodySize   int64       `json:"max_response_body_size"`  // MaxResponseBodySize indicates the max size in bytes of the list and meta responses of SP.
+	Retry                 RetryConfig `json:"retry"`                   // Retry indicates how the failed SP requests are retried, they are not retried if Retry.MaxAttempts is 0.
+	Trace                 TraceConfig `json:"trace"`                   // Trace indicates how the SP requests are traced.
+	FileSystem            FileSystem  `json:"-"`                       // FileSystem indicates where the private key file and the trace file are, it is also used by the client. DefaultFileSystem() is used if it is nil.
+}
+
+// LoadClientConfig - Load the client config from the YAML or JSON file and override it by the environment variables
+// prefixed by ConfigEnvPrefix.
+//
+// - path: The path of the config file, the config is loaded from the environment variables only if it is empty.
+//
+// - ret1: The loaded config.
+//
+// - ret2: Return error when the file can not be read or decoded, or the environment variables are malformed,
+// otherwise return nil.
+func LoadClientConfig(path string) (*ClientConfig, error) {
+	return LoadClientConfigFromFileSystem(DefaultFileSystem(), path)
+}
+
+// LoadClientConfigFromFileSystem - Load the client config in the same way as LoadClientConfig, but the config file is
+// read from fileSystem, which is kept in ClientConfig.FileSystem for the private key file and the trace file.
+//
+// - fileSystem: The file system of the config file.
+//
+// - path: The path of the config file, the config is loaded from the environment variables only if it is empty.
+//
+// - ret1: The loaded config.
+//
+// - ret2: Return error when the file can not be read or decoded, or the environment variables are malformed,
+// otherwise return nil.
+func LoadClientConfigFromFileSystem(fileSystem FileSystem, path string) (*ClientConfig, error) {
+	config := &ClientConfig{}
+	if path != "" {
+		file, err := fileSystem.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		if config, err = DecodeClientConfig(file); err != nil {
+			return nil, fmt.Errorf("fail to decode the config file %s: %w", path, err)
+		}
+	}
+	if err := config.ApplyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	config.FileSystem = fileSystem
+	return config, nil
+}
+
+// DecodeClientConfig - Decode the client config from the YAML or JSON content, the unknown fields are rejected to
+// catch the typos.
+//
+// - r: The YAML or JSON content, JSON is a subset of YAML.
+//
+// - ret1: The decoded config.
+//
+// - ret2: Return error when the content is malformed, otherwise return nil.
+func DecodeClientConfig(r io.Reader) (*ClientConfig, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	config := &ClientConfig{}
+	if err = yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ApplyEnv overrides the config by the environment variables prefixed by ConfigEnvPrefix, the variable names are the
+// upper-cased field paths, e.g. GREENFIELD_RPC_ADDRESS, GREENFIELD_PRIVATE_KEY and GREENFIELD_RETRY_MAX_ATTEMPTS.
+// The list variables are comma separated. lookup is os.LookupEnv in most cases.
+func (c *ClientConfig) ApplyEnv(lookup func(key string) (string, bool)) error {
+	setters := map[string]func(value string) error{
+		"CHAIN_ID":                envString(&c.ChainID),
+		"RPC_ADDRESS":             envString(&c.RPCAddress),
+		"GRPC_ADDRESS":            envString(&c.GrpcAddress),
+		"BROADCAST_ENDPOINTS":     envStrings(&c.BroadcastEndpoints),
+		"SECURE":                  envBool(&c.Secure),
+		"HOST":                    envString(&c.Host),
+		"KEY_NAME":                envString(&c.Key.Name),
+		"PRIVATE_KEY":             envString(&c.Key.PrivateKey),
+		"MNEMONIC":                envString(&c.Key.Mnemonic),
+		"PRIVATE_KEY_FILE":        envString(&c.Key.PrivateKeyFile),
+		"EXPIRE_SECONDS":          envUint(&c.ExpireSeconds),
+		"DIAL_TIMEOUT":            envDuration(&c.DialTimeout),
+		"RESPONSE_HEADER_TIMEOUT": envDuration(&c.ResponseHeaderTimeout),
+		"DEFAULT_PART_SIZE":       envUint(&c.DefaultPartSize),
+		"MAX_RESPONSE_BODY_SIZE":  envInt(&c.MaxResponseBodySize),
+		"RETRY_MAX_ATTEMPTS":      envInt(&c.Retry.MaxAttempts),
+		"RETRY_BASE_DELAY":        envDuration(&c.Retry.BaseDelay),
+		"RETRY_MAX_DELAY":         envDuration(&c.Retry.MaxDelay),
+		"TRACE_ENABLED":           envBool(&c.Trace.Enabled),
+		"TRACE_ONLY_ERRORS":       envBool(&c.Trace.OnlyErrors),
+		"TRACE_OUTPUT":            envString(&c.Trace.Output),
+	}
+	errs := make([]error, 0)
+	for name, set := range setters {
+		value, ok := lookup(ConfigEnvPrefix + name)
+		if !ok {
+			continue
+		}
+		if err := set(strings.TrimSpace(value)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s%s: %w", ConfigEnvPrefix, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate - Check the chain, the key sources, the timeouts and the retries of the config.
+func (c ClientConfig) Validate() error {
+	v := newOptionsValidator("ClientConfig")
+	v.check(c.ChainID != "", "ChainID should not be empty")
+	v.check(c.RPCAddress != "", "RPCAddress should not be empty")
+	sources := 0
+	for _, source := range []string{c.Key.PrivateKey, c.Key.Mnemonic, c.Key.PrivateKeyFile} {
+		if source != "" {
+			sources++
+		}
+	}
+	v.check(sources <= 1, "only one of PrivateKey, Mnemonic and PrivateKeyFile should be set in Key")
+	v.check(c.DialTimeout >= 0, "DialTimeout %s should not be negative", time.Duration(c.DialTimeout))
+	v.check(c.ResponseHeaderTimeout >= 0, "ResponseHeaderTimeout %s should not be negative", time.Duration(c.ResponseHeaderTimeout))
+	v.check(c.MaxResponseBodySize >= 0, "MaxResponseBodySize %d should not be negative", c.MaxResponseBodySize)
+	v.check(c.Retry.MaxAttempts >= 0, "Retry.MaxAttempts %d should not be negative", c.Retry.MaxAttempts)
+	v.check(c.Retry.BaseDelay >= 0 && c.Retry.MaxDelay >= 0, "Retry delays should not be negative")
+	return v.err()
+}
+
+// RetryPolicy returns the retry policy of the config, nil indicates the requests are not retried.
+func (c ClientConfig) RetryPolicy() *RetryPolicy {
+	if c.Retry.MaxAttempts == 0 {
+		return nil
+	}
+	policy := &RetryPolicy{MaxAttempts: c.Retry.MaxAttempts, RetryableStatusCodes: c.Retry.RetryableStatusCodes}
+	if c.Retry.BaseDelay != 0 || c.Retry.MaxDelay != 0 {
+		base, max := time.Duration(c.Retry.BaseDelay), time.Duration(c.Retry.MaxDelay)
+		if base == 0 {
+			base = DefaultRetryBaseDelay
+		}
+		if max == 0 {
+			max = DefaultRetryMaxDelay
+		}
+		policy.Backoff = ExponentialBackoff(base, max)
+	}
+	return policy
+}
+
+func envString(field *string) func(string) error {
+	return func(value string) error {
+		*field = value
+		return nil
+	}
+}
+
+func envStrings(field *[]string) func(string) error {
+	return func(value string) error {
+		*field = make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*field = append(*field, item)
+			}
+		}
+		return nil
+	}
+}
+
+func envBool(field *bool) func(string) error {
+	return func(value string) (err error) {
+		*field, err = strconv.ParseBool(value)
+		return err
+	}
+}
+
+func envUint(field *uint64) func(string) error {
+	return func(value string) (err error) {
+		*field, err = strconv.ParseUint(value, 10, 64)
+		return err
+	}
+}
+
+func envInt[T int | int64](field *T) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		*field = T(parsed)
+		return err
+	}
+}
+
+func envDuration(field *Duration) func(string) error {
+	return func(value string) error {
+		parsed, err := time.ParseDuration(value)
+		*field = Duration(parsed)
+		return err
+	}
+}
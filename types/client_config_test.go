@@ -0,0 +1,97 @@
+package types
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeClientConfig(t *testing.T) {
+	config, err := DecodeClientConfig(strings.NewReader(`
+chain_id: greenfield_1017-1
+rpc_address: https://greenfield-chain.bnbchain.org:443
+broadcast_endpoints:
+  - https://node1.example:443
+key:
+  private_key_file: /run/secrets/gnfd_key
+dial_timeout: 5s
+retry:
+  max_attempts: 3
+  base_delay: 200ms
+trace:
+  enabled: true
+  only_errors: true
+`))
+	require.NoError(t, err)
+	require.Equal(t, "greenfield_1017-1", config.ChainID)
+	require.Equal(t, []string{"https://node1.example:443"}, config.BroadcastEndpoints)
+	require.Equal(t, "/run/secrets/gnfd_key", config.Key.PrivateKeyFile)
+	require.Equal(t, Duration(5*time.Second), config.DialTimeout)
+	require.Equal(t, 3, config.Retry.MaxAttempts)
+	require.True(t, config.Trace.OnlyErrors)
+	require.NoError(t, config.Validate())
+	require.NotNil(t, config.RetryPolicy().Backoff)
+
+	// JSON is decoded in the same way
+	config, err = DecodeClientConfig(strings.NewReader(`{"chain_id": "greenfield_5600-1", "response_header_timeout": "1m"}`))
+	require.NoError(t, err)
+	require.Equal(t, Duration(time.Minute), config.ResponseHeaderTimeout)
+	require.Nil(t, config.RetryPolicy())
+
+	// the typos are rejected
+	_, err = DecodeClientConfig(strings.NewReader(`chain_idd: greenfield_5600-1`))
+	require.Error(t, err)
+}
+
+func TestClientConfigApplyEnv(t *testing.T) {
+	config := &ClientConfig{ChainID: "greenfield_1017-1", RPCAddress: "https://a.example", Key: KeyConfig{Mnemonic: "m"}}
+	env := map[string]string{
+		"GREENFIELD_RPC_ADDRESS":         "https://b.example",
+		"GREENFIELD_BROADCAST_ENDPOINTS": "https://c.example, https://d.example",
+		"GREENFIELD_RETRY_MAX_ATTEMPTS":  "5",
+		"GREENFIELD_TRACE_ENABLED":       "true",
+		"GREENFIELD_PRIVATE_KEY":         "abcd",
+	}
+	lookup := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	require.NoError(t, config.ApplyEnv(lookup))
+	require.Equal(t, "greenfield_1017-1", config.ChainID)
+	require.Equal(t, "https://b.example", config.RPCAddress)
+	require.Equal(t, []string{"https://c.example", "https://d.example"}, config.BroadcastEndpoints)
+	require.Equal(t, 5, config.Retry.MaxAttempts)
+	require.True(t, config.Trace.Enabled)
+
+	// two key sources are ambiguous
+	err := config.Validate()
+	var optionsErr *OptionsError
+	require.True(t, errors.As(err, &optionsErr))
+	require.Len(t, optionsErr.Problems, 1)
+
+	env["GREENFIELD_DIAL_TIMEOUT"] = "5"
+	require.Error(t, config.ApplyEnv(lookup))
+}
+
+func TestLoadClientConfigFromFileSystem(t *testing.T) {
+	fileSystem := NewMemFileSystem()
+	file, err := fileSystem.OpenFile("gnfd.yaml", os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("chain_id: greenfield_5600-1\nkey:\n  private_key_file: gnfd_key\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	config, err := LoadClientConfigFromFileSystem(fileSystem, "gnfd.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "greenfield_5600-1", config.ChainID)
+	require.Equal(t, "gnfd_key", config.Key.PrivateKeyFile)
+	// the key file of the config is read from the same file system
+	require.Same(t, fileSystem, config.FileSystem)
+
+	_, err = LoadClientConfigFromFileSystem(fileSystem, "missing.yaml")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// Clock provides the current time for the client, e.g. the date and expiry timestamps of the requests sent to SP.
+// It can be replaced to use a trusted time source or to control the time in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock implements Clock by the local system time.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
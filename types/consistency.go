@@ -0,0 +1,71 @@
+package types
+
+import "fmt"
+
+// ConsistencyMode indicates how stale the list and meta responses served by the meta service of SP may be.
+type ConsistencyMode int
+
+const (
+	// ConsistencyDefault checks the responses by the MaxMetaBlockLag of the client.
+	ConsistencyDefault ConsistencyMode = iota
+	// ConsistencyAny accepts the responses however stale they are.
+	ConsistencyAny
+	// ConsistencyBoundedStaleness accepts the responses of the meta services lagging behind the chain by at most
+	// MaxBlocks blocks.
+	ConsistencyBoundedStaleness
+	// ConsistencyReadYourWrites waits until the meta service of SP has synced the last tx confirmed by the client,
+	// so that the responses reflect the writes of the caller.
+	ConsistencyReadYourWrites
+	// ConsistencyMinHeight waits until the meta service of SP has synced to MinHeight, e.g. the height which a
+	// resumed listing started at.
+	ConsistencyMinHeight
+)
+
+// Consistency is the consistency of the list and meta queries, the zero value is ConsistencyDefault.
+type Consistency struct {
+	Mode      ConsistencyMode
+	MaxBlocks int64 // MaxBlocks is the max lag of ConsistencyBoundedStaleness.
+	MinHeight int64 // MinHeight is the height awaited by ConsistencyMinHeight.
+}
+
+var (
+	// AnyConsistency accepts the responses however stale they are.
+	AnyConsistency = Consistency{Mode: ConsistencyAny}
+	// ReadYourWrites waits until the responses reflect the last tx confirmed by the client.
+	ReadYourWrites = Consistency{Mode: ConsistencyReadYourWrites}
+)
+
+// BoundedStaleness returns the consistency accepting the responses lagging behind the chain by at most maxBlocks.
+func BoundedStaleness(maxBlocks int64) Consistency {
+	return Consistency{Mode: ConsistencyBoundedStaleness, MaxBlocks: maxBlocks}
+}
+
+// AtLeastHeight returns the consistency waiting until the responses reflect the chain state at the height.
+func AtLeastHeight(height int64) Consistency {
+	return Consistency{Mode: ConsistencyMinHeight, MinHeight: height}
+}
+
+// String returns the name of the consistency.
+func (c Consistency) String() string {
+	switch c.Mode {
+	case ConsistencyAny:
+		return "any"
+	case ConsistencyBoundedStaleness:
+		return fmt.Sprintf("bounded-staleness(%d)", c.MaxBlocks)
+	case ConsistencyReadYourWrites:
+		return "read-your-writes"
+	case ConsistencyMinHeight:
+		return fmt.Sprintf("min-height(%d)", c.MinHeight)
+	default:
+		return "default"
+	}
+}
+
+// Validate - Check the mode, the max blocks and the min height of the consistency.
+func (c Consistency) Validate() error {
+	v := newOptionsValidator("Consistency")
+	v.check(c.Mode >= ConsistencyDefault && c.Mode <= ConsistencyMinHeight, "Mode %d is unknown", c.Mode)
+	v.check(c.MaxBlocks >= 0, "MaxBlocks %d should not be negative", c.MaxBlocks)
+	v.check(c.MinHeight >= 0, "MinHeight %d should not be negative", c.MinHeight)
+	return v.err()
+}
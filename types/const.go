@@ -10,6 +10,8 @@ const (
 	libName   = "greenfield-go-sdk"
 	Version   = "v0.1.0"
 	UserAgent = "Greenfield (" + runtime.GOOS + "; " + runtime.GOARCH + ") " + libName + "/" + Version
+	// TracerName is the instrumentation name of the tracer of the client.
+	TracerName = "github.com/bnb-chain/greenfield-go-sdk"
 
 	HTTPHeaderAuthorization = "Authorization"
 
@@ -26,12 +28,19 @@ const (
 	HTTPHeaderPieceHash       = "X-Gnfd-Piece-Hash"
 
 	HTTPHeaderDate          = "X-Gnfd-Date"
+	HTTPHeaderServerDate    = "Date"
 	HTTPHeaderEtag          = "ETag"
 	HTTPHeaderRange         = "Range"
 	HTTPHeaderUserAgent     = "User-Agent"
 	HTTPHeaderContentSHA256 = "X-Gnfd-Content-Sha256"
 
 	HTTPHeaderUserAddress = "X-Gnfd-User-Address"
+	// HTTPHeaderBlockHeight is the block height which the meta service of SP has synced to when serving the response.
+	HTTPHeaderBlockHeight = "X-Gnfd-Block-Height"
+	// HTTPHeaderRequestID is the id of the request generated by SP, it helps SP to locate the request in its logs.
+	HTTPHeaderRequestID = "X-Gnfd-Request-Id"
+	// HTTPHeaderQueryHeight is the block height which the list and meta requests are pinned to by the client.
+	HTTPHeaderQueryHeight = "X-Gnfd-Query-Height"
 
 	ContentTypeXML = "application/xml"
 	ContentDefault = "application/octet-stream"
@@ -49,10 +58,16 @@ const (
 	CreateObjectAction  = "CreateObject"
 	CreateBucketAction  = "CreateBucket"
 	MigrateBucketAction = "MigrateBucket"
+	CopyObjectAction    = "CopyObject"
 
 	ChallengeUrl           = "challenge"
 	PrimaryRedundancyIndex = -1
 
+	// UniversalViewPath and UniversalDownloadPath are the url paths of the universal endpoint of SP to view and to
+	// download the objects, followed by the bucket and the object names.
+	UniversalViewPath     = "view"
+	UniversalDownloadPath = "download"
+
 	ContextTimeout   = time.Second * 30
 	MaxHeadTryTime   = 4
 	HeadBackOffDelay = time.Millisecond * 500
@@ -67,9 +82,112 @@ const (
 	// putObject behaves internally as multipart.
 	MinPartSize = 1024 * 1024 * 32
 
-	TempFileSuffix = ".temp"            // Temp file suffix
-	FilePermMode   = os.FileMode(0o664) // Default file permission
+	// DefaultDownloadConcurrency - the default number of parts downloaded in parallel
+	DefaultDownloadConcurrency = 4
+
+	// DefaultSyncConcurrency - the default number of files transferred in parallel by the directory sync
+	DefaultSyncConcurrency = 4
+
+	// DefaultListConcurrency - the default number of prefixes listed in parallel by ListObjectsParallel
+	DefaultListConcurrency = 8
+
+	// DefaultConcurrencyDecreaseFactor - the default ratio of the concurrency kept by the adaptive concurrency when
+	// a transfer fails or is slow
+	DefaultConcurrencyDecreaseFactor = 0.5
+
+	// DefaultChunkBufferSize - the default max bytes of the chunks buffered ahead of the upload from chunks
+	DefaultChunkBufferSize = 1024 * 1024 * 16
+
+	TempFileSuffix       = ".temp"            // Temp file suffix
+	CheckpointFileSuffix = ".cp"              // Checkpoint file suffix of the resumable download
+	CheckpointLockSuffix = ".lock"            // Lock file suffix of the checkpoint, appended to the checkpoint file
+	FilePermMode         = os.FileMode(0o664) // Default file permission
 
 	WaitTxContextTimeOut = 1 * time.Second
 	DefaultExpireSeconds = 1000
+
+	// DefaultMaxClockSkew is the default max drift of the local clock from SP before warning, SP rejects the requests
+	// whose timestamps drift too much.
+	DefaultMaxClockSkew = 30 * time.Second
+
+	// DefaultBlockTime is the block time assumed when waiting for blocks if the average one can not be measured.
+	DefaultBlockTime = time.Second
+	// BlockTimeSampleBlocks is the number of the recent blocks to measure the average block time.
+	BlockTimeSampleBlocks = 20
+	// MinBlockPollInterval is the min interval of polling the latest block height when waiting for blocks.
+	MinBlockPollInterval = 100 * time.Millisecond
+	// DefaultParamsPollInterval is the default interval of polling the chain params when watching them.
+	DefaultParamsPollInterval = time.Minute
+	// ReadYourWritesTimeout is the max time of waiting for the meta service of SP to sync the last write of the client.
+	ReadYourWritesTimeout = time.Minute
+	// DefaultSealTimeout is the default timeout of waiting for the object to be sealed.
+	DefaultSealTimeout = 5 * time.Minute
+	// DefaultSealPollInterval is the default interval of the first poll of the object status when waiting for the seal.
+	DefaultSealPollInterval = time.Second
+	// MaxSealPollInterval is the max interval of polling the object status when waiting for the seal.
+	MaxSealPollInterval = 10 * time.Second
+	// DefaultCapabilityTTL is the duration for which the probed capabilities of an SP are cached.
+	DefaultCapabilityTTL = 10 * time.Minute
+	// DefaultUploadOffsetTimeout is the default timeout of waiting for SP to persist the parts of the resumable upload.
+	DefaultUploadOffsetTimeout = 30 * time.Second
+	// DefaultCheckpointLockTTL is the duration for which the lock of the upload checkpoint is held by a worker, the
+	// lock is renewed after each part, and is taken over by another worker once it expires.
+	DefaultCheckpointLockTTL = 10 * time.Minute
+	// DefaultUploadOffsetPollInterval is the default interval of the first poll of the resumable upload offset.
+	DefaultUploadOffsetPollInterval = 500 * time.Millisecond
+	// MaxUploadOffsetPollInterval is the max interval of polling the resumable upload offset.
+	MaxUploadOffsetPollInterval = 5 * time.Second
+
+	// DefaultRetryAttempts is the max number of attempts of the SP requests of DefaultRetryPolicy.
+	DefaultRetryAttempts = 3
+	// DefaultRetryBaseDelay is the delay before the first retry of the exponential backoff by default.
+	DefaultRetryBaseDelay = 200 * time.Millisecond
+	// DefaultRetryMaxDelay is the max delay between the retries of the exponential backoff by default.
+	DefaultRetryMaxDelay = 5 * time.Second
+
+	// MaxResponseBodySize - the max size of the SP response body which will be read into memory and decoded.
+	MaxResponseBodySize = 64 * 1024 * 1024
+	// DecodeErrSnippetSize - the max size of the response body snippet carried by DecodeError.
+	DecodeErrSnippetSize = 256
+	// MaxErrResponseBodySize - the max size of the SP error response body which will be read into memory and decoded.
+	MaxErrResponseBodySize = 10 * 1024 * 1024
+	// MaxDecodeDepth - the max nesting depth of the elements in the SP response body.
+	MaxDecodeDepth = 64
+
+	// MainnetChainID - the chain id of the Greenfield mainnet, the testnet only helpers refuse to work on it.
+	MainnetChainID = "greenfield_1017-1"
+	// TestnetFaucetURL - the public faucet of the Greenfield testnet used by RequestFaucetFunds by default.
+	TestnetFaucetURL = "https://gnfd-testnet-faucet.bnbchain.org"
+
+	// ApprovalExpiryMargin - the number of blocks before the expired height within which the SP approval is treated as
+	// stale, so that the transaction carrying it has enough time to be included in a block.
+	ApprovalExpiryMargin = 10
+
+	// ApprovalValidBlocks - the number of blocks for which the SP approvals are assumed to be valid when planning the
+	// uploads, it is configured by each SP.
+	ApprovalValidBlocks = 100
+
+	// UploadPlanHashThroughput - the assumed bytes per second of computing the checksums of an object locally.
+	UploadPlanHashThroughput = 64 * 1024 * 1024
+	// UploadPlanConnectionThroughput - the assumed bytes per second of a single connection to SP.
+	UploadPlanConnectionThroughput = 8 * 1024 * 1024
+	// UploadPlanPartDuration - the target time of uploading a part, it bounds the work redone after a failure.
+	UploadPlanPartDuration = 30 * time.Second
+	// MaxUploadPlanConcurrency - the max concurrency recommended by the upload plans.
+	MaxUploadPlanConcurrency = 16
+
+	// MaxUnconfirmedTxs - the max number of the mempool txs inspected for the pending txs of an account, it is the
+	// page limit of the unconfirmed txs RPC.
+	MaxUnconfirmedTxs = 100
+
+	// DefaultGasAdjustment - the multiplier applied to the simulated gas by EstimateFee by default, it covers the gas
+	// varying between the simulation and the execution of the transaction.
+	DefaultGasAdjustment = 1.2
+
+	// DefaultCleanupBatchSize - the number of the deletions sent in a transaction by the permission cleanup by default.
+	DefaultCleanupBatchSize = 50
+
+	// MaxObjectPoliciesPerObject - the max number of the policies of an object inspected by the permission hygiene
+	// APIs, it is the page limit of the object policies served by SP.
+	MaxObjectPoliciesPerObject = 1000
 )
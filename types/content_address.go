@@ -0,0 +1,67 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ContentNaming indicates how the object names are derived from the content hashes.
+type ContentNaming int
+
+const (
+	// ContentNamingSHA256 names the objects by the hex-encoded sha256 of their payloads.
+	ContentNamingSHA256 ContentNaming = iota
+	// ContentNamingCID names the objects by the CIDv1 of their payloads, i.e. the base32 raw sha2-256 CIDs used by IPFS.
+	ContentNamingCID
+)
+
+// cidPrefix is the CIDv1 version, the raw codec, the sha2-256 multihash code and the digest length
+var cidPrefix = []byte{0x01, 0x55, 0x12, 0x20}
+
+// ErrContentCollision is returned when the object named by a content hash exists but holds different content.
+var ErrContentCollision = errors.New("the object named by the content hash holds different content")
+
+// String returns the name of the naming.
+func (n ContentNaming) String() string {
+	switch n {
+	case ContentNamingSHA256:
+		return "sha256"
+	case ContentNamingCID:
+		return "cid"
+	default:
+		return "unknown"
+	}
+}
+
+// ContentAddressedName derives the object name from the sha256 digest of the payload, the name is the digest in the
+// form of naming following prefix, e.g. "blobs/" + "e3b0c442...".
+func ContentAddressedName(prefix string, naming ContentNaming, digest []byte) (string, error) {
+	if len(digest) != sha256.Size {
+		return "", fmt.Errorf("the content digest should be %d bytes, got %d", sha256.Size, len(digest))
+	}
+	switch naming {
+	case ContentNamingSHA256:
+		return prefix + hex.EncodeToString(digest), nil
+	case ContentNamingCID:
+		encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(append(append([]byte{}, cidPrefix...), digest...))
+		// "b" is the multibase prefix of the lowercase base32
+		return prefix + "b" + strings.ToLower(encoded), nil
+	default:
+		return "", fmt.Errorf("invalid content naming: %d", naming)
+	}
+}
+
+// ComputeContentAddressedName reads the payload to the end and derives the object name from its sha256 digest, see
+// ContentAddressedName.
+func ComputeContentAddressedName(reader io.Reader, prefix string, naming ContentNaming) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	return ContentAddressedName(prefix, naming, hash.Sum(nil))
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentAddressedName(t *testing.T) {
+	name, err := ComputeContentAddressedName(strings.NewReader(""), "blobs/", ContentNamingSHA256)
+	require.NoError(t, err)
+	require.Equal(t, "blobs/e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", name)
+
+	name, err = ComputeContentAddressedName(strings.NewReader(""), "", ContentNamingCID)
+	require.NoError(t, err)
+	require.Equal(t, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku", name)
+
+	_, err = ContentAddressedName("", ContentNamingSHA256, []byte{1})
+	require.Error(t, err)
+	digest := sha256.Sum256(nil)
+	_, err = ContentAddressedName("", ContentNaming(9), digest[:])
+	require.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"mime"
+	"net/http"
+	"path"
+)
+
+// ContentSniffSize is the number of the leading bytes used to detect the content type, see http.DetectContentType.
+const ContentSniffSize = 512
+
+// DetectContentType - Detect the content type of the object by the extension of its name, or by sniffing the leading
+// bytes of its payload if the extension is unknown.
+//
+// - name: The object name or the file path.
+//
+// - head: The leading bytes of the payload, at most ContentSniffSize bytes are considered.
+//
+// - ret: The detected content type, ContentDefault is returned if it can not be detected.
+func DetectContentType(name string, head []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	if len(head) == 0 {
+		return ContentDefault
+	}
+	// http.DetectContentType falls back to ContentDefault for the unknown binaries
+	return http.DetectContentType(head)
+}
@@ -0,0 +1,16 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectContentType(t *testing.T) {
+	require.Equal(t, "application/json", DetectContentType("data/a.json", nil))
+	require.Equal(t, "image/png", DetectContentType("photos/a.PNG", nil))
+	require.Equal(t, "text/plain; charset=utf-8", DetectContentType("notes", []byte("hello world")))
+	require.Equal(t, "application/pdf", DetectContentType("scan", []byte("%PDF-1.7\n")))
+	require.Equal(t, ContentDefault, DetectContentType("blob", []byte{0x00, 0x01, 0x02}))
+	require.Equal(t, ContentDefault, DetectContentType("empty", nil))
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ListCursor records the progress of a long-running listing, it can be persisted and loaded to resume the listing
+// after the process restarts.
+//
+// The objects are listed in the lexicographic order of their names, the listing resumed from the cursor starts
+// right after the last handled object, so that none of the objects existing when the listing started is skipped.
+type ListCursor struct {
+	BucketName        string    `json:"bucket_name"`
+	Prefix            string    `json:"prefix"`
+	Delimiter         string    `json:"delimiter"`
+	ContinuationToken string    `json:"continuation_token"` // ContinuationToken indicates where to resume the listing.
+	SyncHeight        int64     `json:"sync_height"`        // SyncHeight is the block height when the listing started, the changes after it may be missed. The resumed pages are only listed from the SPs synced to it.
+	Listed            uint64    `json:"listed"`             // Listed indicates the number of the handled objects.
+	Done              bool      `json:"done"`               // Done indicates whether all the objects have been listed.
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// LoadListCursor - Load the cursor persisted at path by fileSystem.
+//
+// - fileSystem: The file system where the cursor is persisted.
+//
+// - path: The path of the cursor file.
+//
+// - ret1: The loaded cursor.
+//
+// - ret2: Return error wrapping os.ErrNotExist if the cursor file does not exist, otherwise return nil if loaded.
+func LoadListCursor(fileSystem FileSystem, path string) (*ListCursor, error) {
+	file, err := fileSystem.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	cursor := &ListCursor{}
+	if err = json.NewDecoder(file).Decode(cursor); err != nil {
+		return nil, fmt.Errorf("fail to decode list cursor %s: %w", path, err)
+	}
+	if cursor.BucketName == "" {
+		return nil, errors.New("invalid list cursor without bucket name")
+	}
+	return cursor, nil
+}
+
+// Save persists the cursor at path by fileSystem. The cursor is written to a temp file and renamed to path, so that
+// the persisted cursor is not corrupted if the process crashes when saving.
+func (c *ListCursor) Save(fileSystem FileSystem, path string) error {
+	c.UpdatedAt = time.Now()
+	content, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tempPath := path + TempFileSuffix
+	file, err := fileSystem.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermMode)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return fileSystem.Rename(tempPath, filepath.Clean(path))
+}
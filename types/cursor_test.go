@@ -0,0 +1,38 @@
+package types
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCursor(t *testing.T) {
+	for name, fileSystem := range map[string]FileSystem{
+		"os":     OSFileSystem{},
+		"memory": NewMemFileSystem(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cursor.json")
+			_, err := LoadListCursor(fileSystem, path)
+			require.True(t, errors.Is(err, os.ErrNotExist))
+
+			cursor := &ListCursor{BucketName: "bucket", Prefix: "a/", ContinuationToken: "YS9i", SyncHeight: 100, Listed: 1000}
+			require.NoError(t, cursor.Save(fileSystem, path))
+			cursor.Listed, cursor.Done = 1500, true
+			require.NoError(t, cursor.Save(fileSystem, path))
+
+			loaded, err := LoadListCursor(fileSystem, path)
+			require.NoError(t, err)
+			require.Equal(t, cursor.ContinuationToken, loaded.ContinuationToken)
+			require.Equal(t, uint64(1500), loaded.Listed)
+			require.True(t, loaded.Done)
+			require.True(t, cursor.UpdatedAt.Equal(loaded.UpdatedAt))
+
+			_, err = fileSystem.Stat(path + TempFileSuffix)
+			require.True(t, errors.Is(err, os.ErrNotExist))
+		})
+	}
+}
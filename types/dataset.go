@@ -0,0 +1,209 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DatasetManifestVersion is the version of the DatasetManifest format.
+const DatasetManifestVersion = 1
+
+// the domain separation prefixes of the Merkle tree nodes, so that a leaf can not be taken as an inner node
+const (
+	datasetLeafPrefix  = 0x00
+	datasetInnerPrefix = 0x01
+)
+
+// DatasetEntry is an object of the dataset.
+type DatasetEntry struct {
+	ObjectName string   `json:"object_name"`
+	Size       uint64   `json:"size"`
+	Checksums  []string `json:"checksums"` // Checksums are the HEX-encoded checksums of the object on chain, the primary checksum first.
+}
+
+// DatasetManifest pins a version of the dataset made of many objects, the Merkle root over the checksums of the
+// objects identifies the version by a single hash.
+type DatasetManifest struct {
+	Version    int            `json:"version"`
+	BucketName string         `json:"bucket_name"`
+	Root       string         `json:"root"`    // Root is the HEX-encoded Merkle root over the entries.
+	Entries    []DatasetEntry `json:"entries"` // Entries are sorted by the object names.
+}
+
+// DatasetProofStep is a sibling on the path from a leaf to the Merkle root.
+type DatasetProofStep struct {
+	Hash string `json:"hash"` // Hash is the HEX-encoded hash of the sibling.
+	Left bool   `json:"left"` // Left indicates whether the sibling is on the left side.
+}
+
+// DatasetVerifyResult is the result of re-checking the objects of a dataset against its manifest.
+type DatasetVerifyResult struct {
+	Root     string   // Root is the HEX-encoded Merkle root recomputed from the manifest.
+	Verified int      // Verified indicates the number of objects which are sealed with the checksums in the manifest.
+	Missing  []string // Missing are the objects of the manifest which do not exist or are not sealed.
+	Changed  []string // Changed are the objects whose size or checksums differ from the manifest.
+}
+
+// Valid reports whether all the objects of the manifest are intact.
+func (r *DatasetVerifyResult) Valid() bool {
+	return len(r.Missing) == 0 && len(r.Changed) == 0
+}
+
+// NewDatasetManifest - Build the manifest of the dataset, the entries are sorted by the object names and the Merkle
+// root is computed over them.
+//
+// - bucketName: The bucket of the objects.
+//
+// - entries: The objects of the dataset.
+//
+// - ret1: The manifest.
+//
+// - ret2: Return error when there is no entry, the object names are duplicated or the checksums are not HEX-encoded,
+// otherwise return nil.
+func NewDatasetManifest(bucketName string, entries []DatasetEntry) (*DatasetManifest, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("the dataset has no object")
+	}
+	sorted := append([]DatasetEntry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ObjectName < sorted[j].ObjectName })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].ObjectName == sorted[i-1].ObjectName {
+			return nil, fmt.Errorf("the object %s is duplicated in the dataset", sorted[i].ObjectName)
+		}
+	}
+	manifest := &DatasetManifest{Version: DatasetManifestVersion, BucketName: bucketName, Entries: sorted}
+	root, err := manifest.ComputeRoot()
+	if err != nil {
+		return nil, err
+	}
+	manifest.Root = hex.EncodeToString(root)
+	return manifest, nil
+}
+
+// ComputeRoot recomputes the Merkle root over the entries of the manifest.
+func (m *DatasetManifest) ComputeRoot() ([]byte, error) {
+	level, err := m.leaves()
+	if err != nil {
+		return nil, err
+	}
+	if len(level) == 0 {
+		return nil, errors.New("the dataset has no object")
+	}
+	for len(level) > 1 {
+		level = nextDatasetLevel(level)
+	}
+	return level[0], nil
+}
+
+// Proof returns the path proving the object is a member of the dataset, see VerifyDatasetProof.
+func (m *DatasetManifest) Proof(objectName string) ([]DatasetProofStep, error) {
+	level, err := m.leaves()
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, entry := range m.Entries {
+		if entry.ObjectName == objectName {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("the object %s is not in the dataset", objectName)
+	}
+	proof := make([]DatasetProofStep, 0)
+	for len(level) > 1 {
+		// the last node of an odd level is promoted without a sibling
+		if sibling := index ^ 1; sibling < len(level) {
+			proof = append(proof, DatasetProofStep{Hash: hex.EncodeToString(level[sibling]), Left: sibling < index})
+		}
+		level = nextDatasetLevel(level)
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyDatasetProof reports whether the entry is a member of the dataset identified by the HEX-encoded Merkle root.
+func VerifyDatasetProof(root string, entry DatasetEntry, proof []DatasetProofStep) (bool, error) {
+	expected, err := hex.DecodeString(root)
+	if err != nil {
+		return false, fmt.Errorf("invalid dataset root %s: %w", root, err)
+	}
+	hash, err := DatasetLeafHash(entry)
+	if err != nil {
+		return false, err
+	}
+	for _, step := range proof {
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil {
+			return false, fmt.Errorf("invalid proof hash %s: %w", step.Hash, err)
+		}
+		if step.Left {
+			hash = datasetInnerHash(sibling, hash)
+		} else {
+			hash = datasetInnerHash(hash, sibling)
+		}
+	}
+	return bytes.Equal(hash, expected), nil
+}
+
+// DatasetLeafHash returns the Merkle leaf of the entry, it commits to the object name, the size and the checksums.
+func DatasetLeafHash(entry DatasetEntry) ([]byte, error) {
+	h := sha256.New()
+	h.Write([]byte{datasetLeafPrefix})
+	// the variable-length fields are length-prefixed, so that the different entries can not encode the same bytes
+	writeUint := func(n uint64) {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], n)
+		h.Write(buf[:])
+	}
+	writeUint(uint64(len(entry.ObjectName)))
+	h.Write([]byte(entry.ObjectName))
+	writeUint(entry.Size)
+	writeUint(uint64(len(entry.Checksums)))
+	for _, checksum := range entry.Checksums {
+		decoded, err := hex.DecodeString(checksum)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checksum of the object %s: %w", entry.ObjectName, err)
+		}
+		writeUint(uint64(len(decoded)))
+		h.Write(decoded)
+	}
+	return h.Sum(nil), nil
+}
+
+func (m *DatasetManifest) leaves() ([][]byte, error) {
+	leaves := make([][]byte, 0, len(m.Entries))
+	for _, entry := range m.Entries {
+		leaf, err := DatasetLeafHash(entry)
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves, nil
+}
+
+func nextDatasetLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i+1 < len(level); i += 2 {
+		next = append(next, datasetInnerHash(level[i], level[i+1]))
+	}
+	if len(level)%2 == 1 {
+		next = append(next, level[len(level)-1])
+	}
+	return next
+}
+
+func datasetInnerHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{datasetInnerPrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetManifest(t *testing.T) {
+	entries := make([]DatasetEntry, 0)
+	for i := 6; i >= 0; i-- {
+		entries = append(entries, DatasetEntry{ObjectName: fmt.Sprintf("data/%d", i), Size: uint64(i), Checksums: []string{fmt.Sprintf("%02x", i)}})
+	}
+	manifest, err := NewDatasetManifest("bucket", entries)
+	require.NoError(t, err)
+	require.Equal(t, "data/0", manifest.Entries[0].ObjectName)
+
+	// the root does not depend on the order of the entries
+	reordered, err := NewDatasetManifest("bucket", manifest.Entries)
+	require.NoError(t, err)
+	require.Equal(t, manifest.Root, reordered.Root)
+
+	for _, entry := range manifest.Entries {
+		proof, err := manifest.Proof(entry.ObjectName)
+		require.NoError(t, err)
+		ok, err := VerifyDatasetProof(manifest.Root, entry, proof)
+		require.NoError(t, err)
+		require.True(t, ok, entry.ObjectName)
+
+		changed := entry
+		changed.Size++
+		ok, err = VerifyDatasetProof(manifest.Root, changed, proof)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	_, err = manifest.Proof("data/7")
+	require.Error(t, err)
+
+	// a changed entry changes the root
+	entries[0].Checksums = []string{"ff"}
+	changed, err := NewDatasetManifest("bucket", entries)
+	require.NoError(t, err)
+	require.NotEqual(t, manifest.Root, changed.Root)
+
+	_, err = NewDatasetManifest("bucket", append(entries, entries[0]))
+	require.Error(t, err)
+	_, err = NewDatasetManifest("bucket", nil)
+	require.Error(t, err)
+	_, err = NewDatasetManifest("bucket", []DatasetEntry{{ObjectName: "a", Checksums: []string{"zz"}}})
+	require.Error(t, err)
+}
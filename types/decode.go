@@ -0,0 +1,335 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrResponseBodyTooLarge indicates the SP response body exceeds the size limit.
+	ErrResponseBodyTooLarge = errors.New("response body exceeds the size limit")
+	// ErrEmptyBody indicates the SP response body has no content to decode.
+	ErrEmptyBody = errors.New("response body is empty")
+	// ErrUnexpectedContent indicates the SP response body carries content after the root element.
+	ErrUnexpectedContent = errors.New("unexpected content after the root element")
+	// ErrNestingTooDeep indicates the elements of the SP response body are nested deeper than MaxDecodeDepth.
+	ErrNestingTooDeep = errors.New("elements are nested too deep")
+	// ErrUnknownElements indicates the SP response body carries the elements which the target type does not declare,
+	// it is only returned by the strict XMLDecoder.
+	ErrUnknownElements = errors.New("unknown elements")
+)
+
+// XMLDecoder decodes the xml content of the SP responses, the zero value is the lenient decoder which ignores the
+// unknown elements, so that the newly added fields of SP responses do not break the old clients.
+type XMLDecoder struct {
+	// Strict rejects the content carrying unknown elements with ErrUnknownElements, e.g. in the tests which verify the
+	// response structs are in sync with SP.
+	Strict bool
+	// OnUnknownElements is called with the target type name and the paths of the unknown elements, e.g.
+	// "ListObjectsResult/Objects/NewField", when the lenient decoder ignores them. It is called before the content is
+	// decoded and should not block.
+	OnUnknownElements func(target string, paths []string)
+}
+
+// Decode decodes the xml content into v in the same way as DecodeXML, and handles the unknown elements as configured.
+func (d XMLDecoder) Decode(body []byte, v interface{}) error {
+	if err := checkXMLStructure(bytes.NewReader(body)); err != nil {
+		return newDecodeError(v, body, err)
+	}
+	if err := d.checkUnknownElements(bytes.NewReader(body), v); err != nil {
+		return newDecodeError(v, body, err)
+	}
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(v); err != nil {
+		return newDecodeError(v, body, err)
+	}
+	return nil
+}
+
+// DecodeStream decodes the xml content of r into v in the same way as DecodeXMLStream, and handles the unknown
+// elements as configured.
+func (d XMLDecoder) DecodeStream(r io.ReadSeeker, v interface{}) error {
+	return d.decodeStream(r, v, true)
+}
+
+// decodeStream decodes the xml content of r into v, the structure of the content is verified before decoding if
+// checkStructure is set, otherwise it should have been verified.
+func (d XMLDecoder) decodeStream(r io.ReadSeeker, v interface{}, checkStructure bool) error {
+	decode := func() error {
+		if checkStructure {
+			if _, err := r.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err := checkXMLStructure(r); err != nil {
+				return err
+			}
+		}
+		if d.needUnknownElements() {
+			if _, err := r.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err := d.checkUnknownElements(r, v); err != nil {
+				return err
+			}
+		}
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return xml.NewDecoder(r).Decode(v)
+	}
+	if err := decode(); err != nil {
+		return newDecodeError(v, readSnippet(r), err)
+	}
+	return nil
+}
+
+// DecodeBody reads the xml content from r and decodes it into v in the same way as DecodeXMLBodyWithSpill, and
+// handles the unknown elements as configured.
+func (d XMLDecoder) DecodeBody(r io.Reader, v interface{}, limit, spillThreshold int64, fileSystem FileSystem, spillDir string) error {
+	if spillThreshold <= 0 || spillThreshold >= limit {
+		body, err := ReadLimitedBody(r, limit)
+		if err != nil {
+			return err
+		}
+		return d.Decode(body, v)
+	}
+
+	// the structure is verified while the content is streamed into the spill buffer
+	spill := &spillBuffer{threshold: spillThreshold, fileSystem: fileSystem, dir: spillDir}
+	defer spill.close()
+	body := io.TeeReader(io.LimitReader(r, limit+1), spill)
+	structureErr := checkXMLStructure(body)
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		return err
+	}
+	if spill.size > limit {
+		return fmt.Errorf("%w: more than %d bytes", ErrResponseBodyTooLarge, limit)
+	}
+	if spill.file == nil {
+		return d.Decode(spill.buf, v)
+	}
+	if structureErr != nil {
+		return newDecodeError(v, readSnippet(spill.file), structureErr)
+	}
+	return d.decodeStream(spill.file, v, false)
+}
+
+// spillBuffer keeps the content written in memory until it exceeds threshold, then moves it to a temp file created
+// by fileSystem in dir and writes the rest there, so that the large content is not held in memory.
+type spillBuffer struct {
+	threshold  int64
+	fileSystem FileSystem
+	dir        string
+	buf        []byte
+	file       File
+	size       int64
+}
+
+func (b *spillBuffer) Write(p []byte) (int, error) {
+	if b.file == nil && b.size+int64(len(p)) <= b.threshold {
+		b.buf = append(b.buf, p...)
+		b.size += int64(len(p))
+		return len(p), nil
+	}
+	if b.file == nil {
+		file, err := b.fileSystem.CreateTemp(b.dir, "gnfd-response-*")
+		if err != nil {
+			return 0, err
+		}
+		b.file = file
+		if _, err = file.Write(b.buf); err != nil {
+			return 0, err
+		}
+		b.buf = nil
+	}
+	n, err := b.file.Write(p)
+	b.size += int64(n)
+	return n, err
+}
+
+// close removes the temp file if the content is spilled.
+func (b *spillBuffer) close() {
+	if b.file != nil {
+		b.file.Close()
+		b.fileSystem.Remove(b.file.Name())
+	}
+}
+
+// readSnippet returns the leading content of r for the DecodeError.
+func readSnippet(r io.ReadSeeker) []byte {
+	snippet := make([]byte, DecodeErrSnippetSize)
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+	n, _ := io.ReadFull(r, snippet)
+	return snippet[:n]
+}
+
+func (d XMLDecoder) needUnknownElements() bool {
+	return d.Strict || d.OnUnknownElements != nil
+}
+
+// checkUnknownElements finds the unknown elements of the content for v if they are handled by the decoder, it
+// returns error wrapping ErrUnknownElements if any is found by the strict decoder.
+func (d XMLDecoder) checkUnknownElements(r io.Reader, v interface{}) error {
+	if !d.needUnknownElements() {
+		return nil
+	}
+	paths, err := UnknownXMLElements(r, v)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+	if d.Strict {
+		return fmt.Errorf("%w: %s", ErrUnknownElements, strings.Join(paths, ", "))
+	}
+	d.OnUnknownElements(fmt.Sprintf("%T", v), paths)
+	return nil
+}
+
+// DecodeError is returned when the SP response body can not be decoded into the target type.
+type DecodeError struct {
+	// Target is the type name of the decoding target.
+	Target string
+	// Snippet is the truncated leading content of the response body, which helps to locate the problem.
+	Snippet string
+	// Err is the underlying decoding error.
+	Err error
+}
+
+// Error returns the error msg
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("fail to decode response into %s: %v (body: %q)", e.Target, e.Err, e.Snippet)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ReadLimitedBody reads the whole content of r, return ErrResponseBodyTooLarge if the content exceeds limit bytes.
+func ReadLimitedBody(r io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseBodyTooLarge, limit)
+	}
+	return body, nil
+}
+
+// DecodeXMLBody reads the xml content from r within the size limit and decodes it into v.
+// The returned error is a *DecodeError if the content is malformed.
+func DecodeXMLBody(r io.Reader, v interface{}, limit int64) error {
+	body, err := ReadLimitedBody(r, limit)
+	if err != nil {
+		return err
+	}
+	return DecodeXML(body, v)
+}
+
+// DecodeXMLBodyWithSpill reads the xml content from r within the size limit and decodes it into v like
+// DecodeXMLBody, but the content larger than spillThreshold is streamed into a temp file created by fileSystem in
+// spillDir while its structure is verified, and then decoded from the file, so that the large response is not held
+// in memory.
+//
+// A spillThreshold not greater than 0 disables the spilling, the default temp directory of fileSystem is used if
+// spillDir is empty.
+func DecodeXMLBodyWithSpill(r io.Reader, v interface{}, limit, spillThreshold int64, fileSystem FileSystem, spillDir string) error {
+	return XMLDecoder{}.DecodeBody(r, v, limit, spillThreshold, fileSystem, spillDir)
+}
+
+// DecodeXML decodes the xml content into v, the returned error is a *DecodeError if the content is malformed.
+//
+// The content must consist of exactly one root element nested no deeper than MaxDecodeDepth, the name of the root
+// element is verified when v declares it by the XMLName field. Unknown child elements are ignored so that the
+// newly added fields of SP responses do not break the old clients, use XMLDecoder to find or reject them.
+func DecodeXML(body []byte, v interface{}) error {
+	return XMLDecoder{}.Decode(body, v)
+}
+
+// DecodeXMLStream decodes the xml content of r into v without loading the whole content into memory, the content
+// is verified in the same way as DecodeXML. The returned error is a *DecodeError if the content is malformed.
+func DecodeXMLStream(r io.ReadSeeker, v interface{}) error {
+	return XMLDecoder{}.DecodeStream(r, v)
+}
+
+// DecodeJSONBody reads the json content from r within the size limit and decodes it into v.
+// The returned error is a *DecodeError if the content is malformed.
+func DecodeJSONBody(r io.Reader, v interface{}, limit int64) error {
+	body, err := ReadLimitedBody(r, limit)
+	if err != nil {
+		return err
+	}
+	return DecodeJSON(body, v)
+}
+
+// DecodeJSON decodes the json content into v, the returned error is a *DecodeError if the content is malformed.
+// The content must consist of exactly one json value.
+func DecodeJSON(body []byte, v interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return newDecodeError(v, body, ErrEmptyBody)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(v); err != nil {
+		return newDecodeError(v, body, err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return newDecodeError(v, body, ErrUnexpectedContent)
+	}
+	return nil
+}
+
+// checkXMLStructure verifies the content has exactly one well-formed root element within the nesting limit.
+func checkXMLStructure(r io.Reader) error {
+	decoder := xml.NewDecoder(r)
+	depth, roots := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				if roots > 0 {
+					return ErrUnexpectedContent
+				}
+				roots++
+			}
+			depth++
+			if depth > MaxDecodeDepth {
+				return ErrNestingTooDeep
+			}
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return ErrUnexpectedContent
+			}
+		}
+	}
+	if roots == 0 {
+		return ErrEmptyBody
+	}
+	return nil
+}
+
+func newDecodeError(v interface{}, body []byte, err error) *DecodeError {
+	snippet := body
+	if len(snippet) > DecodeErrSnippetSize {
+		snippet = snippet[:DecodeErrSnippetSize]
+	}
+	return &DecodeError{
+		Target:  fmt.Sprintf("%T", v),
+		Snippet: string(snippet),
+		Err:     err,
+	}
+}
@@ -0,0 +1,232 @@
+package types
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeXMLBody(t *testing.T) {
+	longBody := "<" + strings.Repeat("a", 2*DecodeErrSnippetSize)
+	nested := strings.Repeat("<a>", MaxDecodeDepth+1) + strings.Repeat("</a>", MaxDecodeDepth+1)
+
+	cases := []struct {
+		name    string
+		body    string
+		limit   int64
+		valid   bool
+		wantErr error
+	}{
+		{name: "valid", body: "<GetBucketReadQuotaResult><BucketName>b</BucketName></GetBucketReadQuotaResult>", limit: 1024, valid: true},
+		{name: "valid with unknown element", body: "<GetBucketReadQuotaResult><Unknown>1</Unknown></GetBucketReadQuotaResult>", limit: 1024, valid: true},
+		{name: "exactly at limit", body: "<GetBucketReadQuotaResult></GetBucketReadQuotaResult>", limit: 53, valid: true},
+		{name: "over limit", body: "<GetBucketReadQuotaResult></GetBucketReadQuotaResult>", limit: 52, wantErr: ErrResponseBodyTooLarge},
+		{name: "empty", body: "  ", limit: 1024, wantErr: ErrEmptyBody},
+		{name: "wrong root element", body: "<Error></Error>", limit: 1024},
+		{name: "trailing element", body: "<GetBucketReadQuotaResult></GetBucketReadQuotaResult><a/>", limit: 1024, wantErr: ErrUnexpectedContent},
+		{name: "trailing text", body: "<GetBucketReadQuotaResult></GetBucketReadQuotaResult>abc", limit: 1024, wantErr: ErrUnexpectedContent},
+		{name: "too deep", body: nested, limit: int64(len(nested)), wantErr: ErrNestingTooDeep},
+		{name: "malformed", body: longBody, limit: int64(len(longBody))},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info := QuotaRecordInfo{}
+			err := DecodeXMLBody(strings.NewReader(c.body), &info, c.limit)
+			if c.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if c.wantErr != nil {
+				require.True(t, errors.Is(err, c.wantErr), "unexpected error: %v", err)
+			}
+			if errors.Is(err, ErrResponseBodyTooLarge) {
+				return
+			}
+			var decodeErr *DecodeError
+			require.True(t, errors.As(err, &decodeErr), "unexpected error type: %T", err)
+			require.LessOrEqual(t, len(decodeErr.Snippet), DecodeErrSnippetSize)
+			require.True(t, strings.HasPrefix(c.body, decodeErr.Snippet))
+			require.Equal(t, "*types.QuotaRecordInfo", decodeErr.Target)
+		})
+	}
+}
+
+func TestDecodeXMLBodyWithSpill(t *testing.T) {
+	body := "<GetBucketReadQuotaResult><NextStartTimestampUs>7</NextStartTimestampUs>" +
+		strings.Repeat("<ReadRecord><ObjectName>o</ObjectName></ReadRecord>", 64) + "</GetBucketReadQuotaResult>"
+	dir := t.TempDir()
+
+	for _, threshold := range []int64{0, 16, int64(len(body)), int64(len(body)) * 2} {
+		info := QuotaRecordInfo{}
+		require.NoError(t, DecodeXMLBodyWithSpill(strings.NewReader(body), &info, int64(len(body)), threshold, OSFileSystem{}, dir))
+		require.Equal(t, int64(7), info.NextStartTimestampUs)
+		require.Len(t, info.ReadRecords, 64)
+	}
+
+	err := DecodeXMLBodyWithSpill(strings.NewReader(body), &QuotaRecordInfo{}, int64(len(body))-1, 16, OSFileSystem{}, dir)
+	require.True(t, errors.Is(err, ErrResponseBodyTooLarge), "unexpected error: %v", err)
+
+	malformed := body + strings.Repeat("<a>", 512)
+	err = DecodeXMLBodyWithSpill(strings.NewReader(malformed), &QuotaRecordInfo{}, int64(len(malformed)), 16, OSFileSystem{}, dir)
+	var decodeErr *DecodeError
+	require.True(t, errors.As(err, &decodeErr), "unexpected error: %v", err)
+	require.Equal(t, malformed[:DecodeErrSnippetSize], decodeErr.Snippet)
+
+	// the temp files are removed after decoding
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// only the content beyond the threshold is spilled
+	for _, threshold := range []int64{16, int64(len(body))} {
+		fileSystem := &tempCountingFileSystem{MemFileSystem: NewMemFileSystem()}
+		info := QuotaRecordInfo{}
+		require.NoError(t, DecodeXMLBodyWithSpill(strings.NewReader(body), &info, int64(len(body))+1, threshold, fileSystem, "spill"))
+		require.Len(t, info.ReadRecords, 64)
+		require.Equal(t, threshold < int64(len(body)), fileSystem.temps == 1)
+		names, err := fileSystem.ReadDirNames("spill")
+		require.NoError(t, err)
+		require.Empty(t, names)
+	}
+}
+
+// tempCountingFileSystem is the in-memory file system counting the temp files created.
+type tempCountingFileSystem struct {
+	*MemFileSystem
+	temps int
+}
+
+func (f *tempCountingFileSystem) CreateTemp(dir, pattern string) (File, error) {
+	f.temps++
+	return f.MemFileSystem.CreateTemp(dir, pattern)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	v := map[string]string{}
+	require.NoError(t, DecodeJSON([]byte(`{"a":"b"}`), &v))
+	require.Equal(t, "b", v["a"])
+
+	err := DecodeJSONBody(strings.NewReader(`{"a":"b"}`), &v, 4)
+	require.True(t, errors.Is(err, ErrResponseBodyTooLarge))
+
+	var decodeErr *DecodeError
+	require.True(t, errors.As(DecodeJSON([]byte(`{"a":"b"}{}`), &v), &decodeErr))
+	require.True(t, errors.Is(decodeErr, ErrUnexpectedContent))
+	require.True(t, errors.As(DecodeJSON(nil, &v), &decodeErr))
+	require.True(t, errors.Is(decodeErr, ErrEmptyBody))
+	require.True(t, errors.As(DecodeJSON([]byte(`{"a":`+strings.Repeat("1", 1024)), &v), &decodeErr))
+	require.Len(t, decodeErr.Snippet, DecodeErrSnippetSize)
+}
+
+func FuzzDecodeXML(f *testing.F) {
+	f.Add([]byte("<GetBucketReadQuotaResult><BucketName>b</BucketName><ReadRecords><ReadRecord></ReadRecord></ReadRecords></GetBucketReadQuotaResult>"))
+	f.Add([]byte("<Error><Code>NoSuchBucket</Code><Message>m</Message></Error>"))
+	f.Add([]byte("<a><b></a>"))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		checkDecodeXML(t, body, &QuotaRecordInfo{})
+		checkDecodeXML(t, body, &ListObjectsResult{})
+		checkDecodeXML(t, body, &ErrResponse{})
+	})
+}
+
+func FuzzDecodeXMLBody(f *testing.F) {
+	f.Add([]byte("<QueryResumeOffset><Offset>1</Offset></QueryResumeOffset>"), int64(64))
+	f.Add([]byte("<QueryResumeOffset></QueryResumeOffset>"), int64(1))
+	f.Fuzz(func(t *testing.T, body []byte, limit int64) {
+		if limit < 0 {
+			limit = -limit
+		}
+		err := DecodeXMLBody(bytes.NewReader(body), &UploadOffset{}, limit)
+		if int64(len(body)) > limit {
+			require.True(t, errors.Is(err, ErrResponseBodyTooLarge), "unexpected error: %v", err)
+			return
+		}
+		require.False(t, errors.Is(err, ErrResponseBodyTooLarge))
+		checkDecodeErr(t, body, err)
+	})
+}
+
+func FuzzDecodeJSON(f *testing.F) {
+	f.Add([]byte(`{"address":"0x1","refundable":true}`))
+	f.Add([]byte(`[1, 2`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		checkDecodeErr(t, body, DecodeJSON(body, &PaymentAccount{}))
+	})
+}
+
+// checkDecodeXML verifies that decoding the arbitrary input never panics and only returns typed decode errors.
+func checkDecodeXML(t *testing.T, body []byte, v interface{}) {
+	err := DecodeXML(body, v)
+	checkDecodeErr(t, body, err)
+	checkDecodeErr(t, body, XMLDecoder{Strict: true}.Decode(body, v))
+	if err == nil {
+		// a successfully decoded body must be accepted by the standard decoder as well
+		require.NoError(t, xml.Unmarshal(body, v))
+	}
+}
+
+func checkDecodeErr(t *testing.T, body []byte, err error) {
+	if err == nil {
+		return
+	}
+	var decodeErr *DecodeError
+	require.True(t, errors.As(err, &decodeErr), "unexpected error type: %T", err)
+	require.LessOrEqual(t, len(decodeErr.Snippet), DecodeErrSnippetSize)
+	require.True(t, bytes.HasPrefix(body, []byte(decodeErr.Snippet)))
+}
+
+type xmlFieldsItem struct {
+	Name string `xml:"Name"`
+	Size int64
+}
+
+type xmlFieldsResult struct {
+	XMLName xml.Name        `xml:"Result"`
+	Owner   string          `xml:"owner,attr"`
+	Items   []xmlFieldsItem `xml:"Item"`
+	Tags    []string        `xml:"Tags>Tag"`
+	Raw     *struct {
+		Content string `xml:",innerxml"`
+	} `xml:"Raw"`
+}
+
+func TestXMLDecoderUnknownElements(t *testing.T) {
+	known := `<Result owner="o"><Item><Name>a</Name><Size>1</Size></Item><Tags><Tag>t</Tag></Tags><Raw><X/></Raw></Result>`
+	unknown := `<Result><Item><Name>a</Name><Color>red</Color></Item><Item><Color>blue</Color></Item><Extra><Y/></Extra></Result>`
+
+	paths, err := UnknownXMLElements(strings.NewReader(known), &xmlFieldsResult{})
+	require.NoError(t, err)
+	require.Empty(t, paths)
+	paths, err = UnknownXMLElements(strings.NewReader(unknown), &xmlFieldsResult{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Result/Item/Color", "Result/Extra"}, paths)
+
+	// the lenient decoder reports the unknown elements and decodes the known ones
+	var reported []string
+	lenient := XMLDecoder{OnUnknownElements: func(target string, paths []string) {
+		require.Equal(t, "*types.xmlFieldsResult", target)
+		reported = paths
+	}}
+	result := xmlFieldsResult{}
+	require.NoError(t, lenient.Decode([]byte(unknown), &result))
+	require.Equal(t, []string{"Result/Item/Color", "Result/Extra"}, reported)
+	require.Len(t, result.Items, 2)
+
+	// the strict decoder rejects them
+	strict := XMLDecoder{Strict: true}
+	require.NoError(t, strict.Decode([]byte(known), &xmlFieldsResult{}))
+	err = strict.Decode([]byte(unknown), &xmlFieldsResult{})
+	var decodeErr *DecodeError
+	require.True(t, errors.As(err, &decodeErr), "unexpected error: %v", err)
+	require.True(t, errors.Is(err, ErrUnknownElements))
+
+	// the spilled content is checked in the same way
+	err = strict.DecodeBody(strings.NewReader(unknown), &xmlFieldsResult{}, int64(len(unknown)), 16, NewMemFileSystem(), "")
+	require.True(t, errors.Is(err, ErrUnknownElements), "unexpected error: %v", err)
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// ErrDeletionDenied is wrapped by the errors returned by the guards made by ProtectPrefixes and ProtectTag.
+var ErrDeletionDenied = errors.New("deletion denied")
+
+// DeletionTarget describes a bucket or an object about to be deleted.
+type DeletionTarget struct {
+	BucketName string
+	ObjectName string                   // ObjectName is empty if the bucket is deleted.
+	BucketInfo *storageTypes.BucketInfo // BucketInfo is the on-chain info of the deleted bucket, it is nil if the object is deleted or the bucket is not found.
+	ObjectInfo *storageTypes.ObjectInfo // ObjectInfo is the on-chain info of the deleted object, it is nil if the bucket is deleted or the object is not found.
+}
+
+// DeletionRequest describes a tx deleting buckets or objects, a batched tx deletes more than one target.
+type DeletionRequest struct {
+	Operator string
+	Targets  []DeletionTarget
+}
+
+// DeletionGuard is invoked with the details before a tx deleting buckets or objects is broadcast, and the tx is not
+// broadcast if it returns error. It allows the applications to enforce the approval workflows or to protect the
+// resources from the programmatic deletion.
+type DeletionGuard func(ctx context.Context, req DeletionRequest) error
+
+// ProtectPrefixes - Create a DeletionGuard refusing to delete the objects whose names begin with any of the prefixes.
+func ProtectPrefixes(prefixes ...string) DeletionGuard {
+	return func(_ context.Context, req DeletionRequest) error {
+		for _, target := range req.Targets {
+			if target.ObjectName == "" {
+				continue
+			}
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(target.ObjectName, prefix) {
+					return fmt.Errorf("%w: object %s/%s is protected by prefix %s", ErrDeletionDenied,
+						target.BucketName, target.ObjectName, prefix)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// ProtectTag - Create a DeletionGuard refusing to delete the buckets and objects tagged with the key and value.
+func ProtectTag(key, value string) DeletionGuard {
+	return func(_ context.Context, req DeletionRequest) error {
+		for _, target := range req.Targets {
+			var tags *storageTypes.ResourceTags
+			if target.ObjectInfo != nil {
+				tags = target.ObjectInfo.Tags
+			} else if target.BucketInfo != nil {
+				tags = target.BucketInfo.Tags
+			}
+			if tags == nil {
+				continue
+			}
+			for _, tag := range tags.Tags {
+				if tag.Key == key && tag.Value == value {
+					return fmt.Errorf("%w: %s is protected by tag %s=%s", ErrDeletionDenied,
+						strings.TrimSuffix(target.BucketName+"/"+target.ObjectName, "/"), key, value)
+				}
+			}
+		}
+		return nil
+	}
+}
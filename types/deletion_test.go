@@ -0,0 +1,28 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletionGuards(t *testing.T) {
+	ctx := context.Background()
+	objects := DeletionRequest{Targets: []DeletionTarget{
+		{BucketName: "bucket", ObjectName: "tmp/a"},
+		{BucketName: "bucket", ObjectName: "backup/b"},
+	}}
+	require.ErrorIs(t, ProtectPrefixes("backup/")(ctx, objects), ErrDeletionDenied)
+	require.NoError(t, ProtectPrefixes("archive/")(ctx, objects))
+
+	tags := &storageTypes.ResourceTags{Tags: []storageTypes.ResourceTags_Tag{{Key: "retain", Value: "true"}}}
+	bucket := DeletionRequest{Targets: []DeletionTarget{
+		{BucketName: "bucket", BucketInfo: &storageTypes.BucketInfo{BucketName: "bucket", Tags: tags}},
+	}}
+	require.ErrorIs(t, ProtectTag("retain", "true")(ctx, bucket), ErrDeletionDenied)
+	require.NoError(t, ProtectTag("retain", "false")(ctx, bucket))
+	// the bucket is not protected by the prefixes of the objects
+	require.NoError(t, ProtectPrefixes("")(ctx, bucket))
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EventDiscontinueBucket is the event emitted when the SP discontinues all the objects of a bucket.
+	EventDiscontinueBucket = "greenfield.storage.EventDiscontinueBucket"
+	// EventDiscontinueObject is the event emitted when the SP discontinues the objects.
+	EventDiscontinueObject = "greenfield.storage.EventDiscontinueObject"
+)
+
+// DiscontinueWarning warns that the objects are discontinued by the SP, they will be deleted by the garbage collection
+// at the deadline.
+type DiscontinueWarning struct {
+	Height     int64     `json:"height"` // Height is the block height of the discontinue event.
+	BucketName string    `json:"bucket_name"`
+	ObjectIDs  []string  `json:"object_ids,omitempty"` // ObjectIDs are the ids of the discontinued objects, all the objects of the bucket are discontinued if it is empty.
+	Reason     string    `json:"reason"`
+	Deadline   time.Time `json:"deadline"` // Deadline is when the objects will be deleted.
+}
+
+// WholeBucket reports whether all the objects of the bucket are discontinued.
+func (w DiscontinueWarning) WholeBucket() bool {
+	return len(w.ObjectIDs) == 0
+}
+
+// ParseDiscontinueWarning - Parse the discontinue event into the warning.
+//
+// - event: The EventDiscontinueBucket or EventDiscontinueObject event.
+//
+// - ret1: The discontinue warning.
+//
+// - ret2: Return error if the event is not a discontinue event or its attributes are malformed, otherwise return nil.
+func ParseDiscontinueWarning(event ChainEvent) (DiscontinueWarning, error) {
+	if event.Type != EventDiscontinueBucket && event.Type != EventDiscontinueObject {
+		return DiscontinueWarning{}, fmt.Errorf("%s is not a discontinue event", event.Type)
+	}
+	warning := DiscontinueWarning{Height: event.Height}
+	if err := unmarshalEventAttribute(event, "bucket_name", &warning.BucketName); err != nil {
+		return DiscontinueWarning{}, err
+	}
+	if err := unmarshalEventAttribute(event, "reason", &warning.Reason); err != nil {
+		return DiscontinueWarning{}, err
+	}
+	deleteAt, err := parseEventInt(event, "delete_at")
+	if err != nil {
+		return DiscontinueWarning{}, err
+	}
+	warning.Deadline = time.Unix(deleteAt, 0)
+	if event.Type == EventDiscontinueObject {
+		if err = unmarshalEventAttribute(event, "object_ids", &warning.ObjectIDs); err != nil {
+			return DiscontinueWarning{}, err
+		}
+		if len(warning.ObjectIDs) == 0 {
+			return DiscontinueWarning{}, fmt.Errorf("no object id in the %s event", event.Type)
+		}
+	}
+	return warning, nil
+}
+
+// unmarshalEventAttribute decodes the json encoded attribute of the typed event.
+func unmarshalEventAttribute(event ChainEvent, key string, v interface{}) error {
+	value, ok := event.Attributes[key]
+	if !ok {
+		return fmt.Errorf("no attribute %s in the %s event", key, event.Type)
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return fmt.Errorf("fail to decode attribute %s of the %s event: %w", key, event.Type, err)
+	}
+	return nil
+}
+
+// parseEventInt parses the integer attribute of the typed event, the 64-bit integers are encoded as json strings.
+func parseEventInt(event ChainEvent, key string) (int64, error) {
+	value, ok := event.Attributes[key]
+	if !ok {
+		return 0, fmt.Errorf("no attribute %s in the %s event", key, event.Type)
+	}
+	n, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("fail to decode attribute %s of the %s event: %w", key, event.Type, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiscontinueWarning(t *testing.T) {
+	warning, err := ParseDiscontinueWarning(ChainEvent{
+		Height: 10,
+		Type:   EventDiscontinueObject,
+		Attributes: map[string]string{
+			"bucket_name": `"photos"`,
+			"object_ids":  `["1","2"]`,
+			"reason":      `"illegal content"`,
+			"delete_at":   `"1700000000"`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, DiscontinueWarning{
+		Height:     10,
+		BucketName: "photos",
+		ObjectIDs:  []string{"1", "2"},
+		Reason:     "illegal content",
+		Deadline:   time.Unix(1700000000, 0),
+	}, warning)
+	require.False(t, warning.WholeBucket())
+
+	warning, err = ParseDiscontinueWarning(ChainEvent{
+		Type: EventDiscontinueBucket,
+		Attributes: map[string]string{
+			"bucket_name": `"photos"`,
+			"bucket_id":   `"7"`,
+			"reason":      `"sp exit"`,
+			"delete_at":   `1700000000`,
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, warning.WholeBucket())
+
+	_, err = ParseDiscontinueWarning(ChainEvent{Type: EventDiscontinueObject, Attributes: map[string]string{
+		"bucket_name": `"photos"`, "reason": `""`, "delete_at": `"1"`,
+	}})
+	require.Error(t, err)
+	_, err = ParseDiscontinueWarning(ChainEvent{Type: "greenfield.storage.EventCreateBucket"})
+	require.Error(t, err)
+}
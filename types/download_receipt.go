@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// DownloadReceipt records a response of the object payload served by SP, the applications can meter the downstream
+// usage by the receipts and reconcile them against the read quota records of SP.
+//
+// The downloads split into parts, e.g. the parallel and the resumable downloads, report a receipt for each part.
+type DownloadReceipt struct {
+	BucketName    string
+	ObjectName    string
+	ObjectID      string    // ObjectID is the id of the object on chain, it is empty if the object fails to be queried.
+	Range         string    // Range is the range requested, it is empty if the whole object is requested.
+	ContentLength int64     // ContentLength is the number of bytes served by SP, it is -1 if unknown.
+	Bytes         int64     // Bytes is the number of bytes read by the caller.
+	Complete      bool      // Complete indicates the payload is read to the end rather than closed early.
+	Requester     string    // Requester is the HEX-encoded address of the account signing the request, it is empty if the client has no account.
+	Endpoint      string    // Endpoint is the host of the SP serving the payload.
+	CDNEndpoint   string    // CDNEndpoint is the host of the CDN or the custom domain forwarding the request, it is empty if the request is sent to SP directly.
+	RequestID     string    // RequestID is the id of the request generated by SP.
+	CompletedAt   time.Time // CompletedAt is the time the payload is consumed.
+}
+
+// DownloadAuditHook is invoked with the receipt once the payload of a GetObject response is consumed, i.e. read to the
+// end or closed. It is called synchronously by the goroutine reading the payload, so it should return quickly.
+type DownloadAuditHook func(receipt DownloadReceipt)
@@ -0,0 +1,19 @@
+package types
+
+// DynamicFeePolicy decides how the fees of the txs are set by BroadcastTx from the min gas price of the chain, so that
+// the txs are accepted when the min gas price of the nodes rises. The fees are bumped when the txs are rejected for
+// the insufficient fees.
+type DynamicFeePolicy struct {
+	Multiplier     float64 // Multiplier is applied to the min gas price returned by the simulation, it should not be less than 1.
+	BumpMultiplier float64 // BumpMultiplier is applied to the gas price when retrying the tx rejected for the insufficient fee, it should be more than 1.
+	MaxBumps       int     // MaxBumps is the max number of the retries with the bumped fees.
+}
+
+// DefaultDynamicFeePolicy returns the DynamicFeePolicy paying the min gas price and bumping the fee up to 3 times.
+func DefaultDynamicFeePolicy() DynamicFeePolicy {
+	return DynamicFeePolicy{
+		Multiplier:     1,
+		BumpMultiplier: 1.5,
+		MaxBumps:       3,
+	}
+}
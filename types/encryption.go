@@ -0,0 +1,277 @@
+package types
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// EncryptionAlgorithm identifies the client-side encryption scheme: the data key is wrapped for each recipient by
+	// X25519 and the content is sealed by AES-256-GCM in chunks.
+	EncryptionAlgorithm = "X25519-AES-256-GCM"
+	// KeyEnvelopeVersion is the version of the KeyEnvelope format.
+	KeyEnvelopeVersion = 1
+	// KeyEnvelopeSuffix is appended to the object name to name the object storing its KeyEnvelope.
+	KeyEnvelopeSuffix = ".gnfd-keys"
+	// DefaultEncryptionChunkSize is the plaintext size of each sealed chunk of the encrypted objects.
+	DefaultEncryptionChunkSize = 64 * 1024
+
+	dataKeySize = 32
+)
+
+// ErrNotRecipient indicates the data key of the encrypted object is not wrapped for the account.
+var ErrNotRecipient = errors.New("the account is not a recipient of the encrypted object")
+
+// KeyDirectory resolves the X25519 public keys which the data keys are wrapped for, e.g. published by the group
+// members when they join.
+type KeyDirectory interface {
+	EncryptionPublicKey(ctx context.Context, address string) (*ecdh.PublicKey, error)
+}
+
+// StaticKeyDirectory is the KeyDirectory of the public keys keyed by the HEX-encoded account addresses.
+type StaticKeyDirectory map[string]*ecdh.PublicKey
+
+// EncryptionPublicKey returns the public key of the address.
+func (d StaticKeyDirectory) EncryptionPublicKey(_ context.Context, address string) (*ecdh.PublicKey, error) {
+	for addr, key := range d {
+		if strings.EqualFold(addr, address) {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no encryption public key of %s", address)
+}
+
+// NewEncryptionKey - Generate the X25519 key pair of an account to receive the data keys of the encrypted objects,
+// the public key should be published to the KeyDirectory.
+//
+// - ret1: The private key, it should be kept as secret as the account key.
+//
+// - ret2: Return error when the random source fails, otherwise return nil.
+func NewEncryptionKey() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// WrappedKey is the data key sealed for a recipient.
+type WrappedKey struct {
+	EphemeralPublicKey []byte `json:"ephemeral_public_key"`
+	Nonce              []byte `json:"nonce"`
+	Ciphertext         []byte `json:"ciphertext"`
+}
+
+// KeyEnvelope is the metadata of an encrypted object, it holds the data key wrapped for each recipient and is stored
+// as the object named by the object name with KeyEnvelopeSuffix.
+type KeyEnvelope struct {
+	Version     int                   `json:"version"`
+	Algorithm   string                `json:"algorithm"`
+	ChunkSize   int                   `json:"chunk_size"`
+	GroupOwner  string                `json:"group_owner,omitempty"` // GroupOwner and GroupName identify the group whose members are the recipients.
+	GroupName   string                `json:"group_name,omitempty"`
+	WrappedKeys map[string]WrappedKey `json:"wrapped_keys"` // WrappedKeys is keyed by the lower-cased HEX-encoded addresses of the recipients.
+}
+
+// NewDataKey generates a random data key to encrypt an object.
+func NewDataKey() ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	return dataKey, nil
+}
+
+// WrapDataKey seals the data key for the recipient by the X25519 shared secret with an ephemeral key.
+func WrapDataKey(dataKey []byte, recipient *ecdh.PublicKey) (WrappedKey, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return WrappedKey{}, err
+	}
+	aead, err := keyWrapAEAD(ephemeral, recipient, ephemeral.PublicKey(), recipient)
+	if err != nil {
+		return WrappedKey{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return WrappedKey{}, err
+	}
+	return WrappedKey{
+		EphemeralPublicKey: ephemeral.PublicKey().Bytes(),
+		Nonce:              nonce,
+		Ciphertext:         aead.Seal(nil, nonce, dataKey, nil),
+	}, nil
+}
+
+// UnwrapDataKey opens the data key sealed for the owner of the private key.
+func UnwrapDataKey(wrapped WrappedKey, key *ecdh.PrivateKey) ([]byte, error) {
+	ephemeral, err := ecdh.X25519().NewPublicKey(wrapped.EphemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := keyWrapAEAD(key, ephemeral, ephemeral, key.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce of the wrapped key")
+	}
+	return aead.Open(nil, wrapped.Nonce, wrapped.Ciphertext, nil)
+}
+
+// keyWrapAEAD derives the key wrapping cipher from the shared secret bound to both public keys.
+func keyWrapAEAD(private *ecdh.PrivateKey, peer, ephemeral, recipient *ecdh.PublicKey) (cipher.AEAD, error) {
+	shared, err := private.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write([]byte(EncryptionAlgorithm))
+	h.Write(shared)
+	h.Write(ephemeral.Bytes())
+	h.Write(recipient.Bytes())
+	return newGCM(h.Sum(nil))
+}
+
+// Wrap seals the data key for the recipient at the address, replacing the wrapped key of the address.
+func (e *KeyEnvelope) Wrap(dataKey []byte, address string, recipient *ecdh.PublicKey) error {
+	wrapped, err := WrapDataKey(dataKey, recipient)
+	if err != nil {
+		return err
+	}
+	if e.WrappedKeys == nil {
+		e.WrappedKeys = make(map[string]WrappedKey)
+	}
+	e.WrappedKeys[strings.ToLower(address)] = wrapped
+	return nil
+}
+
+// DataKey opens the data key wrapped for the address by its private key, it returns ErrNotRecipient if the key is
+// not wrapped for the address.
+func (e *KeyEnvelope) DataKey(address string, key *ecdh.PrivateKey) ([]byte, error) {
+	if e.Version != KeyEnvelopeVersion || e.Algorithm != EncryptionAlgorithm {
+		return nil, fmt.Errorf("unsupported key envelope version %d of algorithm %s", e.Version, e.Algorithm)
+	}
+	wrapped, ok := e.WrappedKeys[strings.ToLower(address)]
+	if !ok {
+		return nil, ErrNotRecipient
+	}
+	return UnwrapDataKey(wrapped, key)
+}
+
+// EncryptedSize returns the size of the content of size bytes once encrypted in chunks of chunkSize bytes.
+func EncryptedSize(size int64, chunkSize int) int64 {
+	chunks := (size + int64(chunkSize) - 1) / int64(chunkSize)
+	// the empty content is sealed in an empty final chunk
+	if chunks == 0 {
+		chunks = 1
+	}
+	return size + chunks*int64(aesGCMOverhead)
+}
+
+const aesGCMOverhead = 16
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce numbers the chunks and marks the final one, so that the reordered or truncated content fails to open.
+func chunkNonce(index uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[4:], index)
+	if final {
+		nonce[0] = 1
+	}
+	return nonce
+}
+
+// chunkCipher seals or opens the content chunk by chunk.
+type chunkCipher struct {
+	source  *bufio.Reader
+	aead    cipher.AEAD
+	encrypt bool
+	index   uint64
+	buf     []byte
+	pending []byte
+	done    bool
+}
+
+// NewEncryptReader returns the reader of the content of r encrypted by the data key in chunks of chunkSize bytes,
+// the content is encrypted in the same way each time, so that the readers can be recreated to read it again.
+func NewEncryptReader(dataKey []byte, r io.Reader, chunkSize int) (io.Reader, error) {
+	return newChunkCipher(dataKey, r, chunkSize, true)
+}
+
+// NewDecryptReader returns the reader of the content of r decrypted by the data key, chunkSize is the plaintext size
+// of the chunks the content is encrypted in. Reading fails if the content is modified, reordered or truncated.
+func NewDecryptReader(dataKey []byte, r io.Reader, chunkSize int) (io.Reader, error) {
+	return newChunkCipher(dataKey, r, chunkSize, false)
+}
+
+func newChunkCipher(dataKey []byte, r io.Reader, chunkSize int, encrypt bool) (*chunkCipher, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", chunkSize)
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	inSize := chunkSize
+	if !encrypt {
+		inSize += aead.Overhead()
+	}
+	return &chunkCipher{source: bufio.NewReader(r), aead: aead, encrypt: encrypt, buf: make([]byte, inSize)}, nil
+}
+
+func (c *chunkCipher) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		if err := c.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// next seals or opens the next chunk, the chunk is final if no content follows it.
+func (c *chunkCipher) next() error {
+	n, err := io.ReadFull(c.source, c.buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	final := err != nil
+	if !final {
+		if _, peekErr := c.source.Peek(1); peekErr == io.EOF {
+			final = true
+		} else if peekErr != nil {
+			return peekErr
+		}
+	}
+	nonce := chunkNonce(c.index, final)
+	c.index++
+	c.done = final
+	if c.encrypt {
+		c.pending = c.aead.Seal(c.pending[:0], nonce, c.buf[:n], nil)
+		return nil
+	}
+	plain, openErr := c.aead.Open(c.pending[:0], nonce, c.buf[:n], nil)
+	if openErr != nil {
+		return fmt.Errorf("fail to decrypt chunk %d, the content is modified or truncated: %w", c.index-1, openErr)
+	}
+	c.pending = plain
+	return nil
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptReader(t *testing.T) {
+	dataKey, err := NewDataKey()
+	require.NoError(t, err)
+	for _, size := range []int{0, 1, 8, 9, 24, 25} {
+		plain := bytes.Repeat([]byte{'a'}, size)
+		encrypted, err := NewEncryptReader(dataKey, bytes.NewReader(plain), 8)
+		require.NoError(t, err)
+		ciphertext, err := io.ReadAll(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, EncryptedSize(int64(size), 8), int64(len(ciphertext)), size)
+
+		// the content is encrypted in the same way each time
+		again, err := NewEncryptReader(dataKey, iotest.OneByteReader(bytes.NewReader(plain)), 8)
+		require.NoError(t, err)
+		againCiphertext, err := io.ReadAll(again)
+		require.NoError(t, err)
+		require.Equal(t, ciphertext, againCiphertext)
+
+		decrypted, err := NewDecryptReader(dataKey, bytes.NewReader(ciphertext), 8)
+		require.NoError(t, err)
+		content, err := io.ReadAll(decrypted)
+		require.NoError(t, err)
+		require.Equal(t, plain, content)
+	}
+
+	// the truncated content fails to decrypt
+	encrypted, err := NewEncryptReader(dataKey, bytes.NewReader(bytes.Repeat([]byte{'a'}, 20)), 8)
+	require.NoError(t, err)
+	ciphertext, err := io.ReadAll(encrypted)
+	require.NoError(t, err)
+	decrypted, err := NewDecryptReader(dataKey, bytes.NewReader(ciphertext[:2*(8+16)]), 8)
+	require.NoError(t, err)
+	_, err = io.ReadAll(decrypted)
+	require.Error(t, err)
+}
+
+func TestKeyEnvelope(t *testing.T) {
+	alice, err := NewEncryptionKey()
+	require.NoError(t, err)
+	bob, err := NewEncryptionKey()
+	require.NoError(t, err)
+	directory := StaticKeyDirectory{"0xAlice": alice.PublicKey()}
+	aliceKey, err := directory.EncryptionPublicKey(context.Background(), "0xalice")
+	require.NoError(t, err)
+	_, err = directory.EncryptionPublicKey(context.Background(), "0xbob")
+	require.Error(t, err)
+
+	dataKey, err := NewDataKey()
+	require.NoError(t, err)
+	envelope := &KeyEnvelope{Version: KeyEnvelopeVersion, Algorithm: EncryptionAlgorithm, ChunkSize: DefaultEncryptionChunkSize}
+	require.NoError(t, envelope.Wrap(dataKey, "0xAlice", aliceKey))
+
+	opened, err := envelope.DataKey("0xALICE", alice)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, opened)
+	_, err = envelope.DataKey("0xbob", bob)
+	require.ErrorIs(t, err, ErrNotRecipient)
+
+	// the key wrapped for alice can not be opened by bob
+	envelope.WrappedKeys["0xbob"] = envelope.WrappedKeys["0xalice"]
+	_, err = envelope.DataKey("0xbob", bob)
+	require.Error(t, err)
+}
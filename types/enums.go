@@ -0,0 +1,82 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// The enums of the chain implement String by their full names, e.g. OBJECT_STATUS_SEALED. The Parse functions below
+// accept the full names and the short names without the type prefix in any case, e.g. "sealed" and "Sealed", so
+// that the user input and the config files do not need to compare the magic strings.
+
+// ParseActionType - Parse the permission action type, e.g. "ACTION_GET_OBJECT" or "get_object".
+func ParseActionType(s string) (permTypes.ActionType, error) {
+	v, err := parseEnum("action type", s, "ACTION_", permTypes.ActionType_value)
+	return permTypes.ActionType(v), err
+}
+
+// ParseEffect - Parse the permission effect, e.g. "EFFECT_ALLOW" or "allow".
+func ParseEffect(s string) (permTypes.Effect, error) {
+	v, err := parseEnum("effect", s, "EFFECT_", permTypes.Effect_value)
+	return permTypes.Effect(v), err
+}
+
+// ParseVisibilityType - Parse the visibility type of buckets and objects, e.g. "VISIBILITY_TYPE_PUBLIC_READ" or "public_read".
+func ParseVisibilityType(s string) (storageTypes.VisibilityType, error) {
+	v, err := parseEnum("visibility type", s, "VISIBILITY_TYPE_", storageTypes.VisibilityType_value)
+	return storageTypes.VisibilityType(v), err
+}
+
+// ParseObjectStatus - Parse the object status, e.g. "OBJECT_STATUS_SEALED" or "sealed".
+func ParseObjectStatus(s string) (storageTypes.ObjectStatus, error) {
+	v, err := parseEnum("object status", s, "OBJECT_STATUS_", storageTypes.ObjectStatus_value)
+	return storageTypes.ObjectStatus(v), err
+}
+
+// ParseBucketStatus - Parse the bucket status, e.g. "BUCKET_STATUS_CREATED" or "created".
+func ParseBucketStatus(s string) (storageTypes.BucketStatus, error) {
+	v, err := parseEnum("bucket status", s, "BUCKET_STATUS_", storageTypes.BucketStatus_value)
+	return storageTypes.BucketStatus(v), err
+}
+
+// ParseRedundancyType - Parse the redundancy type of objects, e.g. "REDUNDANCY_EC_TYPE" or "ec".
+func ParseRedundancyType(s string) (storageTypes.RedundancyType, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(name, "REDUNDANCY_") {
+		name = "REDUNDANCY_" + name + "_TYPE"
+	}
+	v, err := parseEnum("redundancy type", name, "", storageTypes.RedundancyType_value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid redundancy type: %q", s)
+	}
+	return storageTypes.RedundancyType(v), nil
+}
+
+// ParseSPStatus - Parse the storage provider status, e.g. "STATUS_IN_SERVICE" or "in_service".
+func ParseSPStatus(s string) (spTypes.Status, error) {
+	v, err := parseEnum("SP status", s, "STATUS_", spTypes.Status_value)
+	return spTypes.Status(v), err
+}
+
+// ShortName returns the enum name without the type prefix in lower case, e.g. "sealed" for OBJECT_STATUS_SEALED.
+// It is the reverse of the Parse functions.
+func ShortName(enum fmt.Stringer, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(enum.String(), prefix))
+}
+
+// parseEnum looks up the enum value by its full name or the short name without prefix, case-insensitively
+func parseEnum(kind, s, prefix string, values map[string]int32) (int32, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	name = strings.ReplaceAll(name, "-", "_")
+	if v, ok := values[name]; ok {
+		return v, nil
+	}
+	if v, ok := values[prefix+name]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("invalid %s: %q", kind, s)
+}
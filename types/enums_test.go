@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+func TestParseEnums(t *testing.T) {
+	status, err := ParseObjectStatus("OBJECT_STATUS_SEALED")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, status)
+	status, err = ParseObjectStatus(" sealed ")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, status)
+	require.Equal(t, "sealed", ShortName(status, "OBJECT_STATUS_"))
+
+	action, err := ParseActionType("get-object")
+	require.NoError(t, err)
+	require.Equal(t, permTypes.ACTION_GET_OBJECT, action)
+
+	visibility, err := ParseVisibilityType("Public_Read")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.VISIBILITY_TYPE_PUBLIC_READ, visibility)
+
+	spStatus, err := ParseSPStatus("in_service")
+	require.NoError(t, err)
+	require.Equal(t, spTypes.STATUS_IN_SERVICE, spStatus)
+
+	redundancy, err := ParseRedundancyType("ec")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.REDUNDANCY_EC_TYPE, redundancy)
+
+	_, err = ParseObjectStatus("unknown")
+	require.Error(t, err)
+	_, err = ParseBucketStatus("")
+	require.Error(t, err)
+}
@@ -1,12 +1,11 @@
 package types
 
 import (
-	"bytes"
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 )
 
 const unknownErr = "unknown error"
@@ -14,8 +13,87 @@ const unknownErr = "unknown error"
 var (
 	ErrorDefaultAccountNotExist = errors.New("Default account of client is not exist ")
 	ErrorProposalIDNotFound     = errors.New("Proposal ID not found ")
+	// ErrApprovalExpired indicates the SP approval has expired or will expire before the transaction is included.
+	ErrApprovalExpired = errors.New("SP approval is expired")
+	// ErrInvalidPartSize indicates the part size of the resumable upload or download violates the chain params.
+	ErrInvalidPartSize = errors.New("invalid part size")
+	// ErrObjectRejected indicates the object is removed from chain before sealed, e.g. the SP rejects to seal it.
+	ErrObjectRejected = errors.New("object is rejected before sealed")
+	// ErrReadOnlyClient indicates the tx or the SP request modifying data is sent by a client pinned to a block height.
+	ErrReadOnlyClient = errors.New("the client pinned to a block height is read-only")
+	// ErrFaucetRateLimited indicates the faucet refuses the request since the address or the client is funded recently.
+	ErrFaucetRateLimited = errors.New("the faucet request is rate limited")
 )
 
+// StaleMetaError is returned when the meta service of SP lags behind the chain more than the configured blocks,
+// the list result served by it may miss the latest changes.
+type StaleMetaError struct {
+	Endpoint     string // Endpoint indicates the endpoint of the SP which serves the stale response.
+	SyncedHeight int64  // SyncedHeight indicates the block height which the meta service of SP has synced to.
+	LatestHeight int64  // LatestHeight indicates the latest block height of the chain.
+	// WriteHeight indicates the height of the last write awaited by the ReadYourWrites consistency, LatestHeight is not
+	// queried in that case.
+	WriteHeight int64
+	// MinHeight indicates the height awaited by the AtLeastHeight consistency, LatestHeight is not queried in that case.
+	MinHeight int64
+}
+
+// Error returns the error msg
+func (e *StaleMetaError) Error() string {
+	if e.WriteHeight > 0 {
+		return fmt.Sprintf("the meta service of SP %s has not synced the last write: synced height %d, write height %d",
+			e.Endpoint, e.SyncedHeight, e.WriteHeight)
+	}
+	if e.MinHeight > 0 {
+		return fmt.Sprintf("the meta service of SP %s has not synced to the min height: synced height %d, min height %d",
+			e.Endpoint, e.SyncedHeight, e.MinHeight)
+	}
+	return fmt.Sprintf("the meta service of SP %s is stale: synced height %d, latest height %d",
+		e.Endpoint, e.SyncedHeight, e.LatestHeight)
+}
+
+// IntegrityHashSegment is the Segment of ChecksumAckError when the integrity hash of the whole object mismatches.
+const IntegrityHashSegment = -1
+
+// ChecksumAckError is returned when the checksums acknowledged by SP for the uploaded segments differ from the ones
+// computed locally, the upload would fail to seal.
+type ChecksumAckError struct {
+	ObjectName   string
+	Segment      int    // Segment indicates the index of the mismatched segment, or IntegrityHashSegment.
+	Expected     string // Expected is the HEX-encoded checksum computed locally.
+	Acknowledged string // Acknowledged is the HEX-encoded checksum acknowledged by SP.
+	Detail       string
+}
+
+// Error returns the error msg
+func (e *ChecksumAckError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("the checksum acknowledged by SP for object %s mismatches from segment %d: %s", e.ObjectName, e.Segment, e.Detail)
+	}
+	if e.Segment == IntegrityHashSegment {
+		return fmt.Sprintf("the integrity hash acknowledged by SP for object %s mismatches: expected %s, acknowledged %s",
+			e.ObjectName, e.Expected, e.Acknowledged)
+	}
+	return fmt.Sprintf("the checksum acknowledged by SP for segment %d of object %s mismatches: expected %s, acknowledged %s",
+		e.Segment, e.ObjectName, e.Expected, e.Acknowledged)
+}
+
+// IntegrityError is returned by the reader of GetObject with VerifyIntegrity when the downloaded payload does not
+// match the checksum of the primary SP on chain, i.e. the SP serves corrupted or tampered data.
+type IntegrityError struct {
+	ObjectName string
+	Segments   int    // Segments indicates the number of the segments downloaded.
+	Size       int64  // Size indicates the number of the bytes downloaded.
+	Expected   string // Expected is the HEX-encoded checksum of the primary SP on chain.
+	Computed   string // Computed is the HEX-encoded checksum computed from the downloaded segments.
+}
+
+// Error returns the error msg
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("the payload of object %s downloaded in %d segments of %d bytes mismatches the checksum on chain: "+
+		"expected %s, computed %s", e.ObjectName, e.Segments, e.Size, e.Expected, e.Computed)
+}
+
 // ErrResponse define the information of the error response
 type ErrResponse struct {
 	XMLName    xml.Name `xml:"Error"`
@@ -30,35 +108,38 @@ func (r ErrResponse) Error() string {
 		r.StatusCode, r.Code, r.Message)
 }
 
-// ConstructErrResponse  checks the response is an error response
+// ConstructErrResponse  checks the response is an error response, the returned error is a *SPError which unwraps
+// to the ErrResponse.
+//
+// Breaking change: the error was an ErrResponse value before, so the direct type assertion err.(ErrResponse) no
+// longer matches. Use errors.As with an ErrResponse or a *SPError target, or AsSPError, which match the wrapped
+// errors as well.
 func ConstructErrResponse(r *http.Response, bucketName, objectName string) error {
+	if r == nil {
+		return &SPError{Code: unknownErr, Message: "Response is empty "}
+	}
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
+	return newSPError(decodeErrResponse(r, bucketName, objectName), r)
+}
 
-	if r == nil {
-		return ErrResponse{
-			StatusCode: r.StatusCode,
-			Code:       unknownErr,
-			Message:    "Response is empty ",
-		}
-	}
-
+// decodeErrResponse decodes the error response, the well-known errors are inferred by the status code if the body is
+// not a valid xml error.
+func decodeErrResponse(r *http.Response, bucketName, objectName string) ErrResponse {
 	errResp := ErrResponse{}
 	errResp.StatusCode = r.StatusCode
 
-	// read err body of max 10M size
-	const maxBodySize = 10 * 1024 * 1024
-	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	body, err := ReadLimitedBody(r.Body, MaxErrResponseBodySize)
 	if err != nil {
 		return ErrResponse{
 			StatusCode: r.StatusCode,
-			Code:       "InternalError",
+			Code:       SPErrCodeInternalError,
 			Message:    err.Error(),
 		}
 	}
 	// decode the xml content from response body
-	decodeErr := xml.NewDecoder(bytes.NewReader(body)).Decode(&errResp)
+	decodeErr := DecodeXML(body, &errResp)
 	if decodeErr != nil {
 		switch r.StatusCode {
 		case http.StatusNotFound:
@@ -66,13 +147,13 @@ func ConstructErrResponse(r *http.Response, bucketName, objectName string) error
 				if objectName == "" {
 					errResp = ErrResponse{
 						StatusCode: r.StatusCode,
-						Code:       "NoSuchBucket",
+						Code:       SPErrCodeNoSuchBucket,
 						Message:    "The specified bucket does not exist.",
 					}
 				} else {
 					errResp = ErrResponse{
 						StatusCode: r.StatusCode,
-						Code:       "NoSuchObject",
+						Code:       SPErrCodeNoSuchObject,
 						Message:    "The specified object does not exist.",
 					}
 				}
@@ -80,16 +161,18 @@ func ConstructErrResponse(r *http.Response, bucketName, objectName string) error
 		case http.StatusForbidden:
 			errResp = ErrResponse{
 				StatusCode: r.StatusCode,
-				Code:       "AccessDenied",
+				Code:       SPErrCodeAccessDenied,
 				Message:    "no permission to access the resource",
 			}
 		default:
-			errBody := bytes.TrimSpace(body)
+			// the body is not a valid xml error, carry its leading content as the message
 			msg := unknownErr
-			if len(errBody) > 0 {
-				msg = string(errBody)
+			var decodeError *DecodeError
+			if errors.As(decodeErr, &decodeError) {
+				if snippet := strings.TrimSpace(decodeError.Snippet); snippet != "" {
+					msg = snippet
+				}
 			}
-			fmt.Println("default error msg :", msg)
 			errResp = ErrResponse{
 				StatusCode: r.StatusCode,
 				Code:       unknownErr,
@@ -105,7 +188,7 @@ func ConstructErrResponse(r *http.Response, bucketName, objectName string) error
 func ToInvalidArgumentResp(message string) error {
 	return ErrResponse{
 		StatusCode: http.StatusBadRequest,
-		Code:       "InvalidArgument",
+		Code:       SPErrCodeInvalidArgument,
 		Message:    message,
 	}
 }
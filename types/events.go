@@ -0,0 +1,136 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultWatchedEventTypes are the bucket and group events watched by default, they are the proto names of the typed
+// events emitted by the storage module.
+var DefaultWatchedEventTypes = []string{
+	"greenfield.storage.EventCreateBucket",
+	"greenfield.storage.EventDeleteBucket",
+	"greenfield.storage.EventUpdateBucketInfo",
+	EventDiscontinueBucket,
+	"greenfield.storage.EventCreateGroup",
+	"greenfield.storage.EventDeleteGroup",
+	"greenfield.storage.EventUpdateGroupMember",
+	"greenfield.storage.EventLeaveGroup",
+}
+
+// ChainEvent is an event emitted by a tx on chain. The Height, TxIndex and EventIndex identify the event, the sinks can
+// deduplicate the events redelivered after a failure by them.
+type ChainEvent struct {
+	Height     int64             `json:"height"`
+	TxIndex    int               `json:"tx_index"`
+	EventIndex int               `json:"event_index"`
+	Type       string            `json:"type"`
+	Attributes map[string]string `json:"attributes"` // Attributes are the json encoded fields of the typed event.
+}
+
+// EventSink receives the exported chain events, e.g. a Kafka or NATS producer, or a webhook.
+type EventSink interface {
+	// Publish delivers the events of a block, the events are redelivered if it returns error.
+	Publish(ctx context.Context, events []ChainEvent) error
+}
+
+// EventOffsetStore persists the height of the last block whose events are delivered to the sink.
+type EventOffsetStore interface {
+	// LoadOffset returns the stored height, or 0 if nothing is stored.
+	LoadOffset(ctx context.Context) (int64, error)
+	// SaveOffset stores the height.
+	SaveOffset(ctx context.Context, height int64) error
+}
+
+// FileEventOffsetStore implements EventOffsetStore by a file of FileSystem.
+type FileEventOffsetStore struct {
+	FileSystem FileSystem
+	Path       string
+}
+
+// NewFileEventOffsetStore - Create an offset store persisting the offset at path by fileSystem.
+func NewFileEventOffsetStore(fileSystem FileSystem, path string) *FileEventOffsetStore {
+	return &FileEventOffsetStore{FileSystem: fileSystem, Path: path}
+}
+
+// LoadOffset returns the height stored in the file, or 0 if the file does not exist.
+func (s *FileEventOffsetStore) LoadOffset(_ context.Context) (int64, error) {
+	file, err := s.FileSystem.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
+
+// SaveOffset stores the height into a temp file and renames it to the offset file, so that the stored offset is not
+// corrupted if the process crashes when saving.
+func (s *FileEventOffsetStore) SaveOffset(_ context.Context, height int64) error {
+	tempPath := s.Path + TempFileSuffix
+	file, err := s.FileSystem.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermMode)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write([]byte(strconv.FormatInt(height, 10))); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return s.FileSystem.Rename(tempPath, filepath.Clean(s.Path))
+}
+
+// WebhookEventSink implements EventSink by posting the events of a block as a json array to a webhook.
+type WebhookEventSink struct {
+	URL        string
+	HTTPClient *http.Client // HTTPClient sends the requests, http.DefaultClient is used if it is nil.
+}
+
+// NewWebhookEventSink - Create a sink posting the events to the webhook url.
+func NewWebhookEventSink(url string) *WebhookEventSink {
+	return &WebhookEventSink{URL: url}
+}
+
+// Publish posts the events to the webhook, the events are treated as delivered only if it responds with 2xx.
+func (s *WebhookEventSink) Publish(ctx context.Context, events []ChainEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(HTTPHeaderContentType, "application/json")
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, DecodeErrSnippetSize))
+		return fmt.Errorf("webhook responds with status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
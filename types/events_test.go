@@ -0,0 +1,57 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileEventOffsetStore(t *testing.T) {
+	for name, fileSystem := range map[string]FileSystem{
+		"os":     OSFileSystem{},
+		"memory": NewMemFileSystem(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := NewFileEventOffsetStore(fileSystem, filepath.Join(t.TempDir(), "events.offset"))
+			offset, err := store.LoadOffset(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, int64(0), offset)
+
+			require.NoError(t, store.SaveOffset(context.Background(), 100))
+			require.NoError(t, store.SaveOffset(context.Background(), 42))
+			offset, err = store.LoadOffset(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, int64(42), offset)
+		})
+	}
+}
+
+func TestWebhookEventSink(t *testing.T) {
+	var received []ChainEvent
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	events := []ChainEvent{{
+		Height:     10,
+		TxIndex:    1,
+		EventIndex: 2,
+		Type:       "greenfield.storage.EventCreateBucket",
+		Attributes: map[string]string{"bucket_name": `"test-bucket"`},
+	}}
+	sink := NewWebhookEventSink(server.URL)
+	require.NoError(t, sink.Publish(context.Background(), events))
+	require.Equal(t, events, received)
+
+	status = http.StatusServiceUnavailable
+	require.Error(t, sink.Publish(context.Background(), events))
+}
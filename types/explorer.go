@@ -0,0 +1,49 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+)
+
+// ErrNoExplorer is returned when no block explorer is known for the network.
+var ErrNoExplorer = errors.New("no block explorer is known for the network")
+
+// TxURL formats the URL of the transaction page.
+func (t ExplorerTemplates) TxURL(txHash string) (string, error) {
+	return formatExplorerURL(t.Tx, txHash)
+}
+
+// BucketURL formats the URL of the bucket page by the bucket id.
+func (t ExplorerTemplates) BucketURL(bucketID math.Uint) (string, error) {
+	return formatExplorerURL(t.Bucket, resourceIDHex(bucketID))
+}
+
+// ObjectURL formats the URL of the object page by the object id.
+func (t ExplorerTemplates) ObjectURL(objectID math.Uint) (string, error) {
+	return formatExplorerURL(t.Object, resourceIDHex(objectID))
+}
+
+// AddressURL formats the URL of the account page.
+func (t ExplorerTemplates) AddressURL(address string) (string, error) {
+	return formatExplorerURL(t.Address, address)
+}
+
+func formatExplorerURL(template, value string) (string, error) {
+	if template == "" {
+		return "", ErrNoExplorer
+	}
+	if value == "" {
+		return "", errors.New("the explorer resource should not be empty")
+	}
+	return fmt.Sprintf(template, value), nil
+}
+
+// resourceIDHex formats the id of a bucket or an object as the 0x-prefixed 32 bytes hex used by the explorers
+func resourceIDHex(id math.Uint) string {
+	if id.IsNil() {
+		return ""
+	}
+	return fmt.Sprintf("0x%064x", id.BigInt())
+}
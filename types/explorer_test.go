@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplorerTemplates(t *testing.T) {
+	preset, err := LookupPreset(PresetTestnet)
+	require.NoError(t, err)
+
+	url, err := preset.Explorer.TxURL("0xabc")
+	require.NoError(t, err)
+	require.Equal(t, "https://testnet.greenfieldscan.com/tx/0xabc", url)
+	url, err = preset.Explorer.BucketURL(math.NewUint(2699))
+	require.NoError(t, err)
+	require.Equal(t, "https://testnet.greenfieldscan.com/bucket/0x0000000000000000000000000000000000000000000000000000000000000a8b", url)
+	_, err = preset.Explorer.ObjectURL(math.Uint{})
+	require.Error(t, err)
+
+	localnet, err := LookupPreset(PresetLocalnet)
+	require.NoError(t, err)
+	_, err = localnet.Explorer.AddressURL("0x1")
+	require.ErrorIs(t, err, ErrNoExplorer)
+}
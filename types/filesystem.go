@@ -0,0 +1,347 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// File is the file handle returned by FileSystem, *os.File implements it.
+type File interface {
+	io.Reader
+	io.Writer
+	io.Seeker
+	io.Closer
+	Name() string
+	Stat() (os.FileInfo, error)
+	Truncate(size int64) error
+}
+
+// FileSystem isolates the file operations of the client, such as uploading from and downloading to local files,
+// the resumable download temp files and the spilled responses.
+//
+// OSFileSystem is used by default. The environments without a writable file system, e.g. the serverless functions,
+// can use MemFileSystem or their own implementation instead.
+type FileSystem interface {
+	Open(name string) (File, error)
+	OpenFile(name string, flag int, perm os.FileMode) (File, error)
+	Stat(name string) (os.FileInfo, error)
+	Rename(oldPath, newPath string) error
+	Remove(name string) error
+	CreateTemp(dir, pattern string) (File, error)
+}
+
+// DirMaker is implemented by the file systems with the directories, the parent directories of the downloaded files
+// are created by it. The file systems not implementing it, e.g. MemFileSystem, create the files at any path.
+type DirMaker interface {
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+// DefaultFileSystem returns the FileSystem used by the client if none is configured.
+func DefaultFileSystem() FileSystem {
+	return OSFileSystem{}
+}
+
+// OSFileSystem implements FileSystem by the os package.
+type OSFileSystem struct{}
+
+// Open opens the named file for reading.
+func (OSFileSystem) Open(name string) (File, error) {
+	return os.Open(name)
+}
+
+// OpenFile opens the named file with the specified flag and perm.
+func (OSFileSystem) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+// Stat returns the FileInfo of the named file.
+func (OSFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// Rename renames oldPath to newPath.
+func (OSFileSystem) Rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+// Remove removes the named file.
+func (OSFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// CreateTemp creates a new temporary file in the directory dir.
+func (OSFileSystem) CreateTemp(dir, pattern string) (File, error) {
+	return os.CreateTemp(dir, pattern)
+}
+
+// MkdirAll creates the directory path along with any necessary parents.
+func (OSFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// ReadDirNames returns the names of the files in the directory dir.
+func (OSFileSystem) ReadDirNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// MemFileSystem implements FileSystem in memory, it keeps the files in a flat namespace without directories.
+// The zero value is an empty file system ready to use.
+type MemFileSystem struct {
+	mu      sync.Mutex
+	files   map[string]*memFileData
+	tempSeq int
+}
+
+type memFileData struct {
+	content []byte
+	perm    os.FileMode
+	modTime time.Time
+}
+
+// NewMemFileSystem - Create an empty in-memory file system.
+func NewMemFileSystem() *MemFileSystem {
+	return &MemFileSystem{files: make(map[string]*memFileData)}
+}
+
+// Open opens the named file for reading.
+func (m *MemFileSystem) Open(name string) (File, error) {
+	return m.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// OpenFile opens the named file with the specified flag and perm.
+func (m *MemFileSystem) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.files == nil {
+		m.files = make(map[string]*memFileData)
+	}
+	data, ok := m.files[name]
+	switch {
+	case ok && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	case !ok && flag&os.O_CREATE == 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case !ok:
+		data = &memFileData{perm: perm, modTime: time.Now()}
+		m.files[name] = data
+	}
+	if flag&os.O_TRUNC != 0 && flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		data.content = nil
+	}
+	return &memFile{fs: m, name: name, data: data, flag: flag}, nil
+}
+
+// Stat returns the FileInfo of the named file.
+func (m *MemFileSystem) Stat(name string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return data.info(name), nil
+}
+
+// Rename renames oldPath to newPath, newPath is replaced if it exists.
+func (m *MemFileSystem) Rename(oldPath, newPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[oldPath]
+	if !ok {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrNotExist}
+	}
+	delete(m.files, oldPath)
+	m.files[newPath] = data
+	return nil
+}
+
+// Remove removes the named file.
+func (m *MemFileSystem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+// CreateTemp creates a new temporary file, the last "*" in pattern is replaced by a sequence number.
+func (m *MemFileSystem) CreateTemp(dir, pattern string) (File, error) {
+	m.mu.Lock()
+	m.tempSeq++
+	seq := strconv.Itoa(m.tempSeq)
+	m.mu.Unlock()
+
+	name := pattern + seq
+	for i := len(pattern) - 1; i >= 0; i-- {
+		if pattern[i] == '*' {
+			name = pattern[:i] + seq + pattern[i+1:]
+			break
+		}
+	}
+	return m.OpenFile(path.Join(dir, name), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+}
+
+// ReadDirNames returns the names of the files whose paths are directly under dir.
+func (m *MemFileSystem) ReadDirNames(dir string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dir = path.Clean(dir)
+	names := make([]string, 0)
+	for name := range m.files {
+		if path.Dir(name) == dir {
+			names = append(names, path.Base(name))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (d *memFileData) info(name string) os.FileInfo {
+	return memFileInfo{name: path.Base(name), size: int64(len(d.content)), mode: d.perm, modTime: d.modTime}
+}
+
+type memFile struct {
+	fs     *MemFileSystem
+	name   string
+	data   *memFileData
+	flag   int
+	offset int64
+	closed bool
+}
+
+var errFileClosed = errors.New("file already closed")
+
+func (f *memFile) Name() string {
+	return f.name
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return 0, errFileClosed
+	}
+	if f.flag&os.O_WRONLY != 0 {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrPermission}
+	}
+	if f.offset >= int64(len(f.data.content)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data.content[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return 0, errFileClosed
+	}
+	if f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrPermission}
+	}
+	if f.flag&os.O_APPEND != 0 {
+		f.offset = int64(len(f.data.content))
+	}
+	end := f.offset + int64(len(p))
+	if end > int64(len(f.data.content)) {
+		grown := make([]byte, end)
+		copy(grown, f.data.content)
+		f.data.content = grown
+	}
+	copy(f.data.content[f.offset:], p)
+	f.offset = end
+	f.data.modTime = time.Now()
+	return len(p), nil
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return 0, errFileClosed
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.data.content))
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *memFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return errFileClosed
+	}
+	f.closed = true
+	return nil
+}
+
+func (f *memFile) Stat() (os.FileInfo, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	return f.data.info(f.name), nil
+}
+
+func (f *memFile) Truncate(size int64) error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return errFileClosed
+	}
+	if size < 0 {
+		return errors.New("negative size")
+	}
+	if size <= int64(len(f.data.content)) {
+		f.data.content = f.data.content[:size]
+	} else {
+		grown := make([]byte, size)
+		copy(grown, f.data.content)
+		f.data.content = grown
+	}
+	return nil
+}
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() os.FileMode  { return i.mode }
+func (i memFileInfo) ModTime() time.Time { return i.modTime }
+func (i memFileInfo) IsDir() bool        { return false }
+func (i memFileInfo) Sys() interface{}   { return nil }
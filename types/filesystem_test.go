@@ -0,0 +1,57 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemFileSystem(t *testing.T) {
+	var m MemFileSystem
+
+	_, err := m.Open("a")
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+
+	f, err := m.OpenFile("a", os.O_WRONLY|os.O_CREATE|os.O_APPEND, FilePermMode)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("hello "))
+	require.NoError(t, err)
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	// the appended content is always written at the end
+	_, err = f.Write([]byte("world"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Error(t, f.Close())
+
+	info, err := m.Stat("a")
+	require.NoError(t, err)
+	require.Equal(t, int64(11), info.Size())
+
+	f, err = m.OpenFile("a", os.O_RDWR, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(5))
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(content))
+	require.NoError(t, f.Close())
+
+	_, err = m.OpenFile("a", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0)
+	require.True(t, errors.Is(err, fs.ErrExist))
+
+	require.NoError(t, m.Rename("a", "b"))
+	_, err = m.Stat("a")
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+
+	temp1, err := m.CreateTemp("dir", "tmp-*.xml")
+	require.NoError(t, err)
+	temp2, err := m.CreateTemp("dir", "tmp-*.xml")
+	require.NoError(t, err)
+	require.NotEqual(t, temp1.Name(), temp2.Name())
+	require.NoError(t, m.Remove(temp1.Name()))
+	require.Error(t, m.Remove(temp1.Name()))
+}
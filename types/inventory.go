@@ -0,0 +1,114 @@
+package types
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// InventoryFormat is the output format of the bucket inventory.
+type InventoryFormat string
+
+const (
+	// InventoryFormatCSV writes the inventory as CSV with a header row, the tags are encoded in the url query form.
+	InventoryFormatCSV InventoryFormat = "csv"
+	// InventoryFormatJSONLines writes the inventory as JSON lines, one object per line.
+	InventoryFormatJSONLines InventoryFormat = "jsonl"
+)
+
+// inventoryColumns are the CSV columns in the order of the InventoryRecord fields.
+var inventoryColumns = []string{"name", "size", "checksum", "status", "create_height", "tags"}
+
+// InventoryRecord is an entry of the bucket inventory.
+type InventoryRecord struct {
+	Name         string            `json:"name"`
+	Size         uint64            `json:"size"`
+	Checksum     string            `json:"checksum"`      // Checksum is the HEX-encoded primary checksum of the object, i.e. the hash of the whole payload.
+	Status       string            `json:"status"`        // Status is the object status, e.g. OBJECT_STATUS_SEALED.
+	CreateHeight int64             `json:"create_height"` // CreateHeight is the block number when the object is created, as served by the meta service of SP.
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// NewInventoryRecord converts the listed object into the inventory record.
+func NewInventoryRecord(object *ObjectMeta) InventoryRecord {
+	info := object.ObjectInfo
+	record := InventoryRecord{
+		Name:         info.ObjectName,
+		Size:         info.PayloadSize,
+		Status:       info.ObjectStatus.String(),
+		CreateHeight: info.CreateAt,
+	}
+	if len(info.Checksums) > 0 {
+		record.Checksum = hex.EncodeToString(info.Checksums[0])
+	}
+	record.Tags = TagsToMap(info.Tags)
+	return record
+}
+
+// InventoryEncoder writes the inventory records to a stream in the format.
+type InventoryEncoder struct {
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+// NewInventoryEncoder - Create the encoder writing the records to w in the format.
+//
+// - w: The destination of the inventory.
+//
+// - format: The output format.
+//
+// - ret1: The inventory encoder.
+//
+// - ret2: Return error if the format is not supported, otherwise return nil.
+func NewInventoryEncoder(w io.Writer, format InventoryFormat) (*InventoryEncoder, error) {
+	switch format {
+	case InventoryFormatCSV:
+		return &InventoryEncoder{csv: csv.NewWriter(w)}, nil
+	case InventoryFormatJSONLines:
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		return &InventoryEncoder{json: encoder}, nil
+	default:
+		return nil, fmt.Errorf("unsupported inventory format %q", format)
+	}
+}
+
+// WriteHeader writes the header row of the CSV format, it does nothing for the JSON lines.
+func (e *InventoryEncoder) WriteHeader() error {
+	if e.csv == nil {
+		return nil
+	}
+	return e.csv.Write(inventoryColumns)
+}
+
+// Encode writes the record.
+func (e *InventoryEncoder) Encode(record InventoryRecord) error {
+	if e.json != nil {
+		return e.json.Encode(record)
+	}
+	tags := make(url.Values, len(record.Tags))
+	for key, value := range record.Tags {
+		tags.Set(key, value)
+	}
+	return e.csv.Write([]string{
+		record.Name,
+		strconv.FormatUint(record.Size, 10),
+		record.Checksum,
+		record.Status,
+		strconv.FormatInt(record.CreateHeight, 10),
+		tags.Encode(),
+	})
+}
+
+// Flush writes the buffered records to the underlying writer.
+func (e *InventoryEncoder) Flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryEncoder(t *testing.T) {
+	records := []InventoryRecord{
+		{Name: "a,b.txt", Size: 3, Checksum: "0a0b", Status: "OBJECT_STATUS_SEALED", CreateHeight: 10, Tags: map[string]string{"team": "data", "k y": "v&1"}},
+		{Name: "c.txt", Status: "OBJECT_STATUS_CREATED", CreateHeight: 11},
+	}
+
+	var buf bytes.Buffer
+	encoder, err := NewInventoryEncoder(&buf, InventoryFormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, encoder.WriteHeader())
+	for _, record := range records {
+		require.NoError(t, encoder.Encode(record))
+	}
+	require.NoError(t, encoder.Flush())
+	require.Equal(t, "name,size,checksum,status,create_height,tags\n"+
+		"\"a,b.txt\",3,0a0b,OBJECT_STATUS_SEALED,10,k+y=v%261&team=data\n"+
+		"c.txt,0,,OBJECT_STATUS_CREATED,11,\n", buf.String())
+
+	buf.Reset()
+	encoder, err = NewInventoryEncoder(&buf, InventoryFormatJSONLines)
+	require.NoError(t, err)
+	require.NoError(t, encoder.WriteHeader())
+	for _, record := range records {
+		require.NoError(t, encoder.Encode(record))
+	}
+	require.NoError(t, encoder.Flush())
+	require.Equal(t, `{"name":"a,b.txt","size":3,"checksum":"0a0b","status":"OBJECT_STATUS_SEALED","create_height":10,"tags":{"k y":"v&1","team":"data"}}`+"\n"+
+		`{"name":"c.txt","size":0,"checksum":"","status":"OBJECT_STATUS_CREATED","create_height":11}`+"\n", buf.String())
+
+	_, err = NewInventoryEncoder(&buf, "xml")
+	require.Error(t, err)
+}
@@ -39,6 +39,8 @@ type QuotaRecordInfo struct {
 	NextStartTimestampUs int64 `xml:"NextStartTimestampUs"`
 	// ReadRecords defines the result record list.
 	ReadRecords []ReadRecord `xml:"ReadRecord"`
+	// NextPageToken is the token of the next page, it is empty if all the records are listed.
+	NextPageToken PageToken `xml:"-"`
 }
 
 // UploadProgress indicates the progress info of uploading object
@@ -90,6 +92,8 @@ type ListObjectsResult struct {
 	IsTruncated bool `xml:"IsTruncated"`
 	// NextContinuationToken is sent when is_truncated is true, which means there are more keys in the bucket that can be listed
 	NextContinuationToken string `xml:"NextContinuationToken"`
+	// NextPageToken is the token of the next page, it is empty if all the keys are listed.
+	NextPageToken PageToken `xml:"-"`
 	// Name defines the name of the bucket
 	Name string `xml:"Name"`
 	// Prefix is the prefix used during the query
@@ -126,18 +130,24 @@ type ListGroupsResult struct {
 	Groups []*GroupMeta `json:"Groups"`
 	// Count defines total groups amount
 	Count int64 `xml:"Count"`
+	// NextPageToken is the token of the next page, it is empty if all the groups are listed.
+	NextPageToken PageToken `xml:"-"`
 }
 
 // GroupMembersResult indicates the response of ListGroupMembers
 type GroupMembersResult struct {
 	// Groups defines the response of group member list
 	Groups []*GroupMembers `xml:"Groups"`
+	// NextPageToken is the token of the next page, it is empty if all the members are listed.
+	NextPageToken PageToken `xml:"-"`
 }
 
 // GroupsResult indicates a list of group members
 type GroupsResult struct {
 	// Groups defines the response of group member list
 	Groups []*GroupMembers `xml:"Groups"`
+	// NextPageToken is the token of the next page, it is empty if all the groups are listed.
+	NextPageToken PageToken `xml:"-"`
 }
 
 // GroupMembers indicates the group member info
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// groupMembershipTokenTemplate is the message signed by the group membership tokens.
+const groupMembershipTokenTemplate = "Greenfield group membership\nIssuer: %s\nMember: %s\nGroup Owner: %s\nGroup Name: %s\nIssued At: %s\nExpires At: %s"
+
+// ErrInvalidMembershipToken indicates the group membership token is not signed by the expected issuer, it is expired,
+// or it does not assert the membership of the expected group.
+var ErrInvalidMembershipToken = errors.New("invalid group membership token")
+
+// GroupMembershipToken asserts the membership of an account in a group, which is checked on chain by the issuer when
+// the token is issued. It allows the gateway services to gate the contents by the group membership without querying
+// the chain for each request, at the cost of trusting the membership until the token expires.
+type GroupMembershipToken struct {
+	Issuer     string `json:"issuer"`
+	Member     string `json:"member"`
+	GroupOwner string `json:"group_owner"`
+	GroupName  string `json:"group_name"`
+	IssuedAt   string `json:"issued_at"`  // IssuedAt is the time of the token in RFC3339.
+	ExpiresAt  string `json:"expires_at"` // ExpiresAt is the expiry time of the token in RFC3339.
+	Signature  string `json:"signature"`  // Signature is the HEX-encoded personal_sign signature of Message by the issuer.
+}
+
+// Message returns the message signed by the token.
+func (t *GroupMembershipToken) Message() string {
+	return fmt.Sprintf(groupMembershipTokenTemplate, t.Issuer, t.Member, t.GroupOwner, t.GroupName, t.IssuedAt, t.ExpiresAt)
+}
+
+// Encode returns the token in the URL-safe base64 encoding, e.g. to carry it in a cookie or an http header.
+func (t *GroupMembershipToken) Encode() (string, error) {
+	content, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+// ParseGroupMembershipToken - Parse the token encoded by GroupMembershipToken.Encode, the token is not verified.
+func ParseGroupMembershipToken(encoded string) (*GroupMembershipToken, error) {
+	content, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMembershipToken, err)
+	}
+	var token GroupMembershipToken
+	if err = json.Unmarshal(content, &token); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMembershipToken, err)
+	}
+	return &token, nil
+}
+
+// NewGroupMembershipToken - Sign the token asserting the membership of the member in the group, the membership should
+// have been checked by the issuer.
+//
+// - issuer: The account signing the token, the verifiers trust the tokens by its address.
+//
+// - member: The HEX-encoded address of the group member.
+//
+// - groupOwner: The HEX-encoded address of the group owner.
+//
+// - groupName: The name of the group.
+//
+// - issuedAt: The time of the token.
+//
+// - expiresAt: The expiry time of the token.
+//
+// - ret1: The signed token.
+//
+// - ret2: Return error when the fields are invalid or the signing failed, otherwise return nil.
+func NewGroupMembershipToken(issuer *Account, member, groupOwner, groupName string, issuedAt, expiresAt time.Time) (*GroupMembershipToken, error) {
+	if issuer == nil {
+		return nil, ErrorDefaultAccountNotExist
+	}
+	if !common.IsHexAddress(member) || !common.IsHexAddress(groupOwner) {
+		return nil, fmt.Errorf("the member %q and the group owner %q should be HEX-encoded addresses", member, groupOwner)
+	}
+	if groupName == "" || strings.ContainsAny(groupName, "\r\n") {
+		return nil, errors.New("the group name of the token should be a non-empty single line")
+	}
+	if !expiresAt.After(issuedAt) {
+		return nil, errors.New("the token should expire after it is issued")
+	}
+	token := &GroupMembershipToken{
+		Issuer:     issuer.GetAddress().String(),
+		Member:     member,
+		GroupOwner: groupOwner,
+		GroupName:  groupName,
+		IssuedAt:   issuedAt.UTC().Format(time.RFC3339),
+		ExpiresAt:  expiresAt.UTC().Format(time.RFC3339),
+	}
+	sig, err := issuer.Sign(accounts.TextHash([]byte(token.Message())))
+	if err != nil {
+		return nil, err
+	}
+	token.Signature = hexutil.Encode(sig)
+	return token, nil
+}
+
+// VerifyGroupMembershipToken - Verify the token is signed by the issuer, it is not expired and it asserts the
+// membership in the group, the chain is not queried.
+//
+// - token: The token to verify.
+//
+// - issuer: The HEX-encoded address of the trusted issuer.
+//
+// - groupOwner: The HEX-encoded address of the owner of the gating group.
+//
+// - groupName: The name of the gating group.
+//
+// - now: The current time of the verifier.
+//
+// - ret: Return error wrapping ErrInvalidMembershipToken if the token is invalid, otherwise return nil.
+func VerifyGroupMembershipToken(token *GroupMembershipToken, issuer, groupOwner, groupName string, now time.Time) error {
+	if token == nil {
+		return fmt.Errorf("%w: no token", ErrInvalidMembershipToken)
+	}
+	if !common.IsHexAddress(token.Issuer) || common.HexToAddress(token.Issuer) != common.HexToAddress(issuer) {
+		return fmt.Errorf("%w: the issuer %q is not trusted", ErrInvalidMembershipToken, token.Issuer)
+	}
+	if !common.IsHexAddress(token.GroupOwner) || common.HexToAddress(token.GroupOwner) != common.HexToAddress(groupOwner) ||
+		token.GroupName != groupName {
+		return fmt.Errorf("%w: the token is for group %s of %s", ErrInvalidMembershipToken, token.GroupName, token.GroupOwner)
+	}
+	if !common.IsHexAddress(token.Member) {
+		return fmt.Errorf("%w: invalid member %q", ErrInvalidMembershipToken, token.Member)
+	}
+	issuedAt, err := time.Parse(time.RFC3339, token.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("%w: invalid issued time %q", ErrInvalidMembershipToken, token.IssuedAt)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("%w: invalid expiry time %q", ErrInvalidMembershipToken, token.ExpiresAt)
+	}
+	if !now.Before(expiresAt) || issuedAt.Sub(now) > DefaultMaxClockSkew {
+		return fmt.Errorf("%w: the token valid from %s to %s is out of the validity period", ErrInvalidMembershipToken,
+			token.IssuedAt, token.ExpiresAt)
+	}
+
+	sig, err := hexutil.Decode(token.Signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidMembershipToken)
+	}
+	// the wallets sign with the recovery id of 27 or 28
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(token.Message())), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMembershipToken, err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != common.HexToAddress(token.Issuer) {
+		return fmt.Errorf("%w: the signer is not %s", ErrInvalidMembershipToken, token.Issuer)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupMembershipToken(t *testing.T) {
+	issuer, _, err := NewAccount("issuer")
+	require.NoError(t, err)
+	member, _, err := NewAccount("member")
+	require.NoError(t, err)
+	owner, _, err := NewAccount("owner")
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	issuerAddr, memberAddr, ownerAddr := issuer.GetAddress().String(), member.GetAddress().String(), owner.GetAddress().String()
+
+	token, err := NewGroupMembershipToken(issuer, memberAddr, ownerAddr, "vip", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	encoded, err := token.Encode()
+	require.NoError(t, err)
+	parsed, err := ParseGroupMembershipToken(encoded)
+	require.NoError(t, err)
+	require.Equal(t, token, parsed)
+	require.NoError(t, VerifyGroupMembershipToken(parsed, issuerAddr, ownerAddr, "vip", now.Add(time.Minute)))
+
+	for name, verify := range map[string]func() error{
+		"issuer": func() error { return VerifyGroupMembershipToken(token, memberAddr, ownerAddr, "vip", now) },
+		"group":  func() error { return VerifyGroupMembershipToken(token, issuerAddr, ownerAddr, "other", now) },
+		"owner":  func() error { return VerifyGroupMembershipToken(token, issuerAddr, memberAddr, "vip", now) },
+		"expired": func() error {
+			return VerifyGroupMembershipToken(token, issuerAddr, ownerAddr, "vip", now.Add(time.Hour))
+		},
+		"future": func() error {
+			return VerifyGroupMembershipToken(token, issuerAddr, ownerAddr, "vip", now.Add(-time.Hour))
+		},
+		"tampered": func() error {
+			tampered := *token
+			tampered.Member = ownerAddr
+			return VerifyGroupMembershipToken(&tampered, issuerAddr, ownerAddr, "vip", now)
+		},
+	} {
+		err := verify()
+		require.True(t, errors.Is(err, ErrInvalidMembershipToken), name)
+	}
+
+	_, err = ParseGroupMembershipToken("not a token")
+	require.ErrorIs(t, err, ErrInvalidMembershipToken)
+	_, err = NewGroupMembershipToken(issuer, memberAddr, ownerAddr, "vip", now, now)
+	require.Error(t, err)
+	_, err = NewGroupMembershipToken(issuer, memberAddr, ownerAddr, "vip\nMember: 0x0", now, now.Add(time.Hour))
+	require.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package types
+
+import "time"
+
+// TransferDirection indicates whether the bytes are sent to or received from SP.
+type TransferDirection string
+
+const (
+	// TransferUpload indicates the bytes of the request bodies sent to SP.
+	TransferUpload TransferDirection = "upload"
+	// TransferDownload indicates the bytes of the response bodies read from SP.
+	TransferDownload TransferDirection = "download"
+)
+
+// SPRequestMetric records an HTTP request to SP, each retried attempt is recorded separately.
+type SPRequestMetric struct {
+	Method     string        // Method is the HTTP method of the request.
+	Endpoint   string        // Endpoint is the host the request is sent to.
+	StatusCode int           // StatusCode is the status code of the response, it is 0 if no response is received.
+	ErrorCode  string        // ErrorCode is the error code returned by SP, it is empty if the request succeeds or SP returns no code.
+	Latency    time.Duration // Latency is the time until the response headers are received or the request fails.
+}
+
+// TxBroadcastMetric records a transaction broadcast to the blockchain.
+type TxBroadcastMetric struct {
+	Msgs      int           // Msgs is the number of the messages in the transaction.
+	Success   bool          // Success indicates the transaction is accepted with code 0.
+	Code      uint32        // Code is the response code of the transaction, it is 0 if the broadcast fails before a response.
+	Codespace string        // Codespace is the codespace of the response code.
+	Latency   time.Duration // Latency is the time of the broadcast.
+}
+
+// Metrics receives the measurements of the client, e.g. to export them to Prometheus. The methods are called
+// synchronously by the goroutines sending the requests, so they should be safe for concurrent use and return quickly.
+type Metrics interface {
+	// ObserveSPRequest is called once the response headers of an SP request are received or the request fails.
+	ObserveSPRequest(metric SPRequestMetric)
+	// AddTransferredBytes is called with the number of the bytes sent to or read from SP.
+	AddTransferredBytes(direction TransferDirection, bytes int64)
+	// ObserveTxBroadcast is called once a transaction is broadcast.
+	ObserveTxBroadcast(metric TxBroadcastMetric)
+}
+
+// DedupMetrics is optionally implemented by the Metrics to observe the deduplication of the concurrent identical
+// queries, e.g. HeadBucket and HeadObject, the dedup hit rate is the ratio of the shared ones.
+type DedupMetrics interface {
+	// ObserveDedup is called once a query completes, shared indicates it reuses the result of an identical query in
+	// flight rather than being sent.
+	ObserveDedup(query string, shared bool)
+}
+
+// CircuitBreakerMetrics is optionally implemented by the Metrics to observe the circuit breakers of the SP endpoints.
+type CircuitBreakerMetrics interface {
+	// ObserveCircuitState is called when the circuit breaker of the endpoint changes its state.
+	ObserveCircuitState(endpoint string, state CircuitState)
+	// ObserveCircuitRejection is called when a request to the endpoint is failed fast by the open circuit breaker.
+	ObserveCircuitRejection(endpoint string)
+}
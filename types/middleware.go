@@ -0,0 +1,23 @@
+package types
+
+import "net/http"
+
+// RoundTripFunc sends an HTTP request to SP and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the requests sent to SP, e.g. to add custom headers, to log the requests for audits, to inject
+// failures in the chaos tests or to serve the responses from a cache. A middleware calls next to continue the
+// request, or returns its own response without calling next.
+//
+// The requests are signed before the middlewares, so the signed headers and the body of a request should not be
+// modified.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// ChainMiddlewares wraps do by the middlewares, the first middleware is the outermost one which sees the request
+// first and the response last.
+func ChainMiddlewares(do RoundTripFunc, middlewares ...Middleware) RoundTripFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		do = middlewares[i](do)
+	}
+	return do
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainMiddlewares(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+	cached := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodHead {
+				return &http.Response{StatusCode: http.StatusNotModified}, nil
+			}
+			return next(req)
+		}
+	}
+	do := ChainMiddlewares(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}, record("first"), record("second"), cached)
+
+	resp, err := do(&http.Request{Method: http.MethodGet})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []string{"first", "second", "transport"}, order)
+
+	order = nil
+	resp, err = do(&http.Request{Method: http.MethodHead})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	require.Equal(t, []string{"first", "second"}, order)
+}
@@ -22,6 +22,13 @@ type CreateBucketOptions struct {
 	ChargedQuota   uint64                      // ChargedQuota defines the read data that users are charged for, measured in bytes.
 	IsAsyncMode    bool                        // indicate whether to create the bucket in asynchronous mode.
 	Tags           *storageTypes.ResourceTags  // set tags when creating bucket
+	StorageClass   string                      // StorageClass indicates the preset applied to the unset options, the default storage class of the client is used if it is empty.
+}
+
+// TxBuilderBroadcastOptions contains the options for `TxBuilder.Broadcast` API.
+type TxBuilderBroadcastOptions struct {
+	TxOpts      *gnfdsdktypes.TxOption // TxOpts defines the options to customize a transaction, the gas is simulated if the gas limit is not set.
+	IsAsyncMode bool                   // IsAsyncMode indicates whether to return without waiting for the transaction to be included.
 }
 
 // MigrateBucketOptions indicates the metadata to construct `MigrateBucket` msg of storage module.
@@ -134,6 +141,7 @@ type CreateObjectOptions struct {
 	IsAsyncMode         bool                        // IsAsyncMode indicate whether to create the object in asynchronous mode.
 	IsSerialComputeMode bool                        // IsSerialComputeMode indicate whether to compute integrity hash in serial way or parallel way when creating an object.
 	Tags                *storageTypes.ResourceTags  // set tags when creating bucket
+	StorageClass        string                      // StorageClass indicates the preset applied to the unset options, the default storage class of the client is used if it is empty.
 }
 
 // UpdateObjectOptions - indicates the metadata to construct `updateObjectContent` message of storage module.
@@ -181,6 +189,7 @@ type ComputeHashOptions struct {
 type ListReadRecordOptions struct {
 	StartTimeStamp int64 // StartTimeStamp indicates the start timestamp of the return read quota record.
 	MaxRecords     int
+	PageToken      PageToken // PageToken is the NextPageToken returned by the previous page, it takes the place of StartTimeStamp.
 }
 
 // ListObjectsOptions contains the options for `ListObjects` API.
@@ -197,6 +206,13 @@ type ListObjectsOptions struct {
 	// in the list of objects to resume the listing. This is used for pagination.
 	ContinuationToken string
 
+	// PageToken is the NextPageToken returned by the previous page, it takes the place of ContinuationToken.
+	PageToken PageToken
+
+	// TagFilter keeps only the objects carrying all the tags. The objects are filtered after they are listed, since
+	// the metadata service does not filter by the tags, so a page may hold fewer objects than MaxKeys.
+	TagFilter map[string]string
+
 	// Delimiter is a character that is used to group keys.
 	// All keys that contain the same string between the prefix and the first occurrence of the delimiter
 	// are grouped under a single result element in common prefixes.
@@ -211,9 +227,11 @@ type ListObjectsOptions struct {
 	// MaxKeys defines the maximum number of keys returned to the response body.
 	// If not specified, the default value is 50.
 	// The maximum limit for returning objects is 1000
-	MaxKeys   uint64
-	Endpoint  string // indicates the endpoint of sp.
-	SPAddress string // indicates the HEX-encoded string of the sp address to be challenged.
+	MaxKeys      uint64
+	Endpoint     string        // indicates the endpoint of sp.
+	SPAddress    string        // indicates the HEX-encoded string of the sp address to be challenged.
+	ResponseInfo *ResponseInfo // ResponseInfo receives the info of the SP response if it is not nil.
+	Consistency  Consistency   // Consistency indicates how stale the response may be, the MaxMetaBlockLag of the client applies by default.
 }
 
 // PutPolicyOption indicates the metadata to construct `PutPolicy` msg of storage module.
@@ -237,17 +255,70 @@ type PutObjectOptions struct {
 	ContentType      string // ContentType indicates the content type of object.
 	TxnHash          string // TxnHash indicates the transaction hash creating the object meta on chain.
 	DisableResumable bool   // DisableResumable indicates whether upload the object to Storage Provider via resumable upload.
-	PartSize         uint64
-	Delegated        bool // Delegated indicates that the request to SP will require SP to create/update objet behalf of the uploader.
-	IsUpdate         bool // IsUpdate indicates that the request to SP is a delegated update object request.
+	PartSize         uint64 // PartSize indicates the part size of the resumable upload, it should be an integer multiple of the segment size. The default part size of the client is used if it is 0.
+	Delegated        bool   // Delegated indicates that the request to SP will require SP to create/update objet behalf of the uploader.
+	IsUpdate         bool   // IsUpdate indicates that the request to SP is a delegated update object request.
 	Visibility       storageTypes.VisibilityType
+	ChunkBufferSize  int64 // ChunkBufferSize indicates the max bytes of the chunks buffered ahead of the upload by PutObjectFromChunks, the default value is DefaultChunkBufferSize.
+	// VerifyChecksumAck indicates whether to compare the checksums acknowledged by SP in the responses with the ones
+	// computed locally, the upload fails fast with a *ChecksumAckError on mismatch rather than failing to seal later.
+	// Nothing is compared if SP does not acknowledge the checksums.
+	VerifyChecksumAck bool
+	// MinResumeOffset indicates the offset which the previous attempt of the resumable upload is known to have sent,
+	// e.g. recorded before a crash. The upload waits up to DefaultUploadOffsetTimeout for SP to report the offset
+	// before resuming, and resumes from the reported one if SP does not catch up in time.
+	MinResumeOffset uint64
+	Progress        ProgressListener // Progress receives the progress of the upload if it is not nil, the bytes read from the reader are reported.
+	RateLimit       int64            // RateLimit is the max number of bytes uploaded per second by the call in addition to the UploadRateLimit of the client, it is not limited if it is 0.
+	// CreateOptions makes FPutObject create the object on chain before uploading the file if it is not nil, so that a
+	// file is uploaded by a single call. It is ignored by the other APIs and the delegated uploads.
+	CreateOptions *CreateObjectOptions
+	// ContentSHA256 is the hex-encoded sha256 of the payload known by the producer, e.g. the files copied from another
+	// store. It is sent as the content sha256 header of the upload request, so that SP and the proxies can check the
+	// payload without the SDK reading it one more time. It is only sent by the uploads in a single request, the parts
+	// of the resumable uploads carry no payload hash.
+	ContentSHA256 string
 }
 
 // GetObjectOptions contains the options for `GetObject` API.
 type GetObjectOptions struct {
-	Range            string `url:"-" header:"Range,omitempty"` // Range support for downloading partial data.
-	SupportResumable bool   // SupportResumable support resumable download. Resumable downloads refer to the capability of resuming interrupted or incomplete downloads from the point where they were paused or disrupted.
-	PartSize         uint64 // PartSize indicate the resumable download's part size, download a large file in multiple parts. The part size is an integer multiple of the segment size.
+	Range            string        `url:"-" header:"Range,omitempty"` // Range support for downloading partial data.
+	SupportResumable bool          // SupportResumable support resumable download. Resumable downloads refer to the capability of resuming interrupted or incomplete downloads from the point where they were paused or disrupted.
+	PartSize         uint64        // PartSize indicate the resumable download's part size, download a large file in multiple parts. The part size is an integer multiple of the segment size. The default part size of the client is used if it is 0.
+	Concurrency      int           // Concurrency indicates the number of parts downloaded in parallel. GetObject downloads the parts in parallel and reassembles them in order if it is greater than 1 or AdaptiveConcurrency is set, GetObjectToWriterAt uses DefaultDownloadConcurrency if it is 0.
+	ResponseInfo     *ResponseInfo // ResponseInfo receives the info of the SP response if it is not nil.
+	// CDNEndpoint indicates the CDN or custom domain fronting the bucket to download the object from, it overrides the
+	// one configured for the bucket in the client options.
+	CDNEndpoint string
+	// Progress receives the progress of the download if it is not nil, the bytes read from the returned reader are
+	// reported by GetObject, and the bytes written are reported by FGetObject, FGetObjectResumable and GetObjectToWriterAt.
+	Progress ProgressListener
+	// RateLimit is the max number of bytes downloaded per second by the call, the parts downloaded in parallel share
+	// it. It applies in addition to the DownloadRateLimit of the client, and the download is not limited if it is 0.
+	RateLimit int64
+	// AdaptiveConcurrency adjusts the number of parts downloaded in parallel by GetObject, FGetObject and
+	// GetObjectToWriterAt between its bounds instead of using the fixed Concurrency, Concurrency is the initial one in
+	// that case. FGetObjectResumable rejects it since it downloads the parts sequentially.
+	AdaptiveConcurrency *AdaptiveConcurrency
+	// RestartOnCorruption indicates whether FGetObjectResumable discards all the downloaded parts rather than only the
+	// ones failing the checksums when the temp file is found corrupted.
+	RestartOnCorruption bool
+	// SecondaryFailover indicates whether GetObject reconstructs the payload from the pieces stored by the secondary
+	// SPs of the object when its primary SP is unavailable, e.g. the connection failures and the 5xx responses. The
+	// pieces are fetched by the challenge API and verified against the checksums on chain, ResponseInfo is not filled
+	// in that case.
+	SecondaryFailover bool
+	// VerifyIntegrity indicates whether the reader of GetObject hashes the segments of the payload as they stream in
+	// and compares them with the checksum of the primary SP on chain. The checksum covers the whole payload, so the
+	// mismatch is reported by a *IntegrityError in place of io.EOF, the data should not be trusted until io.EOF is
+	// read. FGetObject, FGetObjectResumable and GetObjectToWriterAt return the *IntegrityError after the payload is
+	// downloaded, and FGetObjectResumable discards its temp file in that case. It can not be combined with Range.
+	VerifyIntegrity bool
+}
+
+// UniversalURLOptions contains the options for `GetObjectUniversalURL` API.
+type UniversalURLOptions struct {
+	Download bool // Download indicates whether the url downloads the object as an attachment rather than viewing it in the browser.
 }
 
 // GetChallengeInfoOptions contains the options for querying challenge data.
@@ -268,8 +339,9 @@ type ListGroupsOptions struct {
 	SourceType string // SourceType indicates the source type of group.
 	Limit      int64
 	Offset     int64
-	Endpoint   string // Endpoint indicates the endpoint of sp.
-	SPAddress  string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+	PageToken  PageToken // PageToken is the NextPageToken returned by the previous page, it takes the place of Offset.
+	Endpoint   string    // Endpoint indicates the endpoint of sp.
+	SPAddress  string    // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
 
 // GroupMembersPaginationOptions contains the options for `ListGroupMembers` API.
@@ -278,9 +350,10 @@ type GroupMembersPaginationOptions struct {
 	// If the limit is set to 0, it will default to 50.
 	// If the limit exceeds 1000, only 1000 records will be returned.
 	Limit      int64
-	StartAfter string // StartAfter is used to input the user's account address for pagination purposes.
-	Endpoint   string // indicates the endpoint of sp.
-	SPAddress  string // indicates the HEX-encoded string of the sp address to be challenged.
+	StartAfter string    // StartAfter is used to input the user's account address for pagination purposes.
+	PageToken  PageToken // PageToken is the NextPageToken returned by the previous page, it takes the place of StartAfter.
+	Endpoint   string    // indicates the endpoint of sp.
+	SPAddress  string    // indicates the HEX-encoded string of the sp address to be challenged.
 }
 
 // GroupsOwnerPaginationOptions contains the options for `ListGroupsByOwner` API.
@@ -289,10 +362,11 @@ type GroupsOwnerPaginationOptions struct {
 	// If the limit is set to 0, it will default to 50.
 	// If the limit exceeds 1000, only 1000 records will be returned.
 	Limit      int64
-	StartAfter string // StartAfter is used to input the group id for pagination purposes.
-	Owner      string // Owner defines the owner account address of groups, if owner is set to "", it will default to current user address.
-	Endpoint   string // Endpoint indicates the endpoint of sp.
-	SPAddress  string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+	StartAfter string    // StartAfter is used to input the group id for pagination purposes.
+	PageToken  PageToken // PageToken is the NextPageToken returned by the previous page, it takes the place of StartAfter.
+	Owner      string    // Owner defines the owner account address of groups, if owner is set to "", it will default to current user address.
+	Endpoint   string    // Endpoint indicates the endpoint of sp.
+	SPAddress  string    // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
 
 // GroupsPaginationOptions contains the options for `ListGroupsByAccount` API.
@@ -301,10 +375,11 @@ type GroupsPaginationOptions struct {
 	// If the limit is set to 0, it will default to 50.
 	// If the limit exceeds 1000, only 1000 records will be returned.
 	Limit      int64
-	StartAfter string // StartAfter is used to input the group id for pagination purposes.
-	Account    string // Account defines the user account address, if it is set to "", it will default to the current user address.
-	Endpoint   string // Endpoint indicates the endpoint of sp.
-	SPAddress  string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+	StartAfter string    // StartAfter is used to input the group id for pagination purposes.
+	PageToken  PageToken // PageToken is the NextPageToken returned by the previous page, it takes the place of StartAfter.
+	Account    string    // Account defines the user account address, if it is set to "", it will default to the current user address.
+	Endpoint   string    // Endpoint indicates the endpoint of sp.
+	SPAddress  string    // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
 
 func (o *GetObjectOptions) SetRange(start, end int64) error {
@@ -328,6 +403,13 @@ func (o *GetObjectOptions) SetRange(start, end int64) error {
 type EndPointOptions struct {
 	Endpoint  string // Endpoint indicates the endpoint of sp.
 	SPAddress string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+	// FanOut indicates the number of in-service SPs queried concurrently when neither Endpoint nor SPAddress is set,
+	// the first successful response is returned. A single SP is queried if it is not more than 1.
+	FanOut int
+	// ResponseInfo receives the info of the SP response if it is not nil.
+	ResponseInfo *ResponseInfo
+	// Consistency indicates how stale the response may be, the MaxMetaBlockLag of the client applies by default.
+	Consistency Consistency
 }
 
 // ListBucketsOptions contains the options for `ListBuckets` API.
@@ -336,6 +418,13 @@ type ListBucketsOptions struct {
 	Account           string // Account defines the user account address, if it is set to "", it will default to the current user address.
 	Endpoint          string // Endpoint indicates the endpoint of sp.
 	SPAddress         string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+	// FanOut indicates the number of in-service SPs queried concurrently when neither Endpoint nor SPAddress is set,
+	// the first successful response is returned. A single SP is queried if it is not more than 1.
+	FanOut int
+	// ResponseInfo receives the info of the SP response if it is not nil.
+	ResponseInfo *ResponseInfo
+	// Consistency indicates how stale the response may be, the MaxMetaBlockLag of the client applies by default.
+	Consistency Consistency
 }
 
 // ListBucketsByPaymentAccountOptions contains the options for `ListBucketsByPaymentAccount` API.
@@ -361,3 +450,235 @@ type ListObjectPoliciesOptions struct {
 	Endpoint   string // Endpoint indicates the endpoint of sp.
 	SPAddress  string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
+
+// RequestFaucetOptions contains the options for `RequestFaucetFunds` API.
+type RequestFaucetOptions struct {
+	FaucetURL    string        // FaucetURL indicates the HTTP endpoint of the testnet faucet, the address is posted to it in json and the error field of its json response is reported, TestnetFaucetURL is used if it is empty.
+	WaitTimeout  time.Duration // WaitTimeout indicates the max time to wait for the balance to increase, the default value is ContextTimeout.
+	PollInterval time.Duration // PollInterval indicates the interval of querying the balance, the default value is 1 second.
+}
+
+// WatchParamsOptions contains the options for `WatchParams` API.
+type WatchParamsOptions struct {
+	PollInterval time.Duration // PollInterval indicates the interval of querying the params, the default value is DefaultParamsPollInterval.
+}
+
+// WatchEventsOptions contains the options for `WatchEvents` and `ExportEvents` APIs.
+type WatchEventsOptions struct {
+	StartHeight int64    // StartHeight indicates the first block to watch, the next block is used if it is 0. ExportEvents resumes from the stored offset instead if there is one.
+	EventTypes  []string // EventTypes indicates the proto names of the events to watch, DefaultWatchedEventTypes are used if it is empty.
+}
+
+// WatchDiscontinuesOptions contains the options for `WatchDiscontinues` API.
+type WatchDiscontinuesOptions struct {
+	StartHeight int64    // StartHeight indicates the first block to watch, the next block is used if it is 0.
+	Owner       string   // Owner indicates the HEX-encoded owner address of the watched buckets, the default account is used if it is empty.
+	BucketNames []string // BucketNames limits the watching to the buckets, all the buckets of the owner are watched if it is empty.
+}
+
+// SyncDirOptions contains the options for `SyncDirUpload` and `SyncDirDownload` API.
+type SyncDirOptions struct {
+	Concurrency   int                 // Concurrency indicates the number of files transferred in parallel, DefaultSyncConcurrency is used if it is 0.
+	DryRun        bool                // DryRun compares the files with the objects without transferring them, the result reports the files to transfer.
+	CreateOptions CreateObjectOptions // CreateOptions is used by SyncDirUpload to create the objects of the new files.
+	PutOptions    PutObjectOptions    // PutOptions is used by SyncDirUpload to upload the files.
+	GetOptions    GetObjectOptions    // GetOptions is used by SyncDirDownload to download the objects.
+	// AdaptiveConcurrency adjusts the number of files transferred in parallel between its bounds instead of using the
+	// fixed Concurrency, Concurrency is the initial one in that case. The number is lowered by the failures only, since
+	// the latencies of the files differ by their sizes.
+	AdaptiveConcurrency *AdaptiveConcurrency
+}
+
+// VerifyReplicaOptions contains the options for `VerifyReplica` API.
+type VerifyReplicaOptions struct {
+	Prefix      string // Prefix limits the verification to the objects whose names begin with it.
+	SampleCount int    // SampleCount indicates the number of the matched objects downloaded from both buckets to compare the contents, no object is downloaded if it is 0.
+}
+
+// ExportInventoryOptions contains the options for `ExportBucketInventory` API.
+type ExportInventoryOptions struct {
+	// CursorPath indicates the path where the listing progress is persisted after each page is written, the export
+	// resumes from it if it exists, and the header row is not written again. The progress is not persisted if it is empty.
+	CursorPath        string
+	Prefix            string // Prefix limits the inventory to the objects whose names begin with it.
+	ShowRemovedObject bool   // ShowRemovedObject determines whether to include the objects that have been marked as removed.
+	MaxKeys           uint64 // MaxKeys defines the maximum number of objects listed in each page.
+	Endpoint          string // Endpoint indicates the endpoint of sp.
+	SPAddress         string // SPAddress indicates the HEX-encoded string of the sp address to list the objects.
+}
+
+// AuditBucketOptions contains the options for `AuditBucket` API.
+type AuditBucketOptions struct {
+	Prefix          string    // Prefix limits the audit to the objects whose names begin with it.
+	Mode            AuditMode // Mode indicates how the sampled objects are verified, AuditFullRead is used if it is empty.
+	UseV2Challenge  bool      // UseV2Challenge indicates whether the v2 version of the challenge API is used in the AuditChallenge mode.
+	MaxSampledCount int       // MaxSampledCount limits the number of the audited objects, the sampled objects are not limited if it is 0.
+}
+
+// WaitForObjectSealOptions contains the options for `WaitForObjectSeal` API.
+type WaitForObjectSealOptions struct {
+	Timeout         time.Duration // Timeout indicates the max duration to wait, DefaultSealTimeout is used if it is 0.
+	PollInterval    time.Duration // PollInterval indicates the interval of the first poll, it doubles for each poll up to MaxPollInterval. DefaultSealPollInterval is used if it is 0.
+	MaxPollInterval time.Duration // MaxPollInterval indicates the max interval of polling, MaxSealPollInterval is used if it is 0.
+}
+
+// UploadOffsetOptions contains the options for `GetObjectResumableUploadOffset` API.
+type UploadOffsetOptions struct {
+	MinOffset       uint64        // MinOffset indicates the offset to wait for SP to persist, e.g. of the parts sent before the crash. The offset is queried once if it is 0.
+	Timeout         time.Duration // Timeout indicates the max duration to wait for MinOffset, DefaultUploadOffsetTimeout is used if it is 0.
+	PollInterval    time.Duration // PollInterval indicates the interval of the first poll, it doubles for each poll up to MaxPollInterval. DefaultUploadOffsetPollInterval is used if it is 0.
+	MaxPollInterval time.Duration // MaxPollInterval indicates the max interval of polling, MaxUploadOffsetPollInterval is used if it is 0.
+}
+
+// HealthCheckOptions contains the options for `HealthCheck` API.
+type HealthCheckOptions struct {
+	BucketName   string        // BucketName indicates the bucket whose primary SP is probed, the first in-service SP is probed if it is empty.
+	ProbeAllSPs  bool          // ProbeAllSPs indicates whether to probe all the in-service SPs.
+	MinBalance   math.Int      // MinBalance indicates the min balance of the default account to be healthy, the balance is not required if it is nil.
+	ProbeTimeout time.Duration // ProbeTimeout indicates the timeout of probing each SP, the default value is 5 seconds.
+}
+
+// GenerateStorageProofOptions contains the options for `GenerateStorageProof` API.
+type GenerateStorageProofOptions struct {
+	// SealTxHash indicates the hash of the seal object transaction, the proof is anchored to the block including it if
+	// it is set, otherwise the proof is anchored to the latest block.
+	SealTxHash string
+}
+
+// ListObjectsWithCursorOptions contains the options for `ListObjectsWithCursor` API.
+type ListObjectsWithCursorOptions struct {
+	// CursorPath indicates the path where the cursor is persisted after each page is handled, the listing resumes
+	// from the cursor if it exists. The cursor is not persisted if it is empty.
+	CursorPath        string
+	ShowRemovedObject bool   // ShowRemovedObject determines whether to include objects that have been marked as removed in the list.
+	Prefix            string // Prefix limits the response to keys that begin with the specified prefix.
+	Delimiter         string // Delimiter is a character that is used to group keys, currently only '/' is supported.
+	MaxKeys           uint64 // MaxKeys defines the maximum number of keys returned in each page.
+	Endpoint          string // Endpoint indicates the endpoint of sp.
+	SPAddress         string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+}
+
+// EncryptionSharingOptions indicates the recipients which the data key of an encrypted object is wrapped for.
+type EncryptionSharingOptions struct {
+	KeyDirectory KeyDirectory // KeyDirectory resolves the encryption public keys of the recipients, it is required.
+	GroupName    string       // GroupName indicates the group whose members share the object, the object is not shared with a group if it is empty.
+	GroupOwner   string       // GroupOwner indicates the HEX-encoded address of the group owner, the default account is used if it is empty.
+	Recipients   []string     // Recipients indicates the HEX-encoded addresses of the extra recipients besides the group members and the default account.
+	// SkipMissingKeys indicates whether to skip the group members whose public keys are not found in KeyDirectory,
+	// otherwise the call fails. The missing keys of the explicit Recipients and the default account always fail.
+	SkipMissingKeys bool
+	Endpoint        string // Endpoint indicates the endpoint of sp to list the group members.
+	SPAddress       string // SPAddress indicates the HEX-encoded string of the sp address to list the group members.
+}
+
+// PutEncryptedObjectOptions contains the options for `PutEncryptedObject` API.
+type PutEncryptedObjectOptions struct {
+	Sharing       EncryptionSharingOptions // Sharing indicates the recipients of the data key.
+	ChunkSize     int                      // ChunkSize indicates the plaintext size of each sealed chunk, DefaultEncryptionChunkSize is used if it is 0.
+	CreateOptions CreateObjectOptions      // CreateOptions indicates the options to create the object and its key envelope.
+	PutOptions    PutObjectOptions         // PutOptions indicates the options to upload the object and its key envelope.
+	// SkipGroupPolicy indicates whether to skip granting the group the permission to get the object and its key
+	// envelope, e.g. the permission is granted on the bucket already.
+	SkipGroupPolicy bool
+}
+
+// RewrapObjectKeysOptions contains the options for `RewrapObjectKeys` API.
+type RewrapObjectKeysOptions struct {
+	// Sharing indicates the recipients of the data key, the group recorded in the key envelope is used if
+	// Sharing.GroupName is empty.
+	Sharing    EncryptionSharingOptions
+	PutOptions PutObjectOptions       // PutOptions indicates the options to upload the rewritten key envelope.
+	TxOpts     *gnfdsdktypes.TxOption // TxOpts defines the options to customize the transaction updating the key envelope.
+}
+
+// BuildDatasetManifestOptions contains the options for `BuildDatasetManifest` API.
+type BuildDatasetManifestOptions struct {
+	// Prefix indicates the objects under the prefix make up the dataset if no object names are given, the objects are
+	// listed by the meta service of SP.
+	Prefix        string
+	CreateOptions CreateObjectOptions // CreateOptions indicates the options to create the manifest object.
+	PutOptions    PutObjectOptions    // PutOptions indicates the options to upload the manifest object.
+}
+
+// AbortCheckpointOptions contains the options for `AbortCheckpoint` API.
+type AbortCheckpointOptions struct {
+	CancelCreateObject bool                   // CancelCreateObject indicates whether to cancel the creation of the object of the aborted upload if it is not sealed.
+	TxOpts             *gnfdsdktypes.TxOption // TxOpts defines the options to customize the transaction canceling the creation.
+}
+
+// ListObjectsParallelOptions contains the options for `ListObjectsParallel` API.
+type ListObjectsParallelOptions struct {
+	Concurrency       int         // Concurrency indicates the number of prefixes listed in parallel, DefaultListConcurrency is used if it is 0.
+	ShowRemovedObject bool        // ShowRemovedObject determines whether to include objects that have been marked as removed in the list.
+	Delimiter         string      // Delimiter is a character that is used to group keys, currently only '/' is supported.
+	MaxKeys           uint64      // MaxKeys defines the maximum number of keys returned in each page.
+	Endpoint          string      // Endpoint indicates the endpoint of sp.
+	SPAddress         string      // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+	Consistency       Consistency // Consistency indicates how stale the pages may be, the MaxMetaBlockLag of the client applies by default.
+}
+
+// AdaptiveConcurrency configures the AIMD controller of the transfer concurrency: the concurrency is raised by one after
+// each round of the successful requests, and is cut by DecreaseFactor once a request fails or is slower than
+// TargetLatency, so the throughput is maximized without manual tuning across the heterogeneous SPs.
+//
+// It adjusts the parts downloaded in parallel by GetObjectOptions, and the files transferred in parallel by
+// SyncDirOptions. The parts of an object are uploaded sequentially, so the uploads are adjusted by SyncDirUpload only.
+type AdaptiveConcurrency struct {
+	MinConcurrency int           // MinConcurrency is the lower bound of the concurrency, 1 is used if it is 0.
+	MaxConcurrency int           // MaxConcurrency is the upper bound of the concurrency, it is required.
+	TargetLatency  time.Duration // TargetLatency is the latency of a request above which the concurrency is lowered, the latency is not considered if it is 0.
+	DecreaseFactor float64       // DecreaseFactor is the ratio of the concurrency kept when lowering it, DefaultConcurrencyDecreaseFactor is used if it is 0.
+}
+
+// CopyObjectOptions contains the options for `CopyObject` API.
+type CopyObjectOptions struct {
+	// DisableServerSideCopy skips the copy on chain and always re-uploads the object, e.g. when the SP of the
+	// destination bucket does not sign the copy approvals.
+	DisableServerSideCopy bool
+	TxOpts                *gnfdsdktypes.TxOption // TxOpts defines the options of the copyObject tx.
+	// CreateOptions indicates the options to create the destination object when it is re-uploaded, the content type
+	// of the source object is used if it is not set.
+	CreateOptions CreateObjectOptions
+	GetOptions    GetObjectOptions // GetOptions indicates the options to download the source object, the range is not supported.
+	PutOptions    PutObjectOptions // PutOptions indicates the options to upload the payload of the destination object.
+}
+
+// UploadObjectOptions contains the options for `UploadObject` API.
+type UploadObjectOptions struct {
+	// CreateOptions indicates the options to create the object, the content type of PutOptions is used if it is not
+	// set. The tx is always waited for, IsAsyncMode is ignored.
+	CreateOptions CreateObjectOptions
+	PutOptions    PutObjectOptions         // PutOptions indicates the options to upload the payload, CreateOptions of it should not be set.
+	WaitForSeal   bool                     // WaitForSeal indicates whether to block until the object is sealed.
+	SealOptions   WaitForObjectSealOptions // SealOptions indicates the options to wait for the seal.
+}
+
+// PutIfAbsentOptions contains the options for `PutIfAbsent` API.
+type PutIfAbsentOptions struct {
+	Prefix        string              // Prefix indicates the prefix of the object names, e.g. "blobs/".
+	Naming        ContentNaming       // Naming indicates how the object names are derived from the content hashes.
+	UploadOptions UploadObjectOptions // UploadOptions indicates the options to upload the object if it is absent.
+}
+
+// FindOrphanedGroupsOptions contains the options for `FindOrphanedGroups` API.
+type FindOrphanedGroupsOptions struct {
+	// Buckets indicates the buckets whose policies are searched for the references to the groups, the groups are
+	// only referenced by the policies of these buckets and their objects.
+	Buckets            []string
+	SkipObjectPolicies bool                   // SkipObjectPolicies only searches the bucket policies, which saves listing the objects of the buckets.
+	Cleanup            bool                   // Cleanup deletes the orphaned groups, the default account should be their owner.
+	BatchSize          int                    // BatchSize indicates the number of the deletions sent in a transaction, the default value is DefaultCleanupBatchSize.
+	TxOpts             *gnfdsdktypes.TxOption // TxOpts defines the options to customize the cleanup transactions.
+	Endpoint           string                 // Endpoint indicates the endpoint of sp.
+	SPAddress          string                 // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+}
+
+// FindDanglingPoliciesOptions contains the options for `FindDanglingPolicies` API.
+type FindDanglingPoliciesOptions struct {
+	Prefix    string                 // Prefix limits the search to the objects whose names begin with it.
+	Cleanup   bool                   // Cleanup deletes the policies referencing the deleted groups, the default account should be the owner of the bucket.
+	BatchSize int                    // BatchSize indicates the number of the deletions sent in a transaction, the default value is DefaultCleanupBatchSize.
+	TxOpts    *gnfdsdktypes.TxOption // TxOpts defines the options to customize the cleanup transactions.
+	Endpoint  string                 // Endpoint indicates the endpoint of sp.
+	SPAddress string                 // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
+}
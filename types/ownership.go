@@ -0,0 +1,110 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ownershipProofTemplate is the message signed by the ownership proofs, it is human-readable so that the wallets can
+// sign it by personal_sign.
+const ownershipProofTemplate = "Greenfield account ownership proof\nAddress: %s\nNonce: %s\nIssued At: %s"
+
+// ErrInvalidOwnershipProof indicates the ownership proof is not signed by the account, or it does not match the
+// nonce or the validity period expected by the verifier.
+var ErrInvalidOwnershipProof = errors.New("invalid ownership proof")
+
+// OwnershipProof proves the holder of an account off-chain, by the signature of the account over the nonce issued
+// by the verifier and the time of the proof.
+type OwnershipProof struct {
+	Address   string `json:"address"`
+	Nonce     string `json:"nonce"`
+	IssuedAt  string `json:"issued_at"` // IssuedAt is the time of the proof in RFC3339.
+	Signature string `json:"signature"` // Signature is the HEX-encoded personal_sign signature of Message.
+}
+
+// Message returns the message signed by the proof.
+func (p *OwnershipProof) Message() string {
+	return fmt.Sprintf(ownershipProofTemplate, p.Address, p.Nonce, p.IssuedAt)
+}
+
+// NewOwnershipProof - Sign the ownership proof of the account for the nonce.
+//
+// - account: The account to prove.
+//
+// - nonce: The nonce issued by the verifier, it should be unpredictable and used once.
+//
+// - issuedAt: The time of the proof.
+//
+// - ret1: The signed proof.
+//
+// - ret2: Return error when the nonce is empty or the signing failed, otherwise return nil.
+func NewOwnershipProof(account *Account, nonce string, issuedAt time.Time) (*OwnershipProof, error) {
+	if account == nil {
+		return nil, ErrorDefaultAccountNotExist
+	}
+	if nonce == "" || strings.ContainsAny(nonce, "\r\n") {
+		return nil, errors.New("the nonce of the ownership proof should be a non-empty single line")
+	}
+	proof := &OwnershipProof{
+		Address:  account.GetAddress().String(),
+		Nonce:    nonce,
+		IssuedAt: issuedAt.UTC().Format(time.RFC3339),
+	}
+	sig, err := account.Sign(accounts.TextHash([]byte(proof.Message())))
+	if err != nil {
+		return nil, err
+	}
+	proof.Signature = hexutil.Encode(sig)
+	return proof, nil
+}
+
+// VerifyOwnershipProof - Verify the ownership proof is signed by its address for the nonce within maxAge.
+//
+// - proof: The proof to verify.
+//
+// - nonce: The nonce issued by the verifier.
+//
+// - maxAge: The max age of the proof, the proofs issued in the future beyond DefaultMaxClockSkew are rejected too.
+//
+// - now: The current time of the verifier.
+//
+// - ret: Return error wrapping ErrInvalidOwnershipProof if the proof is invalid, otherwise return nil.
+func VerifyOwnershipProof(proof *OwnershipProof, nonce string, maxAge time.Duration, now time.Time) error {
+	if proof == nil || proof.Nonce != nonce {
+		return fmt.Errorf("%w: the nonce does not match", ErrInvalidOwnershipProof)
+	}
+	if !common.IsHexAddress(proof.Address) {
+		return fmt.Errorf("%w: invalid address %q", ErrInvalidOwnershipProof, proof.Address)
+	}
+	issuedAt, err := time.Parse(time.RFC3339, proof.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("%w: invalid issued time %q", ErrInvalidOwnershipProof, proof.IssuedAt)
+	}
+	if now.Sub(issuedAt) > maxAge || issuedAt.Sub(now) > DefaultMaxClockSkew {
+		return fmt.Errorf("%w: issued at %s is out of the validity period", ErrInvalidOwnershipProof, proof.IssuedAt)
+	}
+
+	sig, err := hexutil.Decode(proof.Signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidOwnershipProof)
+	}
+	// the wallets sign with the recovery id of 27 or 28
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(proof.Message())), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOwnershipProof, err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != common.HexToAddress(proof.Address) {
+		return fmt.Errorf("%w: the signer is not %s", ErrInvalidOwnershipProof, proof.Address)
+	}
+	return nil
+}
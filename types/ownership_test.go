@@ -0,0 +1,39 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnershipProof(t *testing.T) {
+	account, _, err := NewAccount("test")
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	proof, err := NewOwnershipProof(account, "nonce-1", now)
+	require.NoError(t, err)
+	require.Equal(t, account.GetAddress().String(), proof.Address)
+	require.NoError(t, VerifyOwnershipProof(proof, "nonce-1", time.Minute, now.Add(30*time.Second)))
+
+	for name, verify := range map[string]func() error{
+		"nonce":   func() error { return VerifyOwnershipProof(proof, "nonce-2", time.Minute, now) },
+		"expired": func() error { return VerifyOwnershipProof(proof, "nonce-1", time.Minute, now.Add(2*time.Minute)) },
+		"future":  func() error { return VerifyOwnershipProof(proof, "nonce-1", time.Minute, now.Add(-time.Hour)) },
+		"tampered": func() error {
+			other, _, err := NewAccount("other")
+			require.NoError(t, err)
+			tampered := *proof
+			tampered.Address = other.GetAddress().String()
+			return VerifyOwnershipProof(&tampered, "nonce-1", time.Minute, now)
+		},
+	} {
+		err := verify()
+		require.True(t, errors.Is(err, ErrInvalidOwnershipProof), name)
+	}
+
+	_, err = NewOwnershipProof(account, "nonce\nAddress: 0x0", now)
+	require.Error(t, err)
+}
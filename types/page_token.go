@@ -0,0 +1,102 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PageTokenVersion is the version of the PageToken format.
+const PageTokenVersion = 1
+
+// the limits of the pages of the list APIs paginated by StartAfter
+const (
+	DefaultListPageLimit = 50
+	MaxListPageLimit     = 1000
+)
+
+// ErrInvalidPageToken indicates the page token is malformed or is returned by another list API.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PageKind indicates the list API which a PageToken is returned by.
+type PageKind string
+
+const (
+	PageObjects      PageKind = "objects"       // PageObjects is the page of ListObjects.
+	PageGroups       PageKind = "groups"        // PageGroups is the page of ListGroup.
+	PageGroupMembers PageKind = "group_members" // PageGroupMembers is the page of ListGroupMembers.
+	PageUserGroups   PageKind = "user_groups"   // PageUserGroups is the page of ListGroupsByAccount.
+	PageOwnedGroups  PageKind = "owned_groups"  // PageOwnedGroups is the page of ListGroupsByOwner.
+	PageReadRecords  PageKind = "read_records"  // PageReadRecords is the page of ListBucketReadRecord.
+)
+
+// PageToken is the opaque token of the next page returned by the list APIs as NextPageToken, it is passed back by the
+// PageToken field of the options to list that page, instead of the StartAfter, ContinuationToken, Offset or
+// StartTimeStamp of each API. The empty token stands for the first page, and no page is left once the returned token
+// is empty.
+type PageToken string
+
+type pageCursor struct {
+	Version  int      `json:"v"`
+	Kind     PageKind `json:"k"`
+	Position string   `json:"p"`
+}
+
+// NewPageToken - Encode the position of the next page of the list API, the token is empty if position is empty.
+//
+// - kind: The list API returning the token.
+//
+// - position: The position of the next page in the pagination of the API, e.g. the continuation token.
+//
+// - ret: The page token.
+func NewPageToken(kind PageKind, position string) PageToken {
+	if position == "" {
+		return ""
+	}
+	encoded, _ := json.Marshal(pageCursor{Version: PageTokenVersion, Kind: kind, Position: position})
+	return PageToken(base64.RawURLEncoding.EncodeToString(encoded))
+}
+
+// Position - Decode the position of the page encoded by the token.
+//
+// - kind: The list API the token is passed to.
+//
+// - ret1: The position of the page, it is empty for the empty token.
+//
+// - ret2: Return error wrapping ErrInvalidPageToken if the token is malformed or is returned by another API,
+// otherwise return nil.
+func (t PageToken) Position(kind PageKind) (string, error) {
+	if t == "" {
+		return "", nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(string(t))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	cursor := pageCursor{}
+	if err = json.Unmarshal(decoded, &cursor); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	if cursor.Version != PageTokenVersion {
+		return "", fmt.Errorf("%w: unsupported version %d", ErrInvalidPageToken, cursor.Version)
+	}
+	if cursor.Kind != kind || cursor.Position == "" {
+		return "", fmt.Errorf("%w: the token of %s pages is passed to list %s", ErrInvalidPageToken, cursor.Kind, kind)
+	}
+	return cursor.Position, nil
+}
+
+// NextStartAfterToken returns the token of the page following a full page of the list API paginated by StartAfter,
+// the token is empty if the page is not full so that no page is left.
+func NextStartAfterToken(kind PageKind, limit int64, count int, last string) PageToken {
+	if limit <= 0 {
+		limit = DefaultListPageLimit
+	} else if limit > MaxListPageLimit {
+		limit = MaxListPageLimit
+	}
+	if count == 0 || int64(count) < limit {
+		return ""
+	}
+	return NewPageToken(kind, last)
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	require.Equal(t, PageToken(""), NewPageToken(PageObjects, ""))
+	position, err := PageToken("").Position(PageObjects)
+	require.NoError(t, err)
+	require.Empty(t, position)
+
+	token := NewPageToken(PageObjects, "b2JqZWN0")
+	position, err = token.Position(PageObjects)
+	require.NoError(t, err)
+	require.Equal(t, "b2JqZWN0", position)
+
+	_, err = token.Position(PageGroups)
+	require.True(t, errors.Is(err, ErrInvalidPageToken))
+	_, err = PageToken("not a token").Position(PageObjects)
+	require.True(t, errors.Is(err, ErrInvalidPageToken))
+
+	require.Empty(t, NextStartAfterToken(PageGroupMembers, 0, 49, "0x1"))
+	require.Empty(t, NextStartAfterToken(PageGroupMembers, 10, 0, ""))
+	position, err = NextStartAfterToken(PageUserGroups, 2000, 1000, "42").Position(PageUserGroups)
+	require.NoError(t, err)
+	require.Equal(t, "42", position)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	paymentTypes "github.com/bnb-chain/greenfield/x/payment/types"
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// ParamsModule is the chain module whose params can be watched.
+type ParamsModule string
+
+const (
+	StorageParamsModule ParamsModule = "storage"
+	PaymentParamsModule ParamsModule = "payment"
+	SPParamsModule      ParamsModule = "sp"
+)
+
+// ParamsChange carries the params of a module when they are first loaded or changed, only the field of the watched
+// module is set.
+type ParamsChange struct {
+	Module  ParamsModule
+	Storage *storageTypes.Params
+	Payment *paymentTypes.Params
+	SP      *spTypes.Params
+}
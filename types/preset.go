@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExplorerTemplates contains the URL templates of a block explorer, each template has a single %s placeholder.
+type ExplorerTemplates struct {
+	Tx      string // Tx is the template of the transaction page, the placeholder is the tx hash.
+	Bucket  string // Bucket is the template of the bucket page, the placeholder is the 0x-prefixed hex bucket id.
+	Object  string // Object is the template of the object page, the placeholder is the 0x-prefixed hex object id.
+	Address string // Address is the template of the account page, the placeholder is the address.
+}
+
+// Preset contains the well-known settings of a Greenfield network, so that the applications select the network by
+// its name instead of hard-coding the endpoints.
+type Preset struct {
+	Name       string            // Name indicates the name of the network, e.g. "testnet".
+	ChainID    string            // ChainID indicates the chain id of the network.
+	RPCAddress string            // RPCAddress indicates the public RPC URL of the blockchain nodes.
+	Explorer   ExplorerTemplates // Explorer contains the URL templates of the block explorer, they are empty if there is none.
+}
+
+const (
+	// PresetMainnet is the name of the Greenfield mainnet preset.
+	PresetMainnet = "mainnet"
+	// PresetTestnet is the name of the Greenfield testnet preset.
+	PresetTestnet = "testnet"
+	// PresetLocalnet is the name of the preset of a local network started by the deployment scripts of Greenfield.
+	PresetLocalnet = "localnet"
+)
+
+var presets = map[string]Preset{
+	PresetMainnet: {
+		Name:       PresetMainnet,
+		ChainID:    MainnetChainID,
+		RPCAddress: "https://greenfield-chain.bnbchain.org:443",
+		Explorer:   greenfieldScanTemplates("https://greenfieldscan.com"),
+	},
+	PresetTestnet: {
+		Name:       PresetTestnet,
+		ChainID:    "greenfield_5600-1",
+		RPCAddress: "https://gnfd-testnet-fullnode-tendermint-us.bnbchain.org:443",
+		Explorer:   greenfieldScanTemplates("https://testnet.greenfieldscan.com"),
+	},
+	PresetLocalnet: {
+		Name:       PresetLocalnet,
+		ChainID:    "greenfield_9000-121",
+		RPCAddress: "http://localhost:26750",
+	},
+}
+
+func greenfieldScanTemplates(baseURL string) ExplorerTemplates {
+	return ExplorerTemplates{
+		Tx:      baseURL + "/tx/%s",
+		Bucket:  baseURL + "/bucket/%s",
+		Object:  baseURL + "/object/%s",
+		Address: baseURL + "/account/%s",
+	}
+}
+
+// LookupPreset returns the preset of the network by its name, the name is case-insensitive.
+func LookupPreset(name string) (Preset, error) {
+	preset, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return Preset{}, fmt.Errorf("unknown network preset %q, the known presets are %s", name, strings.Join(PresetNames(), ", "))
+	}
+	return preset, nil
+}
+
+// PresetByChainID returns the preset of the network by its chain id, false is returned if the network is unknown.
+func PresetByChainID(chainID string) (Preset, bool) {
+	for _, preset := range presets {
+		if preset.ChainID == chainID {
+			return preset, true
+		}
+	}
+	return Preset{}, false
+}
+
+// PresetNames returns the sorted names of the presets.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupPreset(t *testing.T) {
+	preset, err := LookupPreset("Testnet")
+	require.NoError(t, err)
+	require.Equal(t, "greenfield_5600-1", preset.ChainID)
+
+	preset, ok := PresetByChainID(MainnetChainID)
+	require.True(t, ok)
+	require.Equal(t, PresetMainnet, preset.Name)
+	_, ok = PresetByChainID("greenfield_1-1")
+	require.False(t, ok)
+
+	_, err = LookupPreset("devnet")
+	require.ErrorContains(t, err, "localnet, mainnet, testnet")
+}
@@ -0,0 +1,18 @@
+package types
+
+// ProgressListener receives the progress of an upload or a download, e.g. to render a progress bar or to compute the
+// transfer rate.
+//
+// OnProgress is called after each read with the bytes transferred so far and the total bytes to transfer, total is -1
+// if it is unknown. The calls are serialized, transferred may decrease when a request is retried from the beginning.
+type ProgressListener interface {
+	OnProgress(transferred, total int64)
+}
+
+// ProgressListenerFunc adapts a function to ProgressListener.
+type ProgressListenerFunc func(transferred, total int64)
+
+// OnProgress calls f(transferred, total).
+func (f ProgressListenerFunc) OnProgress(transferred, total int64) {
+	f(transferred, total)
+}
@@ -0,0 +1,44 @@
+package types
+
+// ReplicaDiffKind is the kind of the difference found between the source and the destination buckets.
+type ReplicaDiffKind string
+
+const (
+	// ReplicaMissing indicates the source object is not found in the destination bucket.
+	ReplicaMissing ReplicaDiffKind = "missing"
+	// ReplicaExtra indicates the destination object is not found in the source bucket.
+	ReplicaExtra ReplicaDiffKind = "extra"
+	// ReplicaNotSealed indicates the destination object is not sealed yet.
+	ReplicaNotSealed ReplicaDiffKind = "not_sealed"
+	// ReplicaSizeMismatch indicates the payload sizes of the objects differ.
+	ReplicaSizeMismatch ReplicaDiffKind = "size_mismatch"
+	// ReplicaChecksumMismatch indicates the on-chain checksums of the objects differ.
+	ReplicaChecksumMismatch ReplicaDiffKind = "checksum_mismatch"
+	// ReplicaContentMismatch indicates the downloaded contents of the sampled objects differ.
+	ReplicaContentMismatch ReplicaDiffKind = "content_mismatch"
+	// ReplicaSampleFailed indicates the sampled objects can not be downloaded for comparison.
+	ReplicaSampleFailed ReplicaDiffKind = "sample_failed"
+)
+
+// ReplicaDiff is a difference of an object between the source and the destination buckets.
+type ReplicaDiff struct {
+	ObjectName string          `json:"object_name"`
+	Kind       ReplicaDiffKind `json:"kind"`
+	Detail     string          `json:"detail,omitempty"`
+}
+
+// ReplicaReport is the machine-readable result of verifying a replica bucket against the source bucket.
+type ReplicaReport struct {
+	SourceBucket       string        `json:"source_bucket"`
+	DestinationBucket  string        `json:"destination_bucket"`
+	SourceObjects      int           `json:"source_objects"`      // SourceObjects is the number of the sealed objects in the source bucket.
+	DestinationObjects int           `json:"destination_objects"` // DestinationObjects is the number of the objects in the destination bucket.
+	Matched            int           `json:"matched"`             // Matched is the number of the objects whose sizes and checksums match.
+	Sampled            int           `json:"sampled"`             // Sampled is the number of the matched objects whose contents are compared.
+	Diffs              []ReplicaDiff `json:"diffs"`
+}
+
+// Consistent reports whether no difference is found.
+func (r *ReplicaReport) Consistent() bool {
+	return len(r.Diffs) == 0
+}
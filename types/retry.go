@@ -0,0 +1,73 @@
+package types
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryableStatusCodes are the SP response status codes retried by default, they indicate the transient
+// failures of SP or the gateways in front of it.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// BackoffStrategy returns the delay before a retry, attempt is 1 for the first retry.
+type BackoffStrategy func(attempt int) time.Duration
+
+// ExponentialBackoff - Create a backoff strategy doubling the delay from base for each retry up to max. A random jitter
+// up to half of the delay is subtracted, so that the clients failed at the same time do not retry in lockstep.
+func ExponentialBackoff(base, max time.Duration) BackoffStrategy {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		if half := int64(delay / 2); half > 0 {
+			delay -= time.Duration(rand.Int63n(half))
+		}
+		return delay
+	}
+}
+
+// RetryPolicy decides whether and when the failed SP requests are retried.
+type RetryPolicy struct {
+	MaxAttempts          int             // MaxAttempts indicates the max number of attempts including the first one, the requests are not retried if it is less than 2.
+	Backoff              BackoffStrategy // Backoff indicates the delay before each retry, the default value is ExponentialBackoff(DefaultRetryBaseDelay, DefaultRetryMaxDelay).
+	RetryableStatusCodes []int           // RetryableStatusCodes indicates the response status codes to retry, the default value is DefaultRetryableStatusCodes.
+}
+
+// DefaultRetryPolicy - Create the retry policy retrying the transient failures up to DefaultRetryAttempts attempts
+// with the exponential backoff.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: DefaultRetryAttempts}
+}
+
+// Delay returns the delay before the retry attempt.
+func (p *RetryPolicy) Delay(attempt int) time.Duration {
+	if p.Backoff == nil {
+		return ExponentialBackoff(DefaultRetryBaseDelay, DefaultRetryMaxDelay)(attempt)
+	}
+	return p.Backoff(attempt)
+}
+
+// IsRetryableStatus reports whether the response with the status code should be retried.
+func (p *RetryPolicy) IsRetryableStatus(statusCode int) bool {
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, code := range codes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
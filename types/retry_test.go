@@ -0,0 +1,40 @@
+package types
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, full := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		delay := backoff(attempt)
+		require.LessOrEqual(t, delay, full, "attempt %d", attempt)
+		require.Greater(t, delay, full/2, "attempt %d", attempt)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	require.True(t, policy.IsRetryableStatus(http.StatusServiceUnavailable))
+	require.False(t, policy.IsRetryableStatus(http.StatusNotFound))
+	require.LessOrEqual(t, policy.Delay(1), DefaultRetryBaseDelay)
+
+	policy = &RetryPolicy{
+		MaxAttempts:          2,
+		Backoff:              func(int) time.Duration { return time.Millisecond },
+		RetryableStatusCodes: []int{http.StatusConflict},
+	}
+	require.True(t, policy.IsRetryableStatus(http.StatusConflict))
+	require.False(t, policy.IsRetryableStatus(http.StatusServiceUnavailable))
+	require.Equal(t, time.Millisecond, policy.Delay(3))
+}
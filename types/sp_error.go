@@ -0,0 +1,120 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// The codes of the SP error responses, the ones not sent by SP are inferred from the status codes when the response
+// body is not a valid xml error.
+const (
+	SPErrCodeNoSuchBucket    = "NoSuchBucket"
+	SPErrCodeNoSuchObject    = "NoSuchObject"
+	SPErrCodeAccessDenied    = "AccessDenied"
+	SPErrCodeInvalidArgument = "InvalidArgument"
+	SPErrCodeInternalError   = "InternalError"
+	SPErrCodeUnknown         = unknownErr
+	// SPErrCodeBucketQuotaExceeded is the code returned by the downloader of SP when the read quota of the bucket is
+	// exhausted.
+	SPErrCodeBucketQuotaExceeded = "30004"
+)
+
+// SPError is the structured error response of SP, the callers can branch on it by errors.As or the predicates such as
+// IsNoSuchBucket rather than matching the error messages. It unwraps to the ErrResponse, so errors.As with an
+// ErrResponse target keeps working, while the type assertion err.(ErrResponse) does not.
+type SPError struct {
+	Code       string // Code is the error code of SP, e.g. SPErrCodeNoSuchBucket.
+	Message    string // Message is the human-readable description of the error.
+	HTTPStatus int    // HTTPStatus is the http status code of the response.
+	RequestID  string // RequestID is the id of the request generated by SP, it helps SP to locate the request in its logs.
+	Endpoint   string // Endpoint indicates the host of the SP which returns the error.
+}
+
+func newSPError(errResp ErrResponse, r *http.Response) *SPError {
+	spErr := &SPError{
+		Code:       errResp.Code,
+		Message:    errResp.Message,
+		HTTPStatus: errResp.StatusCode,
+		RequestID:  r.Header.Get(HTTPHeaderRequestID),
+	}
+	if r.Request != nil {
+		spErr.Endpoint = r.Request.URL.Host
+	}
+	return spErr
+}
+
+// Error returns the error msg
+func (e *SPError) Error() string {
+	msg := fmt.Sprintf("statusCode %v : code : %s  (Message: %s)", e.HTTPStatus, e.Code, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (RequestID: %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Unwrap returns the ErrResponse carrying the code, the message and the status code.
+func (e *SPError) Unwrap() error {
+	return ErrResponse{Code: e.Code, Message: e.Message, StatusCode: e.HTTPStatus}
+}
+
+// Retryable reports whether the error indicates a transient failure of SP, i.e. the status code is one of
+// DefaultRetryableStatusCodes.
+func (e *SPError) Retryable() bool {
+	for _, code := range DefaultRetryableStatusCodes {
+		if e.HTTPStatus == code {
+			return true
+		}
+	}
+	return false
+}
+
+// AsSPError - Find the SP error in the chain of err, the ErrResponse built by the SDK is converted as well.
+//
+// - err: The error returned by the APIs.
+//
+// - ret1: The SP error.
+//
+// - ret2: Whether an SP error is found.
+func AsSPError(err error) (*SPError, bool) {
+	var spErr *SPError
+	if errors.As(err, &spErr) {
+		return spErr, true
+	}
+	var errResp ErrResponse
+	if errors.As(err, &errResp) {
+		return &SPError{Code: errResp.Code, Message: errResp.Message, HTTPStatus: errResp.StatusCode}, true
+	}
+	return nil, false
+}
+
+// IsNoSuchBucket reports whether err is the SP error of the missing bucket.
+func IsNoSuchBucket(err error) bool {
+	return hasSPErrCode(err, SPErrCodeNoSuchBucket)
+}
+
+// IsNoSuchObject reports whether err is the SP error of the missing object.
+func IsNoSuchObject(err error) bool {
+	return hasSPErrCode(err, SPErrCodeNoSuchObject)
+}
+
+// IsAccessDenied reports whether err is the SP error of the missing permission.
+func IsAccessDenied(err error) bool {
+	return hasSPErrCode(err, SPErrCodeAccessDenied)
+}
+
+// IsQuotaExceeded reports whether err is the SP error of the exhausted read quota of the bucket.
+func IsQuotaExceeded(err error) bool {
+	return hasSPErrCode(err, SPErrCodeBucketQuotaExceeded)
+}
+
+// IsRetryable reports whether err is the SP error of a transient failure, see SPError.Retryable.
+func IsRetryable(err error) bool {
+	spErr, ok := AsSPError(err)
+	return ok && spErr.Retryable()
+}
+
+func hasSPErrCode(err error, code string) bool {
+	spErr, ok := AsSPError(err)
+	return ok && spErr.Code == code
+}
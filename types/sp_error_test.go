@@ -0,0 +1,95 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newErrHTTPResponse(statusCode int, body string) *http.Response {
+	header := make(http.Header)
+	header.Set(HTTPHeaderRequestID, "req-1")
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{URL: &url.URL{Scheme: "https", Host: "sp0.example.com"}},
+	}
+}
+
+func TestConstructErrResponse(t *testing.T) {
+	require.NoError(t, ConstructErrResponse(newErrHTTPResponse(http.StatusOK, ""), "bucket", ""))
+
+	err := ConstructErrResponse(newErrHTTPResponse(http.StatusBadRequest,
+		"<Error><Code>30004</Code><Message>bucket quota overflow</Message></Error>"), "bucket", "object")
+	spErr, ok := AsSPError(fmt.Errorf("download: %w", err))
+	require.True(t, ok)
+	require.Equal(t, SPError{
+		Code:       SPErrCodeBucketQuotaExceeded,
+		Message:    "bucket quota overflow",
+		HTTPStatus: http.StatusBadRequest,
+		RequestID:  "req-1",
+		Endpoint:   "sp0.example.com",
+	}, *spErr)
+	require.True(t, IsQuotaExceeded(err))
+	require.False(t, IsRetryable(err))
+	require.Contains(t, err.Error(), "RequestID: req-1")
+
+	// the callers matching the ErrResponse keep working
+	var errResp ErrResponse
+	require.True(t, errors.As(err, &errResp))
+	require.Equal(t, SPErrCodeBucketQuotaExceeded, errResp.Code)
+}
+
+func TestConstructErrResponseErrorsAs(t *testing.T) {
+	err := fmt.Errorf("head object: %w", ConstructErrResponse(newErrHTTPResponse(http.StatusNotFound,
+		"<Error><Code>NoSuchObject</Code><Message>object not found</Message></Error>"), "bucket", "object"))
+
+	// the type assertion of the ErrResponse value is broken by the *SPError
+	_, ok := err.(ErrResponse)
+	require.False(t, ok)
+
+	var errResp ErrResponse
+	require.True(t, errors.As(err, &errResp))
+	require.Equal(t, ErrResponse{Code: SPErrCodeNoSuchObject, Message: "object not found", StatusCode: http.StatusNotFound}, errResp)
+
+	var spErr *SPError
+	require.True(t, errors.As(err, &spErr))
+	require.Equal(t, SPErrCodeNoSuchObject, spErr.Code)
+	require.Equal(t, http.StatusNotFound, spErr.HTTPStatus)
+	require.Equal(t, "req-1", spErr.RequestID)
+	require.Equal(t, "sp0.example.com", spErr.Endpoint)
+
+	// the empty response is matched by both targets as well
+	err = ConstructErrResponse(nil, "bucket", "object")
+	require.True(t, errors.As(err, &errResp))
+	require.Equal(t, SPErrCodeUnknown, errResp.Code)
+	require.True(t, errors.As(err, &spErr))
+	require.Equal(t, SPErrCodeUnknown, spErr.Code)
+}
+
+func TestSPErrorPredicates(t *testing.T) {
+	err := ConstructErrResponse(newErrHTTPResponse(http.StatusNotFound, ""), "bucket", "")
+	require.True(t, IsNoSuchBucket(err))
+	require.False(t, IsNoSuchObject(err))
+
+	err = ConstructErrResponse(newErrHTTPResponse(http.StatusNotFound, ""), "bucket", "object")
+	require.True(t, IsNoSuchObject(err))
+
+	err = ConstructErrResponse(newErrHTTPResponse(http.StatusForbidden, ""), "bucket", "object")
+	require.True(t, IsAccessDenied(err))
+
+	err = ConstructErrResponse(newErrHTTPResponse(http.StatusServiceUnavailable, "busy"), "bucket", "object")
+	require.True(t, IsRetryable(err))
+
+	// the ErrResponse built by the SDK is recognized as well
+	require.True(t, IsNoSuchObject(ErrResponse{Code: SPErrCodeNoSuchObject, StatusCode: http.StatusNotFound}))
+	require.False(t, IsNoSuchObject(errors.New(SPErrCodeNoSuchObject)))
+	require.False(t, IsRetryable(nil))
+}
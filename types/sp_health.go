@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// SPHealthPolicy decides when an SP is ejected from the routing of the requests which can be served by any SP, e.g.
+// the list and the metadata queries without a specified endpoint. The requests routed to a specific SP, e.g. those
+// of a bucket to its primary SP, are not affected.
+type SPHealthPolicy struct {
+	Disabled            bool          // Disabled keeps routing to all the SPs regardless of their health.
+	ConsecutiveFailures int           // ConsecutiveFailures ejects the SP after the number of the consecutive failed requests.
+	ErrorRateThreshold  float64       // ErrorRateThreshold ejects the SP when its recent error rate reaches it, between 0 and 1.
+	MinSamples          int64         // MinSamples is the number of the requests to an SP before its error rate is trusted.
+	EjectDuration       time.Duration // EjectDuration is how long an ejected SP is skipped, it is tried again afterwards.
+}
+
+// DefaultSPHealthPolicy returns the SPHealthPolicy used when the client is not configured with one.
+func DefaultSPHealthPolicy() SPHealthPolicy {
+	return SPHealthPolicy{
+		ConsecutiveFailures: 3,
+		ErrorRateThreshold:  0.5,
+		MinSamples:          10,
+		EjectDuration:       30 * time.Second,
+	}
+}
+
+// SPHealthScore is the health of an SP measured from the requests sent to it by the client. The server errors and
+// the connection failures are counted as failures, the client errors such as NoSuchObject are not.
+type SPHealthScore struct {
+	Endpoint            string        // Endpoint is the host of the SP.
+	Requests            int64         // Requests is the number of the requests sent to the SP.
+	Failures            int64         // Failures is the number of the failed requests.
+	ErrorRate           float64       // ErrorRate is the recent error rate, the recent requests weigh more.
+	Latency             time.Duration // Latency is the recent latency until the response headers are received.
+	ConsecutiveFailures int           // ConsecutiveFailures is the number of the failures since the last success.
+	Ejected             bool          // Ejected indicates the SP is skipped by the routing now.
+	EjectedUntil        time.Time     // EjectedUntil is when the SP is tried again, it is zero if the SP has never been ejected.
+	Circuit             CircuitState  // Circuit is the state of the circuit breaker of the SP, it is empty if the circuit breaker is not enabled.
+}
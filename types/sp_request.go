@@ -0,0 +1,27 @@
+package types
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SPRequest describes a request to the REST API of SP, it allows to call the SP endpoints which the SDK has not
+// wrapped yet. The request is built, signed and sent in the same way as the wrapped APIs.
+type SPRequest struct {
+	Method        string      // Method is the http method, http.MethodGet is used if it is empty.
+	BucketName    string      // BucketName indicates the bucket in the url, the request is routed to the primary SP of the bucket unless EndPoint is set.
+	ObjectName    string      // ObjectName indicates the object in the url.
+	Path          string      // Path is the relative path of the url, e.g. "auth/request_nonce" or "greenfield/admin/v1/get-approval".
+	Query         url.Values  // Query contains the url values added into the url.
+	Header        http.Header // Header contains the headers added into the request before it is signed.
+	Body          io.Reader   // Body is the request body, the request is only retried if it implements io.Seeker.
+	ContentLength int64       // ContentLength is the size of Body.
+	ContentType   string      // ContentType is the content type of Body, types.ContentDefault is used if it is empty.
+	ContentSHA256 string      // ContentSHA256 is the hex encoded sha256 of Body, it is signed if it is set.
+	Range         string      // Range is the range header of the GET requests, e.g. "bytes=0-1023".
+	UserAddress   string      // UserAddress is set in the X-Gnfd-User-Address header if it is not empty.
+	// EndPoint indicates the SP to send the request to. The primary SP of BucketName is used if it is nil, and an
+	// in-service SP is used if BucketName is empty as well.
+	EndPoint *EndPointOptions
+}
@@ -0,0 +1,25 @@
+package types
+
+import "errors"
+
+// SPResponseVerification indicates how the contents signed or sealed by SP are verified before they are trusted, e.g.
+// the approvals of the createBucket and createObject msgs and the piece data served by the challenge API.
+type SPResponseVerification int
+
+const (
+	// SPResponseVerificationOff trusts the SP responses without verifying them.
+	SPResponseVerificationOff SPResponseVerification = iota
+	// SPResponseVerificationBestEffort rejects the SP responses failing the verification, the ones which can not be
+	// verified, e.g. the SP is unknown to the client, are accepted with a warning.
+	SPResponseVerificationBestEffort
+	// SPResponseVerificationStrict rejects the SP responses which can not be verified as well.
+	SPResponseVerificationStrict
+)
+
+var (
+	// ErrInvalidSPSignature indicates the content is not signed by the on-chain key of the SP or mismatches the
+	// checksums sealed on chain.
+	ErrInvalidSPSignature = errors.New("the SP response fails the verification")
+	// ErrUnverifiableSPResponse indicates the content can not be verified, e.g. the SP is unknown to the client.
+	ErrUnverifiableSPResponse = errors.New("the SP response can not be verified")
+)
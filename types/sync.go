@@ -0,0 +1,8 @@
+package types
+
+// SyncResult summarizes the directory sync between a local directory and the objects under a prefix.
+type SyncResult struct {
+	Transferred []string         // Transferred contains the names of the objects transferred, or to be transferred in the dry run.
+	Unchanged   []string         // Unchanged contains the names of the objects whose sizes and checksums match the files.
+	Failed      map[string]error // Failed maps the names of the objects failed to transfer to the errors.
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"sort"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+// NewResourceTags converts the tags into the resource tags on chain, the tags are sorted by the keys.
+func NewResourceTags(tags map[string]string) *storageTypes.ResourceTags {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	resourceTags := &storageTypes.ResourceTags{Tags: make([]storageTypes.ResourceTags_Tag, 0, len(keys))}
+	for _, key := range keys {
+		resourceTags.Tags = append(resourceTags.Tags, storageTypes.ResourceTags_Tag{Key: key, Value: tags[key]})
+	}
+	return resourceTags
+}
+
+// TagsToMap converts the resource tags on chain into the map from the keys to the values, it returns nil if there is
+// no tag.
+func TagsToMap(tags *storageTypes.ResourceTags) map[string]string {
+	if tags == nil || len(tags.Tags) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(tags.Tags))
+	for _, tag := range tags.Tags {
+		m[tag.Key] = tag.Value
+	}
+	return m
+}
+
+// MatchTags reports whether the resource carries all the tags of filter, an empty filter matches any resource.
+func MatchTags(tags *storageTypes.ResourceTags, filter map[string]string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	m := TagsToMap(tags)
+	for key, value := range filter {
+		if v, ok := m[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
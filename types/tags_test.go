@@ -0,0 +1,19 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	tags := NewResourceTags(map[string]string{"team": "data", "cost-center": "42"})
+	require.Equal(t, "cost-center", tags.Tags[0].Key)
+	require.Equal(t, map[string]string{"team": "data", "cost-center": "42"}, TagsToMap(tags))
+	require.Nil(t, TagsToMap(nil))
+
+	require.True(t, MatchTags(nil, nil))
+	require.True(t, MatchTags(tags, map[string]string{"team": "data"}))
+	require.False(t, MatchTags(tags, map[string]string{"team": "web"}))
+	require.False(t, MatchTags(nil, map[string]string{"team": "data"}))
+}
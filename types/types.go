@@ -3,9 +3,14 @@ package types
 import (
 	"io"
 	"math/rand"
+	"net/http"
 	"net/url"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	proto "github.com/cosmos/gogoproto/proto"
 
@@ -27,6 +32,52 @@ type ObjectStat struct {
 	Size        int64 // Object size
 }
 
+// UnsignedUploadRequest contains the request info of uploading an object or a part of it, which waits for the
+// signature of the uploader. The signature is generated by signing UnsignedMsg in the GNFD1-ECDSA way.
+//
+// The request should be sent before the expiry timestamp in Header, which is determined by Option.ExpireSeconds.
+type UnsignedUploadRequest struct {
+	BucketName    string
+	ObjectName    string
+	Method        string
+	URL           string
+	Host          string
+	Header        http.Header
+	Offset        int64  // Offset indicates the position of the uploaded data in the object.
+	ContentLength int64  // ContentLength indicates the length of the uploaded data.
+	Complete      bool   // Complete indicates whether it is the last request of the object.
+	UnsignedMsg   []byte // UnsignedMsg is the keccak256 hash of the canonical request, which should be signed by the uploader.
+	// CanonicalRequest is the canonical request from which UnsignedMsg is derived, it can be shown to the uploader
+	// before signing.
+	CanonicalRequest string
+}
+
+// ResponseInfo contains the info carried by the headers of the SP response, it can be requested by the ResponseInfo
+// field of the options of the query APIs for debugging.
+type ResponseInfo struct {
+	Endpoint    string      // Endpoint indicates the host of the SP which serves the response.
+	StatusCode  int         // StatusCode is the http status code of the response.
+	RequestID   string      // RequestID is the id of the request generated by SP.
+	BlockHeight int64       // BlockHeight is the block height the meta service of SP has synced to, it is 0 if not reported.
+	Header      http.Header // Header contains all the headers of the response.
+}
+
+// NewResponseInfo - Collect the info from the headers of the SP response.
+func NewResponseInfo(resp *http.Response) ResponseInfo {
+	info := ResponseInfo{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(HTTPHeaderRequestID),
+		Header:     resp.Header.Clone(),
+	}
+	if resp.Request != nil {
+		info.Endpoint = resp.Request.URL.Host
+	}
+	if height, err := strconv.ParseInt(resp.Header.Get(HTTPHeaderBlockHeight), 10, 64); err == nil {
+		info.BlockHeight = height
+	}
+	return info
+}
+
 // ObjectDetail contains the detailed info of the object stored on Greenfield.
 type ObjectDetail struct {
 	ObjectInfo         *storagetypes.ObjectInfo  `protobuf:"bytes,1,opt,name=object_info" json:"object_info,omitempty"`
@@ -52,6 +103,44 @@ type ChallengeResult struct {
 	PiecesHash    []string      // the hashes of the object's segments/pieces
 }
 
+// UploadObjectResult is the result of uploading an object by `UploadObject` API.
+type UploadObjectResult struct {
+	CreateTxHash string        // CreateTxHash is the hash of the tx creating the object.
+	Sealed       bool          // Sealed indicates whether the object is sealed when returned, it is set only if the seal is waited for.
+	ObjectDetail *ObjectDetail // ObjectDetail is the detail of the sealed object, it is nil if the seal is not waited for.
+}
+
+// PutIfAbsentResult is the result of storing an object by `PutIfAbsent` API.
+type PutIfAbsentResult struct {
+	ObjectName string              // ObjectName is the name derived from the content hash.
+	Existed    bool                // Existed indicates the identical content already exists and the object is not created.
+	Upload     *UploadObjectResult // Upload is the result of uploading the object, it is nil if the object existed.
+}
+
+// OrphanedGroupsResult is the result of `FindOrphanedGroups` API.
+type OrphanedGroupsResult struct {
+	Groups   []*storagetypes.GroupInfo // Groups are the groups referenced by no policy of the searched buckets.
+	TxHashes []string                  // TxHashes are the hashes of the transactions deleting the groups, it is empty if Cleanup is not set.
+}
+
+// DanglingPolicy is an object policy referencing a deleted principal or resource.
+type DanglingPolicy struct {
+	BucketName string
+	ObjectName string
+	Policy     *PolicyMeta
+	// Reason describes why the policy is dangling.
+	Reason string
+	// Removable indicates the policy can be deleted by the bucket owner. The policies of the deleted objects are
+	// tied to the former object ids and are garbage-collected by the chain instead.
+	Removable bool
+}
+
+// DanglingPoliciesResult is the result of `FindDanglingPolicies` API.
+type DanglingPoliciesResult struct {
+	Policies []DanglingPolicy // Policies are the dangling policies found.
+	TxHashes []string         // TxHashes are the hashes of the transactions deleting the removable policies, it is empty if Cleanup is not set.
+}
+
 // RandStr - Generate a random string for test usage.
 func RandStr(n int) string {
 	b := make([]rune, n)
@@ -75,3 +164,183 @@ type StorageProvider struct {
 	Description     spTypes.Description
 	BlsKey          []byte
 }
+
+// HealthReport contains the health info of the chain, the storage providers and the default account checked by
+// HealthCheck, it is suitable for the readiness probes.
+type HealthReport struct {
+	Healthy          bool           // Healthy is true if all the checked items are healthy.
+	CheckedAt        time.Time      // CheckedAt indicates the time when the check started.
+	Chain            ChainHealth    // Chain contains the health info of the chain node.
+	InServiceSPCount int            // InServiceSPCount indicates the number of the in-service SPs on chain.
+	StorageProviders []SPHealth     // StorageProviders contains the health info of the probed SPs.
+	Account          *AccountHealth // Account contains the balance info of the default account, it is nil if the client has no default account.
+}
+
+// ChainHealth contains the health info of the chain node.
+type ChainHealth struct {
+	Reachable         bool
+	CatchingUp        bool
+	LatestBlockHeight int64
+	LatestBlockTime   time.Time
+	Error             string
+}
+
+// SPHealth contains the health info of the storage provider.
+type SPHealth struct {
+	ID              uint32
+	OperatorAddress string
+	Endpoint        string
+	Reachable       bool
+	Latency         time.Duration
+	Error           string
+}
+
+// AccountHealth contains the balance info of the account.
+type AccountHealth struct {
+	Address    string
+	Balance    string
+	Sufficient bool // Sufficient is true if the balance is not less than HealthCheckOptions.MinBalance.
+	Error      string
+}
+
+// StorageProof packages the on-chain info of a sealed object with the block commit signed by the validators, it can
+// be handed to a third party as the attestation that the object is stored on Greenfield.
+//
+// The proof contains the cometbft types, it should be serialized by the cometbft json codec to keep their format.
+type StorageProof struct {
+	ChainID     string                   // ChainID indicates the chain where the object is stored.
+	BucketName  string                   // BucketName indicates the bucket of the object.
+	ObjectName  string                   // ObjectName indicates the name of the object.
+	ObjectInfo  *storagetypes.ObjectInfo // ObjectInfo is the object info on chain when the proof is generated.
+	SealTxHash  string                   // SealTxHash is the hash of the seal object transaction, it is empty if not provided.
+	Height      int64                    // Height is the height of the committed block, it is the seal tx height if SealTxHash is set.
+	Commit      *ctypes.ResultCommit     // Commit is the signed header of the block at Height.
+	GeneratedAt time.Time                // GeneratedAt indicates the time when the proof is generated.
+}
+
+// StorageClass is a named preset of the options of creating buckets and objects. It is applied by CreateBucket and
+// CreateObject to the options which the caller does not set explicitly.
+type StorageClass struct {
+	Name             string                      // Name identifies the storage class.
+	BucketVisibility storagetypes.VisibilityType // BucketVisibility is applied to CreateBucketOptions.Visibility.
+	ObjectVisibility storagetypes.VisibilityType // ObjectVisibility is applied to CreateObjectOptions.Visibility.
+	ChargedQuota     uint64                      // ChargedQuota is applied to CreateBucketOptions.ChargedQuota, measured in bytes.
+	Tags             *storagetypes.ResourceTags  // Tags is applied to the tags of the created buckets and objects.
+}
+
+// The built-in storage classes, they can be overridden by Option.StorageClasses of the client.
+var (
+	// StorageClassHotPublic is for the public content served frequently, e.g. the website assets.
+	StorageClassHotPublic = StorageClass{
+		Name:             "hot-public",
+		BucketVisibility: storagetypes.VISIBILITY_TYPE_PUBLIC_READ,
+		ObjectVisibility: storagetypes.VISIBILITY_TYPE_INHERIT,
+		ChargedQuota:     1024 * 1024 * 1024,
+	}
+	// StorageClassHotPrivate is for the private content read frequently by its owner.
+	StorageClassHotPrivate = StorageClass{
+		Name:             "hot-private",
+		BucketVisibility: storagetypes.VISIBILITY_TYPE_PRIVATE,
+		ObjectVisibility: storagetypes.VISIBILITY_TYPE_INHERIT,
+		ChargedQuota:     1024 * 1024 * 1024,
+	}
+	// StorageClassColdPrivate is for the private content rarely read, e.g. the backups, it is charged no read quota.
+	StorageClassColdPrivate = StorageClass{
+		Name:             "cold-private",
+		BucketVisibility: storagetypes.VISIBILITY_TYPE_PRIVATE,
+		ObjectVisibility: storagetypes.VISIBILITY_TYPE_INHERIT,
+	}
+)
+
+// DefaultStorageClasses returns the built-in storage classes keyed by name.
+func DefaultStorageClasses() map[string]StorageClass {
+	return map[string]StorageClass{
+		StorageClassHotPublic.Name:   StorageClassHotPublic,
+		StorageClassHotPrivate.Name:  StorageClassHotPrivate,
+		StorageClassColdPrivate.Name: StorageClassColdPrivate,
+	}
+}
+
+// ApplyToBucket applies the storage class to the unset bucket options.
+func (s StorageClass) ApplyToBucket(opts *CreateBucketOptions) {
+	if opts.Visibility == storagetypes.VISIBILITY_TYPE_UNSPECIFIED {
+		opts.Visibility = s.BucketVisibility
+	}
+	if opts.ChargedQuota == 0 {
+		opts.ChargedQuota = s.ChargedQuota
+	}
+	if opts.Tags == nil && s.Tags != nil {
+		tags := *s.Tags
+		opts.Tags = &tags
+	}
+}
+
+// ApplyToObject applies the storage class to the unset object options.
+func (s StorageClass) ApplyToObject(opts *CreateObjectOptions) {
+	if opts.Visibility == storagetypes.VISIBILITY_TYPE_UNSPECIFIED {
+		opts.Visibility = s.ObjectVisibility
+	}
+	if opts.Tags == nil && s.Tags != nil {
+		tags := *s.Tags
+		opts.Tags = &tags
+	}
+}
+
+// ContentTypeRule assigns the content type to the objects whose names match the pattern.
+type ContentTypeRule struct {
+	Pattern     string // Pattern is the path.Match pattern, e.g. "*.html" or "assets/*.js". The patterns without "/" are matched against the last element of the object name.
+	ContentType string // ContentType is the content type of the matched objects.
+}
+
+// BucketDefaults is the default options of a bucket registered on the client, so that the repeated calls on the
+// bucket do not need to specify them. They are applied to the options which the caller does not set explicitly.
+type BucketDefaults struct {
+	Visibility       storagetypes.VisibilityType // Visibility is applied to the visibility of the created objects, including the delegated uploads.
+	ContentTypeRules []ContentTypeRule           // ContentTypeRules decides the content type of the objects, the first matched rule is applied.
+	PartSize         uint64                      // PartSize is applied to the part size of the resumable uploads.
+	PaymentAddress   string                      // PaymentAddress is applied to CreateBucketOptions.PaymentAddress when the bucket is created.
+}
+
+// ContentType returns the content type of the object by the first matched rule, it is empty if no rule matches.
+func (d BucketDefaults) ContentType(objectName string) string {
+	for _, rule := range d.ContentTypeRules {
+		name := objectName
+		if !strings.Contains(rule.Pattern, "/") {
+			name = path.Base(objectName)
+		}
+		if matched, _ := path.Match(rule.Pattern, name); matched {
+			return rule.ContentType
+		}
+	}
+	return ""
+}
+
+// ApplyToBucket applies the bucket defaults to the unset bucket options.
+func (d BucketDefaults) ApplyToBucket(opts *CreateBucketOptions) {
+	if opts.PaymentAddress == "" {
+		opts.PaymentAddress = d.PaymentAddress
+	}
+}
+
+// ApplyToObject applies the bucket defaults to the unset options of creating the object.
+func (d BucketDefaults) ApplyToObject(objectName string, opts *CreateObjectOptions) {
+	if opts.Visibility == storagetypes.VISIBILITY_TYPE_UNSPECIFIED {
+		opts.Visibility = d.Visibility
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = d.ContentType(objectName)
+	}
+}
+
+// ApplyToPut applies the bucket defaults to the unset options of uploading the object.
+func (d BucketDefaults) ApplyToPut(objectName string, opts *PutObjectOptions) {
+	if opts.Visibility == storagetypes.VISIBILITY_TYPE_UNSPECIFIED {
+		opts.Visibility = d.Visibility
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = d.ContentType(objectName)
+	}
+	if opts.PartSize == 0 {
+		opts.PartSize = d.PartSize
+	}
+}
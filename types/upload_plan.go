@@ -0,0 +1,24 @@
+package types
+
+import "time"
+
+// UploadPlan estimates the cost of uploading an object, so that the batch schedulers can budget the time and the fees
+// before starting the long uploads.
+type UploadPlan struct {
+	ObjectSize   int64
+	SegmentCount uint64 // SegmentCount is the number of the segments of the object on chain, each of them has a checksum computed locally.
+	PartSize     uint64 // PartSize is the part size of the resumable upload, it is recommended by the bandwidth if not specified.
+	PartCount    uint64 // PartCount is the number of the requests sent to SP by the resumable upload.
+	// Concurrency is the recommended number of the transfers to run in parallel to make use of the bandwidth, e.g. the
+	// Concurrency of SyncDirOptions.
+	Concurrency int
+	// TxCount is the number of the txs sent by the upload, i.e. the createObject tx, the object is sealed by SP.
+	TxCount int
+	// HashDuration is the estimated time of computing the checksums before the createObject tx is sent.
+	HashDuration time.Duration
+	// TransferDuration is the estimated time of sending the payload to SP, it is 0 if the bandwidth is unknown.
+	TransferDuration time.Duration
+	// ApprovalExpiryRisk indicates the SP approval requested before computing the checksums, e.g. by
+	// GetCreateObjectApproval, is likely to expire before the tx is sent, see RefreshCreateObjectApproval.
+	ApprovalExpiryRisk bool
+}
@@ -0,0 +1,438 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/bnb-chain/greenfield/types/s3util"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrInvalidOptions indicates the options of an API call are invalid, the returned error is an *OptionsError.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// OptionsError aggregates all the problems of the options found by their Validate method, which is called before any
+// network call of the API taking the options.
+type OptionsError struct {
+	Options  string   // Options is the type name of the options.
+	Problems []string // Problems describes each invalid field.
+}
+
+// Error returns the error msg
+func (e *OptionsError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Options, strings.Join(e.Problems, "; "))
+}
+
+// Unwrap returns ErrInvalidOptions.
+func (e *OptionsError) Unwrap() error {
+	return ErrInvalidOptions
+}
+
+// optionsValidator collects the problems of the options.
+type optionsValidator struct {
+	options  string
+	problems []string
+}
+
+func newOptionsValidator(options string) *optionsValidator {
+	return &optionsValidator{options: options}
+}
+
+// check records the problem described by format if ok is false.
+func (v *optionsValidator) check(ok bool, format string, args ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *optionsValidator) checkAddress(field, address string) {
+	if address == "" {
+		return
+	}
+	_, err := sdk.AccAddressFromHexUnsafe(address)
+	v.check(err == nil, "%s %q is not a valid HEX-encoded address", field, address)
+}
+
+func (v *optionsValidator) checkVisibility(visibility storageTypes.VisibilityType) {
+	_, ok := storageTypes.VisibilityType_name[int32(visibility)]
+	v.check(ok, "Visibility %d is not a valid visibility type", visibility)
+}
+
+func (v *optionsValidator) checkContentType(contentType string) {
+	if contentType == "" {
+		return
+	}
+	_, _, err := mime.ParseMediaType(contentType)
+	v.check(err == nil, "ContentType %q is not a valid media type", contentType)
+}
+
+// checkPageToken checks the token is returned by the list API of kind, and the pagination field it takes the place of
+// is not set at the same time.
+func (v *optionsValidator) checkPageToken(token PageToken, kind PageKind, replaced string, replacedSet bool) {
+	if token == "" {
+		return
+	}
+	_, err := token.Position(kind)
+	v.check(err == nil, "PageToken is not a token of the %s pages: %v", kind, err)
+	v.check(!replacedSet, "PageToken and %s should not be set together", replaced)
+}
+
+// merge records the problems of the nested options, e.g. the options of SyncDirOptions.
+func (v *optionsValidator) merge(field string, err error) {
+	var optionsErr *OptionsError
+	if errors.As(err, &optionsErr) {
+		for _, problem := range optionsErr.Problems {
+			v.problems = append(v.problems, field+"."+problem)
+		}
+	}
+}
+
+func (v *optionsValidator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &OptionsError{Options: v.options, Problems: v.problems}
+}
+
+// Validate - Check the visibility and the payment address of the new bucket.
+func (o CreateBucketOptions) Validate() error {
+	v := newOptionsValidator("CreateBucketOptions")
+	v.checkVisibility(o.Visibility)
+	v.checkAddress("PaymentAddress", o.PaymentAddress)
+	return v.err()
+}
+
+// Validate - Check the visibility, the content type rules and the payment address of the defaults.
+func (o BucketDefaults) Validate() error {
+	v := newOptionsValidator("BucketDefaults")
+	v.checkVisibility(o.Visibility)
+	for i, rule := range o.ContentTypeRules {
+		_, err := path.Match(rule.Pattern, "")
+		v.check(err == nil, "ContentTypeRules[%d].Pattern %q is malformed", i, rule.Pattern)
+		_, _, err = mime.ParseMediaType(rule.ContentType)
+		v.check(err == nil, "ContentTypeRules[%d].ContentType %q is not a valid media type", i, rule.ContentType)
+	}
+	v.checkAddress("PaymentAddress", o.PaymentAddress)
+	return v.err()
+}
+
+// Validate - Check the new visibility and payment address of the bucket.
+func (o UpdateBucketOptions) Validate() error {
+	v := newOptionsValidator("UpdateBucketOptions")
+	v.checkVisibility(o.Visibility)
+	v.checkAddress("PaymentAddress", o.PaymentAddress)
+	return v.err()
+}
+
+// Validate - Check the visibility, the content type and the secondary SPs of the new object.
+func (o CreateObjectOptions) Validate() error {
+	v := newOptionsValidator("CreateObjectOptions")
+	v.checkVisibility(o.Visibility)
+	v.checkContentType(o.ContentType)
+	for i, acc := range o.SecondarySPAccs {
+		v.check(!acc.Empty(), "SecondarySPAccs[%d] is empty", i)
+	}
+	return v.err()
+}
+
+// Validate - Check the content type and the secondary SPs of the new content.
+func (o UpdateObjectOptions) Validate() error {
+	v := newOptionsValidator("UpdateObjectOptions")
+	v.checkContentType(o.ContentType)
+	for i, acc := range o.SecondarySPAccs {
+		v.check(!acc.Empty(), "SecondarySPAccs[%d] is empty", i)
+	}
+	return v.err()
+}
+
+// Validate - Check the upload options, the part size is checked against the chain params when uploading.
+func (o PutObjectOptions) Validate() error {
+	v := newOptionsValidator("PutObjectOptions")
+	v.checkContentType(o.ContentType)
+	v.checkVisibility(o.Visibility)
+	v.check(o.ChunkBufferSize >= 0, "ChunkBufferSize %d should not be negative", o.ChunkBufferSize)
+	v.check(o.Delegated || !o.IsUpdate, "IsUpdate is only supported by the delegated uploads")
+	v.check(o.RateLimit >= 0, "RateLimit %d should not be negative", o.RateLimit)
+	if o.ContentSHA256 != "" {
+		digest, err := hex.DecodeString(o.ContentSHA256)
+		v.check(err == nil && len(digest) == sha256.Size, "ContentSHA256 %q is not a hex-encoded sha256", o.ContentSHA256)
+	}
+	if o.CreateOptions != nil {
+		v.merge("CreateOptions", o.CreateOptions.Validate())
+	}
+	return v.err()
+}
+
+// Validate - Check the range, the concurrency and the rate limit of the download.
+func (o GetObjectOptions) Validate() error {
+	v := newOptionsValidator("GetObjectOptions")
+	v.check(o.Range == "" || strings.HasPrefix(o.Range, "bytes="), "Range %q should be in the form of \"bytes=start-end\"", o.Range)
+	v.check(o.Concurrency >= 0, "Concurrency %d should not be negative", o.Concurrency)
+	v.check(o.RateLimit >= 0, "RateLimit %d should not be negative", o.RateLimit)
+	v.merge("AdaptiveConcurrency", o.AdaptiveConcurrency.Validate())
+	v.check(!o.VerifyIntegrity || o.Range == "", "VerifyIntegrity can not be combined with Range %q", o.Range)
+	return v.err()
+}
+
+// Validate - Check the pagination, the delimiter, the SP and the consistency of the listing.
+func (o ListObjectsOptions) Validate() error {
+	v := newOptionsValidator("ListObjectsOptions")
+	if o.StartAfter != "" {
+		err := s3util.CheckValidObjectName(o.StartAfter)
+		v.check(err == nil, "StartAfter %q is not a valid object name: %v", o.StartAfter, err)
+	}
+	if o.ContinuationToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(o.ContinuationToken)
+		v.check(err == nil, "ContinuationToken is not base64 encoded: %v", err)
+		if err == nil {
+			err = s3util.CheckValidObjectName(string(decoded))
+			v.check(err == nil, "ContinuationToken does not encode a valid object name: %v", err)
+			v.check(strings.HasPrefix(string(decoded), o.Prefix), "ContinuationToken does not match the Prefix %q", o.Prefix)
+		}
+	}
+	v.checkPageToken(o.PageToken, PageObjects, "ContinuationToken", o.ContinuationToken != "")
+	v.check(o.Delimiter == "" || o.Delimiter == "/", "Delimiter %q is not supported, only \"/\" is supported", o.Delimiter)
+	v.checkAddress("SPAddress", o.SPAddress)
+	v.merge("Consistency", o.Consistency.Validate())
+	return v.err()
+}
+
+// Validate - Check the delimiter and the SP of the listing.
+func (o ListObjectsWithCursorOptions) Validate() error {
+	v := newOptionsValidator("ListObjectsWithCursorOptions")
+	v.check(o.Delimiter == "" || o.Delimiter == "/", "Delimiter %q is not supported, only \"/\" is supported", o.Delimiter)
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the pagination and the SP of the group listing.
+func (o ListGroupsOptions) Validate() error {
+	v := newOptionsValidator("ListGroupsOptions")
+	v.check(o.Limit >= 0, "Limit %d should not be negative", o.Limit)
+	v.check(o.Offset >= 0, "Offset %d should not be negative", o.Offset)
+	v.checkPageToken(o.PageToken, PageGroups, "Offset", o.Offset != 0)
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the pagination and the SP of the member listing.
+func (o GroupMembersPaginationOptions) Validate() error {
+	v := newOptionsValidator("GroupMembersPaginationOptions")
+	v.check(o.Limit >= 0, "Limit %d should not be negative", o.Limit)
+	v.checkAddress("StartAfter", o.StartAfter)
+	v.checkPageToken(o.PageToken, PageGroupMembers, "StartAfter", o.StartAfter != "")
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the pagination, the owner and the SP of the group listing.
+func (o GroupsOwnerPaginationOptions) Validate() error {
+	v := newOptionsValidator("GroupsOwnerPaginationOptions")
+	v.check(o.Limit >= 0, "Limit %d should not be negative", o.Limit)
+	v.checkPageToken(o.PageToken, PageOwnedGroups, "StartAfter", o.StartAfter != "")
+	v.checkAddress("Owner", o.Owner)
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the pagination, the member account and the SP of the group listing.
+func (o GroupsPaginationOptions) Validate() error {
+	v := newOptionsValidator("GroupsPaginationOptions")
+	v.check(o.Limit >= 0, "Limit %d should not be negative", o.Limit)
+	v.checkPageToken(o.PageToken, PageUserGroups, "StartAfter", o.StartAfter != "")
+	v.checkAddress("Account", o.Account)
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the fan-out, the SP and the consistency of the query.
+func (o EndPointOptions) Validate() error {
+	v := newOptionsValidator("EndPointOptions")
+	v.check(o.FanOut >= 0, "FanOut %d should not be negative", o.FanOut)
+	v.checkAddress("SPAddress", o.SPAddress)
+	v.merge("Consistency", o.Consistency.Validate())
+	return v.err()
+}
+
+// Validate - Check the concurrency and the transfer options of the sync.
+func (o SyncDirOptions) Validate() error {
+	v := newOptionsValidator("SyncDirOptions")
+	v.check(o.Concurrency >= 0, "Concurrency %d should not be negative", o.Concurrency)
+	v.merge("AdaptiveConcurrency", o.AdaptiveConcurrency.Validate())
+	v.merge("CreateOptions", o.CreateOptions.Validate())
+	v.merge("PutOptions", o.PutOptions.Validate())
+	v.merge("GetOptions", o.GetOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the sample count of the verification.
+func (o VerifyReplicaOptions) Validate() error {
+	v := newOptionsValidator("VerifyReplicaOptions")
+	v.check(o.SampleCount >= 0, "SampleCount %d should not be negative", o.SampleCount)
+	return v.err()
+}
+
+// Validate - Check the SP of the export.
+func (o ExportInventoryOptions) Validate() error {
+	v := newOptionsValidator("ExportInventoryOptions")
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the mode and the sample count of the audit.
+func (o AuditBucketOptions) Validate() error {
+	v := newOptionsValidator("AuditBucketOptions")
+	v.check(o.Mode == "" || o.Mode == AuditFullRead || o.Mode == AuditChallenge, "Mode %q is not a valid audit mode", o.Mode)
+	v.check(o.MaxSampledCount >= 0, "MaxSampledCount %d should not be negative", o.MaxSampledCount)
+	return v.err()
+}
+
+// Validate - Check the timeout and the poll intervals of the wait.
+func (o WaitForObjectSealOptions) Validate() error {
+	v := newOptionsValidator("WaitForObjectSealOptions")
+	v.check(o.Timeout >= 0, "Timeout %s should not be negative", o.Timeout)
+	v.check(o.PollInterval >= 0, "PollInterval %s should not be negative", o.PollInterval)
+	v.check(o.MaxPollInterval >= 0, "MaxPollInterval %s should not be negative", o.MaxPollInterval)
+	return v.err()
+}
+
+// Validate - Check the timeout and the poll intervals of the offset queries.
+func (o UploadOffsetOptions) Validate() error {
+	v := newOptionsValidator("UploadOffsetOptions")
+	v.check(o.Timeout >= 0, "Timeout %s should not be negative", o.Timeout)
+	v.check(o.PollInterval >= 0, "PollInterval %s should not be negative", o.PollInterval)
+	v.check(o.MaxPollInterval >= 0, "MaxPollInterval %s should not be negative", o.MaxPollInterval)
+	return v.err()
+}
+
+// Validate - Check the start height and the owner of the watch.
+func (o WatchDiscontinuesOptions) Validate() error {
+	v := newOptionsValidator("WatchDiscontinuesOptions")
+	v.check(o.StartHeight >= 0, "StartHeight %d should not be negative", o.StartHeight)
+	v.checkAddress("Owner", o.Owner)
+	return v.err()
+}
+
+// Validate - Check the key directory, the group owner, the recipients and the SP of the sharing.
+func (o EncryptionSharingOptions) Validate() error {
+	v := newOptionsValidator("EncryptionSharingOptions")
+	v.check(o.KeyDirectory != nil, "KeyDirectory should not be nil")
+	v.checkAddress("GroupOwner", o.GroupOwner)
+	for i, recipient := range o.Recipients {
+		v.check(recipient != "", "Recipients[%d] is empty", i)
+		v.checkAddress(fmt.Sprintf("Recipients[%d]", i), recipient)
+	}
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the chunk size, the sharing and the upload options of the encrypted object.
+func (o PutEncryptedObjectOptions) Validate() error {
+	v := newOptionsValidator("PutEncryptedObjectOptions")
+	v.check(o.ChunkSize >= 0, "ChunkSize %d should not be negative", o.ChunkSize)
+	v.merge("Sharing", o.Sharing.Validate())
+	v.merge("CreateOptions", o.CreateOptions.Validate())
+	v.merge("PutOptions", o.PutOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the sharing and the upload options of the rewrapped keys.
+func (o RewrapObjectKeysOptions) Validate() error {
+	v := newOptionsValidator("RewrapObjectKeysOptions")
+	v.merge("Sharing", o.Sharing.Validate())
+	v.merge("PutOptions", o.PutOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the create and upload options of the manifest.
+func (o BuildDatasetManifestOptions) Validate() error {
+	v := newOptionsValidator("BuildDatasetManifestOptions")
+	v.merge("CreateOptions", o.CreateOptions.Validate())
+	v.merge("PutOptions", o.PutOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the concurrency, the delimiter, the SP and the consistency of the listing.
+func (o ListObjectsParallelOptions) Validate() error {
+	v := newOptionsValidator("ListObjectsParallelOptions")
+	v.check(o.Concurrency >= 0, "Concurrency %d should not be negative", o.Concurrency)
+	v.check(o.Delimiter == "" || o.Delimiter == "/", "Delimiter %q is not supported, only \"/\" is supported", o.Delimiter)
+	v.checkAddress("SPAddress", o.SPAddress)
+	v.merge("Consistency", o.Consistency.Validate())
+	return v.err()
+}
+
+// Validate - Check the bounds, the target latency and the decrease factor, the nil options are valid.
+func (o *AdaptiveConcurrency) Validate() error {
+	if o == nil {
+		return nil
+	}
+	v := newOptionsValidator("AdaptiveConcurrency")
+	v.check(o.MinConcurrency >= 0, "MinConcurrency %d should not be negative", o.MinConcurrency)
+	v.check(o.MaxConcurrency > 0 && o.MaxConcurrency >= o.MinConcurrency, "MaxConcurrency %d should be positive and not less than MinConcurrency", o.MaxConcurrency)
+	v.check(o.TargetLatency >= 0, "TargetLatency %s should not be negative", o.TargetLatency)
+	v.check(o.DecreaseFactor >= 0 && o.DecreaseFactor < 1, "DecreaseFactor %v should be in [0, 1)", o.DecreaseFactor)
+	return v.err()
+}
+
+// Validate - Check the options of the copy, which always copies the whole object.
+func (o CopyObjectOptions) Validate() error {
+	v := newOptionsValidator("CopyObjectOptions")
+	v.check(o.GetOptions.Range == "", "GetOptions.Range %q is not supported by the copy", o.GetOptions.Range)
+	v.merge("CreateOptions", o.CreateOptions.Validate())
+	v.merge("GetOptions", o.GetOptions.Validate())
+	v.merge("PutOptions", o.PutOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the create, upload and seal options, the object should be created by CreateOptions.
+func (o UploadObjectOptions) Validate() error {
+	v := newOptionsValidator("UploadObjectOptions")
+	v.check(o.PutOptions.CreateOptions == nil, "PutOptions.CreateOptions should not be set, the object is created by CreateOptions")
+	v.check(!o.PutOptions.Delegated, "PutOptions.Delegated is not supported, the object is created by the client")
+	v.merge("CreateOptions", o.CreateOptions.Validate())
+	v.merge("PutOptions", o.PutOptions.Validate())
+	v.merge("SealOptions", o.SealOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the start time, the max records and the pagination of the read records.
+func (o ListReadRecordOptions) Validate() error {
+	v := newOptionsValidator("ListReadRecordOptions")
+	v.check(o.StartTimeStamp >= 0, "StartTimeStamp %d should not be negative", o.StartTimeStamp)
+	v.check(o.MaxRecords >= 0, "MaxRecords %d should not be negative", o.MaxRecords)
+	v.checkPageToken(o.PageToken, PageReadRecords, "StartTimeStamp", o.StartTimeStamp != 0)
+	return v.err()
+}
+
+// Validate - Check the naming scheme and the upload options.
+func (o PutIfAbsentOptions) Validate() error {
+	v := newOptionsValidator("PutIfAbsentOptions")
+	v.check(o.Naming == ContentNamingSHA256 || o.Naming == ContentNamingCID, "Naming %d is invalid", o.Naming)
+	v.merge("UploadOptions", o.UploadOptions.Validate())
+	return v.err()
+}
+
+// Validate - Check the buckets, the batch size and the SP of the scan.
+func (o FindOrphanedGroupsOptions) Validate() error {
+	v := newOptionsValidator("FindOrphanedGroupsOptions")
+	v.check(len(o.Buckets) > 0, "Buckets should not be empty, the groups are referenced by the policies of the buckets")
+	v.check(o.BatchSize >= 0, "BatchSize %d should not be negative", o.BatchSize)
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
+
+// Validate - Check the batch size and the SP of the scan.
+func (o FindDanglingPoliciesOptions) Validate() error {
+	v := newOptionsValidator("FindDanglingPoliciesOptions")
+	v.check(o.BatchSize >= 0, "BatchSize %d should not be negative", o.BatchSize)
+	v.checkAddress("SPAddress", o.SPAddress)
+	return v.err()
+}
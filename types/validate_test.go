@@ -0,0 +1,86 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	require.NoError(t, CreateBucketOptions{}.Validate())
+	require.NoError(t, CreateBucketOptions{
+		Visibility:     storageTypes.VISIBILITY_TYPE_PRIVATE,
+		PaymentAddress: "0x76d244CE05c3De4BbC6fDd7F56379B145709ade9",
+	}.Validate())
+
+	err := CreateBucketOptions{Visibility: 42, PaymentAddress: "not-an-address"}.Validate()
+	require.True(t, errors.Is(err, ErrInvalidOptions))
+	var optionsErr *OptionsError
+	require.True(t, errors.As(err, &optionsErr))
+	require.Equal(t, "CreateBucketOptions", optionsErr.Options)
+	require.Len(t, optionsErr.Problems, 2)
+
+	require.NoError(t, PutObjectOptions{ContentType: "text/plain; charset=utf-8"}.Validate())
+	require.NoError(t, PutObjectOptions{Delegated: true, IsUpdate: true}.Validate())
+	require.Error(t, PutObjectOptions{IsUpdate: true}.Validate())
+	require.NoError(t, PutObjectOptions{ContentSHA256: EmptyStringSHA256}.Validate())
+	require.Error(t, PutObjectOptions{ContentSHA256: "e3b0c442"}.Validate())
+	require.Error(t, PutObjectOptions{ContentType: "text/"}.Validate())
+
+	require.NoError(t, GetObjectOptions{Range: "bytes=0-9", Concurrency: 2}.Validate())
+	require.Error(t, GetObjectOptions{Range: "0-9"}.Validate())
+	require.Error(t, GetObjectOptions{Concurrency: -1}.Validate())
+	require.NoError(t, GetObjectOptions{VerifyIntegrity: true, Concurrency: 2}.Validate())
+	require.Error(t, GetObjectOptions{VerifyIntegrity: true, Range: "bytes=0-9"}.Validate())
+
+	token := base64.StdEncoding.EncodeToString([]byte("logs/a"))
+	require.NoError(t, ListObjectsOptions{Prefix: "logs/", ContinuationToken: token, Delimiter: "/"}.Validate())
+	require.Error(t, ListObjectsOptions{Prefix: "data/", ContinuationToken: token}.Validate())
+	require.Error(t, ListObjectsOptions{ContinuationToken: "%%%"}.Validate())
+	require.Error(t, ListObjectsOptions{Delimiter: ","}.Validate())
+
+	// the problems of the nested options are prefixed by the field names
+	err = SyncDirOptions{Concurrency: -1, GetOptions: GetObjectOptions{Concurrency: -1}}.Validate()
+	require.True(t, errors.As(err, &optionsErr))
+	require.Len(t, optionsErr.Problems, 2)
+	require.Contains(t, optionsErr.Problems[1], "GetOptions.Concurrency")
+
+	require.NoError(t, AuditBucketOptions{Mode: AuditChallenge}.Validate())
+	require.Error(t, AuditBucketOptions{Mode: "partial"}.Validate())
+	require.Error(t, WaitForObjectSealOptions{PollInterval: -1}.Validate())
+	require.NoError(t, UploadOffsetOptions{MinOffset: 1 << 20}.Validate())
+	require.Error(t, UploadOffsetOptions{Timeout: -1}.Validate())
+
+	require.NoError(t, PutEncryptedObjectOptions{Sharing: EncryptionSharingOptions{KeyDirectory: StaticKeyDirectory{}}}.Validate())
+	err = PutEncryptedObjectOptions{Sharing: EncryptionSharingOptions{Recipients: []string{""}}}.Validate()
+	require.True(t, errors.As(err, &optionsErr))
+	require.Equal(t, []string{"Sharing.KeyDirectory should not be nil", "Sharing.Recipients[0] is empty"}, optionsErr.Problems)
+
+	require.NoError(t, GetObjectOptions{AdaptiveConcurrency: &AdaptiveConcurrency{MaxConcurrency: 16}}.Validate())
+	require.Error(t, GetObjectOptions{AdaptiveConcurrency: &AdaptiveConcurrency{MinConcurrency: 4, MaxConcurrency: 2}}.Validate())
+	require.Error(t, SyncDirOptions{AdaptiveConcurrency: &AdaptiveConcurrency{MaxConcurrency: 4, DecreaseFactor: 1}}.Validate())
+	require.Error(t, PutObjectOptions{RateLimit: -1}.Validate())
+	require.Error(t, GetObjectOptions{RateLimit: -1}.Validate())
+	require.Error(t, CopyObjectOptions{GetOptions: GetObjectOptions{Range: "bytes=0-9"}}.Validate())
+	require.NoError(t, ListObjectsOptions{Consistency: BoundedStaleness(5)}.Validate())
+	require.Error(t, ListObjectsOptions{Consistency: BoundedStaleness(-1)}.Validate())
+	require.NoError(t, ListObjectsOptions{Consistency: AtLeastHeight(100)}.Validate())
+	require.Error(t, ListObjectsOptions{Consistency: AtLeastHeight(-1)}.Validate())
+	require.Error(t, EndPointOptions{Consistency: Consistency{Mode: 9}}.Validate())
+	require.Error(t, PutObjectOptions{CreateOptions: &CreateObjectOptions{ContentType: "text/"}}.Validate())
+	require.NoError(t, UploadObjectOptions{WaitForSeal: true}.Validate())
+	require.Error(t, UploadObjectOptions{PutOptions: PutObjectOptions{Delegated: true}}.Validate())
+	require.NoError(t, ListObjectsOptions{PageToken: NewPageToken(PageObjects, "b2JqZWN0")}.Validate())
+	require.Error(t, ListObjectsOptions{PageToken: NewPageToken(PageGroups, "50")}.Validate())
+	require.Error(t, ListGroupsOptions{Offset: 50, PageToken: NewPageToken(PageGroups, "50")}.Validate())
+	require.Error(t, ListReadRecordOptions{StartTimeStamp: -1}.Validate())
+	require.NoError(t, PutIfAbsentOptions{Naming: ContentNamingCID}.Validate())
+	require.Error(t, PutIfAbsentOptions{Naming: ContentNaming(9)}.Validate())
+	require.Error(t, FindOrphanedGroupsOptions{}.Validate())
+	require.NoError(t, FindOrphanedGroupsOptions{Buckets: []string{"bucket"}, Cleanup: true}.Validate())
+	require.Error(t, FindDanglingPoliciesOptions{BatchSize: -1}.Validate())
+}
@@ -0,0 +1,174 @@
+package types
+
+import (
+	"encoding"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+)
+
+var (
+	xmlUnmarshalerType  = reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// UnknownXMLElements - Find the elements of the xml content which are not declared by the type of v and would be
+// ignored when decoding the content into v.
+//
+// The elements are matched against the struct fields by the xml tags in the same way as encoding/xml, the content of
+// the fields which decode themselves, e.g. by implementing xml.Unmarshaler, or which capture the raw content by the
+// ",any" or ",innerxml" tags is not inspected.
+//
+// - r: The xml content.
+//
+// - v: The decoding target, e.g. a pointer to the response struct.
+//
+// - ret1: The slash separated paths of the unknown elements from the root element, each path is reported once in the
+// order of the first occurrence.
+//
+// - ret2: Return error if the content is malformed, otherwise return nil.
+func UnknownXMLElements(r io.Reader, v interface{}) ([]string, error) {
+	decoder := xml.NewDecoder(r)
+	// the types of the open elements, nil indicates the children of the element are not inspected
+	stack := make([]reflect.Type, 0)
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	unknown := make([]string, 0)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return unknown, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			names = append(names, t.Name.Local)
+			if len(stack) == 0 {
+				stack = append(stack, inspectedXMLType(reflect.TypeOf(v)))
+				continue
+			}
+			parent := stack[len(stack)-1]
+			if parent == nil {
+				stack = append(stack, nil)
+				continue
+			}
+			child, ok := xmlChildType(parent, t.Name.Local)
+			if !ok {
+				path := strings.Join(names, "/")
+				if !seen[path] {
+					seen[path] = true
+					unknown = append(unknown, path)
+				}
+			}
+			stack = append(stack, child)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+				names = names[:len(names)-1]
+			}
+		}
+	}
+}
+
+// inspectedXMLType returns the struct type whose fields the children of the element decoded into t are matched
+// against, or nil if the children are not inspected.
+func inspectedXMLType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	for {
+		if t.Kind() != reflect.Pointer && (reflect.PointerTo(t).Implements(xmlUnmarshalerType) ||
+			reflect.PointerTo(t).Implements(textUnmarshalerType)) {
+			return nil
+		}
+		switch {
+		case t.Kind() == reflect.Pointer:
+			t = t.Elem()
+		case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+			t = t.Elem()
+		case t.Kind() == reflect.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// xmlChildType finds the field of the struct type t which the child element named name is decoded into, it returns
+// the type to inspect the children of the element and whether the element is known.
+func xmlChildType(t reflect.Type, name string) (reflect.Type, bool) {
+	hasAny := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+		tagName, flags, _ := strings.Cut(tag, ",")
+		if field.Name == "XMLName" || strings.Contains(","+flags+",", ",attr,") ||
+			strings.Contains(","+flags+",", ",chardata,") || strings.Contains(","+flags+",", ",comment,") {
+			continue
+		}
+		if strings.Contains(","+flags+",", ",innerxml,") {
+			return nil, true
+		}
+		if strings.Contains(","+flags+",", ",any,") {
+			hasAny = true
+			continue
+		}
+		if field.Anonymous && tagName == "" {
+			if embedded := inspectedXMLType(field.Type); embedded != nil {
+				if child, ok := xmlChildType(embedded, name); ok {
+					return child, true
+				}
+			}
+			continue
+		}
+		// the name may carry the namespace before a space
+		if idx := strings.LastIndex(tagName, " "); idx >= 0 {
+			tagName = tagName[idx+1:]
+		}
+		if tagName == "" {
+			tagName = xmlTypeName(field.Type)
+		}
+		if tagName == "" {
+			tagName = field.Name
+		}
+		first, rest, nested := strings.Cut(tagName, ">")
+		if first != name {
+			continue
+		}
+		if nested && rest != "" {
+			// the children of the parent path elements are not inspected
+			return nil, true
+		}
+		return inspectedXMLType(field.Type), true
+	}
+	return nil, hasAny
+}
+
+// xmlTypeName returns the element name declared by the XMLName field of the struct type t, which encoding/xml uses
+// for the untagged fields of the type.
+func xmlTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer || (t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8) {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	field, ok := t.FieldByName("XMLName")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}